@@ -0,0 +1,116 @@
+package influxdb
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// AuditAction is the kind of change an audited operation makes.
+type AuditAction string
+
+const (
+	// AuditCreate is the action of operations creating resources.
+	AuditCreate AuditAction = "create"
+	// AuditUpdate is the action of operations updating resources.
+	AuditUpdate AuditAction = "update"
+	// AuditDelete is the action of operations deleting resources.
+	AuditDelete AuditAction = "delete"
+)
+
+// AuditEvent is the record of a mutating operation of the API.
+type AuditEvent struct {
+	ID    ID `json:"id"`
+	OrgID ID `json:"orgID,omitempty"`
+	// ActorID is the user that made the operation.
+	ActorID ID `json:"actorID,omitempty"`
+	// AuthorizationID is the token or session the operation was made with.
+	AuthorizationID ID `json:"authorizationID,omitempty"`
+	// AuthorizationKind is the kind of the authorizer of the operation, token or session.
+	AuthorizationKind string       `json:"authorizationKind,omitempty"`
+	ResourceType      ResourceType `json:"resourceType,omitempty"`
+	ResourceID        ID           `json:"resourceID,omitempty"`
+	Action            AuditAction  `json:"action"`
+	Method            string       `json:"method"`
+	Path              string       `json:"path"`
+	// Status is the HTTP status the operation was answered with.
+	Status int `json:"status"`
+	// Diff is the change requested by the operation, with its secrets redacted.
+	Diff json.RawMessage `json:"diff,omitempty"`
+	Time time.Time       `json:"time"`
+}
+
+// AuditEventFilter represents a set of filters that restrict the returned audit events.
+type AuditEventFilter struct {
+	OrgID           *ID
+	ActorID         *ID
+	AuthorizationID *ID
+	ResourceType    *ResourceType
+	ResourceID      *ID
+	Action          *AuditAction
+	// Start and Stop bound the time of the events to [Start, Stop).
+	Start *time.Time
+	Stop  *time.Time
+}
+
+// Matches returns whether the audit event matches the filter.
+func (f AuditEventFilter) Matches(e *AuditEvent) bool {
+	switch {
+	case f.OrgID != nil && *f.OrgID != e.OrgID:
+		return false
+	case f.ActorID != nil && *f.ActorID != e.ActorID:
+		return false
+	case f.AuthorizationID != nil && *f.AuthorizationID != e.AuthorizationID:
+		return false
+	case f.ResourceType != nil && *f.ResourceType != e.ResourceType:
+		return false
+	case f.ResourceID != nil && *f.ResourceID != e.ResourceID:
+		return false
+	case f.Action != nil && *f.Action != e.Action:
+		return false
+	case f.Start != nil && e.Time.Before(*f.Start):
+		return false
+	case f.Stop != nil && !e.Time.Before(*f.Stop):
+		return false
+	}
+	return true
+}
+
+// QueryParams converts AuditEventFilter fields to url query params.
+func (f AuditEventFilter) QueryParams() map[string][]string {
+	qp := map[string][]string{}
+	if f.OrgID != nil {
+		qp["orgID"] = []string{f.OrgID.String()}
+	}
+	if f.ActorID != nil {
+		qp["actorID"] = []string{f.ActorID.String()}
+	}
+	if f.AuthorizationID != nil {
+		qp["authorizationID"] = []string{f.AuthorizationID.String()}
+	}
+	if f.ResourceType != nil {
+		qp["resourceType"] = []string{string(*f.ResourceType)}
+	}
+	if f.ResourceID != nil {
+		qp["resourceID"] = []string{f.ResourceID.String()}
+	}
+	if f.Action != nil {
+		qp["action"] = []string{string(*f.Action)}
+	}
+	if f.Start != nil {
+		qp["start"] = []string{f.Start.UTC().Format(time.RFC3339Nano)}
+	}
+	if f.Stop != nil {
+		qp["stop"] = []string{f.Stop.UTC().Format(time.RFC3339Nano)}
+	}
+	return qp
+}
+
+// AuditService records and retrieves the audit events of the API.
+type AuditService interface {
+	// RecordAuditEvent records the audit event and sets its ID.
+	RecordAuditEvent(ctx context.Context, e *AuditEvent) error
+
+	// FindAuditEvents returns the audit events matching the filter, most recent first, and their count.
+	FindAuditEvents(ctx context.Context, filter AuditEventFilter, opts ...FindOptions) ([]*AuditEvent, int, error)
+}
@@ -3,6 +3,7 @@ package influxdb
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 // AuthorizationKind is returned by (*Authorization).Kind().
@@ -14,6 +15,16 @@ var ErrUnableToCreateToken = &Error{
 	Code: EInvalid,
 }
 
+// ErrAuthorizationExpired is the error message for expired authorizations.
+const ErrAuthorizationExpired = "authorization has expired"
+
+const (
+	// DefaultChildAuthorizationLifetime is how long a child authorization lives when it has no expiration.
+	DefaultChildAuthorizationLifetime = time.Hour
+	// MaxChildAuthorizationLifetime is the longest a child authorization can live.
+	MaxChildAuthorizationLifetime = 24 * time.Hour
+)
+
 // Authorization is an authorization. 🎉
 type Authorization struct {
 	ID          ID           `json:"id"`
@@ -23,6 +34,10 @@ type Authorization struct {
 	OrgID       ID           `json:"orgID"`
 	UserID      ID           `json:"userID,omitempty"`
 	Permissions []Permission `json:"permissions"`
+	// ExpiresAt is the time the authorization stops being valid at, it never expires if nil.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// ParentID is the authorization a child authorization was created from.
+	ParentID ID `json:"parentID,omitempty"`
 	CRUDLog
 }
 
@@ -46,16 +61,33 @@ func (a *Authorization) Valid() error {
 	return nil
 }
 
-// Allowed returns true if the authorization is active and request permission
+// Expired returns an error if the authorization is expired.
+func (a *Authorization) Expired() error {
+	if a.ExpiresAt != nil && !time.Now().Before(*a.ExpiresAt) {
+		return &Error{
+			Code: EUnauthorized,
+			Msg:  ErrAuthorizationExpired,
+		}
+	}
+
+	return nil
+}
+
+// Allowed returns true if the authorization is active, unexpired and request permission
 // exists in the authorization's list of permissions.
 func (a *Authorization) Allowed(p Permission) bool {
-	if !a.IsActive() {
+	if !a.IsActive() || a.Expired() != nil {
 		return false
 	}
 
 	return PermissionAllowed(p, a.Permissions)
 }
 
+// DataRestrictions returns the restrictions the authorization puts on the data of the resource of p.
+func (a *Authorization) DataRestrictions(p Permission) DataRestrictions {
+	return PermissionDataRestrictions(p, a.Permissions)
+}
+
 // IsActive is a stub for idpe.
 func IsActive(a *Authorization) bool {
 	return a.IsActive()
@@ -109,6 +141,13 @@ type AuthorizationService interface {
 	DeleteAuthorization(ctx context.Context, id ID) error
 }
 
+// ChildAuthorizationService creates short-lived authorizations from the authorization of the caller.
+type ChildAuthorizationService interface {
+	// CreateChildAuthorization creates the authorization a from the authorization on the context,
+	// with a subset of its permissions and an expiration no later than its own.
+	CreateChildAuthorization(ctx context.Context, a *Authorization) error
+}
+
 // AuthorizationFilter represents a set of filter that restrict the returned results.
 type AuthorizationFilter struct {
 	Token *string
@@ -0,0 +1,85 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/query"
+)
+
+var _ query.ActiveQueryService = (*ActiveQueryService)(nil)
+
+// ActiveQueryService wraps a query.ActiveQueryService and authorizes actions
+// against it appropriately.
+type ActiveQueryService struct {
+	s query.ActiveQueryService
+}
+
+// NewActiveQueryService constructs an instance of an authorizing active query service.
+func NewActiveQueryService(s query.ActiveQueryService) *ActiveQueryService {
+	return &ActiveQueryService{
+		s: s,
+	}
+}
+
+// FindActiveQueries retrieves all active queries that match the provided filter and then filters the list down to only the queries
+// of the orgs the authorizer on context has read access to.
+func (s *ActiveQueryService) FindActiveQueries(ctx context.Context, filter query.ActiveQueryFilter) ([]*query.ActiveQuery, error) {
+	qs, err := s.s.FindActiveQueries(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// This filters without allocating
+	// https://github.com/golang/go/wiki/SliceTricks#filtering-without-allocating
+	queries := qs[:0]
+	for _, q := range qs {
+		err := authorizeReadOrg(ctx, q.OrganizationID)
+		if err != nil && influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+			return nil, err
+		}
+
+		if influxdb.ErrorCode(err) == influxdb.EUnauthorized {
+			continue
+		}
+
+		queries = append(queries, q)
+	}
+
+	return queries, nil
+}
+
+// FindActiveQueryByID checks to see if the authorizer on context has read access to the org of the query.
+func (s *ActiveQueryService) FindActiveQueryByID(ctx context.Context, id influxdb.ID) (*query.ActiveQuery, error) {
+	q, err := s.s.FindActiveQueryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeReadOrg(ctx, q.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+// CancelActiveQuery checks to see if the authorizer on context made the query, or else has write access to the org of the query.
+func (s *ActiveQueryService) CancelActiveQuery(ctx context.Context, id influxdb.ID) error {
+	q, err := s.FindActiveQueryByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	a, err := influxdbcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return err
+	}
+	if q.UserID == 0 || a.GetUserID() != q.UserID {
+		if err := authorizeWriteOrg(ctx, q.OrganizationID); err != nil {
+			return err
+		}
+	}
+
+	return s.s.CancelActiveQuery(ctx, id)
+}
@@ -0,0 +1,147 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/query/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func newActiveQueryService(qs ...*query.ActiveQuery) *mock.ActiveQueryService {
+	return &mock.ActiveQueryService{
+		FindActiveQueriesF: func(ctx context.Context, filter query.ActiveQueryFilter) ([]*query.ActiveQuery, error) {
+			return append([]*query.ActiveQuery{}, qs...), nil
+		},
+		FindActiveQueryByIDF: func(ctx context.Context, id influxdb.ID) (*query.ActiveQuery, error) {
+			for _, q := range qs {
+				if q.ID == id {
+					return q, nil
+				}
+			}
+			return nil, &influxdb.Error{Code: influxdb.ENotFound, Msg: "active query not found"}
+		},
+		CancelActiveQueryF: func(ctx context.Context, id influxdb.ID) error {
+			return nil
+		},
+	}
+}
+
+func TestActiveQueryService_FindActiveQueries(t *testing.T) {
+	s := authorizer.NewActiveQueryService(newActiveQueryService(
+		&query.ActiveQuery{ID: 1, OrganizationID: 10},
+		&query.ActiveQuery{ID: 2, OrganizationID: 11},
+	))
+
+	ctx := influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{[]influxdb.Permission{
+		{
+			Action: influxdb.ReadAction,
+			Resource: influxdb.Resource{
+				Type: influxdb.OrgsResourceType,
+				ID:   influxdbtesting.IDPtr(10),
+			},
+		},
+	}})
+
+	qs, err := s.FindActiveQueries(ctx, query.ActiveQueryFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*query.ActiveQuery{{ID: 1, OrganizationID: 10}}
+	if diff := cmp.Diff(want, qs); diff != "" {
+		t.Errorf("active queries are different -want/+got\ndiff %s", diff)
+	}
+}
+
+func TestActiveQueryService_CancelActiveQuery(t *testing.T) {
+	type args struct {
+		permissions []influxdb.Permission
+		id          influxdb.ID
+	}
+	type wants struct {
+		err error
+	}
+
+	member := influxdb.Permission{
+		Action: influxdb.ReadAction,
+		Resource: influxdb.Resource{
+			Type: influxdb.OrgsResourceType,
+			ID:   influxdbtesting.IDPtr(10),
+		},
+	}
+	owner := influxdb.Permission{
+		Action: influxdb.WriteAction,
+		Resource: influxdb.Resource{
+			Type: influxdb.OrgsResourceType,
+			ID:   influxdbtesting.IDPtr(10),
+		},
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "org members can cancel their own queries",
+			args: args{
+				permissions: []influxdb.Permission{member},
+				id:          1,
+			},
+		},
+		{
+			name: "org members cannot cancel the queries of others",
+			args: args{
+				permissions: []influxdb.Permission{member},
+				id:          2,
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "write:orgs/000000000000000a is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+		{
+			name: "org owners can cancel the queries of others",
+			args: args{
+				permissions: []influxdb.Permission{member, owner},
+				id:          2,
+			},
+		},
+		{
+			name: "cannot cancel the queries of another org",
+			args: args{
+				permissions: []influxdb.Permission{member, owner},
+				id:          3,
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "read:orgs/000000000000000b is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the mock authorizer is user 2.
+			s := authorizer.NewActiveQueryService(newActiveQueryService(
+				&query.ActiveQuery{ID: 1, OrganizationID: 10, UserID: 2},
+				&query.ActiveQuery{ID: 2, OrganizationID: 10, UserID: 3},
+				&query.ActiveQuery{ID: 3, OrganizationID: 11, UserID: 2},
+			))
+
+			ctx := influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{tt.args.permissions})
+
+			err := s.CancelActiveQuery(ctx, tt.args.id)
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.AuditService = (*AuditService)(nil)
+
+// AuditService wraps a influxdb.AuditService and authorizes actions
+// against it appropriately.
+type AuditService struct {
+	s influxdb.AuditService
+}
+
+// NewAuditService constructs an instance of an authorizing audit service.
+func NewAuditService(s influxdb.AuditService) *AuditService {
+	return &AuditService{
+		s: s,
+	}
+}
+
+// RecordAuditEvent records the audit event, it is made by the server on behalf of any caller.
+func (s *AuditService) RecordAuditEvent(ctx context.Context, e *influxdb.AuditEvent) error {
+	return s.s.RecordAuditEvent(ctx, e)
+}
+
+// FindAuditEvents checks to see if the authorizer on context has write access to the org of the filter,
+// the audit events of all orgs need write access to all orgs.
+func (s *AuditService) FindAuditEvents(ctx context.Context, filter influxdb.AuditEventFilter, opts ...influxdb.FindOptions) ([]*influxdb.AuditEvent, int, error) {
+	if filter.OrgID != nil {
+		if err := authorizeWriteOrg(ctx, *filter.OrgID); err != nil {
+			return nil, 0, err
+		}
+		return s.s.FindAuditEvents(ctx, filter, opts...)
+	}
+
+	p, err := influxdb.NewGlobalPermission(influxdb.WriteAction, influxdb.OrgsResourceType)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := IsAllowed(ctx, *p); err != nil {
+		return nil, 0, err
+	}
+	return s.s.FindAuditEvents(ctx, filter, opts...)
+}
@@ -3,11 +3,16 @@ package authorizer
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/influxdata/influxdb"
+	influxdbcontext "github.com/influxdata/influxdb/context"
 )
 
-var _ influxdb.AuthorizationService = (*AuthorizationService)(nil)
+var (
+	_ influxdb.AuthorizationService      = (*AuthorizationService)(nil)
+	_ influxdb.ChildAuthorizationService = (*AuthorizationService)(nil)
+)
 
 // AuthorizationService wraps a influxdb.AuthorizationService and authorizes actions
 // against it appropriately.
@@ -128,7 +133,61 @@ func (s *AuthorizationService) CreateAuthorization(ctx context.Context, a *influ
 	return s.s.CreateAuthorization(ctx, a)
 }
 
+// CreateChildAuthorization creates the authorization a from the token on context: a gets a subset of the token's permissions,
+// and expires no later than the token, nor than the longest lifetime of a child authorization.
+// a expires after the default lifetime of a child authorization if it has no expiration.
+func (s *AuthorizationService) CreateChildAuthorization(ctx context.Context, a *influxdb.Authorization) error {
+	auth, err := influxdbcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return err
+	}
+
+	parent, ok := auth.(*influxdb.Authorization)
+	if !ok {
+		return &influxdb.Error{
+			Code: influxdb.EForbidden,
+			Msg:  "child authorizations can only be created with a token",
+		}
+	}
+	if !parent.IsActive() || parent.Expired() != nil {
+		return &influxdb.Error{
+			Code: influxdb.EForbidden,
+			Msg:  "child authorizations cannot be created with an inactive or expired token",
+		}
+	}
+
+	if err := VerifyPermissions(ctx, a.Permissions); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	latest := now.Add(influxdb.MaxChildAuthorizationLifetime)
+	if parent.ExpiresAt != nil && parent.ExpiresAt.Before(latest) {
+		latest = *parent.ExpiresAt
+	}
+	if a.ExpiresAt == nil {
+		expiresAt := now.Add(influxdb.DefaultChildAuthorizationLifetime)
+		if expiresAt.After(latest) {
+			expiresAt = latest
+		}
+		a.ExpiresAt = &expiresAt
+	} else if a.ExpiresAt.After(latest) {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("child authorization must expire by %s", latest.UTC().Format(time.RFC3339)),
+		}
+	}
+
+	a.OrgID = parent.OrgID
+	a.UserID = parent.UserID
+	a.ParentID = parent.ID
+	a.Status = influxdb.Active
+
+	return s.s.CreateAuthorization(ctx, a)
+}
+
 // VerifyPermission ensures that an authorization is allowed all of the appropriate permissions.
+// A permission without restriction is not allowed over the data the authorizer restricts.
 func VerifyPermissions(ctx context.Context, ps []influxdb.Permission) error {
 	for _, p := range ps {
 		if err := IsAllowed(ctx, p); err != nil {
@@ -138,6 +197,15 @@ func VerifyPermissions(ctx context.Context, ps []influxdb.Permission) error {
 				Code: influxdb.EForbidden,
 			}
 		}
+
+		// the authorizer is on context since p is allowed.
+		a, _ := influxdbcontext.GetAuthorizer(ctx)
+		if p.Restriction == nil && influxdb.AuthorizerDataRestrictions(a, p) != nil {
+			return &influxdb.Error{
+				Msg:  fmt.Sprintf("permission %s is not allowed without restriction", p),
+				Code: influxdb.EForbidden,
+			}
+		}
 	}
 
 	return nil
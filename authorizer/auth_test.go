@@ -5,6 +5,7 @@ import (
 	"context"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/influxdb"
@@ -270,3 +271,106 @@ func TestAuthorizationService_WriteAuthorization(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthorizationService_CreateChildAuthorization(t *testing.T) {
+	var (
+		orgID    = influxdb.ID(0x7457)
+		bucketID = influxdb.ID(0x7456)
+		read     = influxdb.Permission{Action: influxdb.ReadAction, Resource: influxdb.Resource{Type: influxdb.BucketsResourceType, OrgID: &orgID, ID: &bucketID}}
+		write    = influxdb.Permission{Action: influxdb.WriteAction, Resource: influxdb.Resource{Type: influxdb.BucketsResourceType, OrgID: &orgID, ID: &bucketID}}
+		now      = time.Now()
+	)
+	in := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+	parent := func(expiresAt *time.Time) *influxdb.Authorization {
+		return &influxdb.Authorization{
+			ID:          10,
+			Status:      influxdb.Active,
+			OrgID:       orgID,
+			UserID:      1,
+			Permissions: []influxdb.Permission{read},
+			ExpiresAt:   expiresAt,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		caller  influxdb.Authorizer
+		child   *influxdb.Authorization
+		wantErr bool
+	}{
+		{
+			name:   "child of a token",
+			caller: parent(nil),
+			child:  &influxdb.Authorization{Permissions: []influxdb.Permission{read}},
+		},
+		{
+			name:   "child expiring before its token",
+			caller: parent(in(time.Hour)),
+			child:  &influxdb.Authorization{Permissions: []influxdb.Permission{read}, ExpiresAt: in(10 * time.Minute)},
+		},
+		{
+			name:    "child expiring after its token",
+			caller:  parent(in(10 * time.Minute)),
+			child:   &influxdb.Authorization{Permissions: []influxdb.Permission{read}, ExpiresAt: in(30 * time.Minute)},
+			wantErr: true,
+		},
+		{
+			name:    "child living too long",
+			caller:  parent(nil),
+			child:   &influxdb.Authorization{Permissions: []influxdb.Permission{read}, ExpiresAt: in(48 * time.Hour)},
+			wantErr: true,
+		},
+		{
+			name:    "child with a permission its token does not have",
+			caller:  parent(nil),
+			child:   &influxdb.Authorization{Permissions: []influxdb.Permission{read, write}},
+			wantErr: true,
+		},
+		{
+			name:    "child of an expired token",
+			caller:  parent(in(-time.Minute)),
+			child:   &influxdb.Authorization{Permissions: []influxdb.Permission{read}},
+			wantErr: true,
+		},
+		{
+			name:    "child of a session",
+			caller:  &influxdb.Session{UserID: 1, ExpiresAt: now.Add(time.Hour), Permissions: []influxdb.Permission{read}},
+			child:   &influxdb.Authorization{Permissions: []influxdb.Permission{read}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *influxdb.Authorization
+			s := authorizer.NewAuthorizationService(&mock.AuthorizationService{
+				CreateAuthorizationFn: func(ctx context.Context, a *influxdb.Authorization) error {
+					created = a
+					return nil
+				},
+			})
+
+			ctx := influxdbcontext.SetAuthorizer(context.Background(), tt.caller)
+			err := s.CreateChildAuthorization(ctx, tt.child)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if created == nil {
+				t.Fatal("expected the child authorization to be created")
+			}
+			if created.ParentID != 10 || created.OrgID != orgID || created.UserID != 1 || created.Status != influxdb.Active {
+				t.Errorf("unexpected child authorization %+v", created)
+			}
+			if created.ExpiresAt == nil || created.ExpiresAt.After(now.Add(influxdb.MaxChildAuthorizationLifetime)) {
+				t.Errorf("expected the child authorization to expire, got %v", created.ExpiresAt)
+			}
+		})
+	}
+}
@@ -3,46 +3,131 @@ package authorizer
 import (
 	"context"
 	"io"
+	"path/filepath"
+	"sync"
 
 	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/influxdata/influxdb/tsdb/tsm1"
 )
 
-var _ influxdb.BackupService = (*BackupService)(nil)
+var (
+	_ influxdb.BackupService   = (*BackupService)(nil)
+	_ influxdb.KVBackupService = (*KVBackupService)(nil)
+)
 
 // BackupService wraps a influxdb.BackupService and authorizes actions
 // against it appropriately.
 type BackupService struct {
 	s influxdb.BackupService
+
+	mu sync.Mutex
+	// filters are the filters of the backups that were created, by backup ID.
+	filters map[int]influxdb.BackupFilter
 }
 
 // NewBackupService constructs an instance of an authorizing backup service.
 func NewBackupService(s influxdb.BackupService) *BackupService {
 	return &BackupService{
-		s: s,
+		s:       s,
+		filters: make(map[int]influxdb.BackupFilter),
 	}
 }
 
-func (b BackupService) CreateBackup(ctx context.Context) (int, []string, error) {
+// CreateBackup checks to see if the authorizer on context has read access to the data
+// of the backup. A backup of a bucket or an organization requires read access to the
+// bucket or to the buckets of the organization. Any other backup requires read access
+// to everything.
+func (b *BackupService) CreateBackup(ctx context.Context, filter influxdb.BackupFilter) (int, []string, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx)
 	defer span.Finish()
 
-	if err := IsAllowedAll(ctx, influxdb.ReadAllPermissions()); err != nil {
+	if err := authorizeReadBackup(ctx, filter); err != nil {
 		return 0, nil, err
 	}
-	return b.s.CreateBackup(ctx)
+	id, files, err := b.s.CreateBackup(ctx, filter)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	b.mu.Lock()
+	b.filters[id] = filter
+	b.mu.Unlock()
+	return id, files, nil
 }
 
-func (b BackupService) FetchBackupFile(ctx context.Context, backupID int, backupFile string, w io.Writer) error {
+// FetchBackupFile checks to see if the authorizer on context has read access to the file.
+// The TSM data and manifest of a backup require the same access as creating the backup.
+// Any other file, such as the metadata of the instance, requires read access to everything.
+func (b *BackupService) FetchBackupFile(ctx context.Context, backupID int, backupFile string, w io.Writer) error {
 	span, ctx := tracing.StartSpanFromContext(ctx)
 	defer span.Finish()
 
-	if err := IsAllowedAll(ctx, influxdb.ReadAllPermissions()); err != nil {
+	b.mu.Lock()
+	filter, ok := b.filters[backupID]
+	b.mu.Unlock()
+
+	if ok && isBackupDataFile(backupFile) {
+		if err := authorizeReadBackup(ctx, filter); err != nil {
+			return err
+		}
+	} else if err := IsAllowedAll(ctx, influxdb.ReadAllPermissions()); err != nil {
 		return err
 	}
 	return b.s.FetchBackupFile(ctx, backupID, backupFile, w)
 }
 
-func (b BackupService) InternalBackupPath(backupID int) string {
+func (b *BackupService) InternalBackupPath(backupID int) string {
 	return b.s.InternalBackupPath(backupID)
 }
+
+// authorizeReadBackup authorizes reading the data that matches the backup filter.
+func authorizeReadBackup(ctx context.Context, filter influxdb.BackupFilter) error {
+	switch {
+	case filter.OrgID != nil && filter.BucketID != nil:
+		return authorizeReadBucket(ctx, *filter.OrgID, *filter.BucketID)
+	case filter.OrgID != nil:
+		p, err := influxdb.NewPermission(influxdb.ReadAction, influxdb.BucketsResourceType, *filter.OrgID)
+		if err != nil {
+			return err
+		}
+		return IsAllowed(ctx, *p)
+	default:
+		return IsAllowedAll(ctx, influxdb.ReadAllPermissions())
+	}
+}
+
+// isBackupDataFile returns true if the file is one of the TSM files, tombstone
+// files or the manifest written by the storage engine for a backup.
+func isBackupDataFile(name string) bool {
+	switch filepath.Ext(name) {
+	case "." + tsm1.TSMFileExtension, "." + tsm1.TombstoneFileExtension, influxdb.BackupManifestExtension:
+		return true
+	}
+	return false
+}
+
+// KVBackupService wraps a influxdb.KVBackupService and authorizes actions
+// against it appropriately.
+type KVBackupService struct {
+	s influxdb.KVBackupService
+}
+
+// NewKVBackupService constructs an instance of an authorizing metadata backup service.
+func NewKVBackupService(s influxdb.KVBackupService) *KVBackupService {
+	return &KVBackupService{
+		s: s,
+	}
+}
+
+// Backup checks to see if the authorizer on context has read access to everything,
+// since the metadata includes the authorizations of every user.
+func (b *KVBackupService) Backup(ctx context.Context, w io.Writer) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if err := IsAllowedAll(ctx, influxdb.ReadAllPermissions()); err != nil {
+		return err
+	}
+	return b.s.Backup(ctx, w)
+}
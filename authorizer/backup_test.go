@@ -0,0 +1,207 @@
+package authorizer_test
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestBackupService_CreateBackup(t *testing.T) {
+	type args struct {
+		permissions []influxdb.Permission
+		filter      influxdb.BackupFilter
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to read everything",
+			args: args{
+				permissions: influxdb.ReadAllPermissions(),
+			},
+		},
+		{
+			name: "unauthorized to read everything",
+			args: args{
+				permissions: []influxdb.Permission{{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type:  influxdb.BucketsResourceType,
+						OrgID: influxdbtesting.IDPtr(10),
+					},
+				}},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "read:authorizations is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+		{
+			name: "authorized to read the buckets of the org",
+			args: args{
+				permissions: []influxdb.Permission{{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type:  influxdb.BucketsResourceType,
+						OrgID: influxdbtesting.IDPtr(10),
+					},
+				}},
+				filter: influxdb.BackupFilter{
+					OrgID: influxdbtesting.IDPtr(10),
+				},
+			},
+		},
+		{
+			name: "authorized to read the bucket",
+			args: args{
+				permissions: []influxdb.Permission{{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.BucketsResourceType,
+						ID:   influxdbtesting.IDPtr(1),
+					},
+				}},
+				filter: influxdb.BackupFilter{
+					OrgID:    influxdbtesting.IDPtr(10),
+					BucketID: influxdbtesting.IDPtr(1),
+				},
+			},
+		},
+		{
+			name: "unauthorized to read the bucket",
+			args: args{
+				permissions: []influxdb.Permission{{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.BucketsResourceType,
+						ID:   influxdbtesting.IDPtr(2),
+					},
+				}},
+				filter: influxdb.BackupFilter{
+					OrgID:    influxdbtesting.IDPtr(10),
+					BucketID: influxdbtesting.IDPtr(1),
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "read:orgs/000000000000000a/buckets/0000000000000001 is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewBackupService(mock.NewBackupService())
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{tt.args.permissions})
+
+			_, _, err := s.CreateBackup(ctx, tt.args.filter)
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
+
+func TestBackupService_FetchBackupFile(t *testing.T) {
+	bucketRead := influxdb.Permission{
+		Action: "read",
+		Resource: influxdb.Resource{
+			Type: influxdb.BucketsResourceType,
+			ID:   influxdbtesting.IDPtr(1),
+		},
+	}
+
+	type args struct {
+		permissions []influxdb.Permission
+		file        string
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to fetch a TSM file of the bucket",
+			args: args{
+				permissions: []influxdb.Permission{bucketRead},
+				file:        "20200101T000000.000000000Z-000000001-000000001.tsm",
+			},
+		},
+		{
+			name: "authorized to fetch the manifest of the bucket",
+			args: args{
+				permissions: []influxdb.Permission{bucketRead},
+				file:        "20200101T000000.000000000Z.manifest",
+			},
+		},
+		{
+			name: "unauthorized to fetch the metadata",
+			args: args{
+				permissions: []influxdb.Permission{bucketRead},
+				file:        "influxd.bolt",
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "read:authorizations is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+		{
+			name: "authorized to fetch the metadata",
+			args: args{
+				permissions: influxdb.ReadAllPermissions(),
+				file:        "influxd.bolt",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewBackupService(&mock.BackupService{
+				CreateBackupF: func(ctx context.Context, filter influxdb.BackupFilter) (int, []string, error) {
+					return 1, nil, nil
+				},
+				FetchBackupFileF: func(ctx context.Context, backupID int, backupFile string, w io.Writer) error {
+					return nil
+				},
+			})
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{bucketRead}})
+			filter := influxdb.BackupFilter{
+				OrgID:    influxdbtesting.IDPtr(10),
+				BucketID: influxdbtesting.IDPtr(1),
+			}
+			id, _, err := s.CreateBackup(ctx, filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx = influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{tt.args.permissions})
+			err = s.FetchBackupFile(ctx, id, tt.args.file, ioutil.Discard)
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
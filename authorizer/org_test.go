@@ -317,6 +317,7 @@ func TestOrgService_UpdateOrganization(t *testing.T) {
 	type args struct {
 		id         influxdb.ID
 		permission influxdb.Permission
+		upd        influxdb.OrganizationUpdate
 	}
 	type wants struct {
 		err error
@@ -381,6 +382,64 @@ func TestOrgService_UpdateOrganization(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "unauthorized to update org query quotas",
+			fields: fields{
+				OrgService: &mock.OrganizationService{
+					UpdateOrganizationF: func(ctx context.Context, id influxdb.ID, upd influxdb.OrganizationUpdate) (*influxdb.Organization, error) {
+						return &influxdb.Organization{
+							ID: 1,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				id: 1,
+				permission: influxdb.Permission{
+					Action: "write",
+					Resource: influxdb.Resource{
+						Type: influxdb.OrgsResourceType,
+						ID:   influxdbtesting.IDPtr(1),
+					},
+				},
+				upd: influxdb.OrganizationUpdate{
+					QueryQuotas: &influxdb.OrgQueryQuotas{ConcurrencyQuota: 10},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "write:orgs is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+		{
+			name: "authorized to update org query quotas",
+			fields: fields{
+				OrgService: &mock.OrganizationService{
+					UpdateOrganizationF: func(ctx context.Context, id influxdb.ID, upd influxdb.OrganizationUpdate) (*influxdb.Organization, error) {
+						return &influxdb.Organization{
+							ID: 1,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				id: 1,
+				permission: influxdb.Permission{
+					Action: "write",
+					Resource: influxdb.Resource{
+						Type: influxdb.OrgsResourceType,
+					},
+				},
+				upd: influxdb.OrganizationUpdate{
+					QueryQuotas: &influxdb.OrgQueryQuotas{ConcurrencyQuota: 10},
+				},
+			},
+			wants: wants{
+				err: nil,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -390,7 +449,7 @@ func TestOrgService_UpdateOrganization(t *testing.T) {
 			ctx := context.Background()
 			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
 
-			_, err := s.UpdateOrganization(ctx, tt.args.id, influxdb.OrganizationUpdate{})
+			_, err := s.UpdateOrganization(ctx, tt.args.id, tt.args.upd)
 			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
 		})
 	}
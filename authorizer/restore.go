@@ -0,0 +1,36 @@
+package authorizer
+
+import (
+	"context"
+	"io"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/tracing"
+)
+
+var _ influxdb.RestoreService = (*RestoreService)(nil)
+
+// RestoreService wraps a influxdb.RestoreService and authorizes actions
+// against it appropriately.
+type RestoreService struct {
+	s influxdb.RestoreService
+}
+
+// NewRestoreService constructs an instance of an authorizing restore service.
+func NewRestoreService(s influxdb.RestoreService) *RestoreService {
+	return &RestoreService{
+		s: s,
+	}
+}
+
+// RestoreBucket checks to see if the authorizer on context has write access to the bucket
+// the data is restored into.
+func (b RestoreService) RestoreBucket(ctx context.Context, req influxdb.RestoreBucketRequest, r io.Reader) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if err := authorizeWriteBucket(ctx, req.OrgID, req.BucketID); err != nil {
+		return err
+	}
+	return b.s.RestoreBucket(ctx, req, r)
+}
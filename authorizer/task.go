@@ -3,6 +3,7 @@ package authorizer
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/influxdata/influxdb"
 	platcontext "github.com/influxdata/influxdb/context"
@@ -337,3 +338,117 @@ func (ts *taskServiceValidator) validatePermission(ctx context.Context, perm inf
 
 	return nil
 }
+
+type taskBackfillServiceValidator struct {
+	influxdb.TaskBackfillService
+	tasks *taskServiceValidator
+}
+
+// NewTaskBackfillService wraps bs and checks the permissions on the backfilled task,
+// found with ts, before calling requested methods on bs.
+// Authorization failures are logged to the logger.
+func NewTaskBackfillService(log *zap.Logger, ts influxdb.TaskService, bs influxdb.TaskBackfillService) influxdb.TaskBackfillService {
+	return &taskBackfillServiceValidator{
+		TaskBackfillService: bs,
+		tasks: &taskServiceValidator{
+			TaskService: ts,
+			log:         log,
+		},
+	}
+}
+
+func (bs *taskBackfillServiceValidator) BackfillTask(ctx context.Context, taskID influxdb.ID, start, stop time.Time) (*influxdb.TaskBackfill, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if err := bs.validateTaskPermission(ctx, taskID, influxdb.WriteAction, "BackfillTask"); err != nil {
+		return nil, err
+	}
+
+	return bs.TaskBackfillService.BackfillTask(ctx, taskID, start, stop)
+}
+
+func (bs *taskBackfillServiceValidator) FindBackfillByID(ctx context.Context, taskID, backfillID influxdb.ID) (*influxdb.TaskBackfill, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if err := bs.validateTaskPermission(ctx, taskID, influxdb.ReadAction, "FindBackfillByID"); err != nil {
+		return nil, err
+	}
+
+	return bs.TaskBackfillService.FindBackfillByID(ctx, taskID, backfillID)
+}
+
+func (bs *taskBackfillServiceValidator) CancelBackfill(ctx context.Context, taskID, backfillID influxdb.ID) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if err := bs.validateTaskPermission(ctx, taskID, influxdb.WriteAction, "CancelBackfill"); err != nil {
+		return err
+	}
+
+	return bs.TaskBackfillService.CancelBackfill(ctx, taskID, backfillID)
+}
+
+func (bs *taskBackfillServiceValidator) validateTaskPermission(ctx context.Context, taskID influxdb.ID, action influxdb.Action, method string) error {
+	// Unauthenticated task lookup, to identify the task's organization.
+	task, err := bs.tasks.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	if action == influxdb.WriteAction && task.Status != string(influxdb.TaskActive) {
+		return ErrInactiveTask
+	}
+
+	p, err := influxdb.NewPermissionAtID(taskID, action, influxdb.TasksResourceType, task.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	return bs.tasks.validatePermission(ctx, *p,
+		zap.String("method", method), zap.Stringer("task_id", taskID),
+	)
+}
+
+type taskStatsServiceValidator struct {
+	influxdb.TaskStatsService
+	tasks *taskServiceValidator
+}
+
+// NewTaskStatsService wraps ss and checks the permissions on the task, found with ts,
+// before calling requested methods on ss.
+// Authorization failures are logged to the logger.
+func NewTaskStatsService(log *zap.Logger, ts influxdb.TaskService, ss influxdb.TaskStatsService) influxdb.TaskStatsService {
+	return &taskStatsServiceValidator{
+		TaskStatsService: ss,
+		tasks: &taskServiceValidator{
+			TaskService: ts,
+			log:         log,
+		},
+	}
+}
+
+func (ss *taskStatsServiceValidator) FindTaskStats(ctx context.Context, taskID influxdb.ID, start, stop time.Time) (*influxdb.TaskStats, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	// Unauthenticated task lookup, to identify the task's organization.
+	task, err := ss.tasks.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := influxdb.NewPermissionAtID(taskID, influxdb.ReadAction, influxdb.TasksResourceType, task.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ss.tasks.validatePermission(ctx, *p,
+		zap.String("method", "FindTaskStats"), zap.Stringer("task_id", taskID),
+	); err != nil {
+		return nil, err
+	}
+
+	return ss.TaskStatsService.FindTaskStats(ctx, taskID, start, stop)
+}
@@ -571,6 +571,143 @@ from(bucket:"holder") |> range(start:-5m) |> to(bucket:"holder", org:"thing")`
 	}
 }
 
+func TestBackfillValidations(t *testing.T) {
+	var (
+		orgID  = influxdb.ID(0x7457)
+		taskID = influxdb.ID(0x7456)
+		runID  = influxdb.ID(0x402)
+	)
+
+	svc := authorizer.NewTaskBackfillService(zaptest.NewLogger(t), mockTaskService(orgID, taskID, runID), &mock.TaskBackfillService{
+		BackfillTaskFn: func(context.Context, influxdb.ID, time.Time, time.Time) (*influxdb.TaskBackfill, error) {
+			return &influxdb.TaskBackfill{}, nil
+		},
+		FindBackfillByIDFn: func(context.Context, influxdb.ID, influxdb.ID) (*influxdb.TaskBackfill, error) {
+			return &influxdb.TaskBackfill{}, nil
+		},
+		CancelBackfillFn: func(context.Context, influxdb.ID, influxdb.ID) error {
+			return nil
+		},
+	})
+
+	var (
+		readPermissions = []influxdb.Permission{
+			{Action: influxdb.ReadAction, Resource: influxdb.Resource{Type: influxdb.TasksResourceType, OrgID: &orgID, ID: &taskID}},
+		}
+		writePermissions = []influxdb.Permission{
+			{Action: influxdb.WriteAction, Resource: influxdb.Resource{Type: influxdb.TasksResourceType, OrgID: &orgID, ID: &taskID}},
+		}
+	)
+
+	tests := []struct {
+		name    string
+		perms   []influxdb.Permission
+		check   func(ctx context.Context) error
+		wantErr bool
+	}{
+		{
+			name:  "BackfillTask with task write auth",
+			perms: writePermissions,
+			check: func(ctx context.Context) error {
+				_, err := svc.BackfillTask(ctx, taskID, time.Unix(0, 0), time.Unix(60, 0))
+				return err
+			},
+		},
+		{
+			name:  "BackfillTask with task read auth",
+			perms: readPermissions,
+			check: func(ctx context.Context) error {
+				_, err := svc.BackfillTask(ctx, taskID, time.Unix(0, 0), time.Unix(60, 0))
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name:  "FindBackfillByID with task read auth",
+			perms: readPermissions,
+			check: func(ctx context.Context) error {
+				_, err := svc.FindBackfillByID(ctx, taskID, 1)
+				return err
+			},
+		},
+		{
+			name:  "CancelBackfill with task write auth",
+			perms: writePermissions,
+			check: func(ctx context.Context) error {
+				return svc.CancelBackfill(ctx, taskID, 1)
+			},
+		},
+		{
+			name:  "CancelBackfill with task read auth",
+			perms: readPermissions,
+			check: func(ctx context.Context) error {
+				return svc.CancelBackfill(ctx, taskID, 1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := pctx.SetAuthorizer(context.Background(), &influxdb.Authorization{Status: "active", Permissions: test.perms})
+			if err := test.check(ctx); (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTaskStatsValidations(t *testing.T) {
+	var (
+		orgID  = influxdb.ID(0x7457)
+		taskID = influxdb.ID(0x7456)
+		runID  = influxdb.ID(0x402)
+	)
+
+	svc := authorizer.NewTaskStatsService(zaptest.NewLogger(t), mockTaskService(orgID, taskID, runID), &mock.TaskStatsService{
+		FindTaskStatsFn: func(context.Context, influxdb.ID, time.Time, time.Time) (*influxdb.TaskStats, error) {
+			return &influxdb.TaskStats{}, nil
+		},
+	})
+
+	otherOrg := influxdb.ID(0x7458)
+	tests := []struct {
+		name    string
+		perms   []influxdb.Permission
+		wantErr bool
+	}{
+		{
+			name: "FindTaskStats with task read auth",
+			perms: []influxdb.Permission{
+				{Action: influxdb.ReadAction, Resource: influxdb.Resource{Type: influxdb.TasksResourceType, OrgID: &orgID, ID: &taskID}},
+			},
+		},
+		{
+			name: "FindTaskStats with org read auth",
+			perms: []influxdb.Permission{
+				{Action: influxdb.ReadAction, Resource: influxdb.Resource{Type: influxdb.TasksResourceType, OrgID: &orgID}},
+			},
+		},
+		{
+			name: "FindTaskStats with another org's read auth",
+			perms: []influxdb.Permission{
+				{Action: influxdb.ReadAction, Resource: influxdb.Resource{Type: influxdb.TasksResourceType, OrgID: &otherOrg}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := pctx.SetAuthorizer(context.Background(), &influxdb.Authorization{Status: "active", Permissions: test.perms})
+			_, err := svc.FindTaskStats(ctx, taskID, time.Unix(0, 0), time.Unix(60, 0))
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
 func newKVSVC(t *testing.T) *kv.Service {
 	t.Helper()
 
@@ -205,9 +205,13 @@ func (t ResourceType) Valid() (err error) {
 type Permission struct {
 	Action   Action   `json:"action"`
 	Resource Resource `json:"resource"`
+	// Restriction restricts a bucket permission to some of the data of the bucket.
+	Restriction *DataRestriction `json:"restriction,omitempty"`
 }
 
 // Matches returns whether or not one permission matches the other.
+// A permission without restriction is matched regardless of the restriction of p,
+// the data of the resource is then restricted by DataRestrictions.
 func (p Permission) Matches(perm Permission) bool {
 	if p.Action != perm.Action {
 		return false
@@ -217,6 +221,10 @@ func (p Permission) Matches(perm Permission) bool {
 		return false
 	}
 
+	if perm.Restriction != nil && !p.Restriction.Covers(perm.Restriction) {
+		return false
+	}
+
 	if p.Resource.OrgID == nil && p.Resource.ID == nil {
 		return true
 	}
@@ -282,9 +290,138 @@ func (p *Permission) Valid() error {
 		}
 	}
 
+	if p.Restriction != nil && p.Resource.Type != BucketsResourceType {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "only bucket permissions can be restricted",
+		}
+	}
+
+	return nil
+}
+
+// DataRestriction restricts a bucket permission to the points of some measurements, with some tags.
+type DataRestriction struct {
+	// Measurements are the measurements of the points, any measurement if empty.
+	Measurements []string `json:"measurements,omitempty"`
+	// Tags are the tags the points all have.
+	Tags []Tag `json:"tags,omitempty"`
+}
+
+// Allows returns whether the point of measurement m with the tag values returned by tag is allowed.
+func (r *DataRestriction) Allows(m string, tag func(key string) string) bool {
+	if r == nil {
+		return true
+	}
+
+	if len(r.Measurements) > 0 && !containsString(r.Measurements, m) {
+		return false
+	}
+
+	for _, t := range r.Tags {
+		if tag(t.Key) != t.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// Covers returns whether all the data allowed by o is allowed by r.
+// A nil restriction allows all the data.
+func (r *DataRestriction) Covers(o *DataRestriction) bool {
+	if r == nil {
+		return true
+	}
+	if o == nil {
+		return false
+	}
+
+	if len(r.Measurements) > 0 {
+		if len(o.Measurements) == 0 {
+			return false
+		}
+		for _, m := range o.Measurements {
+			if !containsString(r.Measurements, m) {
+				return false
+			}
+		}
+	}
+
+	for _, t := range r.Tags {
+		var found bool
+		for _, ot := range o.Tags {
+			if ot == t {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// DataRestrictions are the restrictions on the data of a resource, a point is allowed by any of them.
+// Nil restrictions allow all the data.
+type DataRestrictions []DataRestriction
+
+// Allows returns whether the point of measurement m with the tag values returned by tag is allowed.
+func (rs DataRestrictions) Allows(m string, tag func(key string) string) bool {
+	if rs == nil {
+		return true
+	}
+
+	for _, r := range rs {
+		if r.Allows(m, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionDataRestrictions returns the restrictions the permissions ps put on the data of the resource of perm.
+// It is nil when one of the permissions matching perm is not restricted.
+func PermissionDataRestrictions(perm Permission, ps []Permission) DataRestrictions {
+	perm.Restriction = nil
+
+	var rs DataRestrictions
+	for _, p := range ps {
+		if !p.Matches(perm) {
+			continue
+		}
+		if p.Restriction == nil {
+			return nil
+		}
+		rs = append(rs, *p.Restriction)
+	}
+	return rs
+}
+
+// DataRestricter is implemented by the authorizers whose permissions can restrict the data of a resource.
+type DataRestricter interface {
+	// DataRestrictions returns the restrictions on the data of the resource of p.
+	DataRestrictions(p Permission) DataRestrictions
+}
+
+// AuthorizerDataRestrictions returns the restrictions the authorizer puts on the data of the resource of p,
+// nil if it does not restrict the data.
+func AuthorizerDataRestrictions(a Authorizer, p Permission) DataRestrictions {
+	if r, ok := a.(DataRestricter); ok {
+		return r.DataRestrictions(p)
+	}
 	return nil
 }
 
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
 // NewPermission returns a permission with provided arguments.
 func NewPermission(a Action, rt ResourceType, orgID ID) (*Permission, error) {
 	p := &Permission{
@@ -1,6 +1,7 @@
 package influxdb_test
 
 import (
+	"strings"
 	"testing"
 
 	platform "github.com/influxdata/influxdb"
@@ -229,6 +230,78 @@ func TestAuthorizer_PermissionAllowed(t *testing.T) {
 			},
 			allowed: false,
 		},
+		{
+			name: "restricted permission allows the resource",
+			permission: platform.Permission{
+				Action: platform.ReadAction,
+				Resource: platform.Resource{
+					Type:  platform.BucketsResourceType,
+					OrgID: influxdbtesting.IDPtr(1),
+					ID:    influxdbtesting.IDPtr(1),
+				},
+			},
+			permissions: []platform.Permission{
+				{
+					Action: platform.ReadAction,
+					Resource: platform.Resource{
+						Type:  platform.BucketsResourceType,
+						OrgID: influxdbtesting.IDPtr(1),
+						ID:    influxdbtesting.IDPtr(1),
+					},
+					Restriction: &platform.DataRestriction{Measurements: []string{"cpu"}},
+				},
+			},
+			allowed: true,
+		},
+		{
+			name: "restricted permission allows a narrower restriction",
+			permission: platform.Permission{
+				Action: platform.ReadAction,
+				Resource: platform.Resource{
+					Type:  platform.BucketsResourceType,
+					OrgID: influxdbtesting.IDPtr(1),
+					ID:    influxdbtesting.IDPtr(1),
+				},
+				Restriction: &platform.DataRestriction{
+					Measurements: []string{"cpu"},
+					Tags:         []platform.Tag{{Key: "team", Value: "a"}},
+				},
+			},
+			permissions: []platform.Permission{
+				{
+					Action: platform.ReadAction,
+					Resource: platform.Resource{
+						Type:  platform.BucketsResourceType,
+						OrgID: influxdbtesting.IDPtr(1),
+					},
+					Restriction: &platform.DataRestriction{Measurements: []string{"cpu", "mem"}},
+				},
+			},
+			allowed: true,
+		},
+		{
+			name: "restricted permission does not allow a wider restriction",
+			permission: platform.Permission{
+				Action: platform.ReadAction,
+				Resource: platform.Resource{
+					Type:  platform.BucketsResourceType,
+					OrgID: influxdbtesting.IDPtr(1),
+					ID:    influxdbtesting.IDPtr(1),
+				},
+				Restriction: &platform.DataRestriction{Measurements: []string{"cpu", "disk"}},
+			},
+			permissions: []platform.Permission{
+				{
+					Action: platform.ReadAction,
+					Resource: platform.Resource{
+						Type:  platform.BucketsResourceType,
+						OrgID: influxdbtesting.IDPtr(1),
+					},
+					Restriction: &platform.DataRestriction{Measurements: []string{"cpu", "mem"}},
+				},
+			},
+			allowed: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -241,10 +314,67 @@ func TestAuthorizer_PermissionAllowed(t *testing.T) {
 	}
 }
 
+func TestPermissionDataRestrictions(t *testing.T) {
+	bucket := func(id platform.ID, r *platform.DataRestriction) platform.Permission {
+		return platform.Permission{
+			Action: platform.WriteAction,
+			Resource: platform.Resource{
+				Type:  platform.BucketsResourceType,
+				OrgID: influxdbtesting.IDPtr(1),
+				ID:    influxdbtesting.IDPtr(id),
+			},
+			Restriction: r,
+		}
+	}
+	cpu := &platform.DataRestriction{Measurements: []string{"cpu"}}
+	teamA := &platform.DataRestriction{Tags: []platform.Tag{{Key: "team", Value: "a"}}}
+
+	tests := []struct {
+		name        string
+		permissions []platform.Permission
+		points      map[string]bool // measurement,team => allowed
+	}{
+		{
+			name:        "unrestricted",
+			permissions: []platform.Permission{bucket(1, nil), bucket(1, cpu)},
+			points:      map[string]bool{"cpu,a": true, "mem,b": true},
+		},
+		{
+			name:        "restricted to a measurement",
+			permissions: []platform.Permission{bucket(1, cpu), bucket(2, teamA)},
+			points:      map[string]bool{"cpu,a": true, "cpu,b": true, "mem,a": false},
+		},
+		{
+			name:        "restricted to a measurement or a tag",
+			permissions: []platform.Permission{bucket(1, cpu), bucket(1, teamA)},
+			points:      map[string]bool{"cpu,b": true, "mem,a": true, "mem,b": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := platform.PermissionDataRestrictions(bucket(1, nil), tt.permissions)
+			for point, want := range tt.points {
+				parts := strings.Split(point, ",")
+				got := rs.Allows(parts[0], func(key string) string {
+					if key == "team" {
+						return parts[1]
+					}
+					return ""
+				})
+				if got != want {
+					t.Errorf("point %s: got allowed = %v, expected allowed = %v", point, got, want)
+				}
+			}
+		})
+	}
+}
+
 func TestPermission_Valid(t *testing.T) {
 	type fields struct {
-		Action   platform.Action
-		Resource platform.Resource
+		Action      platform.Action
+		Resource    platform.Resource
+		Restriction *platform.DataRestriction
 	}
 	tests := []struct {
 		name    string
@@ -302,12 +432,36 @@ func TestPermission_Valid(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid restricted bucket permission",
+			fields: fields{
+				Action: platform.ReadAction,
+				Resource: platform.Resource{
+					Type:  platform.BucketsResourceType,
+					OrgID: influxdbtesting.IDPtr(1),
+				},
+				Restriction: &platform.DataRestriction{Measurements: []string{"cpu"}},
+			},
+		},
+		{
+			name: "invalid restricted dashboard permission",
+			fields: fields{
+				Action: platform.ReadAction,
+				Resource: platform.Resource{
+					Type:  platform.DashboardsResourceType,
+					OrgID: influxdbtesting.IDPtr(1),
+				},
+				Restriction: &platform.DataRestriction{Measurements: []string{"cpu"}},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := &platform.Permission{
-				Action:   tt.fields.Action,
-				Resource: tt.fields.Resource,
+				Action:      tt.fields.Action,
+				Resource:    tt.fields.Resource,
+				Restriction: tt.fields.Restriction,
 			}
 			if err := p.Valid(); (err != nil) != tt.wantErr {
 				t.Errorf("Permission.Valid() error = %v, wantErr %v", err, tt.wantErr)
@@ -2,14 +2,28 @@ package influxdb
 
 import (
 	"context"
+	"encoding/json"
 	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 )
 
+// BackupManifestExtension is the file extension of a backup manifest.
+const BackupManifestExtension = ".manifest"
+
+// backupManifestTimeFormat is the format of the creation time in the name of a manifest.
+const backupManifestTimeFormat = "20060102T150405.000000000Z"
+
 // BackupService represents the data backup functions of InfluxDB.
 type BackupService interface {
-	// CreateBackup creates a local copy (hard links) of the TSM data for all orgs and buckets.
-	// The return values are used to download each backup file.
-	CreateBackup(context.Context) (backupID int, backupFiles []string, err error)
+	// CreateBackup creates a local copy (hard links) of the TSM data that matches the filter.
+	// The return values are used to download each backup file. The backup files include
+	// a manifest that describes the TSM data in the backup.
+	CreateBackup(ctx context.Context, filter BackupFilter) (backupID int, backupFiles []string, err error)
 	// FetchBackupFile downloads one backup file, data or metadata.
 	FetchBackupFile(ctx context.Context, backupID int, backupFile string, w io.Writer) error
 	// InternalBackupPath is a utility to determine the on-disk location of a backup fileset.
@@ -21,3 +35,175 @@ type KVBackupService interface {
 	// Backup creates a live backup copy of the metadata database.
 	Backup(ctx context.Context, w io.Writer) error
 }
+
+// BackupFilter restricts the TSM data that is included in a backup.
+// The zero value includes all of the data for all orgs and buckets.
+type BackupFilter struct {
+	// OrgID restricts the backup to the data of an organization.
+	OrgID *ID `json:"orgID,omitempty"`
+	// BucketID restricts the backup to the data of a bucket. OrgID must also be set.
+	BucketID *ID `json:"bucketID,omitempty"`
+	// Start and End restrict the backup to the data within the time range.
+	Start *time.Time `json:"start,omitempty"`
+	End   *time.Time `json:"end,omitempty"`
+
+	// Since is the manifest of a previous backup. When set, the backup is incremental
+	// and only the TSM data written since the previous backup is included.
+	Since *BackupManifest `json:"since,omitempty"`
+}
+
+// Valid returns an error if the filter is invalid.
+func (f BackupFilter) Valid() error {
+	if f.BucketID != nil && f.OrgID == nil {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "backup of a bucket requires an organization",
+		}
+	}
+	if f.Start != nil && f.End != nil && f.End.Before(*f.Start) {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "backup start time must not be after the end time",
+		}
+	}
+	if f.Since != nil && !f.Since.Matches(f) {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "incremental backup must use the same filter as the previous backup",
+		}
+	}
+	return nil
+}
+
+// BackupManifest describes the TSM data of a backup. The manifest of an incremental
+// backup lists all of the TSM data needed to restore it, including the data that was
+// written by previous backups, so a chain of backups can be restored from the most
+// recent manifest.
+type BackupManifest struct {
+	Created time.Time `json:"created"`
+
+	// The filter used to create the backup.
+	OrgID    *ID        `json:"orgID,omitempty"`
+	BucketID *ID        `json:"bucketID,omitempty"`
+	Start    *time.Time `json:"start,omitempty"`
+	End      *time.Time `json:"end,omitempty"`
+
+	// Parent is the name of the manifest of the previous backup
+	// that an incremental backup is based on.
+	Parent string `json:"parent,omitempty"`
+
+	Files []BackupManifestFile `json:"files"`
+}
+
+// BackupManifestFile describes a TSM file of the storage engine in a backup.
+type BackupManifestFile struct {
+	// FileName is the name of the TSM file in the storage engine.
+	FileName string `json:"fileName"`
+	// Size is the size of the TSM file in the storage engine.
+	Size int64 `json:"size"`
+	// TombstoneSize is the combined size of the tombstone files of the TSM file.
+	TombstoneSize int64 `json:"tombstoneSize,omitempty"`
+
+	// Files are the names of the backup files that contain the data of the TSM file.
+	// The names begin with the BackupFilePrefix of the backup that wrote them.
+	Files []string `json:"files"`
+	// Manifest is the name of the manifest of the backup that contains the files.
+	Manifest string `json:"manifest"`
+}
+
+// BackupFilePrefix returns the prefix of the names of the files written by the backup
+// with the given manifest name. The files of different backups can have the same name
+// in the storage engine, such as a TSM file with a new tombstone, so the prefix keeps
+// the backups in a directory from overwriting each other's files.
+func BackupFilePrefix(manifest string) string {
+	return strings.TrimSuffix(manifest, BackupManifestExtension) + "-"
+}
+
+// EngineFileName returns the name in the storage engine of a backup file of the TSM file.
+func (f BackupManifestFile) EngineFileName(name string) string {
+	return strings.TrimPrefix(name, BackupFilePrefix(f.Manifest))
+}
+
+// BackupEngineFileName returns the name in the storage engine of a backup file written
+// by any backup. The name is returned unchanged if it has no BackupFilePrefix.
+func BackupEngineFileName(name string) string {
+	n := len(backupManifestTimeFormat)
+	if len(name) <= n || name[n] != '-' {
+		return name
+	}
+	if _, err := time.Parse(backupManifestTimeFormat, name[:n]); err != nil {
+		return name
+	}
+	return name[n+1:]
+}
+
+// Name returns the file name of the manifest. The names sort in the order
+// the backups were created.
+func (m *BackupManifest) Name() string {
+	return m.Created.UTC().Format(backupManifestTimeFormat) + BackupManifestExtension
+}
+
+// Matches returns true if the manifest was created with the same filter.
+// The previous backup of the filter is not compared.
+func (m *BackupManifest) Matches(f BackupFilter) bool {
+	return equalIDs(m.OrgID, f.OrgID) &&
+		equalIDs(m.BucketID, f.BucketID) &&
+		equalTimes(m.Start, f.Start) &&
+		equalTimes(m.End, f.End)
+}
+
+// ReadBackupManifest reads the backup manifest at path.
+func ReadBackupManifest(path string) (*BackupManifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var m BackupManifest
+	if err := json.NewDecoder(f).Decode(&m); err != nil {
+		return nil, &Error{
+			Code: EInvalid,
+			Msg:  "invalid backup manifest " + filepath.Base(path),
+			Err:  err,
+		}
+	}
+	return &m, nil
+}
+
+// ReadLatestBackupManifest reads the manifest of the most recent backup in dir.
+// It returns nil if dir does not contain any backup manifests.
+func ReadLatestBackupManifest(dir string) (*BackupManifest, error) {
+	fis, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, fi := range fis {
+		if !fi.IsDir() && strings.HasSuffix(fi.Name(), BackupManifestExtension) {
+			names = append(names, fi.Name())
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	sort.Strings(names)
+	return ReadBackupManifest(filepath.Join(dir, names[len(names)-1]))
+}
+
+func equalIDs(a, b *ID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalTimes(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,390 @@
+// Package backup implements the portable archive format of InfluxDB backups.
+//
+// An archive is a gzip compressed tar stream of the backup files, followed by a
+// JSON manifest with the SHA-256 checksum of each file. The manifest is the last
+// entry so an archive can be written in a single pass while the files are fetched.
+// An archive can be extracted with standard tools to restore the backup.
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+const (
+	// ManifestFileName is the name of the manifest in an archive.
+	ManifestFileName = "manifest.json"
+
+	// SchemaVersion is the version of the archive format.
+	SchemaVersion = 1
+
+	// ArchiveExtension is the extension of archive files.
+	ArchiveExtension = ".tar.gz"
+
+	// extractExtension is the extension of files that are extracted but not yet verified.
+	extractExtension = ".extract"
+)
+
+// Manifest describes the contents of an archive.
+type Manifest struct {
+	// SchemaVersion is the version of the archive format.
+	SchemaVersion int `json:"schemaVersion"`
+	// Version is the version of InfluxDB that created the backup.
+	Version string `json:"version"`
+	// EngineVersion is the version of the TSM file format.
+	EngineVersion int `json:"engineVersion"`
+	// Created is the time the archive was created.
+	Created time.Time `json:"created"`
+
+	// Backup is the name of the manifest of the TSM data in the archive.
+	Backup string `json:"backup,omitempty"`
+	// Buckets are the buckets whose data is in the archive.
+	Buckets []*influxdb.Bucket `json:"buckets"`
+
+	Files []File `json:"files"`
+}
+
+// File describes a file in an archive.
+type File struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Writer writes the files of a backup to an archive.
+type Writer struct {
+	gw       *gzip.Writer
+	tw       *tar.Writer
+	manifest Manifest
+
+	// The file that is currently being written.
+	file *File
+	hash hash.Hash
+	n    int64
+}
+
+// NewWriter returns a Writer that writes an archive with the manifest to w.
+// The files of the manifest are added as they are written to the archive.
+func NewWriter(w io.Writer, manifest Manifest) *Writer {
+	gw := gzip.NewWriter(w)
+	manifest.SchemaVersion = SchemaVersion
+	manifest.Files = nil
+	return &Writer{
+		gw:       gw,
+		tw:       tar.NewWriter(gw),
+		manifest: manifest,
+	}
+}
+
+// Create adds a file with the given name and size to the archive. The contents of the
+// file must be written to the returned writer before the next call to Create or Close.
+func (w *Writer) Create(name string, size int64) (io.Writer, error) {
+	if err := w.flush(); err != nil {
+		return nil, err
+	}
+	if name == ManifestFileName {
+		return nil, fmt.Errorf("archive file name %q is reserved", name)
+	}
+
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0666,
+		Size:    size,
+		ModTime: w.manifest.Created,
+	}
+	if err := w.tw.WriteHeader(hdr); err != nil {
+		return nil, err
+	}
+
+	w.file = &File{Name: name, Size: size}
+	w.hash = sha256.New()
+	w.n = 0
+	return w, nil
+}
+
+// Write writes to the current file of the archive.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.file == nil {
+		return 0, fmt.Errorf("no archive file created")
+	}
+	n, err := w.tw.Write(p)
+	w.hash.Write(p[:n])
+	w.n += int64(n)
+	return n, err
+}
+
+// flush adds the current file to the manifest.
+func (w *Writer) flush() error {
+	if w.file == nil {
+		return nil
+	}
+	if w.n != w.file.Size {
+		return fmt.Errorf("archive file %s: wrote %d bytes, expected %d", w.file.Name, w.n, w.file.Size)
+	}
+	w.file.SHA256 = hex.EncodeToString(w.hash.Sum(nil))
+	w.manifest.Files = append(w.manifest.Files, *w.file)
+	w.file = nil
+	return nil
+}
+
+// Close writes the manifest and closes the archive. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if err := w.flush(); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(w.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	hdr := &tar.Header{
+		Name:    ManifestFileName,
+		Mode:    0666,
+		Size:    int64(len(data)),
+		ModTime: w.manifest.Created,
+	}
+	if err := w.tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if _, err := w.tw.Write(data); err != nil {
+		return err
+	}
+
+	if err := w.tw.Close(); err != nil {
+		return err
+	}
+	return w.gw.Close()
+}
+
+// Read reads the archive from r and calls fn with the contents of each file.
+// fn may be nil to skip the contents. The checksum and size of each file are
+// verified against the manifest, and the manifest is returned.
+//
+// The manifest is the last entry of the archive, so fn is called before the
+// contents are verified. fn must not use the contents until Read returns
+// without an error. Extract implements this for extracting to a directory.
+func Read(r io.Reader, fn func(name string, r io.Reader) error) (*Manifest, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, invalidArchiveError(err)
+	}
+	defer gr.Close()
+
+	var (
+		manifest *Manifest
+		backup   *influxdb.BackupManifest
+		files    []File
+	)
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, invalidArchiveError(err)
+		}
+		if manifest != nil {
+			return nil, invalidArchiveError(fmt.Errorf("unexpected file %s after manifest", hdr.Name))
+		}
+
+		if hdr.Name == ManifestFileName {
+			manifest = &Manifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, invalidArchiveError(fmt.Errorf("invalid manifest: %v", err))
+			}
+			continue
+		}
+
+		h := sha256.New()
+		var rd io.Reader = io.TeeReader(tr, h)
+
+		// The manifest of the TSM data is kept to verify the data files are in the archive.
+		if strings.HasSuffix(hdr.Name, influxdb.BackupManifestExtension) {
+			data, err := ioutil.ReadAll(rd)
+			if err != nil {
+				return nil, invalidArchiveError(err)
+			}
+			backup = &influxdb.BackupManifest{}
+			if err := json.Unmarshal(data, backup); err != nil {
+				return nil, invalidArchiveError(fmt.Errorf("invalid backup manifest %s: %v", hdr.Name, err))
+			}
+			rd = bytes.NewReader(data)
+		}
+
+		if fn != nil {
+			if err := fn(hdr.Name, rd); err != nil {
+				return nil, err
+			}
+		}
+		// Read any of the file that fn did not.
+		if _, err := io.Copy(ioutil.Discard, rd); err != nil {
+			return nil, invalidArchiveError(err)
+		}
+		files = append(files, File{
+			Name:   hdr.Name,
+			Size:   hdr.Size,
+			SHA256: hex.EncodeToString(h.Sum(nil)),
+		})
+	}
+
+	if manifest == nil {
+		return nil, invalidArchiveError(fmt.Errorf("missing %s", ManifestFileName))
+	}
+	if manifest.SchemaVersion != SchemaVersion {
+		return nil, invalidArchiveError(fmt.Errorf("unsupported schema version %d", manifest.SchemaVersion))
+	}
+	if err := verifyFiles(manifest.Files, files); err != nil {
+		return nil, invalidArchiveError(err)
+	}
+	if backup != nil {
+		if err := verifyBackup(backup, files); err != nil {
+			return nil, invalidArchiveError(err)
+		}
+	}
+	return manifest, nil
+}
+
+// Verify reads the archive from r and verifies the files against the manifest.
+func Verify(r io.Reader) (*Manifest, error) {
+	return Read(r, nil)
+}
+
+// Extract reads the archive from r and extracts the files into dir. The files are
+// only moved into place once the whole archive is verified. On error, none of the
+// files of the archive are left in dir.
+func Extract(r io.Reader, dir string) (*Manifest, error) {
+	var paths []string
+	manifest, err := Read(r, func(name string, r io.Reader) error {
+		if name != filepath.Base(name) || name == "." || name == ".." {
+			return invalidArchiveError(fmt.Errorf("invalid file name %q", name))
+		}
+		path := filepath.Join(dir, name+extractExtension)
+		paths = append(paths, path)
+		return writeFile(path, r)
+	})
+	if err != nil {
+		for _, path := range paths {
+			os.Remove(path)
+		}
+		return nil, err
+	}
+
+	for _, path := range paths {
+		if err := os.Rename(path, strings.TrimSuffix(path, extractExtension)); err != nil {
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
+
+// ExtractAll extracts the archives at path into dir. path is either an archive or
+// a directory of archives. The archives of a directory are extracted in name order,
+// so the files of a later backup replace the files of an earlier one, such as
+// the metadata of an incremental backup. ExtractAll returns the manifest of the
+// last archive, or nil if there are no archives at path.
+func ExtractAll(path, dir string) (*Manifest, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{path}
+	if fi.IsDir() {
+		paths, err = filepath.Glob(filepath.Join(path, "*"+ArchiveExtension))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(paths)
+	}
+
+	var manifest *Manifest
+	for _, path := range paths {
+		if manifest, err = extractFile(path, dir); err != nil {
+			return nil, fmt.Errorf("failed to extract %s: %v", path, err)
+		}
+	}
+	return manifest, nil
+}
+
+func extractFile(path, dir string) (*Manifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Extract(f, dir)
+}
+
+func writeFile(path string, r io.Reader) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// verifyFiles returns an error if the files in the archive do not match the manifest.
+func verifyFiles(exp, got []File) error {
+	if len(exp) != len(got) {
+		return fmt.Errorf("manifest lists %d files, archive contains %d", len(exp), len(got))
+	}
+	for i := range exp {
+		switch {
+		case exp[i].Name != got[i].Name:
+			return fmt.Errorf("expected file %s, got %s", exp[i].Name, got[i].Name)
+		case exp[i].Size != got[i].Size:
+			return fmt.Errorf("file %s: expected size %d, got %d", exp[i].Name, exp[i].Size, got[i].Size)
+		case exp[i].SHA256 != got[i].SHA256:
+			return fmt.Errorf("file %s: checksum mismatch", exp[i].Name)
+		}
+	}
+	return nil
+}
+
+// verifyBackup returns an error if the archive is missing any of the data files that were
+// written by the backup. The files written by previous backups of an incremental backup
+// are in the archives of those backups.
+func verifyBackup(backup *influxdb.BackupManifest, files []File) error {
+	names := make(map[string]bool, len(files))
+	for _, f := range files {
+		names[f.Name] = true
+	}
+	for _, f := range backup.Files {
+		if f.Manifest != backup.Name() {
+			continue
+		}
+		for _, name := range f.Files {
+			if !names[name] {
+				return fmt.Errorf("missing data file %s", name)
+			}
+		}
+	}
+	return nil
+}
+
+func invalidArchiveError(err error) error {
+	return &influxdb.Error{
+		Code: influxdb.EInvalid,
+		Msg:  "invalid backup archive",
+		Err:  err,
+	}
+}
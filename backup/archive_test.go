@@ -0,0 +1,279 @@
+package backup_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/backup"
+)
+
+func TestArchive(t *testing.T) {
+	files := map[string]string{
+		"000000001-000000001.tsm": "tsm data",
+		"influxd.bolt":            "bolt data",
+	}
+
+	var buf bytes.Buffer
+	w := backup.NewWriter(&buf, backup.Manifest{
+		Version:       "2.0.0",
+		EngineVersion: 1,
+		Created:       time.Unix(0, 0).UTC(),
+	})
+	for _, name := range []string{"000000001-000000001.tsm", "influxd.bolt"} {
+		fw, err := w.Create(name, int64(len(files[name])))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(fw, files[name]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]string)
+	manifest, err := backup.Read(bytes.NewReader(buf.Bytes()), func(name string, r io.Reader) error {
+		data, err := ioutil.ReadAll(r)
+		got[name] = string(data)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, files) {
+		t.Fatalf("unexpected files: got %v, exp %v", got, files)
+	}
+
+	if exp, got := backup.SchemaVersion, manifest.SchemaVersion; exp != got {
+		t.Fatalf("unexpected schema version: got %d, exp %d", got, exp)
+	}
+	if exp, got := 2, len(manifest.Files); exp != got {
+		t.Fatalf("unexpected number of files: got %d, exp %d", got, exp)
+	}
+	if exp, got := sha256String([]byte(files[manifest.Files[0].Name])), manifest.Files[0].SHA256; exp != got {
+		t.Fatalf("unexpected checksum: got %s, exp %s", got, exp)
+	}
+}
+
+func TestWriter_SizeMismatch(t *testing.T) {
+	w := backup.NewWriter(ioutil.Discard, backup.Manifest{})
+	fw, err := w.Create("a.tsm", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.WriteString(fw, "short"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err == nil {
+		t.Fatal("expected error for a file smaller than its size")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	created := time.Unix(0, 0).UTC()
+	backupManifest := influxdb.BackupManifest{
+		Created: created,
+		Files: []influxdb.BackupManifestFile{
+			{FileName: "000000001-000000001.tsm", Files: []string{"000000001-000000001.tsm"}},
+		},
+	}
+	backupManifest.Files[0].Manifest = backupManifest.Name()
+	backupData, err := json.Marshal(backupManifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		// corrupt modifies the manifest before it is written.
+		corrupt func(m *backup.Manifest)
+		wantErr string
+	}{
+		{
+			name: "valid",
+			files: map[string]string{
+				"000000001-000000001.tsm": "tsm data",
+				backupManifest.Name():     string(backupData),
+			},
+		},
+		{
+			name: "checksum mismatch",
+			files: map[string]string{
+				"000000001-000000001.tsm": "tsm data",
+			},
+			corrupt: func(m *backup.Manifest) {
+				m.Files[0].SHA256 = strings.Repeat("0", 64)
+			},
+			wantErr: "checksum mismatch",
+		},
+		{
+			name: "missing file",
+			files: map[string]string{
+				"000000001-000000001.tsm": "tsm data",
+			},
+			corrupt: func(m *backup.Manifest) {
+				m.Files = append(m.Files, backup.File{Name: "influxd.bolt"})
+			},
+			wantErr: "manifest lists 2 files, archive contains 1",
+		},
+		{
+			name: "missing data file",
+			files: map[string]string{
+				backupManifest.Name(): string(backupData),
+			},
+			wantErr: "missing data file 000000001-000000001.tsm",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := mustWriteArchive(t, tt.files, tt.corrupt)
+			_, err := backup.Verify(bytes.NewReader(data))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	files := map[string]string{
+		"000000001-000000001.tsm": "tsm data",
+		"influxd.bolt":            "bolt data",
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		dir := mustTempDir(t)
+		defer os.RemoveAll(dir)
+
+		data := mustWriteArchive(t, files, nil)
+		if _, err := backup.Extract(bytes.NewReader(data), dir); err != nil {
+			t.Fatal(err)
+		}
+		for name, exp := range files {
+			got, err := ioutil.ReadFile(filepath.Join(dir, name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != exp {
+				t.Fatalf("unexpected contents of %s: got %q, exp %q", name, got, exp)
+			}
+		}
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		dir := mustTempDir(t)
+		defer os.RemoveAll(dir)
+
+		data := mustWriteArchive(t, files, func(m *backup.Manifest) {
+			m.Files[1].SHA256 = strings.Repeat("0", 64)
+		})
+		if _, err := backup.Extract(bytes.NewReader(data), dir); err == nil {
+			t.Fatal("expected error for checksum mismatch")
+		}
+		// None of the files are extracted if the archive is invalid.
+		if fis, err := ioutil.ReadDir(dir); err != nil {
+			t.Fatal(err)
+		} else if len(fis) != 0 {
+			t.Fatalf("expected no extracted files, got %d", len(fis))
+		}
+	})
+
+	t.Run("invalid file name", func(t *testing.T) {
+		dir := mustTempDir(t)
+		defer os.RemoveAll(dir)
+
+		data := mustWriteArchive(t, map[string]string{"../influxd.bolt": "bolt data"}, nil)
+		if _, err := backup.Extract(bytes.NewReader(data), dir); err == nil || !strings.Contains(err.Error(), "invalid file name") {
+			t.Fatalf("expected invalid file name error, got %v", err)
+		}
+	})
+}
+
+func mustTempDir(t *testing.T) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "backup-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// mustWriteArchive writes an archive of the files in name order.
+// The manifest is modified by corrupt, if set, before it is written.
+func mustWriteArchive(t *testing.T, files map[string]string, corrupt func(m *backup.Manifest)) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	manifest := backup.Manifest{SchemaVersion: backup.SchemaVersion}
+	for _, name := range names {
+		data := []byte(files[name])
+		mustWriteTarFile(t, tw, name, data)
+		manifest.Files = append(manifest.Files, backup.File{
+			Name:   name,
+			Size:   int64(len(data)),
+			SHA256: sha256String(data),
+		})
+	}
+	if corrupt != nil {
+		corrupt(&manifest)
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustWriteTarFile(t, tw, backup.ManifestFileName, data)
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func mustWriteTarFile(t *testing.T, tw *tar.Writer, name string, data []byte) {
+	t.Helper()
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0666, Size: int64(len(data))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func sha256String(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,100 @@
+package influxdb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+func TestBackupFilter_Valid(t *testing.T) {
+	orgID := influxdb.ID(1)
+	bucketID := influxdb.ID(2)
+	start := time.Unix(0, 0).UTC()
+	end := start.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		filter  influxdb.BackupFilter
+		wantErr bool
+	}{
+		{
+			name: "empty filter",
+		},
+		{
+			name: "bucket",
+			filter: influxdb.BackupFilter{
+				OrgID:    &orgID,
+				BucketID: &bucketID,
+				Start:    &start,
+				End:      &end,
+			},
+		},
+		{
+			name: "bucket without org",
+			filter: influxdb.BackupFilter{
+				BucketID: &bucketID,
+			},
+			wantErr: true,
+		},
+		{
+			name: "end before start",
+			filter: influxdb.BackupFilter{
+				Start: &end,
+				End:   &start,
+			},
+			wantErr: true,
+		},
+		{
+			name: "matching previous backup",
+			filter: influxdb.BackupFilter{
+				OrgID: &orgID,
+				Since: &influxdb.BackupManifest{OrgID: &orgID},
+			},
+		},
+		{
+			name: "different previous backup",
+			filter: influxdb.BackupFilter{
+				OrgID: &orgID,
+				Since: &influxdb.BackupManifest{OrgID: &orgID, BucketID: &bucketID},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.filter.Valid(); (err != nil) != tt.wantErr {
+				t.Errorf("BackupFilter.Valid() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBackupManifest_Name(t *testing.T) {
+	m := influxdb.BackupManifest{
+		Created: time.Date(2019, 10, 1, 12, 30, 0, 5, time.UTC),
+	}
+	if got, want := m.Name(), "20191001T123000.000000005Z.manifest"; got != want {
+		t.Errorf("BackupManifest.Name() = %s, want %s", got, want)
+	}
+}
+
+func TestBackupEngineFileName(t *testing.T) {
+	m := influxdb.BackupManifest{
+		Created: time.Date(2019, 10, 1, 12, 30, 0, 5, time.UTC),
+	}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: influxdb.BackupFilePrefix(m.Name()) + "000000000000001-000000001.tsm", want: "000000000000001-000000001.tsm"},
+		{name: influxdb.BackupFilePrefix(m.Name()) + "000000000000001-000000001.tombstone", want: "000000000000001-000000001.tombstone"},
+		{name: "000000000000001-000000001.tsm", want: "000000000000001-000000001.tsm"},
+		{name: "20191001T123000.000000005Z", want: "20191001T123000.000000005Z"},
+	}
+	for _, tt := range tests {
+		if got := influxdb.BackupEngineFileName(tt.name); got != tt.want {
+			t.Errorf("BackupEngineFileName(%s) = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
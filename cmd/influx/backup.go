@@ -3,10 +3,15 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/backup"
 	"github.com/influxdata/influxdb/bolt"
 	"github.com/influxdata/influxdb/http"
 	"github.com/spf13/cobra"
@@ -21,8 +26,18 @@ func cmdBackup(f *globalFlags, opt genericCLIOpts) *cobra.Command {
 		`Backs up data and meta data for the running InfluxDB instance.
 Downloaded files are written to the directory indicated by --path.
 The target directory, and any parent directories, are created automatically.
-Data file have extension .tsm; meta data is written to %s in the same directory.`,
-		bolt.DefaultFilename)
+Data file have extension .tsm; meta data is written to %s in the same directory.
+A manifest describing the data files is written with extension %s.
+
+The data can be restricted to an organization or bucket and to a time range.
+With --incremental, only the data files written since the most recent backup
+in the directory are downloaded. The filter must match the previous backup.
+
+With --archive, the files are downloaded as a single compressed archive with
+extension %s, with the checksums of the files and the metadata of the backed up
+buckets. The archive is verified once it is downloaded, and can be verified again
+with "influx backup verify".`,
+		bolt.DefaultFilename, influxdb.BackupManifestExtension, backup.ArchiveExtension)
 
 	opts := flagOpts{
 		{
@@ -33,14 +48,69 @@ Data file have extension .tsm; meta data is written to %s in the same directory.
 			Desc:     "directory path to write backup files to",
 			Required: true,
 		},
+		{
+			DestP: &backupFlags.OrgID,
+			Flag:  "org-id",
+			Desc:  "The ID of the organization to backup",
+		},
+		{
+			DestP: &backupFlags.Org,
+			Flag:  "org",
+			Short: 'o',
+			Desc:  "The name of the organization to backup",
+		},
+		{
+			DestP: &backupFlags.BucketID,
+			Flag:  "bucket-id",
+			Desc:  "The ID of the bucket to backup",
+		},
+		{
+			DestP:  &backupFlags.Bucket,
+			Flag:   "bucket",
+			Short:  'b',
+			EnvVar: "BUCKET_NAME",
+			Desc:   "The name of the bucket to backup",
+		},
+		{
+			DestP: &backupFlags.Start,
+			Flag:  "start",
+			Desc:  "The earliest time of the data to backup in RFC3339 format",
+		},
+		{
+			DestP: &backupFlags.End,
+			Flag:  "end",
+			Desc:  "The latest time of the data to backup in RFC3339 format",
+		},
+		{
+			DestP:   &backupFlags.Incremental,
+			Flag:    "incremental",
+			Default: false,
+			Desc:    "only backup the data written since the most recent backup in the path",
+		},
+		{
+			DestP:   &backupFlags.Archive,
+			Flag:    "archive",
+			Default: false,
+			Desc:    "write the backup as a single compressed archive",
+		},
 	}
 	opts.mustRegister(cmd)
 
+	cmd.AddCommand(cmdBackupVerify(f, opt))
+
 	return cmd
 }
 
 var backupFlags struct {
-	Path string
+	Path        string
+	OrgID       string
+	Org         string
+	BucketID    string
+	Bucket      string
+	Start       string
+	End         string
+	Incremental bool
+	Archive     bool
 }
 
 func init() {
@@ -53,7 +123,7 @@ func init() {
 	}
 }
 
-func newBackupService() (influxdb.BackupService, error) {
+func newBackupService() (*http.BackupService, error) {
 	return &http.BackupService{
 		Addr:  flags.host,
 		Token: flags.token,
@@ -76,18 +146,37 @@ func backupF(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	filter, err := newBackupFilter(ctx)
+	if err != nil {
+		return err
+	}
+
+	if backupFlags.Incremental {
+		manifest, err := influxdb.ReadLatestBackupManifest(backupFlags.Path)
+		if err != nil {
+			return err
+		} else if manifest == nil {
+			return fmt.Errorf("no previous backup found in %s", backupFlags.Path)
+		}
+		filter.Since = manifest
+	}
+
 	backupService, err := newBackupService()
 	if err != nil {
 		return err
 	}
 
-	id, backupFilenames, err := backupService.CreateBackup(ctx)
+	id, backupFilenames, err := backupService.CreateBackup(ctx, filter)
 	if err != nil {
 		return err
 	}
 
 	fmt.Printf("Backup ID %d contains %d files\n", id, len(backupFilenames))
 
+	if backupFlags.Archive {
+		return fetchBackupArchive(ctx, backupService, id, backupFilenames)
+	}
+
 	for _, backupFilename := range backupFilenames {
 		dest := filepath.Join(backupFlags.Path, backupFilename)
 		w, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
@@ -107,3 +196,201 @@ func backupF(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// fetchBackupArchive downloads the backup as an archive named after the backup manifest.
+// The backup manifest is also written to the path so that the next incremental backup
+// can find it without reading the archive.
+func fetchBackupArchive(ctx context.Context, s *http.BackupService, id int, backupFilenames []string) error {
+	var name string
+	for _, backupFilename := range backupFilenames {
+		if strings.HasSuffix(backupFilename, influxdb.BackupManifestExtension) {
+			name = backupFilename
+		}
+	}
+	if name == "" {
+		return fmt.Errorf("backup %d has no manifest", id)
+	}
+
+	dest := filepath.Join(backupFlags.Path, strings.TrimSuffix(name, influxdb.BackupManifestExtension)+backup.ArchiveExtension)
+	f, err := os.OpenFile(dest, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := s.FetchBackupArchive(ctx, id, f); err != nil {
+		return multierr.Append(fmt.Errorf("error fetching archive: %v", err), os.Remove(dest))
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	var manifest []byte
+	archive, err := backup.Read(f, func(fileName string, r io.Reader) (err error) {
+		if fileName == name {
+			manifest, err = ioutil.ReadAll(r)
+		}
+		return err
+	})
+	if err != nil {
+		return multierr.Append(fmt.Errorf("error verifying archive: %v", err), os.Remove(dest))
+	}
+	if err := ioutil.WriteFile(filepath.Join(backupFlags.Path, name), manifest, 0666); err != nil {
+		return err
+	}
+
+	fmt.Printf("Backup archive %s contains %d files of %d buckets\n", dest, len(archive.Files), len(archive.Buckets))
+	return f.Close()
+}
+
+func cmdBackupVerify(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("verify", func(cmd *cobra.Command, args []string) error {
+		return backupVerifyF(opt)
+	})
+	cmd.Short = "Verify a backup archive"
+	cmd.Long = `Verifies the checksums of the files in a backup archive written by "influx backup --archive",
+without restoring it. The contents of the archive are printed if it is valid.`
+
+	opts := flagOpts{
+		{
+			DestP:    &backupVerifyFlags.Path,
+			Flag:     "path",
+			Short:    'p',
+			Desc:     "path of the backup archive to verify",
+			Required: true,
+		},
+	}
+	opts.mustRegister(cmd)
+
+	return cmd
+}
+
+var backupVerifyFlags struct {
+	Path string
+}
+
+func backupVerifyF(opt genericCLIOpts) error {
+	f, err := os.Open(backupVerifyFlags.Path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	manifest, err := backup.Verify(f)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opt.w, "Archive %s is valid\n", backupVerifyFlags.Path)
+	fmt.Fprintf(opt.w, "Created: %s\n", manifest.Created.Format(time.RFC3339))
+	fmt.Fprintf(opt.w, "InfluxDB version: %s, engine version: %d, schema version: %d\n", manifest.Version, manifest.EngineVersion, manifest.SchemaVersion)
+	if manifest.Backup != "" {
+		fmt.Fprintf(opt.w, "Backup manifest: %s\n", manifest.Backup)
+	}
+
+	w := opt.newTabWriter()
+	w.WriteHeaders("ID", "Name", "OrganizationID")
+	for _, b := range manifest.Buckets {
+		w.Write(map[string]interface{}{
+			"ID":             b.ID.String(),
+			"Name":           b.Name,
+			"OrganizationID": b.OrgID.String(),
+		})
+	}
+	w.Flush()
+
+	w = opt.newTabWriter()
+	w.WriteHeaders("File", "Size", "SHA256")
+	for _, file := range manifest.Files {
+		w.Write(map[string]interface{}{
+			"File":   file.Name,
+			"Size":   file.Size,
+			"SHA256": file.SHA256,
+		})
+	}
+	w.Flush()
+
+	return nil
+}
+
+// newBackupFilter creates the backup filter from the flags.
+func newBackupFilter(ctx context.Context) (influxdb.BackupFilter, error) {
+	var filter influxdb.BackupFilter
+
+	if backupFlags.Org != "" && backupFlags.OrgID != "" {
+		return filter, fmt.Errorf("please specify one of org or org-id")
+	}
+
+	if backupFlags.Bucket != "" && backupFlags.BucketID != "" {
+		return filter, fmt.Errorf("please specify one of bucket or bucket-id")
+	}
+
+	if backupFlags.Start != "" {
+		start, err := time.Parse(time.RFC3339, backupFlags.Start)
+		if err != nil {
+			return filter, fmt.Errorf("failed to parse start time: %v", err)
+		}
+		filter.Start = &start
+	}
+	if backupFlags.End != "" {
+		end, err := time.Parse(time.RFC3339, backupFlags.End)
+		if err != nil {
+			return filter, fmt.Errorf("failed to parse end time: %v", err)
+		}
+		filter.End = &end
+	}
+
+	if backupFlags.Bucket != "" || backupFlags.BucketID != "" {
+		bs, err := newBucketService()
+		if err != nil {
+			return filter, err
+		}
+
+		var bucketFilter influxdb.BucketFilter
+		if backupFlags.BucketID != "" {
+			bucketFilter.ID, err = influxdb.IDFromString(backupFlags.BucketID)
+			if err != nil {
+				return filter, fmt.Errorf("failed to decode bucket-id: %v", err)
+			}
+		}
+		if backupFlags.Bucket != "" {
+			bucketFilter.Name = &backupFlags.Bucket
+		}
+		if backupFlags.OrgID != "" {
+			bucketFilter.OrganizationID, err = influxdb.IDFromString(backupFlags.OrgID)
+			if err != nil {
+				return filter, fmt.Errorf("failed to decode org-id: %v", err)
+			}
+		}
+		if backupFlags.Org != "" {
+			bucketFilter.Org = &backupFlags.Org
+		}
+
+		bucket, err := bs.FindBucket(ctx, bucketFilter)
+		if err != nil {
+			return filter, fmt.Errorf("failed to retrieve bucket: %v", err)
+		}
+		filter.OrgID, filter.BucketID = &bucket.OrgID, &bucket.ID
+		return filter, nil
+	}
+
+	if backupFlags.OrgID != "" {
+		orgID, err := influxdb.IDFromString(backupFlags.OrgID)
+		if err != nil {
+			return filter, fmt.Errorf("failed to decode org-id: %v", err)
+		}
+		filter.OrgID = orgID
+	} else if backupFlags.Org != "" {
+		orgs, err := newOrganizationService()
+		if err != nil {
+			return filter, err
+		}
+
+		org, err := orgs.FindOrganization(ctx, influxdb.OrganizationFilter{Name: &backupFlags.Org})
+		if err != nil {
+			return filter, fmt.Errorf("failed to retrieve organization %q: %v", backupFlags.Org, err)
+		}
+		filter.OrgID = &org.ID
+	}
+	return filter, nil
+}
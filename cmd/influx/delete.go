@@ -3,7 +3,9 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
 
+	"github.com/influxdata/influxdb/cmd/influx/internal"
 	"github.com/influxdata/influxdb/http"
 	"github.com/influxdata/influxdb/kit/signals"
 	"github.com/spf13/cobra"
@@ -15,7 +17,12 @@ func cmdDelete(f *globalFlags, opt genericCLIOpts) *cobra.Command {
 	cmd := opt.newCmd("delete", fluxDeleteF)
 	cmd.Short = "Delete points from influxDB"
 	cmd.Long = `Delete points from influxDB, by specify start, end time
-	and a sql like predicate string.`
+	and a sql like predicate string. The predicate compares tags, _measurement
+	and _field with =, !=, =~ and !~, combined with and and or.
+
+	With --dry-run, nothing is deleted. The number of series, TSM blocks
+	and an estimate of the number of points that would be deleted are
+	printed for each measurement instead.`
 
 	opts := flagOpts{
 		{
@@ -49,7 +56,8 @@ func cmdDelete(f *globalFlags, opt genericCLIOpts) *cobra.Command {
 
 	cmd.PersistentFlags().StringVar(&deleteFlags.Start, "start", "", "the start time in RFC3339Nano format, exp 2009-01-02T23:00:00Z")
 	cmd.PersistentFlags().StringVar(&deleteFlags.Stop, "stop", "", "the stop time in RFC3339Nano format, exp 2009-01-02T23:00:00Z")
-	cmd.PersistentFlags().StringVarP(&deleteFlags.Predicate, "predicate", "p", "", "sql like predicate string, exp 'tag1=\"v1\" and (tag2=123)'")
+	cmd.PersistentFlags().StringVarP(&deleteFlags.Predicate, "predicate", "p", "", "sql like predicate string, exp '_measurement=\"cpu\" and (_field=\"usage\" or host=~/^web-/)'")
+	cmd.PersistentFlags().BoolVar(&deleteFlags.DryRun, "dry-run", false, "report the data that would be deleted without deleting it")
 
 	return cmd
 }
@@ -74,6 +82,26 @@ func fluxDeleteF(cmd *cobra.Command, args []string) error {
 	}
 
 	ctx := signals.WithStandardSignals(context.Background())
+	if deleteFlags.DryRun {
+		estimates, err := s.EstimateDeleteBucketRangePredicate(ctx, deleteFlags)
+		if err != nil && err != context.Canceled {
+			return fmt.Errorf("failed to estimate delete: %v", err)
+		}
+
+		w := internal.NewTabWriter(os.Stdout)
+		w.WriteHeaders("Measurement", "Series", "Blocks", "Points")
+		for _, e := range estimates {
+			w.Write(map[string]interface{}{
+				"Measurement": e.Measurement,
+				"Series":      e.Series,
+				"Blocks":      e.Blocks,
+				"Points":      e.Points,
+			})
+		}
+		w.Flush()
+		return nil
+	}
+
 	if err := s.DeleteBucketRangePredicate(ctx, deleteFlags); err != nil && err != context.Canceled {
 		return fmt.Errorf("failed to delete data: %v", err)
 	}
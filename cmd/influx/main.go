@@ -188,6 +188,8 @@ func influxCmd(opts ...genericCLIOptFn) *cobra.Command {
 		cmdQuery,
 		cmdTranspile,
 		cmdREPL,
+		cmdRestore,
+		cmdScraper,
 		cmdSecret,
 		cmdSetup,
 		cmdTask,
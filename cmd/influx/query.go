@@ -1,11 +1,17 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"os"
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/repl"
 	_ "github.com/influxdata/flux/stdlib"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/cmd/influx/internal"
+	"github.com/influxdata/influxdb/http"
+	"github.com/influxdata/influxdb/query"
 	_ "github.com/influxdata/influxdb/query/stdlib"
 	"github.com/spf13/cobra"
 )
@@ -23,6 +29,11 @@ or execute a literal Flux query contained in a file by specifying the file prefi
 
 	queryFlags.org.register(cmd, true)
 
+	cmd.AddCommand(
+		queryListCmd(opts),
+		queryKillCmd(opts),
+	)
+
 	return cmd
 }
 
@@ -63,3 +74,144 @@ func fluxQueryF(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func queryListCmd(opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("list", queryListF)
+	cmd.Short = "List the queries being compiled, queued or executed"
+	cmd.Long = `List the queries being compiled, queued or executed,
+of the organization when one is specified.`
+	cmd.Args = cobra.NoArgs
+
+	return cmd
+}
+
+func queryListF(cmd *cobra.Command, args []string) error {
+	if flags.local {
+		return fmt.Errorf("local flag not supported for query command")
+	}
+
+	var filter query.ActiveQueryFilter
+	if queryFlags.org.id != "" || queryFlags.org.name != "" {
+		if err := queryFlags.org.validOrgFlags(); err != nil {
+			return err
+		}
+
+		orgSvc, err := newOrganizationService()
+		if err != nil {
+			return fmt.Errorf("failed to initialized organization service client: %v", err)
+		}
+
+		orgID, err := queryFlags.org.getID(orgSvc)
+		if err != nil {
+			return err
+		}
+		filter.OrganizationID = &orgID
+	}
+
+	s, err := newActiveQueryService()
+	if err != nil {
+		return err
+	}
+
+	qs, err := s.FindActiveQueries(context.Background(), filter)
+	if err != nil {
+		return err
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"ID",
+		"OrganizationID",
+		"UserID",
+		"AuthorizationID",
+		"CompilerType",
+		"State",
+		"StartedAt",
+		"MemoryAllocated",
+		"Query",
+	)
+	for _, q := range qs {
+		w.Write(map[string]interface{}{
+			"ID":              q.ID.String(),
+			"OrganizationID":  q.OrganizationID.String(),
+			"UserID":          q.UserID.String(),
+			"AuthorizationID": q.AuthorizationID.String(),
+			"CompilerType":    q.CompilerType,
+			"State":           q.State,
+			"StartedAt":       q.StartedAt,
+			"MemoryAllocated": q.MemoryAllocated,
+			"Query":           q.Query,
+		})
+	}
+	w.Flush()
+
+	return nil
+}
+
+var queryKillFlags struct {
+	id string
+}
+
+func queryKillCmd(opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("kill", queryKillF)
+	cmd.Short = "Cancel a query being compiled, queued or executed"
+	cmd.Args = cobra.NoArgs
+
+	cmd.Flags().StringVarP(&queryKillFlags.id, "id", "i", "", "query id (required)")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func queryKillF(cmd *cobra.Command, args []string) error {
+	if flags.local {
+		return fmt.Errorf("local flag not supported for query command")
+	}
+
+	var id influxdb.ID
+	if err := id.DecodeFromString(queryKillFlags.id); err != nil {
+		return err
+	}
+
+	s, err := newActiveQueryService()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	q, err := s.FindActiveQueryByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.CancelActiveQuery(ctx, id); err != nil {
+		return err
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"ID",
+		"OrganizationID",
+		"UserID",
+		"Query",
+		"Canceled",
+	)
+	w.Write(map[string]interface{}{
+		"ID":             q.ID.String(),
+		"OrganizationID": q.OrganizationID.String(),
+		"UserID":         q.UserID.String(),
+		"Query":          q.Query,
+		"Canceled":       true,
+	})
+	w.Flush()
+
+	return nil
+}
+
+func newActiveQueryService() (query.ActiveQueryService, error) {
+	client, err := newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	return &http.ActiveQueryService{Client: client}, nil
+}
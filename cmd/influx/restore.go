@@ -0,0 +1,363 @@
+package main
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/backup"
+	"github.com/influxdata/influxdb/bolt"
+	"github.com/influxdata/influxdb/http"
+	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/tsdb/tsm1"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func cmdRestore(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("restore", restoreF)
+	cmd.Short = "Restore a bucket from a backup into the running InfluxDB instance"
+	cmd.Long = fmt.Sprintf(
+		`Restores the data of a single bucket from a backup into the running InfluxDB instance.
+The backup is read from the directory indicated by --path, as written by "influx backup".
+The path may also be an archive written by "influx backup --archive", or a directory
+of archives. The archives are verified before any data is restored. The bucket is looked up in the %s file of the backup by name or ID. A bucket backup
+is restored by default. A bucket backup without metadata, created without read
+access to everything, requires --new-bucket.
+
+The data is restored into the bucket with the same name in the same organization,
+or into --new-bucket and --new-org. The bucket is created if it does not exist.
+The TSM files listed by the most recent manifest in the directory are restored,
+or the files listed by --manifest.`,
+		bolt.DefaultFilename)
+
+	opts := flagOpts{
+		{
+			DestP:    &restoreFlags.Path,
+			Flag:     "path",
+			Short:    'p',
+			Desc:     "directory path to read backup files from",
+			Required: true,
+		},
+		{
+			DestP: &restoreFlags.Manifest,
+			Flag:  "manifest",
+			Desc:  "name of the backup manifest to restore, defaults to the most recent manifest",
+		},
+		{
+			DestP: &restoreFlags.OrgID,
+			Flag:  "org-id",
+			Desc:  "The ID of the organization in the backup",
+		},
+		{
+			DestP: &restoreFlags.Org,
+			Flag:  "org",
+			Short: 'o',
+			Desc:  "The name of the organization in the backup",
+		},
+		{
+			DestP: &restoreFlags.BucketID,
+			Flag:  "bucket-id",
+			Desc:  "The ID of the bucket in the backup",
+		},
+		{
+			DestP:  &restoreFlags.Bucket,
+			Flag:   "bucket",
+			Short:  'b',
+			EnvVar: "BUCKET_NAME",
+			Desc:   "The name of the bucket in the backup",
+		},
+		{
+			DestP: &restoreFlags.NewOrg,
+			Flag:  "new-org",
+			Desc:  "The name of the organization to restore the bucket into",
+		},
+		{
+			DestP: &restoreFlags.NewBucket,
+			Flag:  "new-bucket",
+			Desc:  "The name of the bucket to restore the data into",
+		},
+	}
+	opts.mustRegister(cmd)
+
+	return cmd
+}
+
+var restoreFlags struct {
+	Path      string
+	Manifest  string
+	OrgID     string
+	Org       string
+	BucketID  string
+	Bucket    string
+	NewOrg    string
+	NewBucket string
+}
+
+func newRestoreService() (influxdb.RestoreService, error) {
+	return &http.RestoreService{
+		Addr:               flags.host,
+		Token:              flags.token,
+		InsecureSkipVerify: flags.skipVerify,
+	}, nil
+}
+
+func restoreF(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if flags.local {
+		return fmt.Errorf("local flag not supported for restore command")
+	}
+
+	if restoreFlags.Path == "" {
+		return fmt.Errorf("must specify path")
+	}
+
+	// Archives are verified and extracted before any of the data is restored.
+	dir, err := ioutil.TempDir("", "influx-restore")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	if archive, err := backup.ExtractAll(restoreFlags.Path, dir); err != nil {
+		return err
+	} else if archive != nil {
+		restoreFlags.Path = dir
+	}
+
+	var manifest *influxdb.BackupManifest
+	if restoreFlags.Manifest != "" {
+		manifest, err = influxdb.ReadBackupManifest(filepath.Join(restoreFlags.Path, restoreFlags.Manifest))
+	} else {
+		manifest, err = influxdb.ReadLatestBackupManifest(restoreFlags.Path)
+	}
+	if err != nil {
+		return err
+	}
+
+	src, err := findBackupBucket(ctx, manifest)
+	if err != nil {
+		return err
+	}
+
+	dst, err := findOrCreateRestoreBucket(ctx, src)
+	if err != nil {
+		return err
+	}
+
+	files, err := restoreFiles(manifest)
+	if err != nil {
+		return err
+	}
+
+	restoreService, err := newRestoreService()
+	if err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeRestoreArchive(pw, files))
+	}()
+	defer pr.Close()
+
+	req := influxdb.RestoreBucketRequest{
+		SourceOrgID:    src.OrgID,
+		SourceBucketID: src.ID,
+		OrgID:          dst.OrgID,
+		BucketID:       dst.ID,
+	}
+	if err := restoreService.RestoreBucket(ctx, req, pr); err != nil {
+		return err
+	}
+
+	fmt.Printf("Restored bucket %s (%s) into %s (%s) from %d files\n", src.Name, src.ID, dst.Name, dst.ID, len(files))
+	return nil
+}
+
+// findBackupBucket finds the bucket to restore in the metadata of the backup.
+// Without a bucket flag, the bucket of a bucket backup is restored.
+func findBackupBucket(ctx context.Context, manifest *influxdb.BackupManifest) (*influxdb.Bucket, error) {
+	boltPath := filepath.Join(restoreFlags.Path, bolt.DefaultFilename)
+	if _, err := os.Stat(boltPath); os.IsNotExist(err) {
+		return manifestBucket(manifest)
+	}
+
+	store := bolt.NewKVStore(zap.NewNop(), boltPath)
+	if err := store.Open(ctx); err != nil {
+		return nil, fmt.Errorf("failed to open %s in backup: %v", bolt.DefaultFilename, err)
+	}
+	defer store.Close()
+	s := kv.NewService(zap.NewNop(), store)
+
+	var filter influxdb.BucketFilter
+	var err error
+	switch {
+	case restoreFlags.BucketID != "" && restoreFlags.Bucket != "":
+		return nil, fmt.Errorf("please specify one of bucket or bucket-id")
+	case restoreFlags.BucketID != "":
+		filter.ID, err = influxdb.IDFromString(restoreFlags.BucketID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode bucket-id: %v", err)
+		}
+	case restoreFlags.Bucket != "":
+		filter.Name = &restoreFlags.Bucket
+	case manifest != nil && manifest.BucketID != nil:
+		filter.ID = manifest.BucketID
+	default:
+		return nil, fmt.Errorf("please specify one of bucket or bucket-id")
+	}
+
+	switch {
+	case restoreFlags.OrgID != "" && restoreFlags.Org != "":
+		return nil, fmt.Errorf("please specify one of org or org-id")
+	case restoreFlags.OrgID != "":
+		filter.OrganizationID, err = influxdb.IDFromString(restoreFlags.OrgID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode org-id: %v", err)
+		}
+	case restoreFlags.Org != "":
+		filter.Org = &restoreFlags.Org
+	case filter.Name != nil:
+		return nil, fmt.Errorf("please specify one of org or org-id")
+	}
+
+	bucket, err := s.FindBucket(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find bucket in backup: %v", err)
+	}
+	return bucket, nil
+}
+
+// manifestBucket returns the bucket of a bucket backup without metadata, such as a
+// backup created by a user that may not read the metadata of every user. Only the
+// IDs of the bucket are known, so the name of the bucket to restore into is required.
+func manifestBucket(manifest *influxdb.BackupManifest) (*influxdb.Bucket, error) {
+	if manifest == nil || manifest.BucketID == nil {
+		return nil, fmt.Errorf("backup has no %s, a backup of a bucket is required", bolt.DefaultFilename)
+	}
+	if restoreFlags.NewBucket == "" {
+		return nil, fmt.Errorf("backup has no %s, please specify new-bucket", bolt.DefaultFilename)
+	}
+	return &influxdb.Bucket{
+		ID:    *manifest.BucketID,
+		OrgID: *manifest.OrgID,
+	}, nil
+}
+
+// findOrCreateRestoreBucket finds the bucket to restore the data into.
+// If the bucket does not exist, it is created with the settings of the bucket in the backup.
+func findOrCreateRestoreBucket(ctx context.Context, src *influxdb.Bucket) (*influxdb.Bucket, error) {
+	orgID := src.OrgID
+	if restoreFlags.NewOrg != "" {
+		orgs, err := newOrganizationService()
+		if err != nil {
+			return nil, err
+		}
+		org, err := orgs.FindOrganization(ctx, influxdb.OrganizationFilter{Name: &restoreFlags.NewOrg})
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve organization %q: %v", restoreFlags.NewOrg, err)
+		}
+		orgID = org.ID
+	}
+
+	name := src.Name
+	if restoreFlags.NewBucket != "" {
+		name = restoreFlags.NewBucket
+	}
+
+	bs, err := newBucketService()
+	if err != nil {
+		return nil, err
+	}
+
+	bucket, err := bs.FindBucket(ctx, influxdb.BucketFilter{
+		Name:           &name,
+		OrganizationID: &orgID,
+	})
+	if err == nil {
+		return bucket, nil
+	} else if influxdb.ErrorCode(err) != influxdb.ENotFound {
+		return nil, fmt.Errorf("failed to retrieve bucket %q: %v", name, err)
+	}
+
+	bucket = &influxdb.Bucket{
+		OrgID:           orgID,
+		Name:            name,
+		Description:     src.Description,
+		RetentionPeriod: src.RetentionPeriod,
+	}
+	if err := bs.CreateBucket(ctx, bucket); err != nil {
+		return nil, fmt.Errorf("failed to create bucket %q: %v", name, err)
+	}
+	return bucket, nil
+}
+
+// restoreFiles returns the paths of the TSM files and tombstone files to restore.
+// A backup without a manifest restores every TSM file in the backup directory.
+func restoreFiles(manifest *influxdb.BackupManifest) ([]string, error) {
+	var files []string
+	if manifest != nil {
+		for _, f := range manifest.Files {
+			for _, name := range f.Files {
+				path := filepath.Join(restoreFlags.Path, name)
+				if _, err := os.Stat(path); os.IsNotExist(err) {
+					return nil, fmt.Errorf("backup file %s of backup %s is missing", name, f.Manifest)
+				}
+				files = append(files, path)
+			}
+		}
+		return files, nil
+	}
+
+	fis, err := ioutil.ReadDir(restoreFlags.Path)
+	if err != nil {
+		return nil, err
+	}
+	for _, fi := range fis {
+		switch filepath.Ext(fi.Name()) {
+		case "." + tsm1.TSMFileExtension, "." + tsm1.TombstoneFileExtension:
+			files = append(files, filepath.Join(restoreFlags.Path, fi.Name()))
+		}
+	}
+	return files, nil
+}
+
+// writeRestoreArchive writes the files to w as a tar archive.
+func writeRestoreArchive(w io.Writer, files []string) error {
+	tw := tar.NewWriter(w)
+	for _, path := range files {
+		if err := writeArchiveFile(tw, path); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func writeArchiveFile(tw *tar.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	hdr, err := tar.FileInfoHeader(fi, "")
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/http"
+	"github.com/spf13/cobra"
+)
+
+// scraperService is the part of the scraper client used by the scraper commands.
+type scraperService interface {
+	ListTargets(ctx context.Context, filter influxdb.ScraperTargetFilter) ([]influxdb.ScraperTarget, error)
+	GetTargetStatus(ctx context.Context, id influxdb.ID) (*influxdb.ScraperStatus, error)
+}
+
+type scraperSVCsFn func() (scraperService, error)
+
+func cmdScraper(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+	builder := newCmdScraperBuilder(newScraperSVCs, opt)
+	builder.globalFlags = f
+	return builder.cmd()
+}
+
+type cmdScraperBuilder struct {
+	genericCLIOpts
+	*globalFlags
+
+	svcFn scraperSVCsFn
+
+	headers bool
+	id      string
+	name    string
+	org     organization
+}
+
+func newCmdScraperBuilder(svcsFn scraperSVCsFn, opt genericCLIOpts) *cmdScraperBuilder {
+	return &cmdScraperBuilder{
+		genericCLIOpts: opt,
+		svcFn:          svcsFn,
+	}
+}
+
+func (b *cmdScraperBuilder) cmd() *cobra.Command {
+	cmd := b.newCmd("scraper", nil)
+	cmd.Short = "Scraper target management commands"
+	cmd.Run = seeHelp
+	cmd.AddCommand(
+		b.cmdFind(),
+	)
+	return cmd
+}
+
+func (b *cmdScraperBuilder) cmdFind() *cobra.Command {
+	cmd := b.newCmd("list", b.cmdFindRunEFn)
+	cmd.Short = "List scraper targets and the status of their last scrape"
+	cmd.Aliases = []string{"find", "ls"}
+
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The scraper target ID")
+	cmd.Flags().StringVarP(&b.name, "name", "n", "", "The scraper target name")
+	cmd.Flags().BoolVar(&b.headers, "headers", true, "To print the table headers; defaults true")
+	b.org.register(cmd, false)
+
+	return cmd
+}
+
+func (b *cmdScraperBuilder) cmdFindRunEFn(cmd *cobra.Command, args []string) error {
+	if err := b.org.validOrgFlags(); err != nil {
+		return err
+	}
+
+	scraperSVC, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	var filter influxdb.ScraperTargetFilter
+	if b.id != "" {
+		id, err := influxdb.IDFromString(b.id)
+		if err != nil {
+			return fmt.Errorf("failed to decode scraper target id %q: %v", b.id, err)
+		}
+		filter.IDs = map[influxdb.ID]bool{*id: true}
+	}
+	if b.name != "" {
+		filter.Name = &b.name
+	}
+	if b.org.id != "" {
+		orgID, err := influxdb.IDFromString(b.org.id)
+		if err != nil {
+			return fmt.Errorf("failed to decode org id %q: %v", b.org.id, err)
+		}
+		filter.OrgID = orgID
+	}
+	if b.org.name != "" {
+		filter.Org = &b.org.name
+	}
+
+	ctx := context.Background()
+	targets, err := scraperSVC.ListTargets(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve scraper targets: %s", err)
+	}
+
+	w := b.newTabWriter()
+	w.HideHeaders(!b.headers)
+	w.WriteHeaders("ID", "Name", "Type", "URL", "OrganizationID", "BucketID", "LastScrape", "Duration", "Samples", "LastError")
+	for _, t := range targets {
+		row := map[string]interface{}{
+			"ID":             t.ID.String(),
+			"Name":           t.Name,
+			"Type":           t.Type,
+			"URL":            t.URL,
+			"OrganizationID": t.OrgID.String(),
+			"BucketID":       t.BucketID.String(),
+			"LastScrape":     "",
+			"Duration":       "",
+			"Samples":        "",
+			"LastError":      "",
+		}
+		status, err := scraperSVC.GetTargetStatus(ctx, t.ID)
+		if err != nil && influxdb.ErrorCode(err) != influxdb.ENotFound {
+			return fmt.Errorf("failed to retrieve status of scraper target %s: %s", t.ID, err)
+		}
+		if status != nil {
+			row["LastScrape"] = status.LastScrape.Format(time.RFC3339)
+			row["Duration"] = status.Duration.String()
+			row["Samples"] = status.Samples
+			row["LastError"] = status.LastError
+		}
+		w.Write(row)
+	}
+	w.Flush()
+
+	return nil
+}
+
+func newScraperSVCs() (scraperService, error) {
+	return &http.ScraperService{
+		Addr:               flags.host,
+		Token:              flags.token,
+		InsecureSkipVerify: flags.skipVerify,
+	}, nil
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmdScraper(t *testing.T) {
+	t.Run("list", func(t *testing.T) {
+		type called struct {
+			filter   influxdb.ScraperTargetFilter
+			statuses []influxdb.ID
+		}
+
+		orgName := "rg"
+		targetName := "node"
+		tests := []struct {
+			name     string
+			expected called
+			flags    []string
+			command  string
+			envVars  map[string]string
+		}{
+			{
+				name:    "org id",
+				flags:   []string{"--org-id=" + influxdb.ID(3).String()},
+				envVars: envVarsZeroMap,
+				expected: called{
+					filter:   influxdb.ScraperTargetFilter{OrgID: idPtr(3)},
+					statuses: []influxdb.ID{1, 2},
+				},
+			},
+			{
+				name:    "org and name",
+				flags:   []string{"--org=rg", "--name=node"},
+				envVars: envVarsZeroMap,
+				expected: called{
+					filter:   influxdb.ScraperTargetFilter{Org: &orgName, Name: &targetName},
+					statuses: []influxdb.ID{1, 2},
+				},
+			},
+			{
+				name:    "id",
+				flags:   []string{"--org=rg", "--id=" + influxdb.ID(1).String()},
+				envVars: envVarsZeroMap,
+				expected: called{
+					filter: influxdb.ScraperTargetFilter{
+						IDs: map[influxdb.ID]bool{1: true},
+						Org: &orgName,
+					},
+					statuses: []influxdb.ID{1, 2},
+				},
+			},
+			{
+				name:    "ls alias",
+				command: "ls",
+				flags:   []string{"--org=rg"},
+				envVars: envVarsZeroMap,
+				expected: called{
+					filter:   influxdb.ScraperTargetFilter{Org: &orgName},
+					statuses: []influxdb.ID{1, 2},
+				},
+			},
+		}
+
+		cmdFn := func() (func(*globalFlags, genericCLIOpts) *cobra.Command, *called) {
+			calls := new(called)
+			svc := &fakeScraperService{
+				listTargetsFn: func(ctx context.Context, filter influxdb.ScraperTargetFilter) ([]influxdb.ScraperTarget, error) {
+					calls.filter = filter
+					return []influxdb.ScraperTarget{
+						{ID: 1, Name: "node", Type: influxdb.PrometheusScraperType, OrgID: 3, BucketID: 4},
+						{ID: 2, Name: "app", Type: influxdb.JSONScraperType, OrgID: 3, BucketID: 4},
+					}, nil
+				},
+				getTargetStatusFn: func(ctx context.Context, id influxdb.ID) (*influxdb.ScraperStatus, error) {
+					calls.statuses = append(calls.statuses, id)
+					if id == 2 {
+						return nil, &influxdb.Error{
+							Code: influxdb.ENotFound,
+							Msg:  influxdb.ErrScraperStatusNotFound,
+						}
+					}
+					return &influxdb.ScraperStatus{
+						LastScrape: time.Unix(1600000000, 0),
+						Duration:   influxdb.Duration{Duration: time.Second},
+						Samples:    42,
+					}, nil
+				},
+			}
+
+			return func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+				builder := newCmdScraperBuilder(func() (scraperService, error) {
+					return svc, nil
+				}, opt)
+				return builder.cmd()
+			}, calls
+		}
+
+		for _, tt := range tests {
+			fn := func(t *testing.T) {
+				defer addEnvVars(t, tt.envVars)()
+
+				builder := newInfluxCmdBuilder(
+					in(new(bytes.Buffer)),
+					out(ioutil.Discard),
+				)
+				nestedCmdFn, calls := cmdFn()
+				cmd := builder.cmd(nestedCmdFn)
+
+				if tt.command == "" {
+					tt.command = "list"
+				}
+
+				cmd.SetArgs(append([]string{"scraper", tt.command}, tt.flags...))
+
+				require.NoError(t, cmd.Execute())
+				assert.Equal(t, tt.expected, *calls)
+			}
+
+			t.Run(tt.name, fn)
+		}
+	})
+}
+
+type fakeScraperService struct {
+	listTargetsFn     func(context.Context, influxdb.ScraperTargetFilter) ([]influxdb.ScraperTarget, error)
+	getTargetStatusFn func(context.Context, influxdb.ID) (*influxdb.ScraperStatus, error)
+}
+
+func (s *fakeScraperService) ListTargets(ctx context.Context, filter influxdb.ScraperTargetFilter) ([]influxdb.ScraperTarget, error) {
+	return s.listTargetsFn(ctx, filter)
+}
+
+func (s *fakeScraperService) GetTargetStatus(ctx context.Context, id influxdb.ID) (*influxdb.ScraperStatus, error) {
+	return s.getTargetStatusFn(ctx, id)
+}
+
+func idPtr(id influxdb.ID) *influxdb.ID {
+	return &id
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"time"
 
 	"github.com/influxdata/flux/repl"
@@ -29,6 +30,9 @@ func cmdTask(f *globalFlags, opt genericCLIOpts) *cobra.Command {
 	cmd.AddCommand(
 		taskLogCmd(opt),
 		taskRunCmd(opt),
+		taskBackfillCmd(opt),
+		taskStatsCmd(opt),
+		taskRetentionCmd(opt),
 		taskCreateCmd(opt),
 		taskDeleteCmd(opt),
 		taskFindCmd(opt),
@@ -534,6 +538,7 @@ func taskRunFindF(cmd *cobra.Command, args []string) error {
 		"StartedAt",
 		"FinishedAt",
 		"RequestedAt",
+		"Attempts",
 	)
 
 	for _, r := range runs {
@@ -550,6 +555,7 @@ func taskRunFindF(cmd *cobra.Command, args []string) error {
 			"StartedAt":    startedAt,
 			"FinishedAt":   finishedAt,
 			"RequestedAt":  requestedAt,
+			"Attempts":     r.Attempts,
 		})
 	}
 	w.Flush()
@@ -602,3 +608,254 @@ func runRetryF(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+var taskBackfillFlags struct {
+	id          string
+	start, stop string
+}
+
+func taskBackfillCmd(opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("backfill", taskBackfillF)
+	cmd.Short = "Run a task for every time it is scheduled for in a time range"
+	cmd.Long = `Run a task for every time it is scheduled for between start and stop.
+The progress is reported until the backfill finishes, an interrupt cancels it.`
+
+	cmd.Flags().StringVarP(&taskBackfillFlags.id, "id", "i", "", "task id (required)")
+	cmd.Flags().StringVarP(&taskBackfillFlags.start, "start", "", "", "the first time of the range as RFC3339 (required)")
+	cmd.Flags().StringVarP(&taskBackfillFlags.stop, "stop", "", "", "the last time of the range as RFC3339 (required)")
+	cmd.MarkFlagRequired("id")
+	cmd.MarkFlagRequired("start")
+	cmd.MarkFlagRequired("stop")
+
+	return cmd
+}
+
+func taskBackfillF(cmd *cobra.Command, args []string) error {
+	client, err := newHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	s := &http.TaskService{
+		Client:             client,
+		InsecureSkipVerify: flags.skipVerify,
+	}
+
+	var id influxdb.ID
+	if err := id.DecodeFromString(taskBackfillFlags.id); err != nil {
+		return err
+	}
+	start, err := time.Parse(time.RFC3339, taskBackfillFlags.start)
+	if err != nil {
+		return fmt.Errorf("invalid start: %v", err)
+	}
+	stop, err := time.Parse(time.RFC3339, taskBackfillFlags.stop)
+	if err != nil {
+		return fmt.Errorf("invalid stop: %v", err)
+	}
+
+	ctx := context.Background()
+	b, err := s.BackfillTask(ctx, id, start, stop)
+	if err != nil {
+		return err
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for !b.Done() {
+		select {
+		case <-interrupt:
+			fmt.Fprintln(os.Stderr, "Canceling backfill...")
+			if err := s.CancelBackfill(ctx, b.TaskID, b.ID); err != nil {
+				return err
+			}
+		case <-ticker.C:
+		}
+
+		if b, err = s.FindBackfillByID(ctx, b.TaskID, b.ID); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Queued %d of %d runs: %d succeeded, %d failed, %d skipped\n",
+			b.Queued+b.Skipped, b.Total, b.Succeeded, b.Failed, b.Skipped)
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"ID",
+		"TaskID",
+		"Status",
+		"Total",
+		"Queued",
+		"Skipped",
+		"Succeeded",
+		"Failed",
+		"Canceled",
+	)
+	w.Write(map[string]interface{}{
+		"ID":        b.ID,
+		"TaskID":    b.TaskID,
+		"Status":    b.Status,
+		"Total":     b.Total,
+		"Queued":    b.Queued,
+		"Skipped":   b.Skipped,
+		"Succeeded": b.Succeeded,
+		"Failed":    b.Failed,
+		"Canceled":  b.Canceled,
+	})
+	w.Flush()
+
+	if b.Status == influxdb.BackfillFailed {
+		return fmt.Errorf("backfill failed: %s", b.Error)
+	}
+	return nil
+}
+
+var taskStatsFlags struct {
+	id     string
+	window time.Duration
+}
+
+func taskStatsCmd(opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("stats", taskStatsF)
+	cmd.Short = "Statistics about the finished runs of a task"
+	cmd.Long = `Statistics about the runs of a task that finished after starting in the window before now:
+the success rate, and the median and 95th percentile of the run durations and of the lag
+between the times the runs are scheduled for and their start.`
+
+	cmd.Flags().StringVarP(&taskStatsFlags.id, "id", "i", "", "task id (required)")
+	cmd.Flags().DurationVarP(&taskStatsFlags.window, "window", "w", 24*time.Hour, "the window before now the runs started in")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func taskStatsF(cmd *cobra.Command, args []string) error {
+	if taskStatsFlags.window <= 0 {
+		return fmt.Errorf("window must be positive")
+	}
+
+	client, err := newHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	s := &http.TaskService{
+		Client:             client,
+		InsecureSkipVerify: flags.skipVerify,
+	}
+
+	var id influxdb.ID
+	if err := id.DecodeFromString(taskStatsFlags.id); err != nil {
+		return err
+	}
+
+	stop := time.Now()
+	stats, err := s.FindTaskStats(context.Background(), id, stop.Add(-taskStatsFlags.window), stop)
+	if err != nil {
+		return err
+	}
+
+	seconds := func(s float64) time.Duration {
+		return time.Duration(s * float64(time.Second))
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"TaskID",
+		"Runs",
+		"Succeeded",
+		"Failed",
+		"Canceled",
+		"SuccessRate",
+		"DurationP50",
+		"DurationP95",
+		"LagP50",
+		"LagP95",
+	)
+	w.Write(map[string]interface{}{
+		"TaskID":      stats.TaskID,
+		"Runs":        stats.Runs,
+		"Succeeded":   stats.Succeeded,
+		"Failed":      stats.Failed,
+		"Canceled":    stats.Canceled,
+		"SuccessRate": fmt.Sprintf("%.2f", stats.SuccessRate),
+		"DurationP50": seconds(stats.Duration.P50),
+		"DurationP95": seconds(stats.Duration.P95),
+		"LagP50":      seconds(stats.Lag.P50),
+		"LagP95":      seconds(stats.Lag.P95),
+	})
+	w.Flush()
+
+	return nil
+}
+
+var taskRetentionFlags struct {
+	org       organization
+	retention time.Duration
+}
+
+func taskRetentionCmd(opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("retention", taskRetentionF)
+	cmd.Short = "Show or update the retention of the run history of an organization"
+	cmd.Long = `Show the retention of the run history of the tasks of an organization,
+or update it with the retention flag. The run history is kept in the organization's
+` + influxdb.TasksSystemBucketName + ` system bucket, which retains data for this duration.`
+
+	taskRetentionFlags.org.register(cmd, false)
+	cmd.Flags().DurationVarP(&taskRetentionFlags.retention, "retention", "r", 0, "Duration the run history is retained for. 0 is infinite.")
+
+	return cmd
+}
+
+func taskRetentionF(cmd *cobra.Command, args []string) error {
+	if err := taskRetentionFlags.org.validOrgFlags(); err != nil {
+		return err
+	}
+
+	bktSVC, orgSVC, err := newBucketSVCs()
+	if err != nil {
+		return err
+	}
+
+	orgID, err := taskRetentionFlags.org.getID(orgSVC)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	name := influxdb.TasksSystemBucketName
+	bkt, err := bktSVC.FindBucket(ctx, influxdb.BucketFilter{
+		OrganizationID: &orgID,
+		Name:           &name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find the run history of the organization: %v", err)
+	}
+
+	if cmd.Flags().Changed("retention") {
+		if taskRetentionFlags.retention < 0 {
+			return fmt.Errorf("retention must not be negative")
+		}
+		bkt, err = bktSVC.UpdateBucket(ctx, bkt.ID, influxdb.BucketUpdate{
+			RetentionPeriod: &taskRetentionFlags.retention,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update the retention of the run history: %v", err)
+		}
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders("OrganizationID", "BucketID", "Retention")
+	w.Write(map[string]interface{}{
+		"OrganizationID": orgID,
+		"BucketID":       bkt.ID,
+		"Retention":      bkt.RetentionPeriod,
+	})
+	w.Flush()
+
+	return nil
+}
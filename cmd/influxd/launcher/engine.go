@@ -31,8 +31,10 @@ type Engine interface {
 	storage.BucketDeleter
 	prom.PrometheusCollector
 	influxdb.BackupService
+	influxdb.RestoreService
 
 	SeriesCardinality() int64
+	MeasurementFields(ctx context.Context, orgID, bucketID influxdb.ID, measurement string, start, end int64) (map[string]influxql.DataType, error)
 
 	WithLogger(log *zap.Logger)
 	Open(context.Context) error
@@ -121,6 +123,11 @@ func (t *TemporaryEngine) DeleteBucketRangePredicate(ctx context.Context, orgID,
 
 }
 
+// EstimateDeleteBucketRangePredicate estimates the data a delete from the range and predicate would remove.
+func (t *TemporaryEngine) EstimateDeleteBucketRangePredicate(ctx context.Context, orgID, bucketID influxdb.ID, min, max int64, pred influxdb.Predicate) ([]influxdb.DeleteEstimate, error) {
+	return t.engine.EstimateDeleteBucketRangePredicate(ctx, orgID, bucketID, min, max, pred)
+}
+
 // DeleteBucket deletes a bucket from the time-series data.
 func (t *TemporaryEngine) DeleteBucket(ctx context.Context, orgID, bucketID influxdb.ID) error {
 	return t.engine.DeleteBucket(ctx, orgID, bucketID)
@@ -157,6 +164,11 @@ func (t *TemporaryEngine) TagValues(ctx context.Context, orgID, bucketID influxd
 	return t.engine.TagValues(ctx, orgID, bucketID, tagKey, start, end, predicate)
 }
 
+// MeasurementFields calls into the underlying engines MeasurementFields.
+func (t *TemporaryEngine) MeasurementFields(ctx context.Context, orgID, bucketID influxdb.ID, measurement string, start, end int64) (map[string]influxql.DataType, error) {
+	return t.engine.MeasurementFields(ctx, orgID, bucketID, measurement, start, end)
+}
+
 // Flush will remove the time-series files and re-open the engine.
 func (t *TemporaryEngine) Flush(ctx context.Context) {
 	if err := t.Close(); err != nil {
@@ -168,8 +180,8 @@ func (t *TemporaryEngine) Flush(ctx context.Context) {
 	}
 }
 
-func (t *TemporaryEngine) CreateBackup(ctx context.Context) (int, []string, error) {
-	return t.engine.CreateBackup(ctx)
+func (t *TemporaryEngine) CreateBackup(ctx context.Context, filter influxdb.BackupFilter) (int, []string, error) {
+	return t.engine.CreateBackup(ctx, filter)
 }
 
 func (t *TemporaryEngine) FetchBackupFile(ctx context.Context, backupID int, backupFile string, w io.Writer) error {
@@ -179,3 +191,7 @@ func (t *TemporaryEngine) FetchBackupFile(ctx context.Context, backupID int, bac
 func (t *TemporaryEngine) InternalBackupPath(backupID int) string {
 	return t.engine.InternalBackupPath(backupID)
 }
+
+func (t *TemporaryEngine) RestoreBucket(ctx context.Context, req influxdb.RestoreBucketRequest, r io.Reader) error {
+	return t.engine.RestoreBucket(ctx, req, r)
+}
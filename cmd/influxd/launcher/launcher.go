@@ -38,6 +38,7 @@ import (
 	infprom "github.com/influxdata/influxdb/prometheus"
 	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/query/control"
+	"github.com/influxdata/influxdb/query/influxql"
 	"github.com/influxdata/influxdb/query/stdlib/influxdata/influxdb"
 	"github.com/influxdata/influxdb/snowflake"
 	"github.com/influxdata/influxdb/source"
@@ -531,6 +532,7 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		orgLogSvc                 platform.OrganizationOperationLogService = m.kvService
 		onboardingSvc             platform.OnboardingService               = m.kvService
 		scraperTargetSvc          platform.ScraperTargetStoreService       = m.kvService
+		scraperStatusSvc          platform.ScraperStatusService            = m.kvService
 		telegrafSvc               platform.TelegrafConfigStore             = m.kvService
 		userResourceSvc           platform.UserResourceMappingService      = m.kvService
 		labelSvc                  platform.LabelService                    = m.kvService
@@ -580,9 +582,11 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 	m.reg.MustRegister(m.engine.PrometheusCollectors()...)
 
 	var (
-		deleteService platform.DeleteService = m.engine
-		pointsWriter  storage.PointsWriter   = m.engine
-		backupService platform.BackupService = m.engine
+		deleteService  platform.DeleteService  = m.engine
+		pointsWriter   storage.PointsWriter    = m.engine
+		readStore      reads.Store             = readservice.NewStore(m.engine)
+		backupService  platform.BackupService  = m.engine
+		restoreService platform.RestoreService = m.engine
 	)
 
 	// TODO(cwolff): Figure out a good default per-query memory limit:
@@ -594,7 +598,7 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 	)
 
 	deps, err := influxdb.NewDependencies(
-		reads.NewReader(readservice.NewStore(m.engine)),
+		reads.NewReader(readStore),
 		m.engine,
 		authorizer.NewBucketService(bucketSvc),
 		authorizer.NewOrgService(orgSvc),
@@ -610,8 +614,15 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		ConcurrencyQuota:         concurrencyQuota,
 		MemoryBytesQuotaPerQuery: int64(memoryBytesQuotaPerQuery),
 		QueueSize:                QueueSize,
+		OrganizationService:      orgSvc,
 		Logger:                   m.log.With(zap.String("service", "storage-reads")),
-		ExecutorDependencies:     []flux.Dependency{deps},
+		ExecutorDependencies: []flux.Dependency{
+			deps,
+			influxql.Dependencies{
+				SchemaReader: m.engine,
+				BucketLookup: query.FromBucketService(authorizer.NewBucketService(bucketSvc)),
+			},
+		},
 	})
 	if err != nil {
 		m.log.Error("Failed to create query controller", zap.Error(err))
@@ -621,24 +632,30 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 	m.reg.MustRegister(m.queryController.PrometheusCollectors()...)
 
 	var storageQueryService = readservice.NewProxyQueryService(m.queryController)
-	var taskSvc platform.TaskService
+	var (
+		taskSvc         platform.TaskService
+		taskBackfillSvc platform.TaskBackfillService
+		taskStatsSvc    platform.TaskStatsService
+	)
 	{
 		// create the task stack
 		combinedTaskService := taskbackend.NewAnalyticalStorage(m.log.With(zap.String("service", "task-analytical-store")), m.kvService, m.kvService, m.kvService, pointsWriter, query.QueryServiceBridge{AsyncQueryService: m.queryController})
 
-		executor, executorMetrics := executor.NewExecutor(
+		taskExecutor, executorMetrics := executor.NewExecutor(
 			m.log.With(zap.String("service", "task-executor")),
 			query.QueryServiceBridge{AsyncQueryService: m.queryController},
 			authSvc,
 			combinedTaskService,
 			combinedTaskService,
 		)
-		m.executor = executor
+		// Hold back the runs beyond the concurrency option of their task, backfilled runs included.
+		taskExecutor.SetLimitFunc(executor.ConcurrencyLimit(taskExecutor))
+		m.executor = taskExecutor
 		m.reg.MustRegister(executorMetrics.PrometheusCollectors()...)
 		schLogger := m.log.With(zap.String("service", "task-scheduler"))
 
 		sch, sm, err := scheduler.NewScheduler(
-			executor,
+			taskExecutor,
 			taskbackend.NewSchedulableTaskService(m.kvService),
 			scheduler.WithOnErrorFn(func(ctx context.Context, taskID scheduler.ID, scheduledAt time.Time, err error) {
 				schLogger.Info(
@@ -657,9 +674,14 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		taskCoord := coordinator.NewCoordinator(
 			coordLogger,
 			sch,
-			executor)
+			taskExecutor,
+			coordinator.WithTaskFinderOpt(combinedTaskService))
+		// Trigger the runs of the tasks that run after the task of a succeeded run.
+		taskExecutor.SetRunSucceededFunc(taskCoord.RunSucceeded)
 
 		taskSvc = middleware.New(combinedTaskService, taskCoord)
+		taskBackfillSvc = executor.NewBackfiller(m.log.With(zap.String("service", "task-backfiller")), combinedTaskService, taskExecutor)
+		taskStatsSvc = combinedTaskService
 		m.taskControlService = combinedTaskService
 		if err := taskbackend.TaskNotifyCoordinatorOfExisting(
 			ctx,
@@ -667,7 +689,7 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 			combinedTaskService,
 			taskCoord,
 			func(ctx context.Context, taskID platform.ID, runID platform.ID) error {
-				_, err := executor.ResumeCurrentRun(ctx, taskID, runID)
+				_, err := taskExecutor.ResumeCurrentRun(ctx, taskID, runID)
 				return err
 			},
 			coordLogger); err != nil {
@@ -745,7 +767,7 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 	}
 
 	subscriber.Subscribe(gather.MetricsSubject, "metrics", gather.NewRecorderHandler(m.log, gather.PointWriter{Writer: pointsWriter}))
-	scraperScheduler, err := gather.NewScheduler(m.log, 10, scraperTargetSvc, publisher, subscriber, 10*time.Second, 30*time.Second)
+	scraperScheduler, err := gather.NewScheduler(m.log, 10, scraperTargetSvc, scraperStatusSvc, bucketSvc, secretSvc, publisher, subscriber, 10*time.Second, 30*time.Second)
 	if err != nil {
 		m.log.Error("Failed to create scraper subscriber", zap.Error(err))
 		return err
@@ -761,6 +783,32 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		log.Info("Stopping")
 	}(m.log)
 
+	// expired authorizations can no longer be used, so they are removed from the store periodically.
+	m.wg.Add(1)
+	go func(log *zap.Logger) {
+		defer m.wg.Done()
+		log = log.With(zap.String("service", "authorization-cleanup"))
+
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				log.Info("Stopping")
+				return
+			case <-ticker.C:
+				n, err := m.kvService.DeleteExpiredAuthorizations(ctx)
+				if err != nil {
+					log.Error("Failed to delete expired authorizations", zap.Error(err))
+					continue
+				}
+				if n > 0 {
+					log.Info("Deleted expired authorizations", zap.Int("count", n))
+				}
+			}
+		}
+	}(m.log)
+
 	m.httpServer = &nethttp.Server{
 		Addr: m.httpBindAddress,
 	}
@@ -773,10 +821,13 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		NewBucketService:     source.NewBucketService,
 		NewQueryService:      source.NewQueryService,
 		PointsWriter:         pointsWriter,
+		ReadStore:            readStore,
 		DeleteService:        deleteService,
 		BackupService:        backupService,
 		KVBackupService:      m.kvService,
+		RestoreService:       restoreService,
 		AuthorizationService: authSvc,
+		AuditService:         m.kvService,
 		// Wrap the BucketService in a storage backed one that will ensure deleted buckets are removed from the storage engine.
 		BucketService:                   storage.NewBucketService(bucketSvc, m.engine),
 		SessionService:                  sessionSvc,
@@ -795,12 +846,16 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		OnboardingService:               onboardingSvc,
 		InfluxQLService:                 storageQueryService,
 		FluxService:                     storageQueryService,
+		ActiveQueryService:              m.queryController,
 		TaskService:                     taskSvc,
+		TaskBackfillService:             taskBackfillSvc,
+		TaskStatsService:                taskStatsSvc,
 		TelegrafService:                 telegrafSvc,
 		NotificationRuleStore:           notificationRuleSvc,
 		NotificationEndpointService:     endpoints.NewService(notificationEndpointStore, secretSvc, userResourceSvc, orgSvc),
 		CheckService:                    checkSvc,
 		ScraperTargetStoreService:       scraperTargetSvc,
+		ScraperStatusService:            scraperStatusSvc,
 		ChronografService:               chronografSvc,
 		SecretService:                   secretSvc,
 		LookupService:                   lookupSvc,
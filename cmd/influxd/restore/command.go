@@ -3,10 +3,13 @@ package restore
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/backup"
 	"github.com/influxdata/influxdb/bolt"
 	"github.com/influxdata/influxdb/cmd/influxd/inspect"
 	"github.com/influxdata/influxdb/http"
@@ -14,6 +17,7 @@ import (
 	"github.com/influxdata/influxdb/kit/cli"
 	"github.com/influxdata/influxdb/storage"
 	"github.com/spf13/cobra"
+	"go.uber.org/multierr"
 )
 
 var Command = &cobra.Command{
@@ -30,6 +34,14 @@ Rebuilding the index and series file uses default options as in
 For additional performance options, run restore with "-rebuild-index false"
 and build-tsi afterwards.
 
+If the backup path contains backup manifests, the TSM files listed by the most
+recent manifest are restored. This restores a chain of incremental backups.
+Use "-manifest" to restore an earlier backup in the chain.
+
+The backup path may also be an archive written by "influx backup --archive",
+or a directory of archives. The archives are verified and extracted before any
+existing data is moved.
+
 NOTES:
 
 * The influxd server should not be running when using the restore tool
@@ -44,6 +56,7 @@ var flags struct {
 	enginePath string
 	credPath   string
 	backupPath string
+	manifest   string
 	rebuildTSI bool
 }
 
@@ -83,6 +96,12 @@ func init() {
 			Default: "",
 			Desc:    "path to backup files",
 		},
+		{
+			DestP:   &flags.manifest,
+			Flag:    "manifest",
+			Default: "",
+			Desc:    "name of the backup manifest to restore, defaults to the most recent manifest in the backup path",
+		},
 		{
 			DestP:   &flags.rebuildTSI,
 			Flag:    "rebuild-index",
@@ -99,6 +118,12 @@ func restoreE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no backup path given")
 	}
 
+	dir, err := extractArchives()
+	if err != nil {
+		return fmt.Errorf("failed to extract backup archives: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
 	if err := moveBolt(); err != nil {
 		return fmt.Errorf("failed to move existing bolt file: %v", err)
 	}
@@ -147,6 +172,25 @@ func restoreE(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// extractArchives verifies and extracts the archives in the backup path to a temporary
+// directory, which replaces the backup path. The directory is returned for removal.
+func extractArchives() (string, error) {
+	if err := os.MkdirAll(filepath.Dir(flags.enginePath), 0777); err != nil {
+		return "", err
+	}
+	dir, err := ioutil.TempDir(filepath.Dir(flags.enginePath), "restore")
+	if err != nil {
+		return "", err
+	}
+
+	if manifest, err := backup.ExtractAll(flags.backupPath, dir); err != nil {
+		return "", multierr.Append(err, os.RemoveAll(dir))
+	} else if manifest != nil {
+		flags.backupPath = dir
+	}
+	return dir, nil
+}
+
 func moveBolt() error {
 	if _, err := os.Stat(flags.boltPath); os.IsNotExist(err) {
 		return nil
@@ -236,24 +280,17 @@ func restoreEngine() error {
 		return err
 	}
 
+	manifest, err := readManifest()
+	if err != nil {
+		return err
+	} else if manifest != nil {
+		return restoreManifest(manifest, dataDir)
+	}
+
 	count := 0
-	err := filepath.Walk(flags.backupPath, func(path string, info os.FileInfo, err error) error {
+	err = filepath.Walk(flags.backupPath, func(path string, info os.FileInfo, err error) error {
 		if strings.Contains(path, ".tsm") {
-			f, err := os.OpenFile(path, os.O_RDONLY, 0666)
-			if err != nil {
-				return fmt.Errorf("error opening TSM file: %v", err)
-			}
-			defer f.Close()
-
-			tsmPath := filepath.Join(dataDir, filepath.Base(path))
-			w, err := os.OpenFile(tsmPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
-			if err != nil {
-				return err
-			}
-			defer w.Close()
-
-			_, err = io.Copy(w, f)
-			if err != nil {
+			if err := copyFile(path, filepath.Join(dataDir, filepath.Base(path))); err != nil {
 				return err
 			}
 			count++
@@ -265,6 +302,52 @@ func restoreEngine() error {
 	return err
 }
 
+// readManifest reads the backup manifest to restore. It returns nil
+// if no manifest was given and the backup path does not contain one.
+func readManifest() (*influxdb.BackupManifest, error) {
+	if flags.manifest != "" {
+		return influxdb.ReadBackupManifest(filepath.Join(flags.backupPath, flags.manifest))
+	}
+	return influxdb.ReadLatestBackupManifest(flags.backupPath)
+}
+
+// restoreManifest copies the files listed in the manifest to dataDir. The files
+// of an incremental backup may have been written by a previous backup in the chain.
+func restoreManifest(manifest *influxdb.BackupManifest, dataDir string) error {
+	count := 0
+	for _, file := range manifest.Files {
+		for _, name := range file.Files {
+			path := filepath.Join(flags.backupPath, name)
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				return fmt.Errorf("backup file %s of backup %s is missing", name, file.Manifest)
+			}
+			if err := copyFile(path, filepath.Join(dataDir, file.EngineFileName(name))); err != nil {
+				return err
+			}
+		}
+		count++
+	}
+	fmt.Printf("Restored %d TSM files to %v from %s\n", count, dataDir, manifest.Name())
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	f, err := os.OpenFile(src, os.O_RDONLY, 0666)
+	if err != nil {
+		return fmt.Errorf("error opening TSM file: %v", err)
+	}
+	defer f.Close()
+
+	w, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	_, err = io.Copy(w, f)
+	return err
+}
+
 func restoreFile(backup string, target string, filetype string) error {
 	f, err := os.Open(backup)
 	if err != nil {
@@ -12,4 +12,20 @@ type Predicate interface {
 // DeleteService will delete a bucket from the range and predict.
 type DeleteService interface {
 	DeleteBucketRangePredicate(ctx context.Context, orgID, bucketID ID, min, max int64, pred Predicate) error
+	// EstimateDeleteBucketRangePredicate estimates the data that DeleteBucketRangePredicate
+	// would delete with the same arguments, without deleting anything.
+	EstimateDeleteBucketRangePredicate(ctx context.Context, orgID, bucketID ID, min, max int64, pred Predicate) ([]DeleteEstimate, error)
+}
+
+// DeleteEstimate is the data a delete would remove from a measurement.
+type DeleteEstimate struct {
+	Measurement string `json:"measurement"`
+	// Series is the number of series with data in the time range of the delete.
+	Series int64 `json:"series"`
+	// Blocks is the number of TSM blocks with data in the time range of the delete.
+	Blocks int64 `json:"blocks"`
+	// Points is the estimated number of points in the time range of the delete.
+	// A point that was overwritten is counted once for each time it was written
+	// until the files that contain it are compacted.
+	Points int64 `json:"points"`
 }
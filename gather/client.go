@@ -0,0 +1,111 @@
+package gather
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+)
+
+// scrape sends the scrape request of the target with its authentication
+// and TLS settings. The caller must close the body of the response.
+func scrape(ctx context.Context, secrets influxdb.SecretService, target influxdb.ScraperTarget) (*http.Response, error) {
+	req, err := http.NewRequest("GET", target.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := setAuth(ctx, req, secrets, target); err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: target.Timeout.Duration}
+	if target.TLS != nil {
+		cfg, err := newTLSConfig(ctx, secrets, target)
+		if err != nil {
+			return nil, err
+		}
+		// The transport isn't reused, so it must not keep idle connections.
+		client.Transport = &http.Transport{
+			Proxy:             http.ProxyFromEnvironment,
+			TLSClientConfig:   cfg,
+			DisableKeepAlives: true,
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("scraper target %s responded with status %s", target.URL, resp.Status)
+	}
+	return resp, nil
+}
+
+// setAuth sets the authentication header of the target on the request.
+func setAuth(ctx context.Context, req *http.Request, secrets influxdb.SecretService, target influxdb.ScraperTarget) error {
+	if target.Auth == nil {
+		return nil
+	}
+	secret, err := loadSecret(ctx, secrets, target.OrgID, target.Auth.SecretKey)
+	if err != nil {
+		return err
+	}
+	switch target.Auth.Method {
+	case influxdb.ScraperAuthBasic:
+		creds := base64.StdEncoding.EncodeToString([]byte(target.Auth.Username + ":" + secret))
+		req.Header.Set("Authorization", "Basic "+creds)
+	case influxdb.ScraperAuthBearer:
+		req.Header.Set("Authorization", "Bearer "+secret)
+	case influxdb.ScraperAuthHeader:
+		req.Header.Set(target.Auth.Header, secret)
+	default:
+		return fmt.Errorf("unsupported scraper auth method: %s", target.Auth.Method)
+	}
+	return nil
+}
+
+// newTLSConfig returns the TLS configuration of the target.
+func newTLSConfig(ctx context.Context, secrets influxdb.SecretService, target influxdb.ScraperTarget) (*tls.Config, error) {
+	c := target.TLS
+	cfg := &tls.Config{
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+	if c.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(c.CACert)) {
+			return nil, fmt.Errorf("invalid CA certificate for scraper target %s", target.URL)
+		}
+		cfg.RootCAs = pool
+	}
+	if c.Cert != "" {
+		key, err := loadSecret(ctx, secrets, target.OrgID, c.KeySecretKey)
+		if err != nil {
+			return nil, err
+		}
+		cert, err := tls.X509KeyPair([]byte(c.Cert), []byte(key))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate for scraper target %s: %v", target.URL, err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+func loadSecret(ctx context.Context, secrets influxdb.SecretService, orgID influxdb.ID, key string) (string, error) {
+	if secrets == nil {
+		return "", fmt.Errorf("no secret service to load the scraper secret %q", key)
+	}
+	v, err := secrets.LoadSecret(ctx, orgID, key)
+	if err != nil {
+		return "", fmt.Errorf("unable to load the scraper secret %q: %v", key, err)
+	}
+	return v, nil
+}
@@ -4,17 +4,27 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/nats"
 	"go.uber.org/zap"
 )
 
+// ScraperStatusMeasurement is the measurement of the scrape status points
+// written to the monitoring bucket of the target's organization.
+const ScraperStatusMeasurement = "scrapers"
+
 // handler implents nats Handler interface.
 type handler struct {
 	Scraper   Scraper
 	Publisher nats.Publisher
-	log       *zap.Logger
+	// Status records the last scrape of a target, if set.
+	Status influxdb.ScraperStatusService
+	// Buckets finds the monitoring bucket the scrape status
+	// points are written to, if set.
+	Buckets influxdb.BucketService
+	log     *zap.Logger
 }
 
 // Process consumes scraper target from scraper target queue,
@@ -29,22 +39,86 @@ func (h *handler) Process(s nats.Subscription, m nats.Message) {
 		return
 	}
 
-	ms, err := h.Scraper.Gather(context.TODO(), *req)
+	ctx := context.TODO()
+	start := time.Now()
+	ms, err := h.Scraper.Gather(ctx, *req)
+	h.recordStatus(ctx, *req, start, ms, err)
 	if err != nil {
 		h.log.Error("Unable to gather", zap.Error(err))
 		return
 	}
+	ms.MetricsSlice.setTags(req.Labels)
+
+	if err := h.publish(ms); err != nil {
+		h.log.Error("Unable to publish scraper metrics", zap.Error(err))
+		return
+	}
+}
 
-	// send metrics to recorder queue
+// publish sends metrics to the recorder queue.
+func (h *handler) publish(ms MetricsCollection) error {
 	buf := new(bytes.Buffer)
 	if err := json.NewEncoder(buf).Encode(ms); err != nil {
-		h.log.Error("Unable to marshal json", zap.Error(err))
-		return
+		return err
 	}
+	return h.Publisher.Publish(MetricsSubject, buf)
+}
 
-	if err := h.Publisher.Publish(MetricsSubject, buf); err != nil {
-		h.log.Error("Unable to publish scraper metrics", zap.Error(err))
+// recordStatus stores the outcome of a scrape and writes it as a point
+// to the monitoring bucket of the target's organization.
+func (h *handler) recordStatus(ctx context.Context, target influxdb.ScraperTarget, start time.Time, ms MetricsCollection, err error) {
+	status := &influxdb.ScraperStatus{
+		LastScrape: start,
+		Duration:   influxdb.Duration{Duration: time.Since(start)},
+	}
+	if err != nil {
+		status.LastError = err.Error()
+	} else {
+		for _, m := range ms.MetricsSlice {
+			status.Samples += len(m.Fields)
+		}
+	}
+
+	if h.Status != nil {
+		if err := h.Status.PutTargetStatus(ctx, target.ID, status); err != nil {
+			h.log.Error("Unable to record scraper status", zap.Error(err))
+		}
+	}
+	if h.Buckets == nil {
 		return
 	}
 
+	b, err := h.Buckets.FindBucketByName(ctx, target.OrgID, influxdb.MonitoringSystemBucketName)
+	if err != nil {
+		h.log.Error("Unable to find monitoring bucket", zap.Error(err))
+		return
+	}
+	up := 1.0
+	fields := map[string]interface{}{
+		"duration": status.Duration.Seconds(),
+		"samples":  float64(status.Samples),
+	}
+	if status.LastError != "" {
+		up = 0
+		fields["error"] = status.LastError
+	}
+	fields["up"] = up
+	collected := MetricsCollection{
+		OrgID:    target.OrgID,
+		BucketID: b.ID,
+		MetricsSlice: MetricsSlice{{
+			Name: ScraperStatusMeasurement,
+			Tags: map[string]string{
+				"scraperID": target.ID.String(),
+				"scraper":   target.Name,
+				"type":      string(target.Type),
+			},
+			Fields:    fields,
+			Timestamp: status.LastScrape,
+			Type:      MetricTypeGauge,
+		}},
+	}
+	if err := h.publish(collected); err != nil {
+		h.log.Error("Unable to publish scraper status", zap.Error(err))
+	}
 }
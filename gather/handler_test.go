@@ -0,0 +1,146 @@
+package gather
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+	"go.uber.org/zap/zaptest"
+)
+
+type mockMessage struct {
+	data []byte
+}
+
+func (m *mockMessage) Data() []byte { return m.data }
+func (m *mockMessage) Ack() error   { return nil }
+
+type mockPublisher struct {
+	collected []MetricsCollection
+}
+
+func (p *mockPublisher) Publish(subject string, r io.Reader) error {
+	var ms MetricsCollection
+	if err := json.NewDecoder(r).Decode(&ms); err != nil {
+		return err
+	}
+	p.collected = append(p.collected, ms)
+	return nil
+}
+
+func TestHandlerRecordsStatus(t *testing.T) {
+	monitoringID := influxdbtesting.MustIDBase16("020f755c3c082002")
+	ts := httptest.NewServer(&mockHTTPHandler{
+		contentType: "text/plain",
+		responseMap: map[string]string{
+			"/metrics": "cpu,host=a usage=1,idle=2 1000000000\n",
+		},
+	})
+	defer ts.Close()
+
+	cases := []struct {
+		name    string
+		path    string
+		samples int
+		up      float64
+		err     bool
+	}{
+		{
+			name:    "successful scrape",
+			path:    "/metrics",
+			samples: 2,
+			up:      1,
+		},
+		{
+			name: "failed scrape",
+			path: "/missing",
+			up:   0,
+			err:  true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			target := influxdb.ScraperTarget{
+				ID:       influxdbtesting.MustIDBase16("3a0d0a6365646120"),
+				Name:     "node",
+				Type:     influxdb.LineProtocolScraperType,
+				URL:      ts.URL + c.path,
+				OrgID:    *orgID,
+				BucketID: *bucketID,
+			}
+			var status *influxdb.ScraperStatus
+			publisher := &mockPublisher{}
+			h := &handler{
+				Scraper:   &lineProtocolScraper{},
+				Publisher: publisher,
+				Status: &mock.ScraperStatusService{
+					PutTargetStatusF: func(ctx context.Context, id influxdb.ID, s *influxdb.ScraperStatus) error {
+						if id != target.ID {
+							t.Errorf("status of unexpected target %s", id)
+						}
+						status = s
+						return nil
+					},
+				},
+				Buckets: &mock.BucketService{
+					FindBucketByNameFn: func(ctx context.Context, orgID influxdb.ID, name string) (*influxdb.Bucket, error) {
+						if name != influxdb.MonitoringSystemBucketName {
+							t.Errorf("unexpected bucket %q", name)
+						}
+						return &influxdb.Bucket{ID: monitoringID, OrgID: orgID, Name: name}, nil
+					},
+				},
+				log: zaptest.NewLogger(t),
+			}
+			buf := new(bytes.Buffer)
+			if err := json.NewEncoder(buf).Encode(target); err != nil {
+				t.Fatal(err)
+			}
+			h.Process(nil, &mockMessage{data: buf.Bytes()})
+
+			if status == nil {
+				t.Fatal("scrape status was not recorded")
+			}
+			if status.Samples != c.samples {
+				t.Errorf("expected %d samples, got %d", c.samples, status.Samples)
+			}
+			if got := status.LastError != ""; got != c.err {
+				t.Errorf("expected error %t, got %q", c.err, status.LastError)
+			}
+
+			// The status point is published before the scraped metrics.
+			if len(publisher.collected) == 0 {
+				t.Fatal("scrape status point was not published")
+			}
+			point := publisher.collected[0]
+			if point.BucketID != monitoringID {
+				t.Errorf("expected status point in bucket %s, got %s", monitoringID, point.BucketID)
+			}
+			if len(point.MetricsSlice) != 1 {
+				t.Fatalf("expected one status point, got %d", len(point.MetricsSlice))
+			}
+			m := point.MetricsSlice[0]
+			if m.Name != ScraperStatusMeasurement {
+				t.Errorf("expected measurement %q, got %q", ScraperStatusMeasurement, m.Name)
+			}
+			if m.Tags["scraperID"] != target.ID.String() || m.Tags["scraper"] != "node" {
+				t.Errorf("unexpected status point tags %v", m.Tags)
+			}
+			if m.Fields["up"] != c.up {
+				t.Errorf("expected up %v, got %v", c.up, m.Fields["up"])
+			}
+			if m.Fields["samples"] != float64(c.samples) {
+				t.Errorf("expected %d samples field, got %v", c.samples, m.Fields["samples"])
+			}
+			if _, ok := m.Fields["error"]; ok != c.err {
+				t.Errorf("expected error field %t, got %v", c.err, m.Fields)
+			}
+		})
+	}
+}
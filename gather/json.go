@@ -0,0 +1,202 @@
+package gather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+// jsonScraper maps the values of a json document to metrics.
+// implements Scraper interfaces.
+type jsonScraper struct {
+	// Secrets holds the credentials of the targets.
+	Secrets influxdb.SecretService
+}
+
+// Gather reads the json document of a scraper target url.
+func (s *jsonScraper) Gather(ctx context.Context, target influxdb.ScraperTarget) (collected MetricsCollection, err error) {
+	m, err := newJSONMapping(target.JSON)
+	if err != nil {
+		return collected, err
+	}
+	resp, err := scrape(ctx, s.Secrets, target)
+	if err != nil {
+		return collected, err
+	}
+	defer resp.Body.Close()
+
+	return m.parse(resp.Body, target, time.Now())
+}
+
+// jsonMapping is the parsed json config of a target.
+type jsonMapping struct {
+	records jsonPath
+	// name is the measurement if measurement is nil.
+	name        string
+	measurement jsonPath
+	tags        map[string]jsonPath
+	fields      map[string]jsonPath
+	time        jsonPath
+}
+
+func newJSONMapping(c *influxdb.ScraperJSONConfig) (*jsonMapping, error) {
+	if c == nil {
+		return nil, fmt.Errorf("json scraper target has no json config")
+	}
+	m := &jsonMapping{
+		name:   c.Measurement,
+		tags:   make(map[string]jsonPath, len(c.Tags)),
+		fields: make(map[string]jsonPath, len(c.Fields)),
+	}
+	var err error
+	if c.Records != "" {
+		if m.records, err = parseJSONPath(c.Records); err != nil {
+			return nil, err
+		}
+	}
+	if strings.HasPrefix(c.Measurement, "$") {
+		if m.measurement, err = parseJSONPath(c.Measurement); err != nil {
+			return nil, err
+		}
+	}
+	for k, p := range c.Tags {
+		if m.tags[k], err = parseJSONPath(p); err != nil {
+			return nil, err
+		}
+	}
+	for k, p := range c.Fields {
+		if m.fields[k], err = parseJSONPath(p); err != nil {
+			return nil, err
+		}
+	}
+	if c.Time != "" {
+		if m.time, err = parseJSONPath(c.Time); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// parse returns a metric for each record of the document that has at
+// least one field. Missing tags and fields are skipped.
+func (m *jsonMapping) parse(r io.Reader, target influxdb.ScraperTarget, now time.Time) (collected MetricsCollection, err error) {
+	var doc interface{}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return collected, fmt.Errorf("reading json document failed: %s", err)
+	}
+	records := []interface{}{doc}
+	if m.records != nil {
+		records = m.records.eval(doc)
+	}
+
+	ms := make([]Metrics, 0, len(records))
+	for _, rec := range records {
+		me := Metrics{
+			Name:      m.name,
+			Tags:      make(map[string]string, len(m.tags)),
+			Fields:    make(map[string]interface{}, len(m.fields)),
+			Timestamp: now,
+			Type:      MetricTypeUntyped,
+		}
+		if m.measurement != nil {
+			v, ok, err := jsonValue("the measurement", m.measurement, rec)
+			if err != nil {
+				return collected, err
+			}
+			name, _ := v.(string)
+			if !ok || name == "" {
+				continue
+			}
+			me.Name = name
+		}
+		for k, p := range m.tags {
+			v, ok, err := jsonValue(fmt.Sprintf("tag %q", k), p, rec)
+			if err != nil {
+				return collected, err
+			}
+			if !ok {
+				continue
+			}
+			switch v := v.(type) {
+			case string:
+				me.Tags[k] = v
+			case float64:
+				me.Tags[k] = strconv.FormatFloat(v, 'f', -1, 64)
+			case bool:
+				me.Tags[k] = strconv.FormatBool(v)
+			default:
+				return collected, fmt.Errorf("json value of tag %q is not a string, number or boolean", k)
+			}
+		}
+		for k, p := range m.fields {
+			v, ok, err := jsonValue(fmt.Sprintf("field %q", k), p, rec)
+			if err != nil {
+				return collected, err
+			}
+			if !ok {
+				continue
+			}
+			switch v := v.(type) {
+			case string, float64, bool:
+				me.Fields[k] = v
+			default:
+				return collected, fmt.Errorf("json value of field %q is not a string, number or boolean", k)
+			}
+		}
+		if len(me.Fields) == 0 {
+			continue
+		}
+		if m.time != nil {
+			v, ok, err := jsonValue("the time", m.time, rec)
+			if err != nil {
+				return collected, err
+			}
+			if ok {
+				if me.Timestamp, err = jsonTime(v); err != nil {
+					return collected, err
+				}
+			}
+		}
+		ms = append(ms, me)
+	}
+
+	collected = MetricsCollection{
+		MetricsSlice: ms,
+		OrgID:        target.OrgID,
+		BucketID:     target.BucketID,
+	}
+	return collected, nil
+}
+
+// jsonValue returns the single value selected by the path in the record.
+// It returns false if the path selects nothing or null.
+func jsonValue(name string, p jsonPath, rec interface{}) (interface{}, bool, error) {
+	vs := p.eval(rec)
+	switch len(vs) {
+	case 0:
+		return nil, false, nil
+	case 1:
+		return vs[0], vs[0] != nil, nil
+	default:
+		return nil, false, fmt.Errorf("json path of %s selects %d values instead of one", name, len(vs))
+	}
+}
+
+// jsonTime reads an RFC3339 string or a number of seconds since the epoch.
+func jsonTime(v interface{}) (time.Time, error) {
+	switch v := v.(type) {
+	case string:
+		return time.Parse(time.RFC3339Nano, v)
+	case float64:
+		sec, frac := math.Modf(v)
+		return time.Unix(int64(sec), int64(frac*1e9)), nil
+	}
+	return time.Time{}, fmt.Errorf("json time %v is not an RFC3339 string or a number", v)
+}
@@ -0,0 +1,113 @@
+package gather
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonPath is a parsed JSONPath expression. It supports the root $,
+// the .key, ['key'] and [n] child selectors and the .* and [*] wildcards.
+type jsonPath []jsonStep
+
+// jsonStep selects the children of a value.
+type jsonStep struct {
+	key   string
+	index int
+	// isIndex is true if the step selects the element at index.
+	isIndex bool
+	// all is true if the step selects every element of an array
+	// or every value of an object.
+	all bool
+}
+
+// parseJSONPath parses a JSONPath expression.
+func parseJSONPath(s string) (jsonPath, error) {
+	if !strings.HasPrefix(s, "$") {
+		return nil, fmt.Errorf("json path %q must start with $", s)
+	}
+	var p jsonPath
+	for i := 1; i < len(s); {
+		switch s[i] {
+		case '.':
+			i++
+			j := i
+			for j < len(s) && s[j] != '.' && s[j] != '[' {
+				j++
+			}
+			switch key := s[i:j]; key {
+			case "":
+				return nil, fmt.Errorf("json path %q has an empty key at position %d", s, i)
+			case "*":
+				p = append(p, jsonStep{all: true})
+			default:
+				p = append(p, jsonStep{key: key})
+			}
+			i = j
+		case '[':
+			end := strings.IndexByte(s[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("json path %q has an unclosed [ at position %d", s, i)
+			}
+			sel := s[i+1 : i+end]
+			switch {
+			case sel == "*":
+				p = append(p, jsonStep{all: true})
+			case len(sel) >= 2 && sel[0] == '\'' && sel[len(sel)-1] == '\'':
+				p = append(p, jsonStep{key: sel[1 : len(sel)-1]})
+			default:
+				n, err := strconv.Atoi(sel)
+				if err != nil || n < 0 {
+					return nil, fmt.Errorf("json path %q has an invalid selector %q at position %d", s, sel, i)
+				}
+				p = append(p, jsonStep{index: n, isIndex: true})
+			}
+			i += end + 1
+		default:
+			return nil, fmt.Errorf("json path %q has an unexpected %q at position %d", s, s[i], i)
+		}
+	}
+	return p, nil
+}
+
+// eval returns the values selected by the path in v.
+func (p jsonPath) eval(v interface{}) []interface{} {
+	vs := []interface{}{v}
+	for _, st := range p {
+		var next []interface{}
+		for _, v := range vs {
+			next = st.apply(v, next)
+		}
+		vs = next
+	}
+	return vs
+}
+
+// apply appends the children of v selected by the step to vs.
+func (st jsonStep) apply(v interface{}, vs []interface{}) []interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if st.all {
+			// Objects are unordered, sort the keys so the
+			// records are always read in the same order.
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				vs = append(vs, v[k])
+			}
+		} else if c, ok := v[st.key]; ok && !st.isIndex {
+			vs = append(vs, c)
+		}
+	case []interface{}:
+		if st.all {
+			vs = append(vs, v...)
+		} else if st.isIndex && st.index < len(v) {
+			vs = append(vs, v[st.index])
+		}
+	}
+	return vs
+}
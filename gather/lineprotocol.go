@@ -0,0 +1,97 @@
+package gather
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+)
+
+// lineProtocolScraper reads the points of a line protocol endpoint.
+// implements Scraper interfaces.
+type lineProtocolScraper struct {
+	// Secrets holds the credentials of the targets.
+	Secrets influxdb.SecretService
+}
+
+// Gather reads the points of a scraper target url.
+func (s *lineProtocolScraper) Gather(ctx context.Context, target influxdb.ScraperTarget) (collected MetricsCollection, err error) {
+	resp, err := scrape(ctx, s.Secrets, target)
+	if err != nil {
+		return collected, err
+	}
+	defer resp.Body.Close()
+
+	return s.parse(resp.Body, target, time.Now())
+}
+
+func (s *lineProtocolScraper) parse(r io.Reader, target influxdb.ScraperTarget, now time.Time) (collected MetricsCollection, err error) {
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return collected, err
+	}
+	// The parser splits each line into a point per field, with the
+	// measurement and the field key moved to tags and the given name
+	// as the measurement. The points of a line are merged back below.
+	points, err := models.ParsePointsWithPrecision(buf, []byte("_"), now, "n")
+	if err != nil {
+		return collected, fmt.Errorf("reading line protocol failed: %s", err)
+	}
+
+	ms := make([]Metrics, 0, len(points))
+	var last string
+	for _, p := range points {
+		fields, err := p.Fields()
+		if err != nil {
+			return collected, err
+		}
+		var name string
+		tags := make(map[string]string, len(p.Tags()))
+		for _, t := range p.Tags() {
+			switch string(t.Key) {
+			case models.MeasurementTagKey:
+				name = string(t.Value)
+			case models.FieldKeyTagKey:
+			default:
+				tags[string(t.Key)] = string(t.Value)
+			}
+		}
+		// The key without the field identifies the line of the point.
+		key := string(models.MakeKey([]byte(name), models.NewTags(tags))) + " " + p.Time().String()
+		if len(ms) == 0 || key != last {
+			ms = append(ms, Metrics{
+				Name:      name,
+				Tags:      tags,
+				Fields:    make(map[string]interface{}, len(fields)),
+				Timestamp: p.Time(),
+				Type:      MetricTypeUntyped,
+			})
+			last = key
+		}
+		// The metrics are sent to the recorder as json, which decodes
+		// every number as a float, so convert the integers here to
+		// make the scraped values the recorded ones.
+		m := ms[len(ms)-1]
+		for k, v := range fields {
+			switch v := v.(type) {
+			case int64:
+				m.Fields[k] = float64(v)
+			case uint64:
+				m.Fields[k] = float64(v)
+			default:
+				m.Fields[k] = v
+			}
+		}
+	}
+
+	collected = MetricsCollection{
+		MetricsSlice: ms,
+		OrgID:        target.OrgID,
+		BucketID:     target.BucketID,
+	}
+	return collected, nil
+}
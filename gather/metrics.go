@@ -43,6 +43,21 @@ func (ms MetricsSlice) Points() (models.Points, error) {
 	return ps, nil
 }
 
+// setTags sets the tags on every metric, replacing the tags of the same key.
+func (ms MetricsSlice) setTags(tags map[string]string) {
+	if len(tags) == 0 {
+		return
+	}
+	for i := range ms {
+		if ms[i].Tags == nil {
+			ms[i].Tags = make(map[string]string, len(tags))
+		}
+		for k, v := range tags {
+			ms[i].Tags[k] = v
+		}
+	}
+}
+
 // Reader returns an io.Reader that enumerates the metrics.
 // All metrics are allocated into the underlying buffer.
 func (ms MetricsSlice) Reader() (io.Reader, error) {
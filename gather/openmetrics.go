@@ -0,0 +1,587 @@
+package gather
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+// openMetricsScraper parses the metrics of an OpenMetrics text endpoint.
+// implements Scraper interfaces.
+type openMetricsScraper struct {
+	// Secrets holds the credentials of the targets.
+	Secrets influxdb.SecretService
+}
+
+// Gather parses the metrics of a scraper target url.
+func (s *openMetricsScraper) Gather(ctx context.Context, target influxdb.ScraperTarget) (collected MetricsCollection, err error) {
+	resp, err := scrape(ctx, s.Secrets, target)
+	if err != nil {
+		return collected, err
+	}
+	defer resp.Body.Close()
+
+	return s.parse(resp.Body, target, time.Now())
+}
+
+// parse reads an OpenMetrics text exposition. The exposition is rejected
+// if it doesn't follow the format, the metrics of a partial exposition
+// aren't returned.
+//
+// Every label set of a family becomes a metric with the fields of the
+// type of the family: counter and created for counters, gauge for gauges
+// and infos, value for unknowns, a field per state for statesets, and the
+// buckets or quantiles, count, sum and created for histograms and
+// summaries. The unit of the family is added as the unit tag. Exemplars
+// become metrics with the exemplar field, their labels as string fields
+// and the le tag for the exemplars of buckets.
+func (s *openMetricsScraper) parse(r io.Reader, target influxdb.ScraperTarget, now time.Time) (collected MetricsCollection, err error) {
+	p := &omParser{
+		now:      now,
+		families: make(map[string]bool),
+	}
+	if err := p.parse(r); err != nil {
+		return collected, fmt.Errorf("reading openmetrics text failed: %s", err)
+	}
+	collected = MetricsCollection{
+		MetricsSlice: p.ms,
+		OrgID:        target.OrgID,
+		BucketID:     target.BucketID,
+	}
+	return collected, nil
+}
+
+// omFamily is the metric family being read.
+type omFamily struct {
+	name string
+	typ  string
+	unit string
+	// sampled is true once a sample of the family is read,
+	// the metadata must come before the samples.
+	sampled bool
+	metrics map[string]*Metrics
+	order   []string
+	// buckets records the label sets of histograms with a +Inf bucket.
+	buckets map[string]bool
+	// exemplars are appended after the metrics of the family.
+	exemplars []Metrics
+}
+
+// omParser reads an OpenMetrics text exposition.
+type omParser struct {
+	now time.Time
+	// families records the families already read, a family
+	// must not be split across the exposition.
+	families map[string]bool
+	family   *omFamily
+	ms       []Metrics
+	line     int
+}
+
+func (p *omParser) parse(r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<20)
+	eof := false
+	for sc.Scan() {
+		p.line++
+		line := sc.Text()
+		if eof {
+			return p.errorf("unexpected content after # EOF")
+		}
+		var err error
+		switch {
+		case line == "# EOF":
+			eof = true
+		case strings.HasPrefix(line, "#"):
+			err = p.parseDescriptor(line)
+		default:
+			err = p.parseSample(line)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	if !eof {
+		return fmt.Errorf("missing # EOF")
+	}
+	return p.flush()
+}
+
+func (p *omParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+// parseDescriptor reads a TYPE, UNIT or HELP line.
+func (p *omParser) parseDescriptor(line string) error {
+	parts := strings.SplitN(line, " ", 4)
+	if len(parts) < 3 || parts[0] != "#" {
+		return p.errorf("invalid descriptor %q", line)
+	}
+	kind, name := parts[1], parts[2]
+	if !validMetricName(name) {
+		return p.errorf("invalid metric name %q", name)
+	}
+	var text string
+	if len(parts) == 4 {
+		text = parts[3]
+	}
+	f, err := p.startFamily(name)
+	if err != nil {
+		return err
+	}
+	switch kind {
+	case "TYPE":
+		switch text {
+		case "counter", "gauge", "histogram", "gaugehistogram", "summary", "info", "stateset", "unknown":
+		default:
+			return p.errorf("invalid type %q of metric %s", text, name)
+		}
+		f.typ = text
+	case "UNIT":
+		if text != "" && !strings.HasSuffix(name, "_"+text) {
+			return p.errorf("metric %s doesn't end with its unit %s", name, text)
+		}
+		f.unit = text
+	case "HELP":
+	default:
+		return p.errorf("invalid descriptor %q", line)
+	}
+	return nil
+}
+
+// startFamily returns the family of the descriptors of name,
+// starting it if it isn't the current one.
+func (p *omParser) startFamily(name string) (*omFamily, error) {
+	if p.family != nil && p.family.name == name {
+		if p.family.sampled {
+			return nil, p.errorf("metadata of metric %s after its samples", name)
+		}
+		return p.family, nil
+	}
+	if err := p.flush(); err != nil {
+		return nil, err
+	}
+	if p.families[name] {
+		return nil, p.errorf("metric %s is not contiguous", name)
+	}
+	p.families[name] = true
+	p.family = &omFamily{
+		name:    name,
+		typ:     "unknown",
+		metrics: make(map[string]*Metrics),
+		buckets: make(map[string]bool),
+	}
+	return p.family, nil
+}
+
+// flush appends the metrics of the current family.
+func (p *omParser) flush() error {
+	f := p.family
+	if f == nil {
+		return nil
+	}
+	for _, k := range f.order {
+		if (f.typ == "histogram" || f.typ == "gaugehistogram") && !f.buckets[k] {
+			return p.errorf("histogram %s has no +Inf bucket", f.name)
+		}
+		p.ms = append(p.ms, *f.metrics[k])
+	}
+	p.ms = append(p.ms, f.exemplars...)
+	p.family = nil
+	return nil
+}
+
+// omSuffixes are the suffixes of the sample names of each type.
+var omSuffixes = map[string][]string{
+	"counter":        {"_total", "_created"},
+	"gauge":          {""},
+	"histogram":      {"_bucket", "_count", "_sum", "_created"},
+	"gaugehistogram": {"_bucket", "_gcount", "_gsum"},
+	"summary":        {"", "_count", "_sum", "_created"},
+	"info":           {"_info"},
+	"stateset":       {""},
+	"unknown":        {""},
+}
+
+// suffix returns the suffix of the sample name and
+// whether the sample belongs to the family.
+func (f *omFamily) suffix(name string) (string, bool) {
+	if !strings.HasPrefix(name, f.name) {
+		return "", false
+	}
+	for _, sfx := range omSuffixes[f.typ] {
+		if name[len(f.name):] == sfx {
+			return sfx, true
+		}
+	}
+	return "", false
+}
+
+// omSample is a sample line.
+type omSample struct {
+	name     string
+	labels   map[string]string
+	value    float64
+	ts       time.Time
+	exemplar *omExemplar
+}
+
+// omExemplar is the exemplar of a sample.
+type omExemplar struct {
+	labels map[string]string
+	value  float64
+	ts     time.Time
+}
+
+// parseSample reads a sample line and adds it to the metric of its label set.
+func (p *omParser) parseSample(line string) error {
+	s, err := p.scanSample(line)
+	if err != nil {
+		return err
+	}
+
+	// A sample without metadata starts a family of unknown type.
+	f := p.family
+	var suffix string
+	ok := false
+	if f != nil {
+		suffix, ok = f.suffix(s.name)
+	}
+	if !ok {
+		if f != nil && f.name == s.name {
+			return p.errorf("sample %s doesn't belong to the %s %s", s.name, f.typ, f.name)
+		}
+		if f, err = p.startFamily(s.name); err != nil {
+			return err
+		}
+	}
+	if s.exemplar != nil && suffix != "_total" && suffix != "_bucket" {
+		return p.errorf("sample %s can't have an exemplar", s.name)
+	}
+	switch suffix {
+	case "_total", "_bucket", "_count", "_gcount":
+		if s.value < 0 || math.IsNaN(s.value) {
+			return p.errorf("sample %s must not be negative", s.name)
+		}
+	}
+	f.sampled = true
+
+	// The le, quantile and state labels select the field of the metric.
+	var sel string
+	switch {
+	case suffix == "_bucket":
+		le, ok := s.labels["le"]
+		if !ok {
+			return p.errorf("bucket of %s has no le label", f.name)
+		}
+		v, err := parseOMFloat(le)
+		if err != nil {
+			return p.errorf("invalid le label %q of %s", le, f.name)
+		}
+		sel = fmt.Sprint(v)
+		delete(s.labels, "le")
+	case f.typ == "summary" && suffix == "":
+		q, ok := s.labels["quantile"]
+		if !ok {
+			return p.errorf("quantile of %s has no quantile label", f.name)
+		}
+		v, err := parseOMFloat(q)
+		if err != nil || v < 0 || v > 1 {
+			return p.errorf("invalid quantile label %q of %s", q, f.name)
+		}
+		sel = fmt.Sprint(v)
+		delete(s.labels, "quantile")
+	case f.typ == "stateset":
+		st, ok := s.labels[f.name]
+		if !ok {
+			return p.errorf("state of %s has no %s label", f.name, f.name)
+		}
+		sel = st
+		delete(s.labels, f.name)
+	}
+	if f.unit != "" {
+		s.labels["unit"] = f.unit
+	}
+
+	key := labelsKey(s.labels)
+	m, ok := f.metrics[key]
+	if !ok {
+		m = &Metrics{
+			Name:      f.name,
+			Tags:      s.labels,
+			Fields:    make(map[string]interface{}),
+			Timestamp: p.now,
+			Type:      omMetricType(f.typ),
+		}
+		if !s.ts.IsZero() {
+			m.Timestamp = s.ts
+		}
+		f.metrics[key] = m
+		f.order = append(f.order, key)
+	}
+
+	var field string
+	switch {
+	case sel != "":
+		field = sel
+	case suffix == "_total":
+		field = "counter"
+	case suffix == "_created":
+		field = "created"
+	case suffix == "_count" || suffix == "_gcount":
+		field = "count"
+	case suffix == "_sum" || suffix == "_gsum":
+		field = "sum"
+	case f.typ == "gauge":
+		field = "gauge"
+	case f.typ == "info":
+		field = "info"
+	default:
+		field = "value"
+	}
+	if _, ok := m.Fields[field]; ok {
+		return p.errorf("duplicate sample %s", line)
+	}
+	if suffix == "_bucket" && sel == fmt.Sprint(math.Inf(1)) {
+		f.buckets[key] = true
+	}
+	if !math.IsNaN(s.value) {
+		m.Fields[field] = s.value
+	}
+
+	if e := s.exemplar; e != nil {
+		em := Metrics{
+			Name:      f.name,
+			Tags:      make(map[string]string, len(s.labels)+1),
+			Fields:    map[string]interface{}{"exemplar": e.value},
+			Timestamp: m.Timestamp,
+			Type:      m.Type,
+		}
+		if !e.ts.IsZero() {
+			em.Timestamp = e.ts
+		}
+		for k, v := range s.labels {
+			em.Tags[k] = v
+		}
+		if suffix == "_bucket" {
+			em.Tags["le"] = sel
+		}
+		for k, v := range e.labels {
+			em.Fields[k] = v
+		}
+		f.exemplars = append(f.exemplars, em)
+	}
+	return nil
+}
+
+// scanSample splits a sample line into its name, labels, value,
+// timestamp and exemplar.
+func (p *omParser) scanSample(line string) (*omSample, error) {
+	s := &omSample{labels: map[string]string{}}
+	i := strings.IndexAny(line, "{ ")
+	if i < 0 {
+		return nil, p.errorf("invalid sample %q", line)
+	}
+	s.name = line[:i]
+	if !validMetricName(s.name) {
+		return nil, p.errorf("invalid metric name %q", s.name)
+	}
+	rest := line[i:]
+	if rest[0] == '{' {
+		var err error
+		if s.labels, rest, err = p.scanLabels(rest); err != nil {
+			return nil, err
+		}
+	}
+	if !strings.HasPrefix(rest, " ") {
+		return nil, p.errorf("invalid sample %q", line)
+	}
+	rest = rest[1:]
+
+	var exemplar string
+	if i := strings.Index(rest, " # "); i >= 0 {
+		rest, exemplar = rest[:i], rest[i+3:]
+	}
+	parts := strings.Split(rest, " ")
+	if len(parts) > 2 {
+		return nil, p.errorf("invalid sample %q", line)
+	}
+	var err error
+	if s.value, err = parseOMFloat(parts[0]); err != nil {
+		return nil, p.errorf("invalid value %q", parts[0])
+	}
+	if len(parts) == 2 {
+		if s.ts, err = parseOMTime(parts[1]); err != nil {
+			return nil, p.errorf("invalid timestamp %q", parts[1])
+		}
+	}
+
+	if exemplar != "" {
+		e := &omExemplar{}
+		if !strings.HasPrefix(exemplar, "{") {
+			return nil, p.errorf("invalid exemplar %q", exemplar)
+		}
+		if e.labels, rest, err = p.scanLabels(exemplar); err != nil {
+			return nil, err
+		}
+		parts := strings.Split(strings.TrimPrefix(rest, " "), " ")
+		if !strings.HasPrefix(rest, " ") || len(parts) > 2 {
+			return nil, p.errorf("invalid exemplar %q", exemplar)
+		}
+		if e.value, err = parseOMFloat(parts[0]); err != nil {
+			return nil, p.errorf("invalid exemplar value %q", parts[0])
+		}
+		if len(parts) == 2 {
+			if e.ts, err = parseOMTime(parts[1]); err != nil {
+				return nil, p.errorf("invalid exemplar timestamp %q", parts[1])
+			}
+		}
+		s.exemplar = e
+	}
+	return s, nil
+}
+
+// scanLabels reads the label set at the start of s and returns the rest.
+func (p *omParser) scanLabels(s string) (map[string]string, string, error) {
+	labels := make(map[string]string)
+	i := 1
+	for i < len(s) && s[i] != '}' {
+		j := strings.IndexByte(s[i:], '=')
+		if j < 0 {
+			return nil, "", p.errorf("invalid labels %q", s)
+		}
+		name := s[i : i+j]
+		if !validLabelName(name) {
+			return nil, "", p.errorf("invalid label name %q", name)
+		}
+		if _, ok := labels[name]; ok {
+			return nil, "", p.errorf("duplicate label %q", name)
+		}
+		i += j + 1
+		if i >= len(s) || s[i] != '"' {
+			return nil, "", p.errorf("label %s value is not quoted", name)
+		}
+		i++
+		var v strings.Builder
+		for ; i < len(s) && s[i] != '"'; i++ {
+			if s[i] != '\\' {
+				v.WriteByte(s[i])
+				continue
+			}
+			i++
+			if i == len(s) {
+				break
+			}
+			switch s[i] {
+			case '\\', '"':
+				v.WriteByte(s[i])
+			case 'n':
+				v.WriteByte('\n')
+			default:
+				return nil, "", p.errorf("invalid escape in the value of label %s", name)
+			}
+		}
+		if i >= len(s) {
+			return nil, "", p.errorf("label %s value is not closed", name)
+		}
+		labels[name] = v.String()
+		i++
+		if i < len(s) && s[i] == ',' {
+			i++
+			if i < len(s) && s[i] == '}' {
+				return nil, "", p.errorf("invalid labels %q", s)
+			}
+		} else if i < len(s) && s[i] != '}' {
+			return nil, "", p.errorf("invalid labels %q", s)
+		}
+	}
+	if i >= len(s) {
+		return nil, "", p.errorf("labels are not closed %q", s)
+	}
+	return labels, s[i+1:], nil
+}
+
+// labelsKey returns a key that identifies the label set.
+func labelsKey(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(strconv.Quote(k))
+		b.WriteByte('=')
+		b.WriteString(strconv.Quote(labels[k]))
+		b.WriteByte(',')
+	}
+	return b.String()
+}
+
+// omMetricType returns the metric type of an OpenMetrics type.
+func omMetricType(typ string) MetricType {
+	switch typ {
+	case "counter":
+		return MetricTypeCounter
+	case "gauge", "info", "stateset":
+		return MetricTypeGauge
+	case "histogram", "gaugehistogram":
+		return MetricTypeHistogrm
+	case "summary":
+		return MetricTypeSummary
+	}
+	return MetricTypeUntyped
+}
+
+// parseOMFloat parses a number, including the NaN and the infinities.
+func parseOMFloat(s string) (float64, error) {
+	switch s {
+	case "NaN":
+		return math.NaN(), nil
+	case "+Inf":
+		return math.Inf(1), nil
+	case "-Inf":
+		return math.Inf(-1), nil
+	}
+	if strings.ContainsAny(s, "xXpP_") || strings.EqualFold(s, "inf") || strings.EqualFold(s, "infinity") || strings.EqualFold(s, "nan") {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// parseOMTime parses a timestamp in seconds since the epoch.
+func parseOMTime(s string) (time.Time, error) {
+	v, err := parseOMFloat(s)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q", s)
+	}
+	sec, frac := math.Modf(v)
+	return time.Unix(int64(sec), int64(frac*1e9)), nil
+}
+
+func validMetricName(s string) bool {
+	for i, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_', c == ':':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return s != ""
+}
+
+func validLabelName(s string) bool {
+	return validMetricName(s) && !strings.Contains(s, ":")
+}
@@ -15,16 +15,25 @@ import (
 
 // nats subjects
 const (
-	MetricsSubject    = "metrics"
-	promTargetSubject = "promTarget"
+	MetricsSubject            = "metrics"
+	promTargetSubject         = "promTarget"
+	openMetricsTargetSubject  = "openMetricsTarget"
+	lineProtocolTargetSubject = "lineProtocolTarget"
+	jsonTargetSubject         = "jsonTarget"
 )
 
+// scheduleResolution is the maximum time between two checks
+// for the targets that are due for a scrape.
+const scheduleResolution = time.Second
+
 // Scheduler is struct to run scrape jobs.
 type Scheduler struct {
 	Targets influxdb.ScraperTargetStoreService
-	// Interval is between each metrics gathering event.
+	// Interval is between each metrics gathering event of the
+	// targets without their own interval.
 	Interval time.Duration
 	// Timeout is the maxisium time duration allowed by each TCP request
+	// of the targets without their own timeout.
 	Timeout time.Duration
 
 	// Publisher will send the gather requests and gathered metrics to the queue.
@@ -33,6 +42,9 @@ type Scheduler struct {
 	log *zap.Logger
 
 	gather chan struct{}
+
+	// next is the time of the next scrape of each target.
+	next map[influxdb.ID]time.Time
 }
 
 // NewScheduler creates a new Scheduler and subscriptions for scraper jobs.
@@ -40,6 +52,9 @@ func NewScheduler(
 	log *zap.Logger,
 	numScrapers int,
 	targets influxdb.ScraperTargetStoreService,
+	status influxdb.ScraperStatusService,
+	buckets influxdb.BucketService,
+	secrets influxdb.SecretService,
 	p nats.Publisher,
 	s nats.Subscriber,
 	interval time.Duration,
@@ -58,16 +73,27 @@ func NewScheduler(
 		Publisher: p,
 		log:       log,
 		gather:    make(chan struct{}, 100),
+		next:      make(map[influxdb.ID]time.Time),
 	}
 
 	for i := 0; i < numScrapers; i++ {
-		err := s.Subscribe(promTargetSubject, "metrics", &handler{
-			Scraper:   new(prometheusScraper),
-			Publisher: p,
-			log:       log,
-		})
-		if err != nil {
-			return nil, err
+		scrapers := map[string]Scraper{
+			promTargetSubject:         &prometheusScraper{Secrets: secrets},
+			openMetricsTargetSubject:  &openMetricsScraper{Secrets: secrets},
+			lineProtocolTargetSubject: &lineProtocolScraper{Secrets: secrets},
+			jsonTargetSubject:         &jsonScraper{Secrets: secrets},
+		}
+		for subject, scraper := range scrapers {
+			err := s.Subscribe(subject, "metrics", &handler{
+				Scraper:   scraper,
+				Publisher: p,
+				Status:    status,
+				Buckets:   buckets,
+				log:       log,
+			})
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -78,11 +104,13 @@ func NewScheduler(
 // and publish them to nats job queue for gather.
 func (s *Scheduler) Run(ctx context.Context) error {
 	go func(s *Scheduler, ctx context.Context) {
+		ticker := time.NewTicker(s.tick())
+		defer ticker.Stop()
 		for {
 			select {
 			case <-ctx.Done():
 				return
-			case <-time.After(s.Interval): // TODO: change to ticker because of garbage collection
+			case <-ticker.C:
 				s.gather <- struct{}{}
 			}
 		}
@@ -90,6 +118,14 @@ func (s *Scheduler) Run(ctx context.Context) error {
 	return s.run(ctx)
 }
 
+// tick returns the time between two checks for the targets that are due.
+func (s *Scheduler) tick() time.Duration {
+	if s.Interval < scheduleResolution {
+		return s.Interval
+	}
+	return scheduleResolution
+}
+
 func (s *Scheduler) run(ctx context.Context) error {
 	for {
 		select {
@@ -113,12 +149,44 @@ func (s *Scheduler) doGather(ctx context.Context) {
 		tracing.LogError(span, err)
 		return
 	}
+	now := time.Now()
+	seen := make(map[influxdb.ID]bool, len(targets))
 	for _, target := range targets {
+		seen[target.ID] = true
+		if !s.due(target, now) {
+			continue
+		}
+		if target.Timeout.Duration == 0 {
+			target.Timeout.Duration = s.Timeout
+		}
 		if err := requestScrape(target, s.Publisher); err != nil {
 			s.log.Error("JSON encoding error", zap.Error(err))
 			tracing.LogError(span, err)
 		}
 	}
+
+	// Forget the targets that were removed.
+	for id := range s.next {
+		if !seen[id] {
+			delete(s.next, id)
+		}
+	}
+}
+
+// due reports whether the target must be scraped at now, and if so
+// schedules its next scrape.
+func (s *Scheduler) due(target influxdb.ScraperTarget, now time.Time) bool {
+	// The checks happen once per tick, so a target is due if its
+	// scrape time is closer to this check than to the next one.
+	if next, ok := s.next[target.ID]; ok && now.Add(s.tick()/2).Before(next) {
+		return false
+	}
+	interval := target.Interval.Duration
+	if interval == 0 {
+		interval = s.Interval
+	}
+	s.next[target.ID] = now.Add(interval)
+	return true
 }
 
 func requestScrape(t influxdb.ScraperTarget, publisher nats.Publisher) error {
@@ -130,6 +198,12 @@ func requestScrape(t influxdb.ScraperTarget, publisher nats.Publisher) error {
 	switch t.Type {
 	case influxdb.PrometheusScraperType:
 		return publisher.Publish(promTargetSubject, buf)
+	case influxdb.OpenMetricsScraperType:
+		return publisher.Publish(openMetricsTargetSubject, buf)
+	case influxdb.LineProtocolScraperType:
+		return publisher.Publish(lineProtocolTargetSubject, buf)
+	case influxdb.JSONScraperType:
+		return publisher.Publish(jsonTargetSubject, buf)
 	}
 	return fmt.Errorf("unsupported target scrape type: %s", t.Type)
 }
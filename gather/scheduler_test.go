@@ -37,6 +37,7 @@ func TestScheduler(t *testing.T) {
 				URL:      ts.URL + "/metrics",
 				OrgID:    *orgID,
 				BucketID: *bucketID,
+				Labels:   map[string]string{"env": "test"},
 			},
 		},
 		TotalGatherJobs: make(chan struct{}, totalGatherJobs),
@@ -47,7 +48,7 @@ func TestScheduler(t *testing.T) {
 		Recorder: storage,
 	})
 
-	scheduler, err := NewScheduler(logger, 10, storage, publisher, subscriber, time.Millisecond, time.Microsecond)
+	scheduler, err := NewScheduler(logger, 10, storage, nil, nil, nil, publisher, subscriber, time.Millisecond, time.Second)
 
 	go func() {
 		err = scheduler.run(ctx)
@@ -73,7 +74,7 @@ func TestScheduler(t *testing.T) {
 	want := Metrics{
 		Name: "go_goroutines",
 		Type: MetricTypeGauge,
-		Tags: map[string]string{},
+		Tags: map[string]string{"env": "test"},
 		Fields: map[string]interface{}{
 			"gauge": float64(36),
 		},
@@ -91,6 +92,39 @@ func TestScheduler(t *testing.T) {
 	ts.Close()
 }
 
+func TestScheduler_Due(t *testing.T) {
+	s := &Scheduler{
+		Interval: 10 * time.Second,
+		next:     make(map[influxdb.ID]time.Time),
+	}
+	fast := influxdb.ScraperTarget{
+		ID:       influxdbtesting.MustIDBase16("3a0d0a6365646120"),
+		Interval: influxdb.Duration{Duration: 2 * time.Second},
+	}
+	slow := influxdb.ScraperTarget{
+		ID: influxdbtesting.MustIDBase16("3a0d0a6365646121"),
+	}
+
+	start := time.Unix(0, 0)
+	var fastScrapes, slowScrapes int
+	for i := 0; i < 20; i++ {
+		// The checks happen a little late, the scrapes must not drift.
+		now := start.Add(time.Duration(i)*time.Second + time.Duration(i%3)*time.Millisecond)
+		if s.due(fast, now) {
+			fastScrapes++
+		}
+		if s.due(slow, now) {
+			slowScrapes++
+		}
+	}
+	if fastScrapes != 10 {
+		t.Errorf("unexpected number of scrapes of the target with an interval: got %d, want 10", fastScrapes)
+	}
+	if slowScrapes != 2 {
+		t.Errorf("unexpected number of scrapes of the target without an interval: got %d, want 2", slowScrapes)
+	}
+}
+
 const sampleRespSmall = `
 # HELP go_goroutines Number of goroutines that currently exist.
 # TYPE go_goroutines gauge
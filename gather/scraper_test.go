@@ -2,6 +2,8 @@ package gather
 
 import (
 	"context"
+	"encoding/pem"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
 )
 
 var (
@@ -155,6 +158,488 @@ func TestPrometheusScraper(t *testing.T) {
 	}
 }
 
+func TestPrometheusScraper_AuthAndTLS(t *testing.T) {
+	secrets := mock.NewSecretService()
+	secrets.LoadSecretFn = func(ctx context.Context, id influxdb.ID, k string) (string, error) {
+		if id != *orgID {
+			return "", fmt.Errorf("unexpected org %s", id)
+		}
+		switch k {
+		case "token":
+			return "secret-token", nil
+		case "password":
+			return "secret-password", nil
+		}
+		return "", fmt.Errorf("secret %q not found", k)
+	}
+
+	cases := []struct {
+		name   string
+		auth   *influxdb.ScraperAuth
+		header string
+		want   string
+		hasErr bool
+	}{
+		{
+			name:   "bearer",
+			auth:   &influxdb.ScraperAuth{Method: influxdb.ScraperAuthBearer, SecretKey: "token"},
+			header: "Authorization",
+			want:   "Bearer secret-token",
+		},
+		{
+			name:   "basic",
+			auth:   &influxdb.ScraperAuth{Method: influxdb.ScraperAuthBasic, Username: "user", SecretKey: "password"},
+			header: "Authorization",
+			want:   "Basic dXNlcjpzZWNyZXQtcGFzc3dvcmQ=",
+		},
+		{
+			name:   "header",
+			auth:   &influxdb.ScraperAuth{Method: influxdb.ScraperAuthHeader, Header: "X-Api-Key", SecretKey: "token"},
+			header: "X-Api-Key",
+			want:   "secret-token",
+		},
+		{
+			name:   "missing secret",
+			auth:   &influxdb.ScraperAuth{Method: influxdb.ScraperAuthBearer, SecretKey: "missing"},
+			hasErr: true,
+		},
+		{
+			name:   "unauthorized",
+			header: "Authorization",
+			want:   "Bearer secret-token",
+			hasErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			handler := &mockHTTPHandler{
+				responseMap: map[string]string{
+					"/metrics": sampleRespSmall,
+				},
+			}
+			ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get(c.header); got != c.want {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				handler.ServeHTTP(w, r)
+			}))
+			defer ts.Close()
+
+			scraper := &prometheusScraper{Secrets: secrets}
+			results, err := scraper.Gather(context.Background(), influxdb.ScraperTarget{
+				URL:      ts.URL + "/metrics",
+				OrgID:    *orgID,
+				BucketID: *bucketID,
+				Auth:     c.auth,
+				TLS: &influxdb.ScraperTLSConfig{
+					CACert: string(pem.EncodeToMemory(&pem.Block{
+						Type:  "CERTIFICATE",
+						Bytes: ts.Certificate().Raw,
+					})),
+				},
+				Timeout: influxdb.Duration{Duration: 5 * time.Second},
+			})
+			if c.hasErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("scraper gather err: %v", err)
+			}
+			if len(results.MetricsSlice) != 1 {
+				t.Fatalf("scraper parse metrics incorrect length, want 1, got %d", len(results.MetricsSlice))
+			}
+		})
+	}
+}
+
+func TestPrometheusScraper_UnknownCA(t *testing.T) {
+	ts := httptest.NewTLSServer(&mockHTTPHandler{
+		responseMap: map[string]string{
+			"/metrics": sampleRespSmall,
+		},
+	})
+	defer ts.Close()
+
+	scraper := new(prometheusScraper)
+	target := influxdb.ScraperTarget{
+		URL:      ts.URL + "/metrics",
+		OrgID:    *orgID,
+		BucketID: *bucketID,
+		TLS:      &influxdb.ScraperTLSConfig{},
+	}
+	if _, err := scraper.Gather(context.Background(), target); err == nil {
+		t.Fatal("expected an error for the certificate signed by an unknown authority")
+	}
+
+	target.TLS.InsecureSkipVerify = true
+	if _, err := scraper.Gather(context.Background(), target); err != nil {
+		t.Fatalf("scraper gather err: %v", err)
+	}
+}
+
+func TestOpenMetricsScraper(t *testing.T) {
+	cases := []struct {
+		name   string
+		body   string
+		ms     []Metrics
+		hasErr bool
+	}{
+		{
+			name: "all types",
+			body: sampleOpenMetrics,
+			ms: []Metrics{
+				{
+					Name:      "http_requests",
+					Type:      MetricTypeCounter,
+					Tags:      map[string]string{"code": "200"},
+					Fields:    map[string]interface{}{"counter": float64(1027), "created": 1.5e9},
+					Timestamp: time.Unix(1600000000, 0),
+				},
+				{
+					Name:      "http_requests",
+					Type:      MetricTypeCounter,
+					Tags:      map[string]string{"code": "200"},
+					Fields:    map[string]interface{}{"exemplar": float64(1), "trace_id": "abc"},
+					Timestamp: time.Unix(1600000000, 500000000),
+				},
+				{
+					Name:   "temperature_celsius",
+					Type:   MetricTypeGauge,
+					Tags:   map[string]string{"unit": "celsius"},
+					Fields: map[string]interface{}{"gauge": 21.5},
+				},
+				{
+					Name: "request_duration_seconds",
+					Type: MetricTypeHistogrm,
+					Tags: map[string]string{"unit": "seconds"},
+					Fields: map[string]interface{}{
+						"0.1":     float64(5),
+						"+Inf":    float64(8),
+						"count":   float64(8),
+						"sum":     1.25,
+						"created": 1.5e9,
+					},
+				},
+				{
+					Name:   "request_duration_seconds",
+					Type:   MetricTypeHistogrm,
+					Tags:   map[string]string{"unit": "seconds", "le": "0.1"},
+					Fields: map[string]interface{}{"exemplar": 0.05, "trace_id": "def"},
+				},
+				{
+					Name: "rpc_seconds",
+					Type: MetricTypeSummary,
+					Tags: map[string]string{"unit": "seconds"},
+					Fields: map[string]interface{}{
+						"0.5":   0.2,
+						"count": float64(3),
+						"sum":   0.9,
+					},
+				},
+				{
+					Name:   "build",
+					Type:   MetricTypeGauge,
+					Tags:   map[string]string{"version": "2.0.0"},
+					Fields: map[string]interface{}{"info": float64(1)},
+				},
+				{
+					Name:   "state",
+					Type:   MetricTypeGauge,
+					Tags:   map[string]string{},
+					Fields: map[string]interface{}{"up": float64(1), "down": float64(0)},
+				},
+				{
+					Name:   "untyped_metric",
+					Type:   MetricTypeUntyped,
+					Tags:   map[string]string{"path": "a \"b\""},
+					Fields: map[string]interface{}{"value": float64(3)},
+				},
+			},
+		},
+		{
+			name:   "missing eof",
+			body:   "# TYPE up gauge\nup 1\n",
+			hasErr: true,
+		},
+		{
+			name:   "content after eof",
+			body:   "up 1\n# EOF\nup 2\n",
+			hasErr: true,
+		},
+		{
+			name:   "interleaved families",
+			body:   "a 1\nb 1\na{x=\"y\"} 2\n# EOF\n",
+			hasErr: true,
+		},
+		{
+			name:   "histogram without +Inf bucket",
+			body:   "# TYPE h histogram\nh_bucket{le=\"1\"} 1\nh_count 1\n# EOF\n",
+			hasErr: true,
+		},
+		{
+			name:   "unit not in name",
+			body:   "# TYPE t gauge\n# UNIT t celsius\nt 1\n# EOF\n",
+			hasErr: true,
+		},
+		{
+			name:   "counter without total",
+			body:   "# TYPE c counter\nc 1\n# EOF\n",
+			hasErr: true,
+		},
+		{
+			name:   "exemplar on a gauge",
+			body:   "# TYPE g gauge\ng 1 # {a=\"b\"} 1\n# EOF\n",
+			hasErr: true,
+		},
+		{
+			name:   "blank line",
+			body:   "up 1\n\n# EOF\n",
+			hasErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ts := httptest.NewServer(&mockHTTPHandler{
+				contentType: "application/openmetrics-text; version=1.0.0; charset=utf-8",
+				responseMap: map[string]string{
+					"/metrics": c.body,
+				},
+			})
+			defer ts.Close()
+
+			scraper := new(openMetricsScraper)
+			results, err := scraper.Gather(context.Background(), influxdb.ScraperTarget{
+				URL:      ts.URL + "/metrics",
+				OrgID:    *orgID,
+				BucketID: *bucketID,
+			})
+			if (err != nil) != c.hasErr {
+				t.Fatalf("scraper gather err: %v, expected an error: %v", err, c.hasErr)
+			}
+			if diff := cmp.Diff(c.ms, []Metrics(results.MetricsSlice), scrapedCmpOptions...); diff != "" {
+				t.Fatalf("scraper metrics are different -want/+got\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+func TestLineProtocolScraper(t *testing.T) {
+	ts := httptest.NewServer(&mockHTTPHandler{
+		contentType: "text/plain; charset=utf-8",
+		responseMap: map[string]string{
+			"/metrics": "# comment\ncpu,host=a usage=0.5,cores=4i,state=\"ok\",up=true 1600000000000000000\nmem,host=a free=2 1600000000000000000\n",
+			"/invalid": "cpu usage=\n",
+		},
+	})
+	defer ts.Close()
+
+	scraper := new(lineProtocolScraper)
+	results, err := scraper.Gather(context.Background(), influxdb.ScraperTarget{
+		URL:      ts.URL + "/metrics",
+		OrgID:    *orgID,
+		BucketID: *bucketID,
+	})
+	if err != nil {
+		t.Fatalf("scraper gather err: %v", err)
+	}
+	want := []Metrics{
+		{
+			Name: "cpu",
+			Type: MetricTypeUntyped,
+			Tags: map[string]string{"host": "a"},
+			Fields: map[string]interface{}{
+				"usage": 0.5,
+				"cores": float64(4),
+				"state": "ok",
+				"up":    true,
+			},
+			Timestamp: time.Unix(1600000000, 0),
+		},
+		{
+			Name:      "mem",
+			Type:      MetricTypeUntyped,
+			Tags:      map[string]string{"host": "a"},
+			Fields:    map[string]interface{}{"free": float64(2)},
+			Timestamp: time.Unix(1600000000, 0),
+		},
+	}
+	if diff := cmp.Diff(want, []Metrics(results.MetricsSlice), scrapedCmpOptions...); diff != "" {
+		t.Fatalf("scraper metrics are different -want/+got\ndiff %s", diff)
+	}
+
+	if _, err := scraper.Gather(context.Background(), influxdb.ScraperTarget{
+		URL: ts.URL + "/invalid",
+	}); err == nil {
+		t.Fatal("expected an error for invalid line protocol")
+	}
+}
+
+func TestJSONScraper(t *testing.T) {
+	const doc = `{
+	"service": "api",
+	"time": "2020-09-13T12:26:40Z",
+	"nodes": [
+		{"name": "n1", "zone": 1, "stats": {"load": 0.5, "ready": true}, "at": 1600000000},
+		{"name": "n2", "zone": 2, "stats": {"load": 1.5, "ready": false}},
+		{"name": "n3", "stats": {}}
+	],
+	"queues": {"low": {"kind": "queue_low", "size": 3}, "high": {"kind": "queue_high", "size": 1}}
+}`
+	cases := []struct {
+		name   string
+		config *influxdb.ScraperJSONConfig
+		ms     []Metrics
+		hasErr bool
+	}{
+		{
+			name: "records",
+			config: &influxdb.ScraperJSONConfig{
+				Records:     "$.nodes[*]",
+				Measurement: "nodes",
+				Tags:        map[string]string{"node": "$.name", "zone": "$['zone']"},
+				Fields:      map[string]string{"load": "$.stats.load", "ready": "$.stats.ready"},
+				Time:        "$.at",
+			},
+			ms: []Metrics{
+				{
+					Name:      "nodes",
+					Type:      MetricTypeUntyped,
+					Tags:      map[string]string{"node": "n1", "zone": "1"},
+					Fields:    map[string]interface{}{"load": 0.5, "ready": true},
+					Timestamp: time.Unix(1600000000, 0),
+				},
+				{
+					Name:   "nodes",
+					Type:   MetricTypeUntyped,
+					Tags:   map[string]string{"node": "n2", "zone": "2"},
+					Fields: map[string]interface{}{"load": 1.5, "ready": false},
+				},
+			},
+		},
+		{
+			name: "whole document",
+			config: &influxdb.ScraperJSONConfig{
+				Measurement: "service",
+				Tags:        map[string]string{"service": "$.service"},
+				Fields:      map[string]string{"first_load": "$.nodes[0].stats.load"},
+				Time:        "$.time",
+			},
+			ms: []Metrics{
+				{
+					Name:      "service",
+					Type:      MetricTypeUntyped,
+					Tags:      map[string]string{"service": "api"},
+					Fields:    map[string]interface{}{"first_load": 0.5},
+					Timestamp: time.Unix(1600000000, 0),
+				},
+			},
+		},
+		{
+			name: "measurement path",
+			config: &influxdb.ScraperJSONConfig{
+				Records:     "$.queues.*",
+				Measurement: "$.kind",
+				Fields:      map[string]string{"size": "$.size"},
+			},
+			ms: []Metrics{
+				{
+					Name:   "queue_high",
+					Type:   MetricTypeUntyped,
+					Tags:   map[string]string{},
+					Fields: map[string]interface{}{"size": float64(1)},
+				},
+				{
+					Name:   "queue_low",
+					Type:   MetricTypeUntyped,
+					Tags:   map[string]string{},
+					Fields: map[string]interface{}{"size": float64(3)},
+				},
+			},
+		},
+		{
+			name: "path selecting many values",
+			config: &influxdb.ScraperJSONConfig{
+				Measurement: "nodes",
+				Fields:      map[string]string{"load": "$.nodes[*].stats.load"},
+			},
+			hasErr: true,
+		},
+		{
+			name: "object field",
+			config: &influxdb.ScraperJSONConfig{
+				Measurement: "nodes",
+				Fields:      map[string]string{"stats": "$.nodes[0].stats"},
+			},
+			hasErr: true,
+		},
+		{
+			name: "invalid path",
+			config: &influxdb.ScraperJSONConfig{
+				Measurement: "nodes",
+				Fields:      map[string]string{"load": "$.nodes[x]"},
+			},
+			hasErr: true,
+		},
+	}
+	ts := httptest.NewServer(&mockHTTPHandler{
+		contentType: "application/json",
+		responseMap: map[string]string{
+			"/stats": doc,
+		},
+	})
+	defer ts.Close()
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			scraper := new(jsonScraper)
+			results, err := scraper.Gather(context.Background(), influxdb.ScraperTarget{
+				URL:      ts.URL + "/stats",
+				OrgID:    *orgID,
+				BucketID: *bucketID,
+				JSON:     c.config,
+			})
+			if (err != nil) != c.hasErr {
+				t.Fatalf("scraper gather err: %v, expected an error: %v", err, c.hasErr)
+			}
+			if diff := cmp.Diff(c.ms, []Metrics(results.MetricsSlice), scrapedCmpOptions...); diff != "" {
+				t.Fatalf("scraper metrics are different -want/+got\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+const sampleOpenMetrics = `# TYPE http_requests counter
+# HELP http_requests Total HTTP requests.
+http_requests_total{code="200"} 1027 1600000000 # {trace_id="abc"} 1 1600000000.5
+http_requests_created{code="200"} 1.5e9 1600000000
+# TYPE temperature_celsius gauge
+# UNIT temperature_celsius celsius
+temperature_celsius 21.5
+# TYPE request_duration_seconds histogram
+# UNIT request_duration_seconds seconds
+request_duration_seconds_bucket{le="0.1"} 5 # {trace_id="def"} 0.05
+request_duration_seconds_bucket{le="+Inf"} 8
+request_duration_seconds_count 8
+request_duration_seconds_sum 1.25
+request_duration_seconds_created 1.5e9
+# TYPE rpc_seconds summary
+# UNIT rpc_seconds seconds
+rpc_seconds{quantile="0.5"} 0.2
+rpc_seconds_count 3
+rpc_seconds_sum 0.9
+# TYPE build info
+build_info{version="2.0.0"} 1
+# TYPE state stateset
+state{state="up"} 1
+state{state="down"} 0
+untyped_metric{path="a \"b\""} 3
+# EOF
+`
+
 const sampleResp = `
 # 	HELP go_gc_duration_seconds A summary of the GC invocation durations.
 # TYPE go_gc_duration_seconds summary
@@ -278,7 +763,9 @@ func (s *mockStorage) UpdateTarget(ctx context.Context, update *influxdb.Scraper
 type mockHTTPHandler struct {
 	unauthorized bool
 	noContent    bool
-	responseMap  map[string]string
+	// contentType is the prometheus text format if it is empty.
+	contentType string
+	responseMap map[string]string
 }
 
 func (h mockHTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -295,7 +782,11 @@ func (h mockHTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
-	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	contentType := h.contentType
+	if contentType == "" {
+		contentType = "text/plain; version=0.0.4; charset=utf-8"
+	}
+	w.Header().Set("Content-Type", contentType)
 	w.Write([]byte(s))
 }
 
@@ -307,3 +798,11 @@ var metricsCmpOption = cmp.Options{
 			reflect.DeepEqual(x.Fields, y.Fields)
 	}),
 }
+
+// scrapedCmpOptions compare the scraped metrics, the metrics with a zero
+// timestamp are expected to have the scrape time.
+var scrapedCmpOptions = cmp.Options{
+	cmp.Comparer(func(x, y time.Time) bool {
+		return x.IsZero() || y.IsZero() || x.Equal(y)
+	}),
+}
@@ -0,0 +1,230 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/pkg/httpc"
+	"github.com/influxdata/influxdb/query"
+	"go.uber.org/zap"
+)
+
+const (
+	prefixQueries = "/api/v2/queries"
+	queriesIDPath = "/api/v2/queries/:id"
+)
+
+// ActiveQueryBackend is all services and associated parameters required to construct
+// the ActiveQueryHandler.
+type ActiveQueryBackend struct {
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	ActiveQueryService query.ActiveQueryService
+}
+
+// NewActiveQueryBackend returns a new instance of ActiveQueryBackend.
+func NewActiveQueryBackend(log *zap.Logger, b *APIBackend) *ActiveQueryBackend {
+	return &ActiveQueryBackend{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		log:              log,
+
+		ActiveQueryService: b.ActiveQueryService,
+	}
+}
+
+// ActiveQueryHandler is the handler for the queries being run by the server.
+type ActiveQueryHandler struct {
+	*httprouter.Router
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	ActiveQueryService query.ActiveQueryService
+}
+
+// NewActiveQueryHandler returns a new instance of ActiveQueryHandler.
+func NewActiveQueryHandler(log *zap.Logger, b *ActiveQueryBackend) *ActiveQueryHandler {
+	h := &ActiveQueryHandler{
+		Router:           NewRouter(b.HTTPErrorHandler),
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		log:              log,
+
+		ActiveQueryService: b.ActiveQueryService,
+	}
+
+	h.HandlerFunc("GET", prefixQueries, h.handleGetActiveQueries)
+	h.HandlerFunc("GET", queriesIDPath, h.handleGetActiveQuery)
+	h.HandlerFunc("DELETE", queriesIDPath, h.handleDeleteActiveQuery)
+
+	return h
+}
+
+type activeQueryLinks struct {
+	Self string `json:"self"`
+}
+
+type activeQueryResponse struct {
+	*query.ActiveQuery
+	Links activeQueryLinks `json:"links"`
+}
+
+func newActiveQueryResponse(q *query.ActiveQuery) *activeQueryResponse {
+	return &activeQueryResponse{
+		ActiveQuery: q,
+		Links: activeQueryLinks{
+			Self: fmt.Sprintf("/api/v2/queries/%s", q.ID),
+		},
+	}
+}
+
+type activeQueriesResponse struct {
+	Queries []*activeQueryResponse `json:"queries"`
+}
+
+func newActiveQueriesResponse(qs []*query.ActiveQuery) *activeQueriesResponse {
+	res := &activeQueriesResponse{
+		Queries: make([]*activeQueryResponse, 0, len(qs)),
+	}
+	for _, q := range qs {
+		res.Queries = append(res.Queries, newActiveQueryResponse(q))
+	}
+	return res
+}
+
+// handleGetActiveQueries is the HTTP handler for the GET /api/v2/queries route.
+func (h *ActiveQueryHandler) handleGetActiveQueries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var filter query.ActiveQueryFilter
+	if orgID := r.URL.Query().Get("orgID"); orgID != "" {
+		id, err := influxdb.IDFromString(orgID)
+		if err != nil {
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
+		filter.OrganizationID = id
+	}
+
+	qs, err := h.ActiveQueryService.FindActiveQueries(ctx, filter)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Active queries retrieved", zap.Int("queries", len(qs)))
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newActiveQueriesResponse(qs)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+// handleGetActiveQuery is the HTTP handler for the GET /api/v2/queries/:id route.
+func (h *ActiveQueryHandler) handleGetActiveQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := decodeActiveQueryID(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	q, err := h.ActiveQueryService.FindActiveQueryByID(ctx, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Active query retrieved", zap.String("query", fmt.Sprint(q)))
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newActiveQueryResponse(q)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+// handleDeleteActiveQuery is the HTTP handler for the DELETE /api/v2/queries/:id route.
+func (h *ActiveQueryHandler) handleDeleteActiveQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := decodeActiveQueryID(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.ActiveQueryService.CancelActiveQuery(ctx, id); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Active query canceled", zap.String("queryID", id.String()))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeActiveQueryID(ctx context.Context) (influxdb.ID, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return 0, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "url missing id",
+		}
+	}
+
+	var i influxdb.ID
+	if err := i.DecodeFromString(id); err != nil {
+		return 0, err
+	}
+	return i, nil
+}
+
+// ActiveQueryService connects to Influx via HTTP using tokens to manage the queries being run.
+type ActiveQueryService struct {
+	Client *httpc.Client
+}
+
+var _ query.ActiveQueryService = (*ActiveQueryService)(nil)
+
+// FindActiveQueries returns the active queries matching the filter.
+func (s *ActiveQueryService) FindActiveQueries(ctx context.Context, filter query.ActiveQueryFilter) ([]*query.ActiveQuery, error) {
+	var params [][2]string
+	if filter.OrganizationID != nil {
+		params = append(params, [2]string{"orgID", filter.OrganizationID.String()})
+	}
+
+	var res activeQueriesResponse
+	err := s.Client.
+		Get(prefixQueries).
+		QueryParams(params...).
+		DecodeJSON(&res).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	qs := make([]*query.ActiveQuery, 0, len(res.Queries))
+	for _, q := range res.Queries {
+		qs = append(qs, q.ActiveQuery)
+	}
+	return qs, nil
+}
+
+// FindActiveQueryByID returns a single active query by ID.
+func (s *ActiveQueryService) FindActiveQueryByID(ctx context.Context, id influxdb.ID) (*query.ActiveQuery, error) {
+	var res activeQueryResponse
+	err := s.Client.
+		Get(prefixQueries, id.String()).
+		DecodeJSON(&res).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return res.ActiveQuery, nil
+}
+
+// CancelActiveQuery cancels the active query.
+func (s *ActiveQueryService) CancelActiveQuery(ctx context.Context, id influxdb.ID) error {
+	return s.Client.
+		Delete(prefixQueries, id.String()).
+		Do(ctx)
+}
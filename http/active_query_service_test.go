@@ -0,0 +1,141 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	kithttp "github.com/influxdata/influxdb/kit/transport/http"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/query/mock"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestActiveQueryHandler_handleGetActiveQueries(t *testing.T) {
+	var gotFilter query.ActiveQueryFilter
+	s := &mock.ActiveQueryService{
+		FindActiveQueriesF: func(ctx context.Context, filter query.ActiveQueryFilter) ([]*query.ActiveQuery, error) {
+			gotFilter = filter
+			return []*query.ActiveQuery{
+				{
+					ID:              1,
+					OrganizationID:  2,
+					UserID:          3,
+					AuthorizationID: 4,
+					CompilerType:    "flux",
+					Query:           `from(bucket: "b") |> range(start: -1h)`,
+					StartedAt:       time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC),
+					MemoryAllocated: 1024,
+					State:           "executing",
+				},
+			}, nil
+		},
+	}
+
+	b := &ActiveQueryBackend{
+		HTTPErrorHandler:   kithttp.ErrorHandler(0),
+		log:                zaptest.NewLogger(t),
+		ActiveQueryService: s,
+	}
+	h := NewActiveQueryHandler(zaptest.NewLogger(t), b)
+
+	r := httptest.NewRequest("GET", "http://any.url/api/v2/queries?orgID=0000000000000002", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	res := w.Result()
+	body, _ := ioutil.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d, want %d: %s", res.StatusCode, http.StatusOK, body)
+	}
+	if gotFilter.OrganizationID == nil || *gotFilter.OrganizationID != 2 {
+		t.Errorf("got org filter %v, want 0000000000000002", gotFilter.OrganizationID)
+	}
+
+	want := `
+{
+  "queries": [
+    {
+      "id": "0000000000000001",
+      "orgID": "0000000000000002",
+      "userID": "0000000000000003",
+      "authorizationID": "0000000000000004",
+      "compilerType": "flux",
+      "query": "from(bucket: \"b\") |> range(start: -1h)",
+      "startedAt": "2019-12-01T00:00:00Z",
+      "memoryAllocated": 1024,
+      "state": "executing",
+      "links": {
+        "self": "/api/v2/queries/0000000000000001"
+      }
+    }
+  ]
+}
+`
+	if eq, diff, err := jsonEqual(string(body), want); err != nil || !eq {
+		t.Errorf("unexpected response -got/+want\ndiff %s", diff)
+	}
+}
+
+func TestActiveQueryHandler_handleDeleteActiveQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		err        error
+		wantStatus int
+	}{
+		{
+			name:       "cancel an active query",
+			id:         "0000000000000001",
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "cancel a finished query",
+			id:   "0000000000000001",
+			err: &influxdb.Error{
+				Code: influxdb.ENotFound,
+				Msg:  "active query not found",
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "cancel a query with an invalid id",
+			id:         "abc",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var canceled influxdb.ID
+			s := &mock.ActiveQueryService{
+				CancelActiveQueryF: func(ctx context.Context, id influxdb.ID) error {
+					canceled = id
+					return tt.err
+				},
+			}
+
+			b := &ActiveQueryBackend{
+				HTTPErrorHandler:   kithttp.ErrorHandler(0),
+				log:                zaptest.NewLogger(t),
+				ActiveQueryService: s,
+			}
+			h := NewActiveQueryHandler(zaptest.NewLogger(t), b)
+
+			r := httptest.NewRequest("DELETE", "http://any.url/api/v2/queries/"+tt.id, nil)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusNoContent && canceled != 1 {
+				t.Errorf("got canceled query %s, want 0000000000000001", canceled)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	kithttp "github.com/influxdata/influxdb/kit/transport/http"
 	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/storage"
+	"github.com/influxdata/influxdb/storage/reads"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
@@ -51,10 +52,13 @@ type APIBackend struct {
 	QueryEventRecorder metric.EventRecorder
 
 	PointsWriter                    storage.PointsWriter
+	ReadStore                       reads.Store
 	DeleteService                   influxdb.DeleteService
 	BackupService                   influxdb.BackupService
 	KVBackupService                 influxdb.KVBackupService
+	RestoreService                  influxdb.RestoreService
 	AuthorizationService            influxdb.AuthorizationService
+	AuditService                    influxdb.AuditService
 	BucketService                   influxdb.BucketService
 	SessionService                  influxdb.SessionService
 	UserService                     influxdb.UserService
@@ -71,11 +75,15 @@ type APIBackend struct {
 	PasswordsService                influxdb.PasswordsService
 	OnboardingService               influxdb.OnboardingService
 	InfluxQLService                 query.ProxyQueryService
+	ActiveQueryService              query.ActiveQueryService
 	FluxService                     query.ProxyQueryService
 	TaskService                     influxdb.TaskService
+	TaskBackfillService             influxdb.TaskBackfillService
+	TaskStatsService                influxdb.TaskStatsService
 	CheckService                    influxdb.CheckService
 	TelegrafService                 influxdb.TelegrafConfigStore
 	ScraperTargetStoreService       influxdb.ScraperTargetStoreService
+	ScraperStatusService            influxdb.ScraperStatusService
 	SecretService                   influxdb.SecretService
 	LookupService                   influxdb.LookupService
 	ChronografService               *server.Service
@@ -122,9 +130,17 @@ func NewAPIHandler(b *APIBackend, opts ...APIHandlerOptFn) *APIHandler {
 	h.Mount("/api/v2", serveLinksHandler(b.HTTPErrorHandler))
 
 	authorizationBackend := NewAuthorizationBackend(b.Logger.With(zap.String("handler", "authorization")), b)
-	authorizationBackend.AuthorizationService = authorizer.NewAuthorizationService(b.AuthorizationService)
+	authorizationService := authorizer.NewAuthorizationService(b.AuthorizationService)
+	authorizationBackend.AuthorizationService = authorizationService
+	authorizationBackend.ChildAuthorizationService = authorizationService
 	h.Mount(prefixAuthorization, NewAuthorizationHandler(b.Logger, authorizationBackend))
 
+	if b.AuditService != nil {
+		auditBackend := NewAuditBackend(b.Logger.With(zap.String("handler", "audit")), b)
+		auditBackend.AuditService = authorizer.NewAuditService(b.AuditService)
+		h.Mount(prefixAudit, NewAuditHandler(b.Logger, auditBackend))
+	}
+
 	bucketBackend := NewBucketBackend(b.Logger.With(zap.String("handler", "bucket")), b)
 	bucketBackend.BucketService = authorizer.NewBucketService(b.BucketService)
 	h.Mount(prefixBuckets, NewBucketHandler(b.Logger, bucketBackend))
@@ -161,6 +177,12 @@ func NewAPIHandler(b *APIBackend, opts ...APIHandlerOptFn) *APIHandler {
 		b.UserResourceMappingService, b.OrganizationService)
 	h.Mount(prefixNotificationRules, NewNotificationRuleHandler(b.Logger, notificationRuleBackend))
 
+	if b.ActiveQueryService != nil {
+		activeQueryBackend := NewActiveQueryBackend(b.Logger.With(zap.String("handler", "queries")), b)
+		activeQueryBackend.ActiveQueryService = authorizer.NewActiveQueryService(b.ActiveQueryService)
+		h.Mount(prefixQueries, NewActiveQueryHandler(b.Logger, activeQueryBackend))
+	}
+
 	orgBackend := NewOrgBackend(b.Logger.With(zap.String("handler", "org")), b)
 	orgBackend.OrganizationService = authorizer.NewOrgService(b.OrganizationService)
 	orgBackend.SecretService = authorizer.NewSecretService(b.SecretService)
@@ -190,6 +212,12 @@ func NewAPIHandler(b *APIBackend, opts ...APIHandlerOptFn) *APIHandler {
 	taskLogger := b.Logger.With(zap.String("handler", "bucket"))
 	taskBackend := NewTaskBackend(taskLogger, b)
 	taskBackend.TaskService = authorizer.NewTaskService(taskLogger, b.TaskService)
+	if b.TaskBackfillService != nil {
+		taskBackend.TaskBackfillService = authorizer.NewTaskBackfillService(taskLogger, b.TaskService, b.TaskBackfillService)
+	}
+	if b.TaskStatsService != nil {
+		taskBackend.TaskStatsService = authorizer.NewTaskStatsService(taskLogger, b.TaskService, b.TaskStatsService)
+	}
 	taskHandler := NewTaskHandler(b.Logger, taskBackend)
 	h.Mount(prefixTasks, taskHandler)
 
@@ -211,8 +239,18 @@ func NewAPIHandler(b *APIBackend, opts ...APIHandlerOptFn) *APIHandler {
 
 	backupBackend := NewBackupBackend(b)
 	backupBackend.BackupService = authorizer.NewBackupService(backupBackend.BackupService)
+	backupBackend.KVBackupService = authorizer.NewKVBackupService(backupBackend.KVBackupService)
+	backupBackend.BucketService = authorizer.NewBucketService(b.BucketService)
 	h.Mount(prefixBackup, NewBackupHandler(backupBackend))
 
+	prometheusRemoteBackend := NewPrometheusRemoteBackend(b.Logger.With(zap.String("handler", "prometheus")), b)
+	h.Mount(prefixPrometheus, NewPrometheusRemoteHandler(b.Logger, prometheusRemoteBackend))
+
+	restoreBackend := NewRestoreBackend(b)
+	restoreBackend.RestoreService = authorizer.NewRestoreService(restoreBackend.RestoreService)
+	restoreBackend.BucketService = authorizer.NewBucketService(b.BucketService)
+	h.Mount(prefixRestore, NewRestoreHandler(restoreBackend))
+
 	writeBackend := NewWriteBackend(b.Logger.With(zap.String("handler", "write")), b)
 	h.Mount(prefixWrite, NewWriteHandler(b.Logger, writeBackend,
 		WithMaxBatchSizeBytes(b.MaxBatchSizeBytes),
@@ -230,6 +268,7 @@ func NewAPIHandler(b *APIBackend, opts ...APIHandlerOptFn) *APIHandler {
 var apiLinks = map[string]interface{}{
 	// when adding new links, please take care to keep this list alphabetical
 	// as this makes it easier to verify values against the swagger document.
+	"audit":          "/api/v2/audit",
 	"authorizations": "/api/v2/authorizations",
 	"backup":         "/api/v2/backup",
 	"buckets":        "/api/v2/buckets",
@@ -249,6 +288,8 @@ var apiLinks = map[string]interface{}{
 		"analyze":     "/api/v2/query/analyze",
 		"suggestions": "/api/v2/query/suggestions",
 	},
+	"queries":  "/api/v2/queries",
+	"restore":  "/api/v2/restore",
 	"setup":    "/api/v2/setup",
 	"signin":   "/api/v2/signin",
 	"signout":  "/api/v2/signout",
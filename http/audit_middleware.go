@@ -0,0 +1,260 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/influxdata/influxdb"
+	pctx "github.com/influxdata/influxdb/context"
+	"go.uber.org/zap"
+)
+
+const (
+	// auditMaxBodyBytes is the largest request or response body kept to build an audit event.
+	auditMaxBodyBytes = 64 * 1024
+	// auditRedacted replaces the sensitive values of the audited requests.
+	auditRedacted = "[REDACTED]"
+)
+
+// auditSkippedPaths are the first segments of the mutating API paths that are not audited,
+// they write or query data, or open and close sessions, rather than change resources.
+var auditSkippedPaths = map[string]bool{
+	"write":      true,
+	"query":      true,
+	"prometheus": true,
+	"signin":     true,
+	"signout":    true,
+	"audit":      true,
+}
+
+// auditSensitiveKeys are the parts of the JSON keys whose values are redacted from the audit events.
+var auditSensitiveKeys = []string{"token", "password", "secret", "routingkey"}
+
+// AuditRecordingHandler records an audit event for every mutating call made to the API.
+type AuditRecordingHandler struct {
+	log *zap.Logger
+
+	AuditService influxdb.AuditService
+	Handler      http.Handler
+}
+
+// NewAuditRecordingHandler returns a handler that audits the mutating calls made to the handler.
+func NewAuditRecordingHandler(log *zap.Logger, s influxdb.AuditService, h http.Handler) *AuditRecordingHandler {
+	return &AuditRecordingHandler{
+		log:          log,
+		AuditService: s,
+		Handler:      h,
+	}
+}
+
+// ServeHTTP serves the request and records its audit event once it has been answered.
+func (h *AuditRecordingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments, ok := auditedPath(r)
+	if !ok {
+		h.Handler.ServeHTTP(w, r)
+		return
+	}
+
+	// keep the start of the body for the audit event, and hand the whole body over to the handler.
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, auditMaxBodyBytes))
+	if err != nil {
+		h.log.Info("Failed to read request body for audit", zap.Error(err))
+	}
+	r.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+
+	aw := &auditResponseWriter{ResponseWriter: w}
+	h.Handler.ServeHTTP(aw, r)
+
+	e := newAuditEvent(r, segments, body, aw)
+	if err := h.AuditService.RecordAuditEvent(r.Context(), e); err != nil {
+		h.log.Error("Failed to record audit event",
+			zap.String("method", r.Method),
+			zap.String("path", r.URL.Path),
+			zap.Error(err))
+	}
+}
+
+// auditedPath returns the segments of the path following /api/v2 of the requests which are audited.
+func auditedPath(r *http.Request) ([]string, bool) {
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return nil, false
+	}
+
+	p := strings.TrimPrefix(r.URL.Path, "/api/v2/")
+	if p == r.URL.Path {
+		return nil, false
+	}
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	if auditSkippedPaths[segments[0]] {
+		return nil, false
+	}
+	return segments, true
+}
+
+// newAuditEvent builds the audit event of an answered request.
+func newAuditEvent(r *http.Request, segments []string, body []byte, aw *auditResponseWriter) *influxdb.AuditEvent {
+	e := &influxdb.AuditEvent{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Status: aw.Code(),
+	}
+
+	if a, err := pctx.GetAuthorizer(r.Context()); err == nil {
+		e.ActorID = a.GetUserID()
+		e.AuthorizationID = a.Identifier()
+		e.AuthorizationKind = a.Kind()
+		if auth, ok := a.(*influxdb.Authorization); ok {
+			e.OrgID = auth.OrgID
+		}
+	}
+
+	var id influxdb.ID
+	if id.DecodeFromString(r.URL.Query().Get("orgID")) == nil {
+		e.OrgID = id
+	}
+
+	// the resource is identified by the first segments of the path,
+	// the secrets are nested in their org and the current user is /me.
+	nested := len(segments) > 2
+	switch {
+	case segments[0] == "me":
+		e.ResourceType = influxdb.UsersResourceType
+		e.ResourceID = e.ActorID
+		nested = len(segments) > 1
+	case segments[0] == "orgs" && len(segments) > 2 && segments[2] == "secrets":
+		e.ResourceType = influxdb.SecretsResourceType
+		nested = false
+		if id.DecodeFromString(segments[1]) == nil {
+			e.OrgID = id
+		}
+	case influxdb.ResourceType(segments[0]).Valid() == nil:
+		e.ResourceType = influxdb.ResourceType(segments[0])
+	}
+	if len(segments) > 1 && e.ResourceType != influxdb.SecretsResourceType && id.DecodeFromString(segments[1]) == nil {
+		e.ResourceID = id
+		if e.ResourceType == influxdb.OrgsResourceType {
+			e.OrgID = id
+		}
+	}
+
+	switch {
+	case r.Method == http.MethodPost && e.ResourceID == 0 && !nested:
+		e.Action = influxdb.AuditCreate
+	case r.Method == http.MethodDelete && !nested:
+		e.Action = influxdb.AuditDelete
+	default:
+		// changes to the members, labels or runs of a resource update the resource.
+		e.Action = influxdb.AuditUpdate
+	}
+	if e.ResourceType == influxdb.SecretsResourceType && segments[len(segments)-1] == "delete" {
+		e.Action = influxdb.AuditDelete
+	}
+
+	var req map[string]interface{}
+	if err := json.Unmarshal(body, &req); err == nil {
+		if id, ok := auditID(req, "orgID"); ok && e.ResourceType != influxdb.OrgsResourceType {
+			e.OrgID = id
+		}
+		redact(req, e.ResourceType == influxdb.SecretsResourceType && r.Method != http.MethodPost)
+		if diff, err := json.Marshal(req); err == nil {
+			e.Diff = diff
+		}
+	}
+
+	// the created resources are identified by the response.
+	var res map[string]interface{}
+	if e.Action == influxdb.AuditCreate && json.Unmarshal(aw.body.Bytes(), &res) == nil {
+		if id, ok := auditID(res, "id"); ok {
+			e.ResourceID = id
+			if e.ResourceType == influxdb.OrgsResourceType {
+				e.OrgID = id
+			}
+		}
+		if id, ok := auditID(res, "orgID"); ok && e.ResourceType != influxdb.OrgsResourceType {
+			e.OrgID = id
+		}
+	}
+
+	return e
+}
+
+// auditID returns the ID found at the key of the JSON object.
+func auditID(obj map[string]interface{}, key string) (influxdb.ID, bool) {
+	s, ok := obj[key].(string)
+	if !ok {
+		return 0, false
+	}
+	var id influxdb.ID
+	if err := id.DecodeFromString(s); err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+// redact replaces the sensitive values of the JSON value v, all of its values when all is set.
+func redact(v interface{}, all bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			if all || isSensitiveKey(k) {
+				v[k] = auditRedacted
+				continue
+			}
+			v[k] = redact(val, false)
+		}
+	case []interface{}:
+		for i, val := range v {
+			v[i] = redact(val, all)
+		}
+	}
+	return v
+}
+
+func isSensitiveKey(k string) bool {
+	k = strings.ToLower(k)
+	for _, s := range auditSensitiveKeys {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// auditResponseWriter captures the status and the start of the body of a response.
+type auditResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *auditResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *auditResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if n := auditMaxBodyBytes - w.body.Len(); n > 0 {
+		if n > len(b) {
+			n = len(b)
+		}
+		w.body.Write(b[:n])
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Code returns the status of the response.
+func (w *auditResponseWriter) Code() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
@@ -0,0 +1,183 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestAuditRecordingHandler(t *testing.T) {
+	auth := &influxdb.Authorization{
+		ID:     influxdb.ID(3),
+		OrgID:  influxdb.ID(1),
+		UserID: influxdb.ID(2),
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		status   int
+		response string
+		want     *influxdb.AuditEvent
+	}{
+		{
+			name:     "create a bucket",
+			method:   "POST",
+			path:     "/api/v2/buckets",
+			body:     `{"orgID":"0000000000000005","name":"b"}`,
+			status:   http.StatusCreated,
+			response: `{"id":"0000000000000010","orgID":"0000000000000005","name":"b"}`,
+			want: &influxdb.AuditEvent{
+				OrgID:             influxdb.ID(5),
+				ActorID:           influxdb.ID(2),
+				AuthorizationID:   influxdb.ID(3),
+				AuthorizationKind: influxdb.AuthorizationKind,
+				ResourceType:      influxdb.BucketsResourceType,
+				ResourceID:        influxdb.ID(16),
+				Action:            influxdb.AuditCreate,
+				Method:            "POST",
+				Path:              "/api/v2/buckets",
+				Status:            http.StatusCreated,
+				Diff:              json.RawMessage(`{"name":"b","orgID":"0000000000000005"}`),
+			},
+		},
+		{
+			name:   "delete a task",
+			method: "DELETE",
+			path:   "/api/v2/tasks/0000000000000020",
+			status: http.StatusNoContent,
+			want: &influxdb.AuditEvent{
+				OrgID:             influxdb.ID(1),
+				ActorID:           influxdb.ID(2),
+				AuthorizationID:   influxdb.ID(3),
+				AuthorizationKind: influxdb.AuthorizationKind,
+				ResourceType:      influxdb.TasksResourceType,
+				ResourceID:        influxdb.ID(32),
+				Action:            influxdb.AuditDelete,
+				Method:            "DELETE",
+				Path:              "/api/v2/tasks/0000000000000020",
+				Status:            http.StatusNoContent,
+			},
+		},
+		{
+			name:   "add a label to a check updates the check",
+			method: "POST",
+			path:   "/api/v2/checks/0000000000000020/labels",
+			body:   `{"labelID":"0000000000000030"}`,
+			status: http.StatusCreated,
+			want: &influxdb.AuditEvent{
+				OrgID:             influxdb.ID(1),
+				ActorID:           influxdb.ID(2),
+				AuthorizationID:   influxdb.ID(3),
+				AuthorizationKind: influxdb.AuthorizationKind,
+				ResourceType:      influxdb.ChecksResourceType,
+				ResourceID:        influxdb.ID(32),
+				Action:            influxdb.AuditUpdate,
+				Method:            "POST",
+				Path:              "/api/v2/checks/0000000000000020/labels",
+				Status:            http.StatusCreated,
+				Diff:              json.RawMessage(`{"labelID":"0000000000000030"}`),
+			},
+		},
+		{
+			name:   "passwords are redacted",
+			method: "PUT",
+			path:   "/api/v2/me/password",
+			body:   `{"password":"hunter22"}`,
+			status: http.StatusNoContent,
+			want: &influxdb.AuditEvent{
+				OrgID:             influxdb.ID(1),
+				ActorID:           influxdb.ID(2),
+				AuthorizationID:   influxdb.ID(3),
+				AuthorizationKind: influxdb.AuthorizationKind,
+				ResourceType:      influxdb.UsersResourceType,
+				ResourceID:        influxdb.ID(2),
+				Action:            influxdb.AuditUpdate,
+				Method:            "PUT",
+				Path:              "/api/v2/me/password",
+				Status:            http.StatusNoContent,
+				Diff:              json.RawMessage(`{"password":"[REDACTED]"}`),
+			},
+		},
+		{
+			name:   "secrets are redacted",
+			method: "PATCH",
+			path:   "/api/v2/orgs/0000000000000005/secrets",
+			body:   `{"apikey":"abc"}`,
+			status: http.StatusNoContent,
+			want: &influxdb.AuditEvent{
+				OrgID:             influxdb.ID(5),
+				ActorID:           influxdb.ID(2),
+				AuthorizationID:   influxdb.ID(3),
+				AuthorizationKind: influxdb.AuthorizationKind,
+				ResourceType:      influxdb.SecretsResourceType,
+				Action:            influxdb.AuditUpdate,
+				Method:            "PATCH",
+				Path:              "/api/v2/orgs/0000000000000005/secrets",
+				Status:            http.StatusNoContent,
+				Diff:              json.RawMessage(`{"apikey":"[REDACTED]"}`),
+			},
+		},
+		{
+			name:   "writes are not audited",
+			method: "POST",
+			path:   "/api/v2/write",
+			body:   `m f=1`,
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "reads are not audited",
+			method: "GET",
+			path:   "/api/v2/buckets",
+			status: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *influxdb.AuditEvent
+			s := mock.NewAuditService()
+			s.RecordAuditEventFn = func(ctx context.Context, e *influxdb.AuditEvent) error {
+				got = e
+				return nil
+			}
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(body) != tt.body {
+					t.Errorf("handler got body %q, want %q", body, tt.body)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.response))
+			})
+			h := NewAuditRecordingHandler(zaptest.NewLogger(t), s, next)
+
+			r := httptest.NewRequest(tt.method, "http://any.url"+tt.path, bytes.NewBufferString(tt.body))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), auth))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.status {
+				t.Errorf("got status %d, want %d", w.Code, tt.status)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unexpected audit event -want/+got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/pkg/httpc"
+	"go.uber.org/zap"
+)
+
+const (
+	prefixAudit = "/api/v2/audit"
+
+	// auditJSONLinesFormat is the format param exporting the audit events as JSON lines.
+	auditJSONLinesFormat = "jsonl"
+)
+
+// AuditBackend is all services and associated parameters required to construct
+// the AuditHandler.
+type AuditBackend struct {
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	AuditService influxdb.AuditService
+}
+
+// NewAuditBackend returns a new instance of AuditBackend.
+func NewAuditBackend(log *zap.Logger, b *APIBackend) *AuditBackend {
+	return &AuditBackend{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		log:              log,
+
+		AuditService: b.AuditService,
+	}
+}
+
+// AuditHandler is the handler for the audit service.
+type AuditHandler struct {
+	*httprouter.Router
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	AuditService influxdb.AuditService
+}
+
+// NewAuditHandler returns a new instance of AuditHandler.
+func NewAuditHandler(log *zap.Logger, b *AuditBackend) *AuditHandler {
+	h := &AuditHandler{
+		Router:           NewRouter(b.HTTPErrorHandler),
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		log:              log,
+
+		AuditService: b.AuditService,
+	}
+
+	h.HandlerFunc("GET", prefixAudit, h.handleGetAuditEvents)
+
+	return h
+}
+
+type auditEventsResponse struct {
+	Links  *influxdb.PagingLinks  `json:"links"`
+	Events []*influxdb.AuditEvent `json:"events"`
+}
+
+// handleGetAuditEvents is the HTTP handler for the GET /api/v2/audit route.
+func (h *AuditHandler) handleGetAuditEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req, err := decodeGetAuditEventsRequest(r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	events, _, err := h.AuditService.FindAuditEvents(ctx, req.filter, req.opts)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Audit events retrieved", zap.Int("events", len(events)))
+
+	if req.format == auditJSONLinesFormat {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		enc := json.NewEncoder(w)
+		for _, e := range events {
+			if err := enc.Encode(e); err != nil {
+				logEncodingError(h.log, r, err)
+				return
+			}
+		}
+		return
+	}
+
+	res := auditEventsResponse{
+		Links:  newPagingLinks(prefixAudit, req.opts, req.filter, len(events)),
+		Events: events,
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+type getAuditEventsRequest struct {
+	filter influxdb.AuditEventFilter
+	opts   influxdb.FindOptions
+	format string
+}
+
+func decodeGetAuditEventsRequest(r *http.Request) (*getAuditEventsRequest, error) {
+	qp := r.URL.Query()
+	req := &getAuditEventsRequest{
+		format: qp.Get("format"),
+	}
+
+	opts, err := decodeFindOptions(r)
+	if err != nil {
+		return nil, err
+	}
+	req.opts = *opts
+	// the JSON lines export all of the audit events unless it is limited.
+	if req.format == auditJSONLinesFormat && qp.Get("limit") == "" {
+		req.opts.Limit = 0
+	}
+
+	for k, f := range map[string]**influxdb.ID{
+		"orgID":           &req.filter.OrgID,
+		"actorID":         &req.filter.ActorID,
+		"authorizationID": &req.filter.AuthorizationID,
+		"resourceID":      &req.filter.ResourceID,
+	} {
+		if v := qp.Get(k); v != "" {
+			id, err := influxdb.IDFromString(v)
+			if err != nil {
+				return nil, &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  k + " is invalid",
+					Err:  err,
+				}
+			}
+			*f = id
+		}
+	}
+
+	if v := qp.Get("resourceType"); v != "" {
+		rt := influxdb.ResourceType(v)
+		if err := rt.Valid(); err != nil {
+			return nil, err
+		}
+		req.filter.ResourceType = &rt
+	}
+
+	if v := qp.Get("action"); v != "" {
+		a := influxdb.AuditAction(v)
+		switch a {
+		case influxdb.AuditCreate, influxdb.AuditUpdate, influxdb.AuditDelete:
+		default:
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "action must be one of create, update or delete",
+			}
+		}
+		req.filter.Action = &a
+	}
+
+	for k, f := range map[string]**time.Time{
+		"start": &req.filter.Start,
+		"stop":  &req.filter.Stop,
+	} {
+		if v := qp.Get(k); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  k + " must be an RFC3339 time",
+					Err:  err,
+				}
+			}
+			*f = &t
+		}
+	}
+
+	return req, nil
+}
+
+// AuditService connects to Influx via HTTP using tokens to retrieve the audit events.
+type AuditService struct {
+	Client *httpc.Client
+}
+
+var _ influxdb.AuditService = (*AuditService)(nil)
+
+// RecordAuditEvent is not implemented for http, the server records its own audit events.
+func (s *AuditService) RecordAuditEvent(ctx context.Context, e *influxdb.AuditEvent) error {
+	return &influxdb.Error{
+		Code: influxdb.EMethodNotAllowed,
+		Msg:  "record audit event is not implemented for http",
+	}
+}
+
+// FindAuditEvents returns the audit events matching the filter, most recent first.
+func (s *AuditService) FindAuditEvents(ctx context.Context, filter influxdb.AuditEventFilter, opts ...influxdb.FindOptions) ([]*influxdb.AuditEvent, int, error) {
+	params := findOptionParams(opts...)
+	for k, vs := range filter.QueryParams() {
+		for _, v := range vs {
+			params = append(params, [2]string{k, v})
+		}
+	}
+
+	var res auditEventsResponse
+	err := s.Client.
+		Get(prefixAudit).
+		QueryParams(params...).
+		DecodeJSON(&res).
+		Do(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return res.Events, len(res.Events), nil
+}
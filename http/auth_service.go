@@ -23,10 +23,11 @@ type AuthorizationBackend struct {
 	platform.HTTPErrorHandler
 	log *zap.Logger
 
-	AuthorizationService platform.AuthorizationService
-	OrganizationService  platform.OrganizationService
-	UserService          platform.UserService
-	LookupService        platform.LookupService
+	AuthorizationService      platform.AuthorizationService
+	ChildAuthorizationService platform.ChildAuthorizationService
+	OrganizationService       platform.OrganizationService
+	UserService               platform.UserService
+	LookupService             platform.LookupService
 }
 
 // NewAuthorizationBackend returns a new instance of AuthorizationBackend.
@@ -48,10 +49,11 @@ type AuthorizationHandler struct {
 	platform.HTTPErrorHandler
 	log *zap.Logger
 
-	OrganizationService  platform.OrganizationService
-	UserService          platform.UserService
-	AuthorizationService platform.AuthorizationService
-	LookupService        platform.LookupService
+	OrganizationService       platform.OrganizationService
+	UserService               platform.UserService
+	AuthorizationService      platform.AuthorizationService
+	ChildAuthorizationService platform.ChildAuthorizationService
+	LookupService             platform.LookupService
 }
 
 // NewAuthorizationHandler returns a new instance of AuthorizationHandler.
@@ -61,10 +63,11 @@ func NewAuthorizationHandler(log *zap.Logger, b *AuthorizationBackend) *Authoriz
 		HTTPErrorHandler: b.HTTPErrorHandler,
 		log:              log,
 
-		AuthorizationService: b.AuthorizationService,
-		OrganizationService:  b.OrganizationService,
-		UserService:          b.UserService,
-		LookupService:        b.LookupService,
+		AuthorizationService:      b.AuthorizationService,
+		ChildAuthorizationService: b.ChildAuthorizationService,
+		OrganizationService:       b.OrganizationService,
+		UserService:               b.UserService,
+		LookupService:             b.LookupService,
 	}
 
 	h.HandlerFunc("POST", "/api/v2/authorizations", h.handlePostAuthorization)
@@ -72,6 +75,7 @@ func NewAuthorizationHandler(log *zap.Logger, b *AuthorizationBackend) *Authoriz
 	h.HandlerFunc("GET", "/api/v2/authorizations/:id", h.handleGetAuthorization)
 	h.HandlerFunc("PATCH", "/api/v2/authorizations/:id", h.handleUpdateAuthorization)
 	h.HandlerFunc("DELETE", "/api/v2/authorizations/:id", h.handleDeleteAuthorization)
+	h.HandlerFunc("POST", "/api/v2/authorizations/:id/children", h.handlePostChildAuthorization)
 	return h
 }
 
@@ -86,6 +90,8 @@ type authResponse struct {
 	User        string               `json:"user"`
 	Permissions []permissionResponse `json:"permissions"`
 	Links       map[string]string    `json:"links"`
+	ExpiresAt   *time.Time           `json:"expiresAt,omitempty"`
+	ParentID    platform.ID          `json:"parentID,omitempty"`
 	CreatedAt   time.Time            `json:"createdAt"`
 	UpdatedAt   time.Time            `json:"updatedAt"`
 }
@@ -105,9 +111,14 @@ func newAuthResponse(a *platform.Authorization, org *platform.Organization, user
 			"self": fmt.Sprintf("/api/v2/authorizations/%s", a.ID),
 			"user": fmt.Sprintf("/api/v2/users/%s", a.UserID),
 		},
+		ExpiresAt: a.ExpiresAt,
+		ParentID:  a.ParentID,
 		CreatedAt: a.CreatedAt,
 		UpdatedAt: a.UpdatedAt,
 	}
+	if a.ParentID.Valid() {
+		res.Links["parent"] = fmt.Sprintf("/api/v2/authorizations/%s", a.ParentID)
+	}
 	return res
 }
 
@@ -119,20 +130,23 @@ func (a *authResponse) toPlatform() *platform.Authorization {
 		Description: a.Description,
 		OrgID:       a.OrgID,
 		UserID:      a.UserID,
+		ExpiresAt:   a.ExpiresAt,
+		ParentID:    a.ParentID,
 		CRUDLog: platform.CRUDLog{
 			CreatedAt: a.CreatedAt,
 			UpdatedAt: a.UpdatedAt,
 		},
 	}
 	for _, p := range a.Permissions {
-		res.Permissions = append(res.Permissions, platform.Permission{Action: p.Action, Resource: p.Resource.Resource})
+		res.Permissions = append(res.Permissions, platform.Permission{Action: p.Action, Resource: p.Resource.Resource, Restriction: p.Restriction})
 	}
 	return res
 }
 
 type permissionResponse struct {
-	Action   platform.Action  `json:"action"`
-	Resource resourceResponse `json:"resource"`
+	Action      platform.Action           `json:"action"`
+	Resource    resourceResponse          `json:"resource"`
+	Restriction *platform.DataRestriction `json:"restriction,omitempty"`
 }
 
 type resourceResponse struct {
@@ -149,6 +163,7 @@ func newPermissionsResponse(ctx context.Context, ps []platform.Permission, svc p
 			Resource: resourceResponse{
 				Resource: p.Resource,
 			},
+			Restriction: p.Restriction,
 		}
 
 		if p.Resource.ID != nil {
@@ -245,6 +260,7 @@ type postAuthorizationRequest struct {
 	UserID      *platform.ID          `json:"userID,omitempty"`
 	Description string                `json:"description"`
 	Permissions []platform.Permission `json:"permissions"`
+	ExpiresAt   *time.Time            `json:"expiresAt,omitempty"`
 }
 
 func (p *postAuthorizationRequest) toPlatform(userID platform.ID) *platform.Authorization {
@@ -254,6 +270,7 @@ func (p *postAuthorizationRequest) toPlatform(userID platform.ID) *platform.Auth
 		Description: p.Description,
 		Permissions: p.Permissions,
 		UserID:      userID,
+		ExpiresAt:   p.ExpiresAt,
 	}
 }
 
@@ -263,6 +280,7 @@ func newPostAuthorizationRequest(a *platform.Authorization) (*postAuthorizationR
 		Description: a.Description,
 		Permissions: a.Permissions,
 		Status:      a.Status,
+		ExpiresAt:   a.ExpiresAt,
 	}
 
 	if a.UserID.Valid() {
@@ -331,6 +349,118 @@ func decodePostAuthorizationRequest(ctx context.Context, r *http.Request) (*post
 	return a, a.Validate()
 }
 
+// handlePostChildAuthorization is the HTTP handler for the POST /api/v2/authorizations/:id/children route.
+// The child authorization is created from the token of the request, which must be the authorization :id.
+func (h *AuthorizationHandler) handlePostChildAuthorization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodePostChildAuthorizationRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	a, err := platcontext.GetAuthorizer(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	if parent, ok := a.(*platform.Authorization); !ok || parent.ID != req.ParentID {
+		h.HandleHTTPError(ctx, &platform.Error{
+			Code: platform.EForbidden,
+			Msg:  "child authorizations can only be created from the token of the request",
+		}, w)
+		return
+	}
+
+	auth := &platform.Authorization{
+		Description: req.Description,
+		Permissions: req.Permissions,
+		ExpiresAt:   req.ExpiresAt,
+	}
+	if err := h.ChildAuthorizationService.CreateChildAuthorization(ctx, auth); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	org, err := h.OrganizationService.FindOrganizationByID(ctx, auth.OrgID)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	user, err := h.UserService.FindUserByID(ctx, auth.UserID)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	perms, err := newPermissionsResponse(ctx, auth.Permissions, h.LookupService)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	h.log.Debug("Child auth created ", zap.String("auth", fmt.Sprint(auth)))
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newAuthResponse(auth, org, user, perms)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+type postChildAuthorizationRequest struct {
+	ParentID    platform.ID           `json:"-"`
+	Description string                `json:"description"`
+	Permissions []platform.Permission `json:"permissions"`
+	ExpiresAt   *time.Time            `json:"expiresAt,omitempty"`
+}
+
+func (p *postChildAuthorizationRequest) Validate() error {
+	if len(p.Permissions) == 0 {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "authorization must include permissions",
+		}
+	}
+
+	for _, perm := range p.Permissions {
+		if err := perm.Valid(); err != nil {
+			return &platform.Error{
+				Err: err,
+			}
+		}
+	}
+
+	return nil
+}
+
+func decodePostChildAuthorizationRequest(ctx context.Context, r *http.Request) (*postChildAuthorizationRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "url missing id",
+		}
+	}
+
+	a := &postChildAuthorizationRequest{}
+	if err := a.ParentID.DecodeFromString(id); err != nil {
+		return nil, err
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(a); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid json structure",
+			Err:  err,
+		}
+	}
+
+	return a, a.Validate()
+}
+
 // handleGetAuthorizations is the HTTP handler for the GET /api/v2/authorizations route.
 func (h *AuthorizationHandler) handleGetAuthorizations(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -634,7 +764,10 @@ type AuthorizationService struct {
 	Client *httpc.Client
 }
 
-var _ platform.AuthorizationService = (*AuthorizationService)(nil)
+var (
+	_ platform.AuthorizationService      = (*AuthorizationService)(nil)
+	_ platform.ChildAuthorizationService = (*AuthorizationService)(nil)
+)
 
 // FindAuthorizationByID finds the authorization against a remote influx server.
 func (s *AuthorizationService) FindAuthorizationByID(ctx context.Context, id platform.ID) (*platform.Authorization, error) {
@@ -705,6 +838,38 @@ func (s *AuthorizationService) CreateAuthorization(ctx context.Context, a *platf
 		Do(ctx)
 }
 
+// CreateChildAuthorization creates a short-lived authorization from the token of the client,
+// a.ParentID must be the ID of the authorization of that token.
+func (s *AuthorizationService) CreateChildAuthorization(ctx context.Context, a *platform.Authorization) error {
+	if !a.ParentID.Valid() {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "parent authorization id required",
+		}
+	}
+
+	req := &postChildAuthorizationRequest{
+		Description: a.Description,
+		Permissions: a.Permissions,
+		ExpiresAt:   a.ExpiresAt,
+	}
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	var res authResponse
+	err := s.Client.
+		PostJSON(req, prefixAuthorization, a.ParentID.String(), "children").
+		DecodeJSON(&res).
+		Do(ctx)
+	if err != nil {
+		return err
+	}
+
+	*a = *res.toPlatform()
+	return nil
+}
+
 // UpdateAuthorization updates the status and description if available.
 func (s *AuthorizationService) UpdateAuthorization(ctx context.Context, id platform.ID, upd *platform.AuthorizationUpdate) (*platform.Authorization, error) {
 	var res authResponse
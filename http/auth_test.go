@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/influxdata/httprouter"
 	platform "github.com/influxdata/influxdb"
@@ -26,10 +27,11 @@ func NewMockAuthorizationBackend(t *testing.T) *AuthorizationBackend {
 	return &AuthorizationBackend{
 		log: zaptest.NewLogger(t),
 
-		AuthorizationService: mock.NewAuthorizationService(),
-		OrganizationService:  mock.NewOrganizationService(),
-		UserService:          mock.NewUserService(),
-		LookupService:        mock.NewLookupService(),
+		AuthorizationService:      mock.NewAuthorizationService(),
+		ChildAuthorizationService: mock.NewAuthorizationService(),
+		OrganizationService:       mock.NewOrganizationService(),
+		UserService:               mock.NewUserService(),
+		LookupService:             mock.NewLookupService(),
 	}
 }
 
@@ -741,6 +743,170 @@ func TestService_handlePostAuthorization(t *testing.T) {
 	}
 }
 
+func TestService_handlePostChildAuthorization(t *testing.T) {
+	parent := &platform.Authorization{
+		Token:  "parent-token",
+		ID:     platformtesting.MustIDBase16("020f755c3c082000"),
+		Status: platform.Active,
+		UserID: platformtesting.MustIDBase16("aaaaaaaaaaaaaaaa"),
+		OrgID:  platformtesting.MustIDBase16("020f755c3c083000"),
+		Permissions: []platform.Permission{
+			{
+				Action: platform.ReadAction,
+				Resource: platform.Resource{
+					Type:  platform.DashboardsResourceType,
+					OrgID: platformtesting.IDPtr(platformtesting.MustIDBase16("020f755c3c083000")),
+				},
+			},
+		},
+	}
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		parentID string
+		child    *postChildAuthorizationRequest
+	}
+	type wants struct {
+		statusCode int
+		body       string
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "create a child authorization",
+			args: args{
+				parentID: "020f755c3c082000",
+				child: &postChildAuthorizationRequest{
+					Description: "short lived",
+					Permissions: parent.Permissions,
+					ExpiresAt:   &expiresAt,
+				},
+			},
+			wants: wants{
+				statusCode: http.StatusCreated,
+				body: `
+{
+  "createdAt": "0001-01-01T00:00:00Z",
+  "updatedAt": "0001-01-01T00:00:00Z",
+  "description": "short lived",
+  "expiresAt": "2030-01-01T00:00:00Z",
+  "id": "020f755c3c082001",
+  "links": {
+    "parent": "/api/v2/authorizations/020f755c3c082000",
+    "self": "/api/v2/authorizations/020f755c3c082001",
+    "user": "/api/v2/users/aaaaaaaaaaaaaaaa"
+  },
+  "org": "o1",
+  "orgID": "020f755c3c083000",
+  "parentID": "020f755c3c082000",
+  "permissions": [
+    {
+      "action": "read",
+      "resource": {
+        "type": "dashboards",
+        "orgID": "020f755c3c083000",
+        "org": "o1"
+      }
+    }
+  ],
+  "status": "active",
+  "token": "child-token",
+  "user": "u1",
+  "userID": "aaaaaaaaaaaaaaaa"
+}
+`,
+			},
+		},
+		{
+			name: "create a child of another authorization",
+			args: args{
+				parentID: "020f755c3c082002",
+				child: &postChildAuthorizationRequest{
+					Permissions: parent.Permissions,
+				},
+			},
+			wants: wants{
+				statusCode: http.StatusForbidden,
+			},
+		},
+		{
+			name: "create a child without permissions",
+			args: args{
+				parentID: "020f755c3c082000",
+				child:    &postChildAuthorizationRequest{},
+			},
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorizationBackend := NewMockAuthorizationBackend(t)
+			authorizationBackend.HTTPErrorHandler = kithttp.ErrorHandler(0)
+			authorizationBackend.ChildAuthorizationService = &mock.AuthorizationService{
+				CreateChildAuthorizationFn: func(ctx context.Context, a *platform.Authorization) error {
+					a.ID = platformtesting.MustIDBase16("020f755c3c082001")
+					a.Token = "child-token"
+					a.Status = platform.Active
+					a.OrgID = parent.OrgID
+					a.UserID = parent.UserID
+					a.ParentID = parent.ID
+					return nil
+				},
+			}
+			authorizationBackend.UserService = &mock.UserService{
+				FindUserByIDFn: func(ctx context.Context, id platform.ID) (*platform.User, error) {
+					return &platform.User{ID: id, Name: "u1"}, nil
+				},
+			}
+			authorizationBackend.OrganizationService = &mock.OrganizationService{
+				FindOrganizationByIDF: func(ctx context.Context, id platform.ID) (*platform.Organization, error) {
+					return &platform.Organization{ID: id, Name: "o1"}, nil
+				},
+			}
+			authorizationBackend.LookupService = &mock.LookupService{
+				NameFn: func(ctx context.Context, resource platform.ResourceType, id platform.ID) (string, error) {
+					return "o1", nil
+				},
+			}
+			h := NewAuthorizationHandler(zaptest.NewLogger(t), authorizationBackend)
+
+			b, err := json.Marshal(tt.args.child)
+			if err != nil {
+				t.Fatalf("failed to marshal child authorization: %v", err)
+			}
+
+			r := httptest.NewRequest("POST", "http://any.url/api/v2/authorizations/"+tt.args.parentID+"/children", bytes.NewReader(b))
+			r = r.WithContext(pcontext.SetAuthorizer(context.Background(), parent))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+
+			if res.StatusCode != tt.wants.statusCode {
+				t.Logf("headers: %v body: %s", res.Header, body)
+				t.Errorf("%q. handlePostChildAuthorization() = %v, want %v", tt.name, res.StatusCode, tt.wants.statusCode)
+			}
+			if tt.wants.body == "" {
+				return
+			}
+			if eq, diff, err := jsonEqual(string(body), tt.wants.body); err != nil {
+				t.Errorf("%q, handlePostChildAuthorization(). error unmarshaling json %v", tt.name, err)
+			} else if !eq {
+				t.Errorf("%q. handlePostChildAuthorization() = ***%s***", tt.name, diff)
+			}
+		})
+	}
+}
+
 func TestService_handleDeleteAuthorization(t *testing.T) {
 	type fields struct {
 		AuthorizationService platform.AuthorizationService
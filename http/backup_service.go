@@ -1,6 +1,7 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -10,14 +11,18 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/influxdata/httprouter"
 	"github.com/influxdata/influxdb"
+	backuparchive "github.com/influxdata/influxdb/backup"
 	"github.com/influxdata/influxdb/bolt"
 	"github.com/influxdata/influxdb/internal/fs"
 	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/influxdata/influxdb/tsdb/tsm1"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 )
@@ -31,6 +36,7 @@ type BackupBackend struct {
 
 	BackupService   influxdb.BackupService
 	KVBackupService influxdb.KVBackupService
+	BucketService   influxdb.BucketService
 }
 
 // NewBackupBackend returns a new instance of BackupBackend.
@@ -41,6 +47,7 @@ func NewBackupBackend(b *APIBackend) *BackupBackend {
 		HTTPErrorHandler: b.HTTPErrorHandler,
 		BackupService:    b.BackupService,
 		KVBackupService:  b.KVBackupService,
+		BucketService:    b.BucketService,
 	}
 }
 
@@ -51,6 +58,7 @@ type BackupHandler struct {
 
 	BackupService   influxdb.BackupService
 	KVBackupService influxdb.KVBackupService
+	BucketService   influxdb.BucketService
 }
 
 const (
@@ -58,6 +66,7 @@ const (
 	backupIDParamName   = "backup_id"
 	backupFileParamName = "backup_file"
 	backupFilePath      = prefixBackup + "/:" + backupIDParamName + "/file/:" + backupFileParamName
+	backupArchivePath   = prefixBackup + "/:" + backupIDParamName + "/archive"
 
 	httpClientTimeout = time.Hour
 )
@@ -66,6 +75,10 @@ func composeBackupFilePath(backupID int, backupFile string) string {
 	return path.Join(prefixBackup, fmt.Sprint(backupID), "file", fmt.Sprint(backupFile))
 }
 
+func composeBackupArchivePath(backupID int) string {
+	return path.Join(prefixBackup, fmt.Sprint(backupID), "archive")
+}
+
 // NewBackupHandler creates a new handler at /api/v2/backup to receive backup requests.
 func NewBackupHandler(b *BackupBackend) *BackupHandler {
 	h := &BackupHandler{
@@ -74,10 +87,12 @@ func NewBackupHandler(b *BackupBackend) *BackupHandler {
 		Logger:           b.Logger,
 		BackupService:    b.BackupService,
 		KVBackupService:  b.KVBackupService,
+		BucketService:    b.BucketService,
 	}
 
 	h.HandlerFunc(http.MethodPost, prefixBackup, h.handleCreate)
 	h.HandlerFunc(http.MethodGet, backupFilePath, h.handleFetchFile)
+	h.HandlerFunc(http.MethodGet, backupArchivePath, h.handleFetchArchive)
 
 	return h
 }
@@ -93,7 +108,13 @@ func (h *BackupHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
-	id, files, err := h.BackupService.CreateBackup(ctx)
+	filter, err := decodeBackupFilter(r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	id, files, err := h.BackupService.CreateBackup(ctx, filter)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
 		return
@@ -101,42 +122,67 @@ func (h *BackupHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
 
 	internalBackupPath := h.BackupService.InternalBackupPath(id)
 
-	boltPath := filepath.Join(internalBackupPath, bolt.DefaultFilename)
-	boltFile, err := os.OpenFile(boltPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0660)
-	if err != nil {
+	// The metadata includes the authorizations of every user, so it is only part of a
+	// backup of an organization or a bucket if the caller may read everything.
+	metadata, err := h.backupMetadata(ctx, internalBackupPath)
+	if err != nil && !(influxdb.ErrorCode(err) == influxdb.EUnauthorized && filter.OrgID != nil) {
 		err = multierr.Append(err, os.RemoveAll(internalBackupPath))
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
+	files = append(files, metadata...)
 
-	if err = h.KVBackupService.Backup(ctx, boltFile); err != nil {
+	b := backup{
+		ID:    id,
+		Files: files,
+	}
+	if err = json.NewEncoder(w).Encode(&b); err != nil {
 		err = multierr.Append(err, os.RemoveAll(internalBackupPath))
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
+}
 
-	files = append(files, bolt.DefaultFilename)
-
-	credsExist, err := h.backupCredentials(internalBackupPath)
+// decodeBackupFilter decodes the filter from the request body.
+// A request without a body backs up all of the data.
+func decodeBackupFilter(r *http.Request) (influxdb.BackupFilter, error) {
+	var filter influxdb.BackupFilter
+	if err := json.NewDecoder(r.Body).Decode(&filter); err != nil && err != io.EOF {
+		return filter, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid backup filter",
+			Err:  err,
+		}
+	}
+	return filter, nil
+}
 
+// backupMetadata writes the metadata database and the credentials to the backup
+// and returns the names of the files.
+func (h *BackupHandler) backupMetadata(ctx context.Context, internalBackupPath string) ([]string, error) {
+	boltPath := filepath.Join(internalBackupPath, bolt.DefaultFilename)
+	boltFile, err := os.OpenFile(boltPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0660)
 	if err != nil {
-		h.HandleHTTPError(ctx, err, w)
-		return
+		return nil, err
 	}
 
-	if credsExist {
-		files = append(files, DefaultTokenFile)
+	if err := h.KVBackupService.Backup(ctx, boltFile); err != nil {
+		boltFile.Close()
+		return nil, multierr.Append(err, os.Remove(boltPath))
 	}
+	if err := boltFile.Close(); err != nil {
+		return nil, err
+	}
+	files := []string{bolt.DefaultFilename}
 
-	b := backup{
-		ID:    id,
-		Files: files,
+	credsExist, err := h.backupCredentials(internalBackupPath)
+	if err != nil {
+		return nil, err
 	}
-	if err = json.NewEncoder(w).Encode(&b); err != nil {
-		err = multierr.Append(err, os.RemoveAll(internalBackupPath))
-		h.HandleHTTPError(ctx, err, w)
-		return
+	if credsExist {
+		files = append(files, DefaultTokenFile)
 	}
+	return files, nil
 }
 
 func (h *BackupHandler) backupCredentials(internalBackupPath string) (bool, error) {
@@ -179,6 +225,94 @@ func (h *BackupHandler) handleFetchFile(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// handleFetchArchive writes all of the files of a backup as a single archive.
+// As with fetching a single file, the files are removed once they are written.
+func (h *BackupHandler) handleFetchArchive(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "BackupHandler.handleFetchArchive")
+	defer span.Finish()
+
+	ctx := r.Context()
+
+	params := httprouter.ParamsFromContext(ctx)
+	backupID, err := strconv.Atoi(params.ByName(backupIDParamName))
+	if err != nil {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid backup id",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	internalBackupPath := h.BackupService.InternalBackupPath(backupID)
+	fis, err := ioutil.ReadDir(internalBackupPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = &influxdb.Error{
+				Code: influxdb.ENotFound,
+				Msg:  fmt.Sprintf("backup %d not found", backupID),
+			}
+		}
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	sort.Slice(fis, func(i, j int) bool { return fis[i].Name() < fis[j].Name() })
+
+	manifest := backuparchive.Manifest{
+		Version:       influxdb.GetBuildInfo().Version,
+		EngineVersion: int(tsm1.Version),
+		Created:       time.Now().UTC(),
+	}
+	for _, fi := range fis {
+		if strings.HasSuffix(fi.Name(), influxdb.BackupManifestExtension) {
+			manifest.Backup = fi.Name()
+		}
+	}
+	if manifest.Backup == "" {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  fmt.Sprintf("manifest of backup %d not found", backupID),
+		}, w)
+		return
+	}
+	if manifest.Buckets, err = h.backupBuckets(ctx, filepath.Join(internalBackupPath, manifest.Backup)); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+	aw := backuparchive.NewWriter(w, manifest)
+	for _, fi := range fis {
+		fw, err := aw.Create(fi.Name(), fi.Size())
+		if err == nil {
+			err = h.BackupService.FetchBackupFile(ctx, backupID, fi.Name(), fw)
+		}
+		if err != nil {
+			// The response has started, so the error leaves the client with an incomplete archive.
+			h.Logger.Error("Failed to write backup archive", zap.Error(err), zap.Int("backup_id", backupID), zap.String("backup_file", fi.Name()))
+			return
+		}
+	}
+	if err := aw.Close(); err != nil {
+		h.Logger.Error("Failed to write backup archive", zap.Error(err), zap.Int("backup_id", backupID))
+	}
+}
+
+// backupBuckets returns the buckets whose data is in the backup with the manifest at path.
+func (h *BackupHandler) backupBuckets(ctx context.Context, path string) ([]*influxdb.Bucket, error) {
+	var filter influxdb.BucketFilter
+	if m, err := influxdb.ReadBackupManifest(path); err != nil {
+		return nil, err
+	} else if m.BucketID != nil {
+		filter.ID = m.BucketID
+	} else if m.OrgID != nil {
+		filter.OrganizationID = m.OrgID
+	}
+
+	buckets, _, err := h.BucketService.FindBuckets(ctx, filter)
+	return buckets, err
+}
+
 // BackupService is the client implementation of influxdb.BackupService.
 type BackupService struct {
 	Addr               string
@@ -186,7 +320,7 @@ type BackupService struct {
 	InsecureSkipVerify bool
 }
 
-func (s *BackupService) CreateBackup(ctx context.Context) (int, []string, error) {
+func (s *BackupService) CreateBackup(ctx context.Context, filter influxdb.BackupFilter) (int, []string, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx)
 	defer span.Finish()
 
@@ -195,10 +329,16 @@ func (s *BackupService) CreateBackup(ctx context.Context) (int, []string, error)
 		return 0, nil, err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, u.String(), nil)
+	octets, err := json.Marshal(filter)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, u.String(), bytes.NewReader(octets))
 	if err != nil {
 		return 0, nil, err
 	}
+	req.Header.Set("Content-Type", "application/json")
 	SetToken(s.Token, req)
 	req = req.WithContext(ctx)
 
@@ -258,6 +398,40 @@ func (s *BackupService) FetchBackupFile(ctx context.Context, backupID int, backu
 	return nil
 }
 
+// FetchBackupArchive writes all of the files of a backup to w as a single archive.
+// The archive is written as it is received, so it is only valid if the archive is verified.
+func (s *BackupService) FetchBackupArchive(ctx context.Context, backupID int, w io.Writer) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := NewURL(s.Addr, composeBackupArchivePath(backupID))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	SetToken(s.Token, req)
+	req = req.WithContext(ctx)
+
+	hc := NewClient(u.Scheme, s.InsecureSkipVerify)
+	hc.Timeout = httpClientTimeout
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
 func defaultTokenPath() (string, error) {
 	dir, err := fs.InfluxDir()
 	if err != nil {
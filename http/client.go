@@ -43,6 +43,7 @@ type Service struct {
 
 	*AuthorizationService
 	*BackupService
+	*RestoreService
 	*BucketService
 	*TaskService
 	*DashboardService
@@ -81,6 +82,10 @@ func NewService(httpClient *httpc.Client, addr, token string) (*Service, error)
 			Addr:  addr,
 			Token: token,
 		},
+		RestoreService: &RestoreService{
+			Addr:  addr,
+			Token: token,
+		},
 		BucketService:           &BucketService{Client: httpClient},
 		TaskService:             &TaskService{Client: httpClient},
 		DashboardService:        &DashboardService{Client: httpClient},
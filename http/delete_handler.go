@@ -114,6 +114,28 @@ func (h *DeleteHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if dr.DryRun {
+		// estimate the points that would be deleted without deleting them
+		estimates, err := h.DeleteService.EstimateDeleteBucketRangePredicate(ctx,
+			dr.Org.ID,
+			dr.Bucket.ID,
+			dr.Start,
+			dr.Stop,
+			dr.Predicate,
+		)
+		if err != nil {
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
+		if estimates == nil {
+			estimates = []influxdb.DeleteEstimate{}
+		}
+		if err := encodeResponse(ctx, w, http.StatusOK, deleteEstimateResponse{Measurements: estimates}); err != nil {
+			logEncodingError(h.log, r, err)
+		}
+		return
+	}
+
 	// send delete points request to storage
 	err = h.DeleteService.DeleteBucketRangePredicate(ctx,
 		dr.Org.ID,
@@ -160,12 +182,19 @@ type deleteRequest struct {
 	Start     int64
 	Stop      int64
 	Predicate influxdb.Predicate
+	DryRun    bool
 }
 
 type deleteRequestDecode struct {
 	Start     string `json:"start"`
 	Stop      string `json:"stop"`
 	Predicate string `json:"predicate"`
+	DryRun    bool   `json:"dryRun"`
+}
+
+// deleteEstimateResponse is the response to a dry run of a delete.
+type deleteEstimateResponse struct {
+	Measurements []influxdb.DeleteEstimate `json:"measurements"`
 }
 
 // DeleteRequest is the request send over http to delete points.
@@ -177,6 +206,7 @@ type DeleteRequest struct {
 	Start     string `json:"start"`
 	Stop      string `json:"stop"`
 	Predicate string `json:"predicate"`
+	DryRun    bool   `json:"dryRun,omitempty"`
 }
 
 func (dr *deleteRequest) UnmarshalJSON(b []byte) error {
@@ -188,7 +218,7 @@ func (dr *deleteRequest) UnmarshalJSON(b []byte) error {
 			Err:  err,
 		}
 	}
-	*dr = deleteRequest{}
+	*dr = deleteRequest{DryRun: drd.DryRun}
 	start, err := time.Parse(time.RFC3339Nano, drd.Start)
 	if err != nil {
 		return &influxdb.Error{
@@ -225,17 +255,49 @@ type DeleteService struct {
 
 // DeleteBucketRangePredicate send delete request over http to delete points.
 func (s *DeleteService) DeleteBucketRangePredicate(ctx context.Context, dr DeleteRequest) error {
-	u, err := NewURL(s.Addr, prefixDelete)
+	dr.DryRun = false
+	resp, err := s.do(ctx, dr)
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
+
+	return CheckError(resp)
+}
+
+// EstimateDeleteBucketRangePredicate sends a dry run of the delete request over http
+// and returns the data that would be deleted from each measurement.
+func (s *DeleteService) EstimateDeleteBucketRangePredicate(ctx context.Context, dr DeleteRequest) ([]influxdb.DeleteEstimate, error) {
+	dr.DryRun = true
+	resp, err := s.do(ctx, dr)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var res deleteEstimateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, err
+	}
+	return res.Measurements, nil
+}
+
+func (s *DeleteService) do(ctx context.Context, dr DeleteRequest) (*http.Response, error) {
+	u, err := NewURL(s.Addr, prefixDelete)
+	if err != nil {
+		return nil, err
+	}
 	buf := new(bytes.Buffer)
 	if err := json.NewEncoder(buf).Encode(dr); err != nil {
-		return err
+		return nil, err
 	}
 	req, err := http.NewRequest("POST", u.String(), buf)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
@@ -257,11 +319,5 @@ func (s *DeleteService) DeleteBucketRangePredicate(ctx context.Context, dr Delet
 
 	hc := NewClient(u.Scheme, s.InsecureSkipVerify)
 
-	resp, err := hc.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	return CheckError(resp)
+	return hc.Do(req.WithContext(ctx))
 }
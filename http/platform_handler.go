@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	kithttp "github.com/influxdata/influxdb/kit/transport/http"
+	"go.uber.org/zap"
 )
 
 // PlatformHandler is a collection of all the service handlers.
@@ -18,6 +19,9 @@ type PlatformHandler struct {
 func NewPlatformHandler(b *APIBackend, opts ...APIHandlerOptFn) *PlatformHandler {
 	h := NewAuthenticationHandler(b.Logger, b.HTTPErrorHandler)
 	h.Handler = NewAPIHandler(b, opts...)
+	if b.AuditService != nil {
+		h.Handler = NewAuditRecordingHandler(b.Logger.With(zap.String("handler", "audit_recording")), b.AuditService, h.Handler)
+	}
 	h.AuthorizationService = b.AuthorizationService
 	h.SessionService = b.SessionService
 	h.SessionRenewDisabled = b.SessionRenewDisabled
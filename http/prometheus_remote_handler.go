@@ -0,0 +1,334 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/influxdata/influxdb/prometheus/remote"
+	"github.com/influxdata/influxdb/storage"
+	"github.com/influxdata/influxdb/storage/reads"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
+)
+
+// PrometheusRemoteBackend is all services and associated parameters required to construct
+// the PrometheusRemoteHandler.
+type PrometheusRemoteBackend struct {
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	MaxBatchSizeBytes int64
+
+	PointsWriter        storage.PointsWriter
+	ReadStore           reads.Store
+	BucketService       influxdb.BucketService
+	OrganizationService influxdb.OrganizationService
+}
+
+// NewPrometheusRemoteBackend returns a new instance of PrometheusRemoteBackend.
+func NewPrometheusRemoteBackend(log *zap.Logger, b *APIBackend) *PrometheusRemoteBackend {
+	return &PrometheusRemoteBackend{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		log:              log,
+
+		MaxBatchSizeBytes: b.MaxBatchSizeBytes,
+
+		PointsWriter:        b.PointsWriter,
+		ReadStore:           b.ReadStore,
+		BucketService:       b.BucketService,
+		OrganizationService: b.OrganizationService,
+	}
+}
+
+// PrometheusRemoteHandler serves the remote write and remote read requests of Prometheus servers.
+type PrometheusRemoteHandler struct {
+	*httprouter.Router
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	maxBatchSizeBytes int64
+
+	PointsWriter        storage.PointsWriter
+	ReadStore           reads.Store
+	BucketService       influxdb.BucketService
+	OrganizationService influxdb.OrganizationService
+}
+
+const (
+	prefixPrometheus    = "/api/v2/prometheus"
+	prometheusWritePath = "/api/v2/prometheus/write"
+	prometheusReadPath  = "/api/v2/prometheus/read"
+)
+
+// NewPrometheusRemoteHandler creates a new handler at /api/v2/prometheus to receive
+// the remote write and remote read requests of Prometheus.
+func NewPrometheusRemoteHandler(log *zap.Logger, b *PrometheusRemoteBackend) *PrometheusRemoteHandler {
+	h := &PrometheusRemoteHandler{
+		Router:           NewRouter(b.HTTPErrorHandler),
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		log:              log,
+
+		maxBatchSizeBytes: b.MaxBatchSizeBytes,
+
+		PointsWriter:        b.PointsWriter,
+		ReadStore:           b.ReadStore,
+		BucketService:       b.BucketService,
+		OrganizationService: b.OrganizationService,
+	}
+
+	h.HandlerFunc("POST", prometheusWritePath, h.handleWrite)
+	h.HandlerFunc("POST", prometheusReadPath, h.handleRead)
+	return h
+}
+
+// Prefix provides the route prefix.
+func (*PrometheusRemoteHandler) Prefix() string {
+	return prefixPrometheus
+}
+
+func (h *PrometheusRemoteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "PrometheusRemoteHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+	defer r.Body.Close()
+
+	a, org, bucket, p, err := h.authorize(ctx, r, influxdb.WriteAction)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	var req remote.WriteRequest
+	if err := h.decodeRequest(ctx, r, &req); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	points, err := remote.Points(&req)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	// a token restricted to some of the data of the bucket can only write that data.
+	if rs := influxdb.AuthorizerDataRestrictions(a, *p); rs != nil {
+		for _, pt := range points {
+			m, tags := string(pt.Name()), pt.Tags()
+			if !rs.Allows(m, tags.GetString) {
+				h.HandleHTTPError(ctx, &influxdb.Error{
+					Code: influxdb.EForbidden,
+					Op:   "http/handlePrometheusWrite",
+					Msg:  fmt.Sprintf("insufficient permissions to write measurement %q", m),
+				}, w)
+				return
+			}
+		}
+	}
+
+	points, err = tsdb.ExplodePoints(org.ID, bucket.ID, points)
+	if err != nil {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Op:   "http/handlePrometheusWrite",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	if err := h.PointsWriter.WritePoints(ctx, points); err != nil {
+		h.log.Error("Error writing points", zap.Error(err))
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EInternal,
+			Op:   "http/handlePrometheusWrite",
+			Msg:  "unexpected error writing points to database",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *PrometheusRemoteHandler) handleRead(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "PrometheusRemoteHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+	defer r.Body.Close()
+
+	a, org, bucket, p, err := h.authorize(ctx, r, influxdb.ReadAction)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	var req remote.ReadRequest
+	if err := h.decodeRequest(ctx, r, &req); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	// a token restricted to some of the data of the bucket only reads that data.
+	rs := influxdb.AuthorizerDataRestrictions(a, *p)
+
+	resp := &remote.ReadResponse{
+		Results: make([]*remote.QueryResult, 0, len(req.Queries)),
+	}
+	for _, q := range req.Queries {
+		res, err := remote.Read(ctx, h.ReadStore, org.ID, bucket.ID, q, rs)
+		if err != nil {
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
+		resp.Results = append(resp.Results, res)
+	}
+
+	data, err := proto.Marshal(resp)
+	if err != nil {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EInternal,
+			Op:   "http/handlePrometheusRead",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.Header().Set("Content-Encoding", "snappy")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(snappy.Encode(nil, data)); err != nil {
+		logEncodingError(h.log, r, err)
+	}
+}
+
+// authorize returns the authorizer, organization and bucket of the request,
+// and the permission of the action on the bucket the authorizer is allowed.
+func (h *PrometheusRemoteHandler) authorize(ctx context.Context, r *http.Request, action influxdb.Action) (influxdb.Authorizer, *influxdb.Organization, *influxdb.Bucket, *influxdb.Permission, error) {
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	org, err := queryOrganization(ctx, r, h.OrganizationService)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	bucket, err := h.findBucket(ctx, org.ID, r.URL.Query().Get(Bucket))
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	p, err := influxdb.NewPermissionAtID(bucket.ID, action, influxdb.BucketsResourceType, org.ID)
+	if err != nil {
+		return nil, nil, nil, nil, &influxdb.Error{
+			Code: influxdb.EInternal,
+			Msg:  fmt.Sprintf("unable to create permission for bucket: %v", err),
+			Err:  err,
+		}
+	}
+
+	if !a.Allowed(*p) {
+		return nil, nil, nil, nil, &influxdb.Error{
+			Code: influxdb.EForbidden,
+			Msg:  fmt.Sprintf("insufficient permissions to %s the bucket", action),
+		}
+	}
+	return a, org, bucket, p, nil
+}
+
+// findBucket finds the bucket of the organization by its ID or its name.
+func (h *PrometheusRemoteHandler) findBucket(ctx context.Context, orgID influxdb.ID, bucket string) (*influxdb.Bucket, error) {
+	if bucket == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "bucket parameter is required",
+		}
+	}
+
+	if id, err := influxdb.IDFromString(bucket); err == nil {
+		// Decoded ID successfully. Make sure it's a real bucket.
+		b, err := h.BucketService.FindBucket(ctx, influxdb.BucketFilter{
+			OrganizationID: &orgID,
+			ID:             id,
+		})
+		if err == nil {
+			return b, nil
+		} else if influxdb.ErrorCode(err) != influxdb.ENotFound {
+			return nil, err
+		}
+	}
+
+	return h.BucketService.FindBucket(ctx, influxdb.BucketFilter{
+		OrganizationID: &orgID,
+		Name:           &bucket,
+	})
+}
+
+// decodeRequest decodes the snappy compressed protobuf message of the body of the request.
+func (h *PrometheusRemoteHandler) decodeRequest(ctx context.Context, r *http.Request, pb proto.Message) error {
+	rc := r.Body
+	if h.maxBatchSizeBytes > 0 {
+		rc = newLimitedReadCloser(rc, h.maxBatchSizeBytes)
+	}
+
+	compressed, err := ioutil.ReadAll(rc)
+	if err == nil {
+		err = rc.Close()
+	}
+	if err != nil {
+		code := influxdb.EInternal
+		if errors.Is(err, ErrMaxBatchSizeExceeded) {
+			code = influxdb.ETooLarge
+		}
+		return &influxdb.Error{
+			Code: code,
+			Msg:  "unable to read data",
+			Err:  err,
+		}
+	}
+
+	n, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "body is not snappy compressed",
+			Err:  err,
+		}
+	}
+	if h.maxBatchSizeBytes > 0 && int64(n) > h.maxBatchSizeBytes {
+		return &influxdb.Error{
+			Code: influxdb.ETooLarge,
+			Msg:  "unable to read data",
+			Err:  ErrMaxBatchSizeExceeded,
+		}
+	}
+
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "body is not snappy compressed",
+			Err:  err,
+		}
+	}
+
+	if err := proto.Unmarshal(data, pb); err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid protobuf message",
+			Err:  err,
+		}
+	}
+	return nil
+}
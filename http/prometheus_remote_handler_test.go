@@ -0,0 +1,229 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	httpmock "github.com/influxdata/influxdb/http/mock"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/prometheus/remote"
+	"github.com/influxdata/influxdb/storage/reads"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+	influxtesting "github.com/influxdata/influxdb/testing"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestPrometheusRemoteHandler_handleWrite(t *testing.T) {
+	series := []*remote.TimeSeries{
+		{
+			Labels:  []*remote.Label{{Name: "__name__", Value: "up"}, {Name: "job", Value: "node"}},
+			Samples: []*remote.Sample{{Value: 1, Timestamp: 1575194400000}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		auth   influxdb.Authorizer
+		body   []byte
+		code   int
+		points []string
+	}{
+		{
+			name:   "series are written",
+			auth:   bucketWritePermission("043e0780ee2b1000", "04504b356e23b000"),
+			body:   snappyMessage(t, &remote.WriteRequest{Timeseries: series}),
+			code:   204,
+			points: explodedPoints(t, "043e0780ee2b1000", "04504b356e23b000", "up,job=node value=1 1575194400000000000"),
+		},
+		{
+			name: "missing permission is forbidden",
+			auth: bucketWritePermission("043e0780ee2b1000", "043e0780ee2b1000"),
+			body: snappyMessage(t, &remote.WriteRequest{Timeseries: series}),
+			code: 403,
+		},
+		{
+			name: "restricted measurement is forbidden",
+			auth: restrictedBucketWritePermission("043e0780ee2b1000", "04504b356e23b000", "node_load1"),
+			body: snappyMessage(t, &remote.WriteRequest{Timeseries: series}),
+			code: 403,
+		},
+		{
+			name: "uncompressed body is invalid",
+			auth: bucketWritePermission("043e0780ee2b1000", "04504b356e23b000"),
+			body: []byte("up,job=node value=1"),
+			code: 400,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pw := &mock.PointsWriter{}
+			h := newPrometheusRemoteTestHandler(t, pw, nil)
+
+			r := httptest.NewRequest("POST", "http://localhost:9999/api/v2/prometheus/write?org=043e0780ee2b1000&bucket=04504b356e23b000", bytes.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			httpmock.NewAuthMiddlewareHandler(h, tt.auth).ServeHTTP(w, r)
+			if got, want := w.Code, tt.code; got != want {
+				t.Fatalf("unexpected status code: got %d want %d: %s", got, want, w.Body.String())
+			}
+
+			var points []string
+			for _, pt := range pw.Points {
+				points = append(points, pt.String())
+			}
+			if diff := cmp.Diff(tt.points, points); diff != "" {
+				t.Errorf("unexpected points -want/+got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrometheusRemoteHandler_handleRead(t *testing.T) {
+	store := &prometheusTestStore{
+		tags:       models.ParseTags([]byte("m,_field=value,_measurement=up,job=node")),
+		timestamps: []int64{1575194400000000000},
+		values:     []float64{1},
+	}
+	h := newPrometheusRemoteTestHandler(t, &mock.PointsWriter{}, store)
+
+	body := snappyMessage(t, &remote.ReadRequest{
+		Queries: []*remote.Query{{
+			StartTimestampMs: 1575194100000,
+			EndTimestampMs:   1575194400000,
+			Matchers:         []*remote.LabelMatcher{{Type: remote.MatchEqual, Name: "__name__", Value: "up"}},
+		}},
+	})
+	r := httptest.NewRequest("POST", "http://localhost:9999/api/v2/prometheus/read?org=043e0780ee2b1000&bucket=04504b356e23b000", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	httpmock.NewAuthMiddlewareHandler(h, bucketReadPermission("043e0780ee2b1000", "04504b356e23b000")).ServeHTTP(w, r)
+	if got, want := w.Code, 200; got != want {
+		t.Fatalf("unexpected status code: got %d want %d: %s", got, want, w.Body.String())
+	}
+	if got, want := w.Header().Get("Content-Encoding"), "snappy"; got != want {
+		t.Errorf("unexpected content encoding: got %s want %s", got, want)
+	}
+
+	data, err := snappy.Decode(nil, w.Body.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got remote.ReadResponse
+	if err := proto.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := remote.ReadResponse{
+		Results: []*remote.QueryResult{{
+			Timeseries: []*remote.TimeSeries{{
+				Labels:  []*remote.Label{{Name: "__name__", Value: "up"}, {Name: "job", Value: "node"}},
+				Samples: []*remote.Sample{{Value: 1, Timestamp: 1575194400000}},
+			}},
+		}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected response -want/+got:\n%s", diff)
+	}
+}
+
+func newPrometheusRemoteTestHandler(t *testing.T, pw *mock.PointsWriter, store reads.Store) *PrometheusRemoteHandler {
+	orgs := mock.NewOrganizationService()
+	orgs.FindOrganizationF = func(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+		return testOrg("043e0780ee2b1000"), nil
+	}
+	buckets := mock.NewBucketService()
+	buckets.FindBucketFn = func(context.Context, influxdb.BucketFilter) (*influxdb.Bucket, error) {
+		return testBucket("043e0780ee2b1000", "04504b356e23b000"), nil
+	}
+
+	b := &APIBackend{
+		HTTPErrorHandler:    DefaultErrorHandler,
+		Logger:              zaptest.NewLogger(t),
+		OrganizationService: orgs,
+		BucketService:       buckets,
+		PointsWriter:        pw,
+		ReadStore:           store,
+	}
+	return NewPrometheusRemoteHandler(zaptest.NewLogger(t), NewPrometheusRemoteBackend(zaptest.NewLogger(t), b))
+}
+
+func bucketReadPermission(org, bucket string) *influxdb.Authorization {
+	a := bucketWritePermission(org, bucket)
+	a.Permissions[0].Action = influxdb.ReadAction
+	return a
+}
+
+// explodedPoints returns the points of the line protocol as written to the bucket.
+func explodedPoints(t *testing.T, org, bucket, lp string) []string {
+	encoded := tsdb.EncodeName(influxtesting.MustIDBase16(org), influxtesting.MustIDBase16(bucket))
+	points, err := models.ParsePoints([]byte(lp), models.EscapeMeasurement(encoded[:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ss []string
+	for _, pt := range points {
+		ss = append(ss, pt.String())
+	}
+	return ss
+}
+
+func snappyMessage(t *testing.T, pb proto.Message) []byte {
+	data, err := proto.Marshal(pb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return snappy.Encode(nil, data)
+}
+
+// prometheusTestStore is a store of a single float series.
+type prometheusTestStore struct {
+	reads.Store
+	tags       models.Tags
+	timestamps []int64
+	values     []float64
+	done       bool
+}
+
+func (s *prometheusTestStore) ReadFilter(ctx context.Context, req *datatypes.ReadFilterRequest) (reads.ResultSet, error) {
+	return s, nil
+}
+
+func (s *prometheusTestStore) GetSource(orgID, bucketID uint64) proto.Message {
+	return &datatypes.ReadFilterRequest{}
+}
+
+func (s *prometheusTestStore) Next() bool {
+	next := !s.done
+	s.done = true
+	return next
+}
+
+func (s *prometheusTestStore) Cursor() cursors.Cursor {
+	return &prometheusTestCursor{a: &cursors.FloatArray{Timestamps: s.timestamps, Values: s.values}}
+}
+
+func (s *prometheusTestStore) Tags() models.Tags          { return s.tags }
+func (s *prometheusTestStore) Close()                     {}
+func (s *prometheusTestStore) Err() error                 { return nil }
+func (s *prometheusTestStore) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+type prometheusTestCursor struct {
+	a *cursors.FloatArray
+}
+
+func (c *prometheusTestCursor) Next() *cursors.FloatArray {
+	a := c.a
+	c.a = &cursors.FloatArray{}
+	return a
+}
+
+func (c *prometheusTestCursor) Close()                     {}
+func (c *prometheusTestCursor) Err() error                 { return nil }
+func (c *prometheusTestCursor) Stats() cursors.CursorStats { return cursors.CursorStats{} }
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 	"unicode/utf8"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/influxdata/influxdb/jsonweb"
 	"github.com/influxdata/influxdb/query"
 	transpiler "github.com/influxdata/influxdb/query/influxql"
+	"github.com/influxdata/influxdb/query/promql"
 	"github.com/influxdata/influxql"
 )
 
@@ -38,9 +40,15 @@ type QueryRequest struct {
 	AST     *ast.Package `json:"ast,omitempty"`
 	Dialect QueryDialect `json:"dialect"`
 
-	// InfluxQL fields
+	// InfluxQL and PromQL fields
 	Bucket string `json:"bucket,omitempty"`
 
+	// PromQL fields, a range query from Start to End every Step
+	// or an instant query at End without Start and Step.
+	Start *time.Time `json:"start,omitempty"`
+	End   *time.Time `json:"end,omitempty"`
+	Step  string     `json:"step,omitempty"`
+
 	Org *influxdb.Organization `json:"-"`
 
 	// PreferNoContent specifies if the Response to this request should
@@ -59,6 +67,47 @@ type QueryRequest struct {
 	// To obtain a QueryRequest with no result but runtime errors,
 	// add the header `Prefer: return-no-content-with-error` to the HTTP request.
 	PreferNoContentWithError bool
+
+	// Accept is the media type of the response to a flux query as chosen by
+	// the `Accept` header of the HTTP request, JSON, Apache Arrow IPC stream
+	// or line protocol. The response is annotated CSV when it is empty.
+	Accept string `json:"-"`
+}
+
+// The media types of the responses to flux queries other than annotated CSV.
+const (
+	acceptJSON         = "application/json"
+	acceptArrow        = "application/vnd.apache.arrow.stream"
+	acceptLineProtocol = "text/plain"
+)
+
+// negotiateAccept returns the media type of the response acceptable to the `Accept` header
+// with the highest quality, the empty string for annotated CSV.
+func negotiateAccept(header string) string {
+	accept, quality := "", 0.0
+	for _, v := range strings.Split(header, ",") {
+		mt, params, err := mime.ParseMediaType(v)
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		if q <= quality {
+			continue
+		}
+
+		switch mt {
+		case "text/csv", "application/csv", "text/*", "*/*":
+			accept, quality = "", q
+		case acceptJSON, acceptArrow, acceptLineProtocol:
+			accept, quality = mt, q
+		}
+	}
+	return accept
 }
 
 // QueryDialect is the formatting options for the query response.
@@ -103,12 +152,26 @@ func (r QueryRequest) Validate() error {
 		}
 	}
 
-	if r.Type != "flux" && r.Type != "influxql" {
+	if r.Type != "flux" && r.Type != "influxql" && r.Type != "promql" {
 		return fmt.Errorf(`unknown query type: %s`, r.Type)
 	}
 
-	if r.Type == "influxql" && r.Bucket == "" {
-		return fmt.Errorf("bucket parameter is required for influxql queries")
+	if (r.Type == "influxql" || r.Type == "promql") && r.Bucket == "" {
+		return fmt.Errorf("bucket parameter is required for %s queries", r.Type)
+	}
+
+	if r.Type == "promql" {
+		if r.Query == "" {
+			return fmt.Errorf("query parameter is required for promql queries")
+		}
+		if (r.Start == nil) != (r.Step == "") {
+			return fmt.Errorf("start and step parameters are both required for promql range queries")
+		}
+		if r.Step != "" {
+			if _, err := parsePromQLStep(r.Step); err != nil {
+				return err
+			}
+		}
 	}
 
 	if len(r.Dialect.CommentPrefix) > 1 {
@@ -161,6 +224,8 @@ func (r QueryRequest) Analyze() (*QueryAnalysis, error) {
 		return r.analyzeFluxQuery()
 	case "influxql":
 		return r.analyzeInfluxQLQuery()
+	case "promql":
+		return r.analyzePromQLQuery()
 	}
 
 	return nil, fmt.Errorf("unknown query request type %s", r.Type)
@@ -243,6 +308,58 @@ func columnFromCharacter(q string, char int) int {
 
 var influxqlParseErrorRE = regexp.MustCompile(`^(.+) at line (\d+), char (\d+)$`)
 
+func (r QueryRequest) analyzePromQLQuery() (*QueryAnalysis, error) {
+	a := &QueryAnalysis{
+		Errors: []queryParseError{},
+	}
+	if _, err := promql.ParsePromQL(r.Query); err != nil {
+		a.Errors = append(a.Errors, queryParseError{
+			Message: err.Error(),
+		})
+	}
+	return a, nil
+}
+
+// parsePromQLStep parses the step of a PromQL range query, a duration or a number of seconds.
+func parsePromQLStep(s string) (time.Duration, error) {
+	step, err := time.ParseDuration(s)
+	if err != nil {
+		secs, ferr := strconv.ParseFloat(s, 64)
+		if ferr != nil {
+			return 0, fmt.Errorf("invalid promql step %q: must be a duration or a number of seconds", s)
+		}
+		step = time.Duration(secs * float64(time.Second))
+	}
+	if step <= 0 {
+		return 0, fmt.Errorf("invalid promql step %q: must be positive", s)
+	}
+	return step, nil
+}
+
+// promqlCompiler returns the compiler of a PromQL query, evaluated now unless the request has an end.
+func (r QueryRequest) promqlCompiler(now func() time.Time) (*promql.Compiler, error) {
+	c := &promql.Compiler{
+		Query:  r.Query,
+		Bucket: r.Bucket,
+		End:    now(),
+	}
+	if r.End != nil {
+		c.End = *r.End
+	}
+	if r.Start != nil {
+		step, err := parsePromQLStep(r.Step)
+		if err != nil {
+			return nil, err
+		}
+		if c.End.Before(*r.Start) {
+			return nil, fmt.Errorf("invalid promql range query: end is before start")
+		}
+		c.Start = *r.Start
+		c.Step = step
+	}
+	return c, nil
+}
+
 // ProxyRequest returns a request to proxy from the flux.
 func (r QueryRequest) ProxyRequest() (*query.ProxyRequest, error) {
 	return r.proxyRequest(time.Now)
@@ -263,6 +380,12 @@ func (r QueryRequest) proxyRequest(now func() time.Time) (*query.ProxyRequest, e
 				Query:  r.Query,
 				Bucket: r.Bucket,
 			}
+		case "promql":
+			c, err := r.promqlCompiler(now)
+			if err != nil {
+				return nil, err
+			}
+			compiler = c
 		case "flux":
 			fallthrough
 		default:
@@ -298,25 +421,52 @@ func (r QueryRequest) proxyRequest(now func() time.Time) (*query.ProxyRequest, e
 	if r.PreferNoContent {
 		dialect = &query.NoContentDialect{}
 	} else {
-		if r.Type == "influxql" {
+		switch r.Type {
+		case "influxql":
 			// Use default transpiler dialect
 			dialect = &transpiler.Dialect{}
-		} else {
-			// TODO(nathanielc): Use commentPrefix and dateTimeFormat
-			// once they are supported.
+		case "promql":
+			c := compiler.(*promql.Compiler)
+			rt, err := c.ResultType()
+			if err != nil {
+				return nil, err
+			}
+			dialect = &promql.Dialect{
+				ResultType: rt,
+				Time:       c.End,
+			}
+		default:
 			encConfig := csv.ResultEncoderConfig{
 				NoHeader:    noHeader,
 				Delimiter:   delimiter,
 				Annotations: r.Dialect.Annotations,
 			}
-			if r.PreferNoContentWithError {
+			switch {
+			case r.PreferNoContentWithError:
 				dialect = &query.NoContentWithErrorDialect{
 					ResultEncoderConfig: encConfig,
 				}
-			} else {
+			case r.Accept == acceptJSON:
+				dialect = &query.JSONDialect{
+					DateTimeFormat: r.Dialect.DateTimeFormat,
+				}
+			case r.Accept == acceptArrow:
+				dialect = &query.ArrowDialect{}
+			case r.Accept == acceptLineProtocol:
+				dialect = &query.LineProtocolDialect{}
+			case (r.Dialect.CommentPrefix == "" || r.Dialect.CommentPrefix == "#") && r.Dialect.DateTimeFormat == query.RFC3339DateTimeFormat:
+				// the csv dialect of flux encodes the default comment prefix and date time format.
 				dialect = &csv.Dialect{
 					ResultEncoderConfig: encConfig,
 				}
+			default:
+				dialect = &query.CSVDialect{
+					Annotations:    encConfig.Annotations,
+					NoHeader:       encConfig.NoHeader,
+					Delimiter:      encConfig.Delimiter,
+					DateTimeFormat: r.Dialect.DateTimeFormat,
+					CommentPrefix:  r.Dialect.CommentPrefix,
+				}
 			}
 		}
 	}
@@ -356,6 +506,20 @@ func QueryRequestFromProxyRequest(req *query.ProxyRequest) (*QueryRequest, error
 		qr.Dialect.CommentPrefix = "#"
 		qr.Dialect.DateTimeFormat = "RFC3339"
 		qr.Dialect.Annotations = d.ResultEncoderConfig.Annotations
+	case *query.CSVDialect:
+		var header = !d.NoHeader
+		qr.Dialect.Header = &header
+		qr.Dialect.Delimiter = string(d.Delimiter)
+		qr.Dialect.CommentPrefix = d.CommentPrefix
+		qr.Dialect.DateTimeFormat = d.DateTimeFormat
+		qr.Dialect.Annotations = d.Annotations
+	case *query.JSONDialect:
+		qr.Accept = acceptJSON
+		qr.Dialect.DateTimeFormat = d.DateTimeFormat
+	case *query.ArrowDialect:
+		qr.Accept = acceptArrow
+	case *query.LineProtocolDialect:
+		qr.Accept = acceptLineProtocol
 	case *query.NoContentDialect:
 		qr.PreferNoContent = true
 	case *query.NoContentWithErrorDialect:
@@ -399,6 +563,7 @@ func decodeQueryRequest(ctx context.Context, r *http.Request, svc influxdb.Organ
 	case query.PreferNoContentWErrHeaderValue:
 		req.PreferNoContentWithError = true
 	}
+	req.Accept = negotiateAccept(r.Header.Get("Accept"))
 
 	req = req.WithDefaults()
 	if err := req.Validate(); err != nil {
@@ -389,8 +389,12 @@ func (s *FluxService) Query(ctx context.Context, w io.Writer, r *query.ProxyRequ
 
 	SetToken(s.Token, hreq)
 
+	accept := "text/csv"
+	if qreq.Accept != "" {
+		accept = qreq.Accept
+	}
 	hreq.Header.Set("Content-Type", "application/json")
-	hreq.Header.Set("Accept", "text/csv")
+	hreq.Header.Set("Accept", accept)
 	if r.Request.Source != "" {
 		hreq.Header.Add("User-Agent", r.Request.Source)
 	} else if s.Name != "" {
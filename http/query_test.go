@@ -21,6 +21,7 @@ import (
 	"github.com/influxdata/influxdb/mock"
 	"github.com/influxdata/influxdb/query"
 	_ "github.com/influxdata/influxdb/query/builtin"
+	"github.com/influxdata/influxdb/query/promql"
 )
 
 var cmpOptions = cmp.Options{
@@ -81,6 +82,9 @@ func TestQueryRequest_Validate(t *testing.T) {
 		AST     *ast.Package
 		Query   string
 		Type    string
+		Bucket  string
+		Start   *time.Time
+		Step    string
 		Dialect QueryDialect
 		org     *platform.Organization
 	}
@@ -185,6 +189,61 @@ func TestQueryRequest_Validate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "promql requires bucket",
+			fields: fields{
+				Query: "up",
+				Type:  "promql",
+				Dialect: QueryDialect{
+					Delimiter:      ",",
+					DateTimeFormat: "RFC3339",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "promql range query requires step",
+			fields: fields{
+				Query:  "up",
+				Type:   "promql",
+				Bucket: "metrics",
+				Start:  &time.Time{},
+				Dialect: QueryDialect{
+					Delimiter:      ",",
+					DateTimeFormat: "RFC3339",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "promql step must be positive",
+			fields: fields{
+				Query:  "up",
+				Type:   "promql",
+				Bucket: "metrics",
+				Start:  &time.Time{},
+				Step:   "-15s",
+				Dialect: QueryDialect{
+					Delimiter:      ",",
+					DateTimeFormat: "RFC3339",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid promql range query",
+			fields: fields{
+				Query:  "up",
+				Type:   "promql",
+				Bucket: "metrics",
+				Start:  &time.Time{},
+				Step:   "15",
+				Dialect: QueryDialect{
+					Delimiter:      ",",
+					DateTimeFormat: "RFC3339",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -194,6 +253,9 @@ func TestQueryRequest_Validate(t *testing.T) {
 				AST:     tt.fields.AST,
 				Query:   tt.fields.Query,
 				Type:    tt.fields.Type,
+				Bucket:  tt.fields.Bucket,
+				Start:   tt.fields.Start,
+				Step:    tt.fields.Step,
 				Dialect: tt.fields.Dialect,
 				Org:     tt.fields.org,
 			}
@@ -205,13 +267,18 @@ func TestQueryRequest_Validate(t *testing.T) {
 }
 
 func TestQueryRequest_proxyRequest(t *testing.T) {
+	promqlStart := time.Unix(0, 0)
 	type fields struct {
 		Extern  *ast.File
 		Spec    *flux.Spec
 		AST     *ast.Package
 		Query   string
 		Type    string
+		Bucket  string
+		Start   *time.Time
+		Step    string
 		Dialect QueryDialect
+		Accept  string
 		org     *platform.Organization
 	}
 	tests := []struct {
@@ -255,6 +322,64 @@ func TestQueryRequest_proxyRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid promql instant query",
+			fields: fields{
+				Query:  `up{job="influxd"}`,
+				Type:   "promql",
+				Bucket: "metrics",
+				Dialect: QueryDialect{
+					Delimiter:      ",",
+					DateTimeFormat: "RFC3339",
+				},
+				org: &platform.Organization{},
+			},
+			now: func() time.Time { return time.Unix(1, 1) },
+			want: &query.ProxyRequest{
+				Request: query.Request{
+					Compiler: &promql.Compiler{
+						Query:  `up{job="influxd"}`,
+						Bucket: "metrics",
+						End:    time.Unix(1, 1),
+					},
+				},
+				Dialect: &promql.Dialect{
+					ResultType: promql.VectorResult,
+					Time:       time.Unix(1, 1),
+				},
+			},
+		},
+		{
+			name: "valid promql range query",
+			fields: fields{
+				Query:  `sum(up)`,
+				Type:   "promql",
+				Bucket: "metrics",
+				Start:  &promqlStart,
+				Step:   "1m",
+				Dialect: QueryDialect{
+					Delimiter:      ",",
+					DateTimeFormat: "RFC3339",
+				},
+				org: &platform.Organization{},
+			},
+			now: func() time.Time { return time.Unix(3600, 0) },
+			want: &query.ProxyRequest{
+				Request: query.Request{
+					Compiler: &promql.Compiler{
+						Query:  `sum(up)`,
+						Bucket: "metrics",
+						Start:  promqlStart,
+						End:    time.Unix(3600, 0),
+						Step:   time.Minute,
+					},
+				},
+				Dialect: &promql.Dialect{
+					ResultType: promql.MatrixResult,
+					Time:       time.Unix(3600, 0),
+				},
+			},
+		},
 		{
 			name: "valid AST",
 			fields: fields{
@@ -361,6 +486,83 @@ func TestQueryRequest_proxyRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid query with comment prefix and date time format",
+			fields: fields{
+				Query: "howdy",
+				Type:  "flux",
+				Dialect: QueryDialect{
+					Delimiter:      ";",
+					CommentPrefix:  "@",
+					DateTimeFormat: "RFC3339Nano",
+					Annotations:    []string{"datatype"},
+				},
+				org: &platform.Organization{},
+			},
+			now: func() time.Time { return time.Unix(1, 1) },
+			want: &query.ProxyRequest{
+				Request: query.Request{
+					Compiler: lang.FluxCompiler{
+						Now:   time.Unix(1, 1),
+						Query: `howdy`,
+					},
+				},
+				Dialect: &query.CSVDialect{
+					Annotations:    []string{"datatype"},
+					Delimiter:      ';',
+					DateTimeFormat: "RFC3339Nano",
+					CommentPrefix:  "@",
+				},
+			},
+		},
+		{
+			name: "valid query accepting json",
+			fields: fields{
+				Query: "howdy",
+				Type:  "flux",
+				Dialect: QueryDialect{
+					Delimiter:      ",",
+					DateTimeFormat: "RFC3339Nano",
+				},
+				Accept: "application/json",
+				org:    &platform.Organization{},
+			},
+			now: func() time.Time { return time.Unix(1, 1) },
+			want: &query.ProxyRequest{
+				Request: query.Request{
+					Compiler: lang.FluxCompiler{
+						Now:   time.Unix(1, 1),
+						Query: `howdy`,
+					},
+				},
+				Dialect: &query.JSONDialect{
+					DateTimeFormat: "RFC3339Nano",
+				},
+			},
+		},
+		{
+			name: "valid query accepting line protocol",
+			fields: fields{
+				Query: "howdy",
+				Type:  "flux",
+				Dialect: QueryDialect{
+					Delimiter:      ",",
+					DateTimeFormat: "RFC3339",
+				},
+				Accept: "text/plain",
+				org:    &platform.Organization{},
+			},
+			now: func() time.Time { return time.Unix(1, 1) },
+			want: &query.ProxyRequest{
+				Request: query.Request{
+					Compiler: lang.FluxCompiler{
+						Now:   time.Unix(1, 1),
+						Query: `howdy`,
+					},
+				},
+				Dialect: &query.LineProtocolDialect{},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -370,7 +572,11 @@ func TestQueryRequest_proxyRequest(t *testing.T) {
 				AST:     tt.fields.AST,
 				Query:   tt.fields.Query,
 				Type:    tt.fields.Type,
+				Bucket:  tt.fields.Bucket,
+				Start:   tt.fields.Start,
+				Step:    tt.fields.Step,
 				Dialect: tt.fields.Dialect,
+				Accept:  tt.fields.Accept,
 				Org:     tt.fields.org,
 			}
 			got, err := r.proxyRequest(tt.now)
@@ -451,6 +657,36 @@ func Test_decodeQueryRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid query request accepting arrow",
+			args: args{
+				r: func() *http.Request {
+					r := httptest.NewRequest("POST", "/", bytes.NewBufferString(`{"query": "from()"}`))
+					r.Header.Set("Accept", "text/csv;q=0.5, application/vnd.apache.arrow.stream")
+					return r
+				}(),
+				svc: &mock.OrganizationService{
+					FindOrganizationF: func(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+						return &platform.Organization{
+							ID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+						}, nil
+					},
+				},
+			},
+			want: &QueryRequest{
+				Query: "from()",
+				Type:  "flux",
+				Dialect: QueryDialect{
+					Delimiter:      ",",
+					DateTimeFormat: "RFC3339",
+					Header:         func(x bool) *bool { return &x }(true),
+				},
+				Accept: "application/vnd.apache.arrow.stream",
+				Org: &platform.Organization{
+					ID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+				},
+			},
+		},
 		{
 			name: "error decoding json",
 			args: args{
@@ -480,6 +716,32 @@ func Test_decodeQueryRequest(t *testing.T) {
 	}
 }
 
+func Test_negotiateAccept(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "", want: ""},
+		{header: "*/*", want: ""},
+		{header: "text/csv", want: ""},
+		{header: "application/json", want: "application/json"},
+		{header: "application/json; charset=utf-8", want: "application/json"},
+		{header: "application/vnd.apache.arrow.stream", want: "application/vnd.apache.arrow.stream"},
+		{header: "text/plain", want: "text/plain"},
+		{header: "text/html, text/plain;q=0.9, */*;q=0.8", want: "text/plain"},
+		{header: "application/json;q=0.5, text/csv", want: ""},
+		{header: "application/json;q=0, */*", want: ""},
+		{header: "image/png", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := negotiateAccept(tt.header); got != tt.want {
+				t.Errorf("negotiateAccept(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_decodeProxyQueryRequest(t *testing.T) {
 	type args struct {
 		ctx  context.Context
@@ -0,0 +1,162 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"go.uber.org/zap"
+)
+
+// RestoreBackend is all services and associated parameters required to construct the RestoreHandler.
+type RestoreBackend struct {
+	Logger *zap.Logger
+	influxdb.HTTPErrorHandler
+
+	RestoreService influxdb.RestoreService
+	BucketService  influxdb.BucketService
+}
+
+// NewRestoreBackend returns a new instance of RestoreBackend.
+func NewRestoreBackend(b *APIBackend) *RestoreBackend {
+	return &RestoreBackend{
+		Logger: b.Logger.With(zap.String("handler", "restore")),
+
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		RestoreService:   b.RestoreService,
+		BucketService:    b.BucketService,
+	}
+}
+
+// RestoreHandler receives the TSM files of a backup and restores them into a running instance.
+type RestoreHandler struct {
+	*httprouter.Router
+	influxdb.HTTPErrorHandler
+	Logger *zap.Logger
+
+	RestoreService influxdb.RestoreService
+	BucketService  influxdb.BucketService
+}
+
+const (
+	prefixRestore     = "/api/v2/restore"
+	restoreBucketPath = prefixRestore + "/bucket"
+)
+
+// NewRestoreHandler creates a new handler at /api/v2/restore to receive restore requests.
+func NewRestoreHandler(b *RestoreBackend) *RestoreHandler {
+	h := &RestoreHandler{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		Router:           NewRouter(b.HTTPErrorHandler),
+		Logger:           b.Logger,
+		RestoreService:   b.RestoreService,
+		BucketService:    b.BucketService,
+	}
+
+	h.HandlerFunc(http.MethodPost, restoreBucketPath, h.handleRestoreBucket)
+
+	return h
+}
+
+func (h *RestoreHandler) handleRestoreBucket(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "RestoreHandler.handleRestoreBucket")
+	defer span.Finish()
+
+	ctx := r.Context()
+
+	req, err := decodeRestoreBucketRequest(r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	// The data is only restored into a bucket that exists in the organization.
+	bucket, err := h.BucketService.FindBucketByID(ctx, req.BucketID)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	if bucket.OrgID != req.OrgID {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  "bucket not found in organization",
+		}, w)
+		return
+	}
+
+	if err := h.RestoreService.RestoreBucket(ctx, req, r.Body); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeRestoreBucketRequest decodes the source and target buckets from the query parameters.
+func decodeRestoreBucketRequest(r *http.Request) (influxdb.RestoreBucketRequest, error) {
+	var req influxdb.RestoreBucketRequest
+	qp := r.URL.Query()
+	for _, p := range []struct {
+		name string
+		id   *influxdb.ID
+	}{
+		{name: "sourceOrgID", id: &req.SourceOrgID},
+		{name: "sourceBucketID", id: &req.SourceBucketID},
+		{name: "orgID", id: &req.OrgID},
+		{name: "bucketID", id: &req.BucketID},
+	} {
+		if err := p.id.DecodeFromString(qp.Get(p.name)); err != nil {
+			return req, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "invalid " + p.name,
+				Err:  err,
+			}
+		}
+	}
+	return req, req.Valid()
+}
+
+// RestoreService is the client implementation of influxdb.RestoreService.
+type RestoreService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+func (s *RestoreService) RestoreBucket(ctx context.Context, req influxdb.RestoreBucketRequest, r io.Reader) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := NewURL(s.Addr, restoreBucketPath)
+	if err != nil {
+		return err
+	}
+
+	qp := u.Query()
+	qp.Set("sourceOrgID", req.SourceOrgID.String())
+	qp.Set("sourceBucketID", req.SourceBucketID.String())
+	qp.Set("orgID", req.OrgID.String())
+	qp.Set("bucketID", req.BucketID.String())
+	u.RawQuery = qp.Encode()
+
+	hreq, err := http.NewRequest(http.MethodPost, u.String(), r)
+	if err != nil {
+		return err
+	}
+	hreq.Header.Set("Content-Type", "application/x-tar")
+	SetToken(s.Token, hreq)
+	hreq = hreq.WithContext(ctx)
+
+	hc := NewClient(u.Scheme, s.InsecureSkipVerify)
+	hc.Timeout = httpClientTimeout
+	resp, err := hc.Do(hreq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return CheckError(resp)
+}
@@ -0,0 +1,171 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	pcontext "github.com/influxdata/influxdb/context"
+	kithttp "github.com/influxdata/influxdb/kit/transport/http"
+	"github.com/influxdata/influxdb/mock"
+	influxtesting "github.com/influxdata/influxdb/testing"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestRestoreHandler_handleRestoreBucket(t *testing.T) {
+	bucketPermission := func(action influxdb.Action, orgID, id influxdb.ID) influxdb.Permission {
+		return influxdb.Permission{
+			Action: action,
+			Resource: influxdb.Resource{
+				Type:  influxdb.BucketsResourceType,
+				ID:    influxtesting.IDPtr(id),
+				OrgID: influxtesting.IDPtr(orgID),
+			},
+		}
+	}
+
+	type wants struct {
+		statusCode int
+		request    *influxdb.RestoreBucketRequest
+		body       string
+	}
+
+	tests := []struct {
+		name        string
+		queryParams map[string]string
+		permissions []influxdb.Permission
+		wants       wants
+	}{
+		{
+			name: "restore bucket",
+			queryParams: map[string]string{
+				"sourceOrgID":    "0000000000000001",
+				"sourceBucketID": "0000000000000002",
+				"orgID":          "0000000000000003",
+				"bucketID":       "0000000000000004",
+			},
+			permissions: []influxdb.Permission{
+				bucketPermission(influxdb.ReadAction, 3, 4),
+				bucketPermission(influxdb.WriteAction, 3, 4),
+			},
+			wants: wants{
+				statusCode: http.StatusNoContent,
+				request: &influxdb.RestoreBucketRequest{
+					SourceOrgID:    1,
+					SourceBucketID: 2,
+					OrgID:          3,
+					BucketID:       4,
+				},
+				body: "tar data",
+			},
+		},
+		{
+			name: "invalid bucket id",
+			queryParams: map[string]string{
+				"sourceOrgID":    "0000000000000001",
+				"sourceBucketID": "0000000000000002",
+				"orgID":          "0000000000000003",
+				"bucketID":       "invalid",
+			},
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "bucket of another organization",
+			queryParams: map[string]string{
+				"sourceOrgID":    "0000000000000001",
+				"sourceBucketID": "0000000000000002",
+				"orgID":          "0000000000000005",
+				"bucketID":       "0000000000000004",
+			},
+			permissions: []influxdb.Permission{
+				bucketPermission(influxdb.ReadAction, 3, 4),
+				bucketPermission(influxdb.WriteAction, 5, 4),
+			},
+			wants: wants{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		{
+			name: "unauthorized to read bucket",
+			queryParams: map[string]string{
+				"sourceOrgID":    "0000000000000001",
+				"sourceBucketID": "0000000000000002",
+				"orgID":          "0000000000000003",
+				"bucketID":       "0000000000000004",
+			},
+			permissions: []influxdb.Permission{
+				bucketPermission(influxdb.WriteAction, 3, 4),
+			},
+			wants: wants{
+				statusCode: http.StatusUnauthorized,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				gotRequest *influxdb.RestoreBucketRequest
+				gotBody    []byte
+			)
+			restoreService := &mock.RestoreService{
+				RestoreBucketF: func(ctx context.Context, req influxdb.RestoreBucketRequest, r io.Reader) (err error) {
+					gotRequest = &req
+					gotBody, err = ioutil.ReadAll(r)
+					return err
+				},
+			}
+			bucketService := &mock.BucketService{
+				FindBucketByIDFn: func(ctx context.Context, id influxdb.ID) (*influxdb.Bucket, error) {
+					return &influxdb.Bucket{ID: id, OrgID: 3, Name: "b1"}, nil
+				},
+			}
+
+			h := NewRestoreHandler(&RestoreBackend{
+				Logger:           zaptest.NewLogger(t),
+				HTTPErrorHandler: kithttp.ErrorHandler(0),
+				RestoreService:   restoreService,
+				BucketService:    authorizer.NewBucketService(bucketService),
+			})
+
+			r := httptest.NewRequest("POST", "http://any.tld"+restoreBucketPath, bytes.NewBufferString("tar data"))
+			qp := r.URL.Query()
+			for k, v := range tt.queryParams {
+				qp.Set(k, v)
+			}
+			r.URL.RawQuery = qp.Encode()
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Authorization{
+				UserID:      user1ID,
+				Status:      influxdb.Active,
+				Permissions: tt.permissions,
+			}))
+
+			w := httptest.NewRecorder()
+			h.handleRestoreBucket(w, r)
+
+			if got, exp := w.Result().StatusCode, tt.wants.statusCode; got != exp {
+				t.Fatalf("unexpected status code: got %d, exp %d: %s", got, exp, w.Body.String())
+			}
+			if tt.wants.request == nil {
+				if gotRequest != nil {
+					t.Fatalf("unexpected restore of %+v", *gotRequest)
+				}
+				return
+			}
+			if gotRequest == nil || *gotRequest != *tt.wants.request {
+				t.Fatalf("unexpected restore request: got %+v, exp %+v", gotRequest, *tt.wants.request)
+			}
+			if string(gotBody) != tt.wants.body {
+				t.Fatalf("unexpected restore body: got %q, exp %q", gotBody, tt.wants.body)
+			}
+		})
+	}
+}
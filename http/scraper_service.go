@@ -21,6 +21,7 @@ type ScraperBackend struct {
 	log *zap.Logger
 
 	ScraperStorageService      influxdb.ScraperTargetStoreService
+	ScraperStatusService       influxdb.ScraperStatusService
 	BucketService              influxdb.BucketService
 	OrganizationService        influxdb.OrganizationService
 	UserService                influxdb.UserService
@@ -35,6 +36,7 @@ func NewScraperBackend(log *zap.Logger, b *APIBackend) *ScraperBackend {
 		log:              log,
 
 		ScraperStorageService:      b.ScraperTargetStoreService,
+		ScraperStatusService:       b.ScraperStatusService,
 		BucketService:              b.BucketService,
 		OrganizationService:        b.OrganizationService,
 		UserService:                b.UserService,
@@ -52,6 +54,7 @@ type ScraperHandler struct {
 	UserResourceMappingService influxdb.UserResourceMappingService
 	LabelService               influxdb.LabelService
 	ScraperStorageService      influxdb.ScraperTargetStoreService
+	ScraperStatusService       influxdb.ScraperStatusService
 	BucketService              influxdb.BucketService
 	OrganizationService        influxdb.OrganizationService
 }
@@ -76,6 +79,7 @@ func NewScraperHandler(log *zap.Logger, b *ScraperBackend) *ScraperHandler {
 		UserResourceMappingService: b.UserResourceMappingService,
 		LabelService:               b.LabelService,
 		ScraperStorageService:      b.ScraperStorageService,
+		ScraperStatusService:       b.ScraperStatusService,
 		BucketService:              b.BucketService,
 		OrganizationService:        b.OrganizationService,
 	}
@@ -552,6 +556,44 @@ func (s *ScraperService) GetTargetByID(ctx context.Context, id influxdb.ID) (*in
 	return &targetResp.ScraperTarget, nil
 }
 
+// GetTargetStatus returns the status of the last scrape of a target.
+func (s *ScraperService) GetTargetStatus(ctx context.Context, id influxdb.ID) (*influxdb.ScraperStatus, error) {
+	url, err := NewURL(s.Addr, targetIDPath(id))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", url.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SetToken(s.Token, req)
+
+	hc := NewClient(url.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var targetResp targetResponse
+	if err := json.NewDecoder(resp.Body).Decode(&targetResp); err != nil {
+		return nil, err
+	}
+	if targetResp.Status == nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  influxdb.ErrScraperStatusNotFound,
+		}
+	}
+
+	return targetResp.Status, nil
+}
+
 func targetIDPath(id influxdb.ID) string {
 	return path.Join(prefixTargets, id.String())
 }
@@ -575,9 +617,10 @@ type targetLinks struct {
 
 type targetResponse struct {
 	influxdb.ScraperTarget
-	Org    string      `json:"org,omitempty"`
-	Bucket string      `json:"bucket,omitempty"`
-	Links  targetLinks `json:"links"`
+	Org    string                  `json:"org,omitempty"`
+	Bucket string                  `json:"bucket,omitempty"`
+	Status *influxdb.ScraperStatus `json:"status,omitempty"`
+	Links  targetLinks             `json:"links"`
 }
 
 func (h *ScraperHandler) newListTargetsResponse(ctx context.Context, targets []influxdb.ScraperTarget) (getTargetsResponse, error) {
@@ -626,5 +669,14 @@ func (h *ScraperHandler) newTargetResponse(ctx context.Context, target influxdb.
 		res.OrgID = influxdb.InvalidID()
 	}
 
+	// The status is missing until the first scrape of the target.
+	if h.ScraperStatusService != nil {
+		status, err := h.ScraperStatusService.GetTargetStatus(ctx, target.ID)
+		if err != nil && influxdb.ErrorCode(err) != influxdb.ENotFound {
+			return res, err
+		}
+		res.Status = status
+	}
+
 	return res, nil
 }
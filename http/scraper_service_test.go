@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/influxdata/httprouter"
 	"github.com/influxdata/influxdb"
@@ -128,6 +129,8 @@ func TestService_handleGetScraperTargets(t *testing.T) {
 						  "org": "org1",
 						  "orgID": "0000000000000211",
 						  "type": "prometheus",
+						  "interval": "0s",
+						  "timeout": "0s",
 						  "url": "www.one.url",
 						  "links": {
 						    "bucket": "/api/v2/buckets/0000000000000212",
@@ -145,6 +148,8 @@ func TestService_handleGetScraperTargets(t *testing.T) {
 						  "orgID": "0000000000000211",
 						  "org": "org1",
 						  "type": "prometheus",
+						  "interval": "0s",
+						  "timeout": "0s",
 						  "url": "www.two.url",
 						  "links": {
 						    "bucket": "/api/v2/buckets/0000000000000212",
@@ -252,6 +257,7 @@ func TestService_handleGetScraperTarget(t *testing.T) {
 		OrganizationService       influxdb.OrganizationService
 		BucketService             influxdb.BucketService
 		ScraperTargetStoreService influxdb.ScraperTargetStoreService
+		ScraperStatusService      influxdb.ScraperStatusService
 	}
 
 	type args struct {
@@ -320,6 +326,8 @@ func TestService_handleGetScraperTarget(t *testing.T) {
                       "id": "%s",
                       "name": "target-1",
                       "type": "prometheus",
+                      "interval": "0s",
+                      "timeout": "0s",
 					  "url": "www.some.url",
 					  "bucket": "bucket1",
                       "bucketID": "0000000000000212",
@@ -338,6 +346,92 @@ func TestService_handleGetScraperTarget(t *testing.T) {
 				),
 			},
 		},
+		{
+			name: "get a scraper target by id with status",
+			fields: fields{
+				OrganizationService: &mock.OrganizationService{
+					FindOrganizationByIDF: func(ctx context.Context, id influxdb.ID) (*influxdb.Organization, error) {
+						return &influxdb.Organization{
+							ID:   platformtesting.MustIDBase16("0000000000000211"),
+							Name: "org1",
+						}, nil
+					},
+				},
+				BucketService: &mock.BucketService{
+					FindBucketByIDFn: func(ctx context.Context, id influxdb.ID) (*influxdb.Bucket, error) {
+						return &influxdb.Bucket{
+							ID:   platformtesting.MustIDBase16("0000000000000212"),
+							Name: "bucket1",
+						}, nil
+					},
+				},
+				ScraperStatusService: &mock.ScraperStatusService{
+					GetTargetStatusF: func(ctx context.Context, id influxdb.ID) (*influxdb.ScraperStatus, error) {
+						return &influxdb.ScraperStatus{
+							LastScrape: time.Unix(1600000000, 0).UTC(),
+							Duration:   influxdb.Duration{Duration: 1500 * time.Millisecond},
+							Samples:    42,
+							LastError:  "connection refused",
+						}, nil
+					},
+				},
+				ScraperTargetStoreService: &mock.ScraperTargetStoreService{
+					GetTargetByIDF: func(ctx context.Context, id influxdb.ID) (*influxdb.ScraperTarget, error) {
+						if id == targetOneID {
+							return &influxdb.ScraperTarget{
+								ID:       targetOneID,
+								Name:     "target-1",
+								Type:     influxdb.PrometheusScraperType,
+								URL:      "www.some.url",
+								OrgID:    platformtesting.MustIDBase16("0000000000000211"),
+								BucketID: platformtesting.MustIDBase16("0000000000000212"),
+							}, nil
+						}
+						return nil, &influxdb.Error{
+							Code: influxdb.ENotFound,
+							Msg:  "scraper target is not found",
+						}
+					},
+				},
+			},
+			args: args{
+				id: targetOneIDString,
+			},
+			wants: wants{
+				statusCode:  http.StatusOK,
+				contentType: "application/json; charset=utf-8",
+				body: fmt.Sprintf(
+					`
+                    {
+                      "id": "%s",
+                      "name": "target-1",
+                      "type": "prometheus",
+                      "interval": "0s",
+                      "timeout": "0s",
+					  "url": "www.some.url",
+					  "bucket": "bucket1",
+                      "bucketID": "0000000000000212",
+					  "orgID": "0000000000000211",
+					  "org": "org1",
+                      "status": {
+                        "lastScrape": "2020-09-13T12:26:40Z",
+                        "duration": "1.5s",
+                        "samples": 42,
+                        "lastError": "connection refused"
+                      },
+                      "links": {
+                        "bucket": "/api/v2/buckets/0000000000000212",
+                        "organization": "/api/v2/orgs/0000000000000211",
+                        "self": "/api/v2/scrapers/%s",
+                        "members": "/api/v2/scrapers/%s/members",
+                        "owners": "/api/v2/scrapers/%s/owners"
+                      }
+                    }
+                    `,
+					targetOneIDString, targetOneIDString, targetOneIDString, targetOneIDString,
+				),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -345,6 +439,7 @@ func TestService_handleGetScraperTarget(t *testing.T) {
 			scraperBackend := NewMockScraperBackend(t)
 			scraperBackend.HTTPErrorHandler = kithttp.ErrorHandler(0)
 			scraperBackend.ScraperStorageService = tt.fields.ScraperTargetStoreService
+			scraperBackend.ScraperStatusService = tt.fields.ScraperStatusService
 			scraperBackend.OrganizationService = tt.fields.OrganizationService
 			scraperBackend.BucketService = tt.fields.BucketService
 			h := NewScraperHandler(zaptest.NewLogger(t), scraperBackend)
@@ -559,6 +654,8 @@ func TestService_handlePostScraperTarget(t *testing.T) {
                       "id": "%s",
                       "name": "hello",
                       "type": "prometheus",
+                      "interval": "0s",
+                      "timeout": "0s",
                       "url": "www.some.url",
 					  "orgID": "0000000000000211",
 					  "org": "org1",
@@ -695,6 +792,8 @@ func TestService_handlePatchScraperTarget(t *testing.T) {
 		              "id":"%s",
 		              "name":"name",
 		              "type":"prometheus",
+		              "interval":"0s",
+		              "timeout":"0s",
 					  "url":"www.example.url",
 					  "org": "org1",
 					  "orgID":"0000000000000211",
@@ -33,6 +33,8 @@ type TaskBackend struct {
 	LabelService               influxdb.LabelService
 	UserService                influxdb.UserService
 	BucketService              influxdb.BucketService
+	TaskBackfillService        influxdb.TaskBackfillService
+	TaskStatsService           influxdb.TaskStatsService
 }
 
 // NewTaskBackend returns a new instance of TaskBackend.
@@ -47,6 +49,8 @@ func NewTaskBackend(log *zap.Logger, b *APIBackend) *TaskBackend {
 		LabelService:               b.LabelService,
 		UserService:                b.UserService,
 		BucketService:              b.BucketService,
+		TaskBackfillService:        b.TaskBackfillService,
+		TaskStatsService:           b.TaskStatsService,
 	}
 }
 
@@ -63,6 +67,8 @@ type TaskHandler struct {
 	LabelService               influxdb.LabelService
 	UserService                influxdb.UserService
 	BucketService              influxdb.BucketService
+	TaskBackfillService        influxdb.TaskBackfillService
+	TaskStatsService           influxdb.TaskStatsService
 }
 
 const (
@@ -79,6 +85,9 @@ const (
 	tasksIDRunsIDRetryPath = "/api/v2/tasks/:id/runs/:rid/retry"
 	tasksIDLabelsPath      = "/api/v2/tasks/:id/labels"
 	tasksIDLabelsIDPath    = "/api/v2/tasks/:id/labels/:lid"
+	tasksIDBackfillsPath   = "/api/v2/tasks/:id/backfills"
+	tasksIDBackfillsIDPath = "/api/v2/tasks/:id/backfills/:bid"
+	tasksIDStatsPath       = "/api/v2/tasks/:id/stats"
 )
 
 // NewTaskHandler returns a new instance of TaskHandler.
@@ -95,6 +104,8 @@ func NewTaskHandler(log *zap.Logger, b *TaskBackend) *TaskHandler {
 		LabelService:               b.LabelService,
 		UserService:                b.UserService,
 		BucketService:              b.BucketService,
+		TaskBackfillService:        b.TaskBackfillService,
+		TaskStatsService:           b.TaskStatsService,
 	}
 
 	h.HandlerFunc("GET", prefixTasks, h.handleGetTasks)
@@ -137,6 +148,12 @@ func NewTaskHandler(log *zap.Logger, b *TaskBackend) *TaskHandler {
 	h.HandlerFunc("POST", tasksIDRunsIDRetryPath, h.handleRetryRun)
 	h.HandlerFunc("DELETE", tasksIDRunsIDPath, h.handleCancelRun)
 
+	h.HandlerFunc("POST", tasksIDBackfillsPath, h.handlePostBackfill)
+	h.HandlerFunc("GET", tasksIDBackfillsIDPath, h.handleGetBackfill)
+	h.HandlerFunc("DELETE", tasksIDBackfillsIDPath, h.handleCancelBackfill)
+
+	h.HandlerFunc("GET", tasksIDStatsPath, h.handleGetTaskStats)
+
 	labelBackend := &LabelBackend{
 		HTTPErrorHandler: b.HTTPErrorHandler,
 		log:              b.log.With(zap.String("handler", "label")),
@@ -164,6 +181,7 @@ type Task struct {
 	Every           string                 `json:"every,omitempty"`
 	Cron            string                 `json:"cron,omitempty"`
 	Offset          string                 `json:"offset,omitempty"`
+	UpstreamID      influxdb.ID            `json:"upstreamID,omitempty"`
 	LatestCompleted string                 `json:"latestCompleted,omitempty"`
 	LastRunStatus   string                 `json:"lastRunStatus,omitempty"`
 	LastRunError    string                 `json:"lastRunError,omitempty"`
@@ -209,6 +227,7 @@ func NewFrontEndTask(t influxdb.Task) Task {
 		Every:           t.Every,
 		Cron:            t.Cron,
 		Offset:          offset,
+		UpstreamID:      t.UpstreamID,
 		LatestCompleted: latestCompleted,
 		LastRunStatus:   t.LastRunStatus,
 		LastRunError:    t.LastRunError,
@@ -334,23 +353,27 @@ type runResponse struct {
 // it uses a pointer to a time.Time instead of a time.Time so that we can pass a nil
 // value for empty time values
 type httpRun struct {
-	ID           influxdb.ID    `json:"id,omitempty"`
-	TaskID       influxdb.ID    `json:"taskID"`
-	Status       string         `json:"status"`
-	ScheduledFor *time.Time     `json:"scheduledFor"`
-	StartedAt    *time.Time     `json:"startedAt,omitempty"`
-	FinishedAt   *time.Time     `json:"finishedAt,omitempty"`
-	RequestedAt  *time.Time     `json:"requestedAt,omitempty"`
-	Log          []influxdb.Log `json:"log,omitempty"`
+	ID            influxdb.ID    `json:"id,omitempty"`
+	TaskID        influxdb.ID    `json:"taskID"`
+	Status        string         `json:"status"`
+	ScheduledFor  *time.Time     `json:"scheduledFor"`
+	StartedAt     *time.Time     `json:"startedAt,omitempty"`
+	FinishedAt    *time.Time     `json:"finishedAt,omitempty"`
+	RequestedAt   *time.Time     `json:"requestedAt,omitempty"`
+	UpstreamRunID influxdb.ID    `json:"upstreamRunID,omitempty"`
+	Attempts      int            `json:"attempts,omitempty"`
+	Log           []influxdb.Log `json:"log,omitempty"`
 }
 
 func newRunResponse(r influxdb.Run) runResponse {
 	run := httpRun{
-		ID:           r.ID,
-		TaskID:       r.TaskID,
-		Status:       r.Status,
-		Log:          r.Log,
-		ScheduledFor: &r.ScheduledFor,
+		ID:            r.ID,
+		TaskID:        r.TaskID,
+		Status:        r.Status,
+		Log:           r.Log,
+		ScheduledFor:  &r.ScheduledFor,
+		UpstreamRunID: r.UpstreamRunID,
+		Attempts:      r.Attempts,
 	}
 
 	if !r.StartedAt.IsZero() {
@@ -376,10 +399,12 @@ func newRunResponse(r influxdb.Run) runResponse {
 
 func convertRun(r httpRun) *influxdb.Run {
 	run := &influxdb.Run{
-		ID:     r.ID,
-		TaskID: r.TaskID,
-		Status: r.Status,
-		Log:    r.Log,
+		ID:            r.ID,
+		TaskID:        r.TaskID,
+		Status:        r.Status,
+		Log:           r.Log,
+		UpstreamRunID: r.UpstreamRunID,
+		Attempts:      r.Attempts,
 	}
 
 	if r.StartedAt != nil {
@@ -1355,6 +1380,271 @@ func decodeRetryRunRequest(ctx context.Context, r *http.Request) (*retryRunReque
 	}, nil
 }
 
+type backfillResponse struct {
+	Links map[string]string `json:"links"`
+	influxdb.TaskBackfill
+}
+
+func newBackfillResponse(b influxdb.TaskBackfill) backfillResponse {
+	return backfillResponse{
+		Links: map[string]string{
+			"self": taskIDBackfillIDPath(b.TaskID, b.ID),
+			"task": taskIDPath(b.TaskID),
+		},
+		TaskBackfill: b,
+	}
+}
+
+func (h *TaskHandler) handlePostBackfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodePostBackfillRequest(ctx, r)
+	if err != nil {
+		err = &influxdb.Error{
+			Err:  err,
+			Code: influxdb.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	b, err := h.TaskBackfillService.BackfillTask(ctx, req.TaskID, req.Start, req.Stop)
+	if err != nil {
+		err := &influxdb.Error{
+			Err: err,
+			Msg: "failed to backfill task",
+		}
+		if err.Err == influxdb.ErrTaskNotFound {
+			err.Code = influxdb.ENotFound
+		}
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	if err := encodeResponse(ctx, w, http.StatusCreated, newBackfillResponse(*b)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+type postBackfillRequest struct {
+	TaskID      influxdb.ID
+	Start, Stop time.Time
+}
+
+func decodePostBackfillRequest(ctx context.Context, r *http.Request) (*postBackfillRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	tid := params.ByName("id")
+	if tid == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "you must provide a task ID",
+		}
+	}
+
+	var ti influxdb.ID
+	if err := ti.DecodeFromString(tid); err != nil {
+		return nil, err
+	}
+
+	var req struct {
+		Start time.Time `json:"start"`
+		Stop  time.Time `json:"stop"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, err
+	}
+	if req.Start.IsZero() || req.Stop.IsZero() {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "you must provide a backfill start and stop",
+		}
+	}
+
+	return &postBackfillRequest{
+		TaskID: ti,
+		Start:  req.Start,
+		Stop:   req.Stop,
+	}, nil
+}
+
+func (h *TaskHandler) handleGetBackfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeBackfillRequest(ctx, r)
+	if err != nil {
+		err = &influxdb.Error{
+			Err:  err,
+			Code: influxdb.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	b, err := h.TaskBackfillService.FindBackfillByID(ctx, req.TaskID, req.BackfillID)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, newBackfillResponse(*b)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func (h *TaskHandler) handleCancelBackfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeBackfillRequest(ctx, r)
+	if err != nil {
+		err = &influxdb.Error{
+			Err:  err,
+			Code: influxdb.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.TaskBackfillService.CancelBackfill(ctx, req.TaskID, req.BackfillID); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type backfillRequest struct {
+	TaskID, BackfillID influxdb.ID
+}
+
+func decodeBackfillRequest(ctx context.Context, r *http.Request) (*backfillRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	tid := params.ByName("id")
+	if tid == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "you must provide a task ID",
+		}
+	}
+	bid := params.ByName("bid")
+	if bid == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "you must provide a backfill ID",
+		}
+	}
+
+	var ti, bi influxdb.ID
+	if err := ti.DecodeFromString(tid); err != nil {
+		return nil, err
+	}
+	if err := bi.DecodeFromString(bid); err != nil {
+		return nil, err
+	}
+
+	return &backfillRequest{
+		TaskID:     ti,
+		BackfillID: bi,
+	}, nil
+}
+
+// defaultTaskStatsWindow is the window task stats are computed over when the request has no start.
+const defaultTaskStatsWindow = 24 * time.Hour
+
+type taskStatsResponse struct {
+	Links map[string]string `json:"links"`
+	influxdb.TaskStats
+}
+
+func newTaskStatsResponse(s influxdb.TaskStats) taskStatsResponse {
+	return taskStatsResponse{
+		Links: map[string]string{
+			"self": taskIDStatsPath(s.TaskID),
+			"task": taskIDPath(s.TaskID),
+			"runs": taskIDRunsPath(s.TaskID),
+		},
+		TaskStats: s,
+	}
+}
+
+func (h *TaskHandler) handleGetTaskStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetTaskStatsRequest(ctx, r)
+	if err != nil {
+		err = &influxdb.Error{
+			Err:  err,
+			Code: influxdb.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	s, err := h.TaskStatsService.FindTaskStats(ctx, req.TaskID, req.Start, req.Stop)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, newTaskStatsResponse(*s)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+type getTaskStatsRequest struct {
+	TaskID      influxdb.ID
+	Start, Stop time.Time
+}
+
+func decodeGetTaskStatsRequest(ctx context.Context, r *http.Request) (*getTaskStatsRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	tid := params.ByName("id")
+	if tid == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "you must provide a task ID",
+		}
+	}
+
+	var ti influxdb.ID
+	if err := ti.DecodeFromString(tid); err != nil {
+		return nil, err
+	}
+
+	req := &getTaskStatsRequest{
+		TaskID: ti,
+		Stop:   time.Now().UTC(),
+	}
+
+	qp := r.URL.Query()
+	if stop := qp.Get("stop"); stop != "" {
+		t, err := time.Parse(time.RFC3339, stop)
+		if err != nil {
+			return nil, err
+		}
+		req.Stop = t
+	}
+	req.Start = req.Stop.Add(-defaultTaskStatsWindow)
+	if start := qp.Get("start"); start != "" {
+		t, err := time.Parse(time.RFC3339, start)
+		if err != nil {
+			return nil, err
+		}
+		req.Start = t
+	}
+
+	if !req.Stop.After(req.Start) {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "stop must be later than start",
+		}
+	}
+
+	return req, nil
+}
+
 func (h *TaskHandler) populateTaskCreateOrg(ctx context.Context, tc *influxdb.TaskCreate) error {
 	if tc.OrganizationID.Valid() && tc.Organization != "" {
 		return nil
@@ -1697,6 +1987,76 @@ func (t TaskService) CancelRun(ctx context.Context, taskID, runID influxdb.ID) e
 	return nil
 }
 
+// BackfillTask starts queueing a run of the task for every time in [start, stop] the task is scheduled for.
+func (t TaskService) BackfillTask(ctx context.Context, taskID influxdb.ID, start, stop time.Time) (*influxdb.TaskBackfill, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	body := struct {
+		Start time.Time `json:"start"`
+		Stop  time.Time `json:"stop"`
+	}{Start: start.UTC(), Stop: stop.UTC()}
+
+	var b backfillResponse
+	err := t.Client.
+		PostJSON(body, taskIDBackfillsPath(taskID)).
+		DecodeJSON(&b).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &b.TaskBackfill, nil
+}
+
+// FindBackfillByID returns the progress of a backfill of a task.
+func (t TaskService) FindBackfillByID(ctx context.Context, taskID, backfillID influxdb.ID) (*influxdb.TaskBackfill, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var b backfillResponse
+	err := t.Client.
+		Get(taskIDBackfillIDPath(taskID, backfillID)).
+		DecodeJSON(&b).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &b.TaskBackfill, nil
+}
+
+// CancelBackfill stops queueing the runs of a backfill and cancels its unfinished runs.
+func (t TaskService) CancelBackfill(ctx context.Context, taskID, backfillID influxdb.ID) error {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	return t.Client.
+		Delete(taskIDBackfillIDPath(taskID, backfillID)).
+		Do(ctx)
+}
+
+// FindTaskStats computes statistics over the finished runs of a task that started in [start, stop).
+func (t TaskService) FindTaskStats(ctx context.Context, taskID influxdb.ID, start, stop time.Time) (*influxdb.TaskStats, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var s taskStatsResponse
+	err := t.Client.
+		Get(taskIDStatsPath(taskID)).
+		QueryParams(
+			[2]string{"start", start.UTC().Format(time.RFC3339)},
+			[2]string{"stop", stop.UTC().Format(time.RFC3339)},
+		).
+		DecodeJSON(&s).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s.TaskStats, nil
+}
+
 func taskIDPath(id influxdb.ID) string {
 	return path.Join(prefixTasks, id.String())
 }
@@ -1708,3 +2068,15 @@ func taskIDRunsPath(id influxdb.ID) string {
 func taskIDRunIDPath(taskID, runID influxdb.ID) string {
 	return path.Join(prefixTasks, taskID.String(), "runs", runID.String())
 }
+
+func taskIDBackfillsPath(id influxdb.ID) string {
+	return path.Join(prefixTasks, id.String(), "backfills")
+}
+
+func taskIDBackfillIDPath(taskID, backfillID influxdb.ID) string {
+	return path.Join(prefixTasks, taskID.String(), "backfills", backfillID.String())
+}
+
+func taskIDStatsPath(id influxdb.ID) string {
+	return path.Join(prefixTasks, id.String(), "stats")
+}
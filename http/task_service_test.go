@@ -818,6 +818,260 @@ func TestTaskHandler_handleGetRuns(t *testing.T) {
 	}
 }
 
+func TestTaskHandler_handleGetBackfill(t *testing.T) {
+	type fields struct {
+		taskBackfillService influxdb.TaskBackfillService
+	}
+	type args struct {
+		taskID     influxdb.ID
+		backfillID influxdb.ID
+	}
+	type wants struct {
+		statusCode  int
+		contentType string
+		body        string
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "get a backfill by id",
+			fields: fields{
+				taskBackfillService: &mock.TaskBackfillService{
+					FindBackfillByIDFn: func(ctx context.Context, taskID, backfillID influxdb.ID) (*influxdb.TaskBackfill, error) {
+						start, _ := time.Parse(time.RFC3339, "2018-12-01T00:00:00Z")
+						stop, _ := time.Parse(time.RFC3339, "2018-12-01T01:00:00Z")
+						createdAt, _ := time.Parse(time.RFC3339, "2018-12-02T17:00:00Z")
+						return &influxdb.TaskBackfill{
+							ID:        backfillID,
+							TaskID:    taskID,
+							Start:     start,
+							Stop:      stop,
+							Status:    influxdb.BackfillRunning,
+							Total:     4,
+							Queued:    2,
+							Skipped:   1,
+							Succeeded: 1,
+							CreatedAt: createdAt,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				taskID:     1,
+				backfillID: 2,
+			},
+			wants: wants{
+				statusCode:  http.StatusOK,
+				contentType: "application/json; charset=utf-8",
+				body: `
+{
+  "links": {
+    "self": "/api/v2/tasks/0000000000000001/backfills/0000000000000002",
+    "task": "/api/v2/tasks/0000000000000001"
+  },
+  "id": "0000000000000002",
+  "taskID": "0000000000000001",
+  "start": "2018-12-01T00:00:00Z",
+  "stop": "2018-12-01T01:00:00Z",
+  "status": "running",
+  "total": 4,
+  "queued": 2,
+  "skipped": 1,
+  "succeeded": 1,
+  "failed": 0,
+  "canceled": 0,
+  "createdAt": "2018-12-02T17:00:00Z",
+  "finishedAt": "0001-01-01T00:00:00Z"
+}`,
+			},
+		},
+		{
+			name: "backfill not found",
+			fields: fields{
+				taskBackfillService: &mock.TaskBackfillService{
+					FindBackfillByIDFn: func(ctx context.Context, taskID, backfillID influxdb.ID) (*influxdb.TaskBackfill, error) {
+						return nil, influxdb.ErrBackfillNotFound
+					},
+				},
+			},
+			args: args{
+				taskID:     1,
+				backfillID: 2,
+			},
+			wants: wants{
+				statusCode: http.StatusNotFound,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://any.url", nil)
+			r = r.WithContext(context.WithValue(
+				context.Background(),
+				httprouter.ParamsKey,
+				httprouter.Params{
+					{
+						Key:   "id",
+						Value: tt.args.taskID.String(),
+					},
+					{
+						Key:   "bid",
+						Value: tt.args.backfillID.String(),
+					},
+				}))
+			w := httptest.NewRecorder()
+			taskBackend := NewMockTaskBackend(t)
+			taskBackend.HTTPErrorHandler = kithttp.ErrorHandler(0)
+			taskBackend.TaskBackfillService = tt.fields.taskBackfillService
+			h := NewTaskHandler(zaptest.NewLogger(t), taskBackend)
+			h.handleGetBackfill(w, r)
+
+			res := w.Result()
+			content := res.Header.Get("Content-Type")
+			body, _ := ioutil.ReadAll(res.Body)
+
+			if res.StatusCode != tt.wants.statusCode {
+				t.Errorf("%q. handleGetBackfill() = %v, want %v", tt.name, res.StatusCode, tt.wants.statusCode)
+			}
+			if tt.wants.contentType != "" && content != tt.wants.contentType {
+				t.Errorf("%q. handleGetBackfill() = %v, want %v", tt.name, content, tt.wants.contentType)
+			}
+			if tt.wants.body != "" {
+				if eq, diff, err := jsonEqual(string(body), tt.wants.body); err != nil {
+					t.Errorf("%q, handleGetBackfill(). error unmarshaling json %v", tt.name, err)
+				} else if !eq {
+					t.Errorf("%q. handleGetBackfill() = ***%s***", tt.name, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestTaskHandler_handleGetTaskStats(t *testing.T) {
+	type args struct {
+		taskID influxdb.ID
+		query  string
+	}
+	type wants struct {
+		statusCode  int
+		contentType string
+		body        string
+	}
+
+	taskStatsService := &mock.TaskStatsService{
+		FindTaskStatsFn: func(ctx context.Context, taskID influxdb.ID, start, stop time.Time) (*influxdb.TaskStats, error) {
+			return &influxdb.TaskStats{
+				TaskID:      taskID,
+				Start:       start,
+				Stop:        stop,
+				Runs:        4,
+				Succeeded:   3,
+				Failed:      1,
+				SuccessRate: 0.75,
+				Duration:    influxdb.TaskStatsPercentiles{P50: 4, P95: 8},
+				Lag:         influxdb.TaskStatsPercentiles{P50: 2, P95: 4},
+			}, nil
+		},
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "get the stats of a task",
+			args: args{
+				taskID: 1,
+				query:  "?start=2018-12-01T00:00:00Z&stop=2018-12-02T00:00:00Z",
+			},
+			wants: wants{
+				statusCode:  http.StatusOK,
+				contentType: "application/json; charset=utf-8",
+				body: `
+{
+  "links": {
+    "self": "/api/v2/tasks/0000000000000001/stats",
+    "task": "/api/v2/tasks/0000000000000001",
+    "runs": "/api/v2/tasks/0000000000000001/runs"
+  },
+  "taskID": "0000000000000001",
+  "start": "2018-12-01T00:00:00Z",
+  "stop": "2018-12-02T00:00:00Z",
+  "runs": 4,
+  "succeeded": 3,
+  "failed": 1,
+  "canceled": 0,
+  "successRate": 0.75,
+  "duration": {
+    "p50": 4,
+    "p95": 8
+  },
+  "lag": {
+    "p50": 2,
+    "p95": 4
+  }
+}`,
+			},
+		},
+		{
+			name: "stop before start",
+			args: args{
+				taskID: 1,
+				query:  "?start=2018-12-02T00:00:00Z&stop=2018-12-01T00:00:00Z",
+			},
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://any.url"+tt.args.query, nil)
+			r = r.WithContext(context.WithValue(
+				context.Background(),
+				httprouter.ParamsKey,
+				httprouter.Params{
+					{
+						Key:   "id",
+						Value: tt.args.taskID.String(),
+					},
+				}))
+			w := httptest.NewRecorder()
+			taskBackend := NewMockTaskBackend(t)
+			taskBackend.HTTPErrorHandler = kithttp.ErrorHandler(0)
+			taskBackend.TaskStatsService = taskStatsService
+			h := NewTaskHandler(zaptest.NewLogger(t), taskBackend)
+			h.handleGetTaskStats(w, r)
+
+			res := w.Result()
+			content := res.Header.Get("Content-Type")
+			body, _ := ioutil.ReadAll(res.Body)
+
+			if res.StatusCode != tt.wants.statusCode {
+				t.Errorf("%q. handleGetTaskStats() = %v, want %v", tt.name, res.StatusCode, tt.wants.statusCode)
+			}
+			if tt.wants.contentType != "" && content != tt.wants.contentType {
+				t.Errorf("%q. handleGetTaskStats() = %v, want %v", tt.name, content, tt.wants.contentType)
+			}
+			if tt.wants.body != "" {
+				if eq, diff, err := jsonEqual(string(body), tt.wants.body); err != nil {
+					t.Errorf("%q, handleGetTaskStats(). error unmarshaling json %v", tt.name, err)
+				} else if !eq {
+					t.Errorf("%q. handleGetTaskStats() = ***%s***", tt.name, diff)
+				}
+			}
+		})
+	}
+}
+
 func TestTaskHandler_NotFoundStatus(t *testing.T) {
 	// Ensure that the HTTP handlers return 404s for missing resources, and OKs for matching.
 
@@ -7,7 +7,10 @@ import (
 	"strings"
 )
 
-const tokenScheme = "Token " // TODO(goller): I'd like this to be Bearer
+const (
+	tokenScheme  = "Token " // TODO(goller): I'd like this to be Bearer
+	bearerScheme = "Bearer "
+)
 
 // errors
 var (
@@ -16,15 +19,19 @@ var (
 )
 
 // GetToken will parse the token from http Authorization Header.
+// The Bearer scheme is accepted as well, as sent by clients such as Prometheus.
 func GetToken(r *http.Request) (string, error) {
 	header := r.Header.Get("Authorization")
 	if header == "" {
 		return "", ErrAuthHeaderMissing
 	}
-	if !strings.HasPrefix(header, tokenScheme) {
-		return "", ErrAuthBadScheme
+	switch {
+	case strings.HasPrefix(header, tokenScheme):
+		return header[len(tokenScheme):], nil
+	case strings.HasPrefix(header, bearerScheme):
+		return header[len(bearerScheme):], nil
 	}
-	return header[len(tokenScheme):], nil
+	return "", ErrAuthBadScheme
 }
 
 // SetToken adds the token to the request.
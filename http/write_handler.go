@@ -301,6 +301,18 @@ func (h *WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// a token restricted to some of the data of the bucket can only write that data.
+	if rs := influxdb.AuthorizerDataRestrictions(a, *p); rs != nil {
+		for _, pt := range points {
+			tags := pt.Tags()
+			m := tags.GetString(models.MeasurementTagKey)
+			if !rs.Allows(m, tags.GetString) {
+				handleError(nil, influxdb.EForbidden, fmt.Sprintf("insufficient permissions to write measurement %q", m))
+				return
+			}
+		}
+	}
+
 	if err := h.PointsWriter.WritePoints(ctx, points); err != nil {
 		log.Error("Error writing points", zap.Error(err))
 		handleError(err, influxdb.EInternal, "unexpected error writing points to database")
@@ -241,6 +241,39 @@ func TestWriteHandler_handleWrite(t *testing.T) {
 				body: `{"code":"forbidden","message":"insufficient permissions for write"}`,
 			},
 		},
+		{
+			name: "restricted permission writes allowed measurements",
+			request: request{
+				org:    "043e0780ee2b1000",
+				bucket: "04504b356e23b000",
+				body:   "m1,t1=v1 f1=1",
+				auth:   restrictedBucketWritePermission("043e0780ee2b1000", "04504b356e23b000", "m1"),
+			},
+			state: state{
+				org:    testOrg("043e0780ee2b1000"),
+				bucket: testBucket("043e0780ee2b1000", "04504b356e23b000"),
+			},
+			wants: wants{
+				code: 204,
+			},
+		},
+		{
+			name: "forbidden to write measurement outside of restricted permission",
+			request: request{
+				org:    "043e0780ee2b1000",
+				bucket: "04504b356e23b000",
+				body:   "m1,t1=v1 f1=1\nm2,t1=v1 f1=1",
+				auth:   restrictedBucketWritePermission("043e0780ee2b1000", "04504b356e23b000", "m1"),
+			},
+			state: state{
+				org:    testOrg("043e0780ee2b1000"),
+				bucket: testBucket("043e0780ee2b1000", "04504b356e23b000"),
+			},
+			wants: wants{
+				code: 403,
+				body: `{"code":"forbidden","message":"insufficient permissions to write measurement \"m2\""}`,
+			},
+		},
 		{
 			// authorization extraction happens in a different middleware.
 			name: "no authorizer is an internal error",
@@ -397,6 +430,14 @@ func bucketWritePermission(org, bucket string) *influxdb.Authorization {
 	}
 }
 
+func restrictedBucketWritePermission(org, bucket string, measurements ...string) *influxdb.Authorization {
+	a := bucketWritePermission(org, bucket)
+	a.Permissions[0].Restriction = &influxdb.DataRestriction{
+		Measurements: measurements,
+	}
+	return a
+}
+
 func testOrg(org string) *influxdb.Organization {
 	oid := influxtesting.MustIDBase16(org)
 	return &influxdb.Organization{
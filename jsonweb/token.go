@@ -107,6 +107,12 @@ func (t *Token) Allowed(p influxdb.Permission) bool {
 	return false
 }
 
+// DataRestrictions returns the restrictions the permissions
+// of the Token put on the data of the resource of p
+func (t *Token) DataRestrictions(p influxdb.Permission) influxdb.DataRestrictions {
+	return influxdb.PermissionDataRestrictions(p, t.Permissions)
+}
+
 // Identifier returns the identifier for this Token
 // as found in the standard claims
 func (t *Token) Identifier() influxdb.ID {
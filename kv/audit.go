@@ -0,0 +1,136 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+)
+
+var auditBucket = []byte("auditv1")
+
+var _ influxdb.AuditService = (*Service)(nil)
+
+func (s *Service) initializeAudit(ctx context.Context, tx Tx) error {
+	_, err := s.auditBucket(tx)
+	return err
+}
+
+func (s *Service) auditBucket(tx Tx) (Bucket, error) {
+	b, err := tx.Bucket(auditBucket)
+	if err != nil {
+		return nil, UnexpectedAuditError(err)
+	}
+	return b, nil
+}
+
+// UnexpectedAuditError is used when the error comes from an internal system.
+func UnexpectedAuditError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  "unexpected error retrieving audit bucket",
+		Err:  err,
+		Op:   "kv/audit",
+	}
+}
+
+// auditEventKey orders the audit events by time, then by ID.
+func auditEventKey(e *influxdb.AuditEvent) ([]byte, error) {
+	id, err := e.ID.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	key := make([]byte, 8, 8+len(id))
+	binary.BigEndian.PutUint64(key, uint64(e.Time.UnixNano()))
+	return append(key, id...), nil
+}
+
+// RecordAuditEvent records the audit event and sets its ID and, if not set, its time.
+func (s *Service) RecordAuditEvent(ctx context.Context, e *influxdb.AuditEvent) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		e.ID = s.IDGenerator.ID()
+		if e.Time.IsZero() {
+			e.Time = s.Now()
+		}
+
+		key, err := auditEventKey(e)
+		if err != nil {
+			return err
+		}
+
+		v, err := json.Marshal(e)
+		if err != nil {
+			return &influxdb.Error{
+				Code: influxdb.EInternal,
+				Err:  err,
+			}
+		}
+
+		b, err := s.auditBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		if err := b.Put(key, v); err != nil {
+			return UnexpectedAuditError(err)
+		}
+		return nil
+	})
+}
+
+// FindAuditEvents returns the audit events matching the filter, most recent first.
+func (s *Service) FindAuditEvents(ctx context.Context, filter influxdb.AuditEventFilter, opts ...influxdb.FindOptions) ([]*influxdb.AuditEvent, int, error) {
+	var opt influxdb.FindOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	events := []*influxdb.AuditEvent{}
+	err := s.kv.View(ctx, func(tx Tx) error {
+		b, err := s.auditBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		cur, err := b.Cursor()
+		if err != nil {
+			return err
+		}
+
+		var seen int
+		for k, v := cur.Last(); k != nil; k, v = cur.Prev() {
+			e := &influxdb.AuditEvent{}
+			if err := json.Unmarshal(v, e); err != nil {
+				return &influxdb.Error{
+					Code: influxdb.EInternal,
+					Msg:  "audit event could not be unmarshaled",
+					Err:  err,
+				}
+			}
+
+			// the events before start are all older.
+			if filter.Start != nil && e.Time.Before(*filter.Start) {
+				break
+			}
+			if !filter.Matches(e) {
+				continue
+			}
+
+			seen++
+			if seen <= opt.Offset {
+				continue
+			}
+			events = append(events, e)
+			if opt.Limit > 0 && len(events) >= opt.Limit {
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, len(events), nil
+}
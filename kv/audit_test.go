@@ -0,0 +1,90 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/inmem"
+	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/mock"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestService_FindAuditEvents(t *testing.T) {
+	ctx := context.Background()
+	svc := kv.NewService(zaptest.NewLogger(t), inmem.NewKVStore())
+	svc.IDGenerator = mock.NewMockIDGenerator()
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing audit service: %v", err)
+	}
+
+	var (
+		orgA    = influxdb.ID(10)
+		orgB    = influxdb.ID(11)
+		start   = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		buckets = influxdb.BucketsResourceType
+		deleted = influxdb.AuditDelete
+	)
+	events := []*influxdb.AuditEvent{
+		{OrgID: orgA, ResourceType: influxdb.BucketsResourceType, Action: influxdb.AuditCreate, Time: start},
+		{OrgID: orgB, ResourceType: influxdb.BucketsResourceType, Action: influxdb.AuditCreate, Time: start.Add(time.Minute)},
+		{OrgID: orgA, ResourceType: influxdb.TasksResourceType, Action: influxdb.AuditUpdate, Time: start.Add(2 * time.Minute)},
+		{OrgID: orgA, ResourceType: influxdb.BucketsResourceType, Action: influxdb.AuditDelete, Time: start.Add(3 * time.Minute)},
+	}
+	for _, e := range events {
+		if err := svc.RecordAuditEvent(ctx, e); err != nil {
+			t.Fatalf("failed to record audit event: %v", err)
+		}
+	}
+
+	since := start.Add(time.Minute)
+	tests := []struct {
+		name   string
+		filter influxdb.AuditEventFilter
+		opts   influxdb.FindOptions
+		want   []*influxdb.AuditEvent
+	}{
+		{
+			name: "all events, most recent first",
+			want: []*influxdb.AuditEvent{events[3], events[2], events[1], events[0]},
+		},
+		{
+			name:   "events of an org",
+			filter: influxdb.AuditEventFilter{OrgID: &orgA},
+			want:   []*influxdb.AuditEvent{events[3], events[2], events[0]},
+		},
+		{
+			name:   "events of a resource type and action",
+			filter: influxdb.AuditEventFilter{ResourceType: &buckets, Action: &deleted},
+			want:   []*influxdb.AuditEvent{events[3]},
+		},
+		{
+			name:   "events since a time",
+			filter: influxdb.AuditEventFilter{Start: &since},
+			want:   []*influxdb.AuditEvent{events[3], events[2], events[1]},
+		},
+		{
+			name: "paged events",
+			opts: influxdb.FindOptions{Offset: 1, Limit: 2},
+			want: []*influxdb.AuditEvent{events[2], events[1]},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, n, err := svc.FindAuditEvents(ctx, tt.filter, tt.opts)
+			if err != nil {
+				t.Fatalf("failed to find audit events: %v", err)
+			}
+			if n != len(tt.want) {
+				t.Errorf("expected %d audit events, got %d", len(tt.want), n)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unexpected audit events -want/+got:\n%s", diff)
+			}
+		})
+	}
+}
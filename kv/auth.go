@@ -318,6 +318,13 @@ func (s *Service) createAuthorization(ctx context.Context, tx Tx, a *influxdb.Au
 		return influxdb.ErrUnableToCreateToken
 	}
 
+	if a.ExpiresAt != nil && !a.ExpiresAt.After(s.TimeGenerator.Now()) {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "authorization expiration must be in the future",
+		}
+	}
+
 	if err := s.uniqueAuthToken(ctx, tx, a); err != nil {
 		return err
 	}
@@ -471,6 +478,23 @@ func (s *Service) deleteAuthorization(ctx context.Context, tx Tx, id influxdb.ID
 		return err
 	}
 
+	// the child authorizations are revoked with their parent.
+	var children []influxdb.ID
+	err = s.forEachAuthorization(ctx, tx, nil, func(c *influxdb.Authorization) bool {
+		if c.ParentID == id {
+			children = append(children, c.ID)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		if err := s.deleteAuthorization(ctx, tx, child); err != nil {
+			return err
+		}
+	}
+
 	idx, err := authIndexBucket(tx)
 	if err != nil {
 		return err
@@ -501,6 +525,38 @@ func (s *Service) deleteAuthorization(ctx context.Context, tx Tx, id influxdb.ID
 	return nil
 }
 
+// DeleteExpiredAuthorizations deletes the authorizations that expired, and returns how many were deleted.
+func (s *Service) DeleteExpiredAuthorizations(ctx context.Context) (int, error) {
+	var n int
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		now := s.TimeGenerator.Now()
+		var expired []influxdb.ID
+		err := s.forEachAuthorization(ctx, tx, nil, func(a *influxdb.Authorization) bool {
+			if a.ExpiresAt != nil && !now.Before(*a.ExpiresAt) {
+				expired = append(expired, a.ID)
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, id := range expired {
+			err := s.deleteAuthorization(ctx, tx, id)
+			// the authorization was already deleted with its expired parent.
+			if influxdb.ErrorCode(err) == influxdb.ENotFound {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			n++
+		}
+		return nil
+	})
+	return n, err
+}
+
 // UpdateAuthorization updates the status and description if available.
 func (s *Service) UpdateAuthorization(ctx context.Context, id influxdb.ID, upd *influxdb.AuthorizationUpdate) (*influxdb.Authorization, error) {
 	var a *influxdb.Authorization
@@ -3,9 +3,12 @@ package kv_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/inmem"
 	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/mock"
 	influxdbtesting "github.com/influxdata/influxdb/testing"
 	"go.uber.org/zap/zaptest"
 )
@@ -76,3 +79,58 @@ func initAuthorizationService(s kv.Store, f influxdbtesting.AuthorizationFields,
 		}
 	}
 }
+
+func TestService_DeleteExpiredAuthorizations(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	in := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+
+	ctx := context.Background()
+	svc := kv.NewService(zaptest.NewLogger(t), inmem.NewKVStore())
+	svc.TimeGenerator = mock.TimeGenerator{FakeValue: now}
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing authorization service: %v", err)
+	}
+
+	auths := []*influxdb.Authorization{
+		{ID: 1, Token: "parent", OrgID: 10, UserID: 20, Status: influxdb.Active},
+		{ID: 2, Token: "child", OrgID: 10, UserID: 20, Status: influxdb.Active, ParentID: 1, ExpiresAt: in(time.Hour)},
+		{ID: 3, Token: "expired", OrgID: 10, UserID: 20, Status: influxdb.Active, ExpiresAt: in(-time.Minute)},
+		{ID: 4, Token: "child of expired", OrgID: 10, UserID: 20, Status: influxdb.Active, ParentID: 3, ExpiresAt: in(time.Hour)},
+	}
+	for _, a := range auths {
+		if err := svc.PutAuthorization(ctx, a); err != nil {
+			t.Fatalf("failed to populate authorizations: %v", err)
+		}
+	}
+
+	n, err := svc.DeleteExpiredAuthorizations(ctx)
+	if err != nil {
+		t.Fatalf("failed to delete expired authorizations: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1 expired authorization to be deleted, got %d", n)
+	}
+
+	remaining, _, err := svc.FindAuthorizations(ctx, influxdb.AuthorizationFilter{})
+	if err != nil {
+		t.Fatalf("failed to find authorizations: %v", err)
+	}
+	var ids []influxdb.ID
+	for _, a := range remaining {
+		ids = append(ids, a.ID)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Fatalf("expected authorizations 1 and 2 to remain, got %v", ids)
+	}
+
+	// the child is revoked with its parent.
+	if err := svc.DeleteAuthorization(ctx, 1); err != nil {
+		t.Fatalf("failed to delete authorization: %v", err)
+	}
+	if _, err := svc.FindAuthorizationByID(ctx, 2); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected child authorization to be deleted with its parent, got %v", err)
+	}
+}
@@ -283,6 +283,11 @@ func (s *Service) createOrganization(ctx context.Context, tx Tx, o *influxdb.Org
 	if err := s.validOrganizationName(ctx, tx, o); err != nil {
 		return err
 	}
+	if o.QueryQuotas != nil {
+		if err := o.QueryQuotas.Valid(); err != nil {
+			return err
+		}
+	}
 
 	if o.ID, err = s.generateOrgID(ctx, tx); err != nil {
 		return err
@@ -468,6 +473,13 @@ func (s *Service) updateOrganization(ctx context.Context, tx Tx, id influxdb.ID,
 		o.Description = *upd.Description
 	}
 
+	if upd.QueryQuotas != nil {
+		if err := upd.QueryQuotas.Valid(); err != nil {
+			return nil, err
+		}
+		o.QueryQuotas = upd.QueryQuotas
+	}
+
 	o.UpdatedAt = s.Now()
 
 	if err := s.appendOrganizationEventToLog(ctx, tx, o.ID, organizationUpdatedEvent); err != nil {
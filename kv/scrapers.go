@@ -35,6 +35,12 @@ var (
 		Code: influxdb.EInvalid,
 		Msg:  "provided organization ID has invalid format",
 	}
+
+	// ErrScraperStatusNotFound is used when the scraper target wasn't scraped yet.
+	ErrScraperStatusNotFound = &influxdb.Error{
+		Code: influxdb.ENotFound,
+		Msg:  influxdb.ErrScraperStatusNotFound,
+	}
 )
 
 // UnexpectedScrapersBucketError is used when the error comes from an internal system.
@@ -76,13 +82,18 @@ func InternalScraperServiceError(err error) *influxdb.Error {
 }
 
 var (
-	scrapersBucket = []byte("scraperv2")
+	scrapersBucket      = []byte("scraperv2")
+	scraperStatusBucket = []byte("scraperstatusv1")
 )
 
 var _ influxdb.ScraperTargetStoreService = (*Service)(nil)
+var _ influxdb.ScraperStatusService = (*Service)(nil)
 
 func (s *Service) initializeScraperTargets(ctx context.Context, tx Tx) error {
-	_, err := s.scrapersBucket(tx)
+	if _, err := s.scrapersBucket(tx); err != nil {
+		return err
+	}
+	_, err := s.scraperStatusBucket(tx)
 	return err
 }
 
@@ -95,6 +106,15 @@ func (s *Service) scrapersBucket(tx Tx) (Bucket, error) {
 	return b, nil
 }
 
+func (s *Service) scraperStatusBucket(tx Tx) (Bucket, error) {
+	b, err := tx.Bucket(scraperStatusBucket)
+	if err != nil {
+		return nil, UnexpectedScrapersBucketError(err)
+	}
+
+	return b, nil
+}
+
 // ListTargets will list all scrape targets.
 func (s *Service) ListTargets(ctx context.Context, filter influxdb.ScraperTargetFilter) ([]influxdb.ScraperTarget, error) {
 	targets := []influxdb.ScraperTarget{}
@@ -172,6 +192,10 @@ func (s *Service) addTarget(ctx context.Context, tx Tx, target *influxdb.Scraper
 		return ErrInvalidScrapersBucketID
 	}
 
+	if err := target.Valid(); err != nil {
+		return err
+	}
+
 	target.ID = s.IDGenerator.ID()
 	if err := s.putTarget(ctx, tx, target); err != nil {
 		return err
@@ -220,6 +244,14 @@ func (s *Service) removeTarget(ctx context.Context, tx Tx, id influxdb.ID) error
 		return InternalScraperServiceError(err)
 	}
 
+	statusBucket, err := s.scraperStatusBucket(tx)
+	if err != nil {
+		return err
+	}
+	if err := statusBucket.Delete(encID); err != nil {
+		return InternalScraperServiceError(err)
+	}
+
 	return s.deleteUserResourceMappings(ctx, tx, influxdb.UserResourceMappingFilter{
 		ResourceID:   id,
 		ResourceType: influxdb.ScraperResourceType,
@@ -248,13 +280,19 @@ func (s *Service) updateTarget(ctx context.Context, tx Tx, update *influxdb.Scra
 		return nil, err
 	}
 
-	// If the bucket or org are invalid, just use the ids from the original.
+	// If the bucket, org or type are not set, just use the ones from the original.
 	if !update.BucketID.Valid() {
 		update.BucketID = target.BucketID
 	}
 	if !update.OrgID.Valid() {
 		update.OrgID = target.OrgID
 	}
+	if update.Type == "" {
+		update.Type = target.Type
+	}
+	if err := update.Valid(); err != nil {
+		return nil, err
+	}
 	target = update
 	return target, s.putTarget(ctx, tx, target)
 }
@@ -328,6 +366,67 @@ func (s *Service) putTarget(ctx context.Context, tx Tx, target *influxdb.Scraper
 	return nil
 }
 
+// GetTargetStatus returns the status of the last scrape of a target.
+func (s *Service) GetTargetStatus(ctx context.Context, id influxdb.ID) (*influxdb.ScraperStatus, error) {
+	var status *influxdb.ScraperStatus
+	err := s.kv.View(ctx, func(tx Tx) error {
+		encID, err := id.Encode()
+		if err != nil {
+			return ErrInvalidScraperID
+		}
+
+		bucket, err := s.scraperStatusBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		v, err := bucket.Get(encID)
+		if IsNotFound(err) {
+			return ErrScraperStatusNotFound
+		}
+		if err != nil {
+			return InternalScraperServiceError(err)
+		}
+
+		status = &influxdb.ScraperStatus{}
+		if err := json.Unmarshal(v, status); err != nil {
+			return CorruptScraperError(err)
+		}
+		return nil
+	})
+
+	return status, err
+}
+
+// PutTargetStatus records the status of the last scrape of a target.
+func (s *Service) PutTargetStatus(ctx context.Context, id influxdb.ID, status *influxdb.ScraperStatus) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		// The target could have been removed during the scrape.
+		if _, err := s.findTargetByID(ctx, tx, id); err != nil {
+			return err
+		}
+		encID, err := id.Encode()
+		if err != nil {
+			return ErrInvalidScraperID
+		}
+
+		v, err := json.Marshal(status)
+		if err != nil {
+			return ErrUnprocessableScraper(err)
+		}
+
+		bucket, err := s.scraperStatusBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		if err := bucket.Put(encID, v); err != nil {
+			return UnexpectedScrapersBucketError(err)
+		}
+		return nil
+	})
+}
+
 // unmarshalScraper turns the stored byte slice in the kv into a *influxdb.ScraperTarget.
 func unmarshalScraper(v []byte) (*influxdb.ScraperTarget, error) {
 	s := &influxdb.ScraperTarget{}
@@ -3,7 +3,9 @@ package kv_test
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/kv"
 	influxdbtesting "github.com/influxdata/influxdb/testing"
@@ -14,6 +16,64 @@ func TestBoltScraperTargetStoreService(t *testing.T) {
 	influxdbtesting.ScraperService(initBoltTargetService, t)
 }
 
+func TestBoltScraperTargetStatus(t *testing.T) {
+	s, closeFn, err := NewTestBoltStore(t)
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+	defer closeFn()
+
+	svc := kv.NewService(zaptest.NewLogger(t), s)
+	ctx := context.Background()
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing scraper service: %v", err)
+	}
+
+	target := &influxdb.ScraperTarget{
+		ID:       influxdbtesting.MustIDBase16("020f755c3c082000"),
+		Name:     "name1",
+		Type:     influxdb.PrometheusScraperType,
+		OrgID:    influxdbtesting.MustIDBase16("020f755c3c083000"),
+		BucketID: influxdbtesting.MustIDBase16("020f755c3c084000"),
+		URL:      "url1",
+	}
+	if err := svc.PutTarget(ctx, target); err != nil {
+		t.Fatalf("failed to populate targets: %v", err)
+	}
+
+	if _, err := svc.GetTargetStatus(ctx, target.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected a not found error for a target that wasn't scraped, got %v", err)
+	}
+
+	want := &influxdb.ScraperStatus{
+		LastScrape: time.Unix(1600000000, 0).UTC(),
+		Duration:   influxdb.Duration{Duration: 1500 * time.Millisecond},
+		Samples:    42,
+		LastError:  "connection refused",
+	}
+	if err := svc.PutTargetStatus(ctx, target.ID, want); err != nil {
+		t.Fatalf("failed to put the target status: %v", err)
+	}
+	got, err := svc.GetTargetStatus(ctx, target.ID)
+	if err != nil {
+		t.Fatalf("failed to get the target status: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("target status is different -want/+got\ndiff %s", diff)
+	}
+
+	if err := svc.PutTargetStatus(ctx, influxdbtesting.MustIDBase16("020f755c3c082001"), want); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected a not found error for the status of a missing target, got %v", err)
+	}
+
+	if err := svc.RemoveTarget(ctx, target.ID); err != nil {
+		t.Fatalf("failed to remove the target: %v", err)
+	}
+	if _, err := svc.GetTargetStatus(ctx, target.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected the status to be removed with the target, got %v", err)
+	}
+}
+
 func initBoltTargetService(f influxdbtesting.TargetFields, t *testing.T) (influxdb.ScraperTargetStoreService, string, func()) {
 	s, closeFn, err := NewTestBoltStore(t)
 	if err != nil {
@@ -3,6 +3,7 @@ package kv
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 
@@ -48,6 +49,7 @@ type kvTask struct {
 	LastRunStatus   string                 `json:"lastRunStatus,omitempty"`
 	LastRunError    string                 `json:"lastRunError,omitempty"`
 	Offset          influxdb.Duration      `json:"offset,omitempty"`
+	UpstreamID      influxdb.ID            `json:"upstreamID,omitempty"`
 	LatestCompleted time.Time              `json:"latestCompleted,omitempty"`
 	LatestScheduled time.Time              `json:"latestScheduled,omitempty"`
 	CreatedAt       time.Time              `json:"createdAt,omitempty"`
@@ -71,6 +73,7 @@ func kvToInfluxTask(k *kvTask) *influxdb.Task {
 		LastRunStatus:   k.LastRunStatus,
 		LastRunError:    k.LastRunError,
 		Offset:          k.Offset.Duration,
+		UpstreamID:      k.UpstreamID,
 		LatestCompleted: k.LatestCompleted,
 		LatestScheduled: k.LatestScheduled,
 		CreatedAt:       k.CreatedAt,
@@ -486,6 +489,14 @@ func newTaskMatchFn(f influxdb.TaskFilter, org *influxdb.Organization) func(t *i
 		}
 	}
 
+	if f.Upstream != nil {
+		prevFn := fn
+		fn = func(t *influxdb.Task) bool {
+			res := prevFn == nil || prevFn(t)
+			return res && (t.UpstreamID == *f.Upstream)
+		}
+	}
+
 	return fn
 }
 
@@ -628,6 +639,10 @@ func (s *Service) createTask(ctx context.Context, tx Tx, tc influxdb.TaskCreate)
 
 	}
 
+	if err := s.setUpstream(ctx, tx, task, opt.After); err != nil {
+		return nil, err
+	}
+
 	taskBucket, err := tx.Bucket(taskBucket)
 	if err != nil {
 		return nil, influxdb.ErrUnexpectedTaskBucketErr(err)
@@ -695,6 +710,51 @@ func (s *Service) createTask(ctx context.Context, tx Tx, tc influxdb.TaskCreate)
 	return task, nil
 }
 
+// setUpstream sets the task the given task runs after from the after option of the task.
+// The upstream task must belong to the organization of the task, and its upstream tasks must not lead back to the task.
+func (s *Service) setUpstream(ctx context.Context, tx Tx, task *influxdb.Task, after string) error {
+	task.UpstreamID = 0
+	if after == "" {
+		return nil
+	}
+
+	id, err := influxdb.IDFromString(after)
+	if err != nil {
+		return influxdb.ErrInvalidUpstreamTask(err)
+	}
+	upstream, err := s.findTaskByID(ctx, tx, *id)
+	if err != nil {
+		if err == influxdb.ErrTaskNotFound {
+			return influxdb.ErrInvalidUpstreamTask(err)
+		}
+		return err
+	}
+	if upstream.OrganizationID != task.OrganizationID {
+		return influxdb.ErrInvalidUpstreamTask(fmt.Errorf("task %s belongs to another organization", upstream.ID))
+	}
+
+	// walk up the dependencies, which are acyclic until this task joins them.
+	for up := upstream; ; {
+		if up.ID == task.ID {
+			return influxdb.ErrTaskDependencyCycle
+		}
+		if !up.UpstreamID.Valid() {
+			break
+		}
+		up, err = s.findTaskByID(ctx, tx, up.UpstreamID)
+		if err == influxdb.ErrTaskNotFound {
+			// the chain ends at a deleted task.
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	task.UpstreamID = upstream.ID
+	return nil
+}
+
 func (s *Service) createTaskURM(ctx context.Context, tx Tx, t *influxdb.Task) error {
 	// TODO(jsteenb2): should not be getting authorizer inside the store, should terminate at the
 	//  transport layer then pass user id everywhere else.
@@ -761,6 +821,10 @@ func (s *Service) updateTask(ctx context.Context, tx Tx, id influxdb.ID, upd inf
 			}
 		}
 		task.Offset = off
+
+		if err := s.setUpstream(ctx, tx, task, options.After); err != nil {
+			return nil, err
+		}
 		task.UpdatedAt = updatedAt
 	}
 
@@ -1297,25 +1361,63 @@ func (s *Service) createRun(ctx context.Context, tx Tx, taskID influxdb.ID, sche
 		Log:          []influxdb.Log{},
 	}
 
+	if err := s.putRun(ctx, tx, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// CreateDownstreamRun creates a run of a task with the scheduled for time of the upstream run that triggered it.
+func (s *Service) CreateDownstreamRun(ctx context.Context, taskID influxdb.ID, upstream *influxdb.Run) (*influxdb.Run, error) {
+	var r *influxdb.Run
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		run, err := s.createDownstreamRun(ctx, tx, taskID, upstream)
+		if err != nil {
+			return err
+		}
+		r = run
+		return nil
+	})
+	return r, err
+}
+
+func (s *Service) createDownstreamRun(ctx context.Context, tx Tx, taskID influxdb.ID, upstream *influxdb.Run) (*influxdb.Run, error) {
+	run := influxdb.Run{
+		ID:            s.IDGenerator.ID(),
+		TaskID:        taskID,
+		ScheduledFor:  upstream.ScheduledFor.UTC(),
+		RunAt:         s.clock.Now().UTC(),
+		Status:        influxdb.RunScheduled.String(),
+		UpstreamRunID: upstream.ID,
+		Log:           []influxdb.Log{},
+	}
+
+	if err := s.putRun(ctx, tx, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (s *Service) putRun(ctx context.Context, tx Tx, run *influxdb.Run) error {
 	b, err := tx.Bucket(taskRunBucket)
 	if err != nil {
-		return nil, influxdb.ErrUnexpectedTaskBucketErr(err)
+		return influxdb.ErrUnexpectedTaskBucketErr(err)
 	}
 
 	runBytes, err := json.Marshal(run)
 	if err != nil {
-		return nil, influxdb.ErrInternalTaskServiceError(err)
+		return influxdb.ErrInternalTaskServiceError(err)
 	}
 
-	runKey, err := taskRunKey(taskID, run.ID)
+	runKey, err := taskRunKey(run.TaskID, run.ID)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if err := b.Put(runKey, runBytes); err != nil {
-		return nil, influxdb.ErrUnexpectedTaskBucketErr(err)
+		return influxdb.ErrUnexpectedTaskBucketErr(err)
 	}
 
-	return &run, nil
+	return nil
 }
 
 func (s *Service) CurrentlyRunning(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Run, error) {
@@ -1572,7 +1674,11 @@ func (s *Service) updateRunState(ctx context.Context, tx Tx, taskID, runID influ
 	run.Status = state.String()
 	switch state {
 	case influxdb.RunStarted:
-		run.StartedAt = when
+		// a retried run keeps the time its first attempt started.
+		if run.StartedAt.IsZero() {
+			run.StartedAt = when
+		}
+		run.Attempts++
 	case influxdb.RunSuccess, influxdb.RunFail, influxdb.RunCanceled:
 		run.FinishedAt = when
 	}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 	"time"
 
@@ -238,6 +239,76 @@ func TestService_UpdateTask_InactiveToActive(t *testing.T) {
 	}
 }
 
+func TestService_TaskDependencies(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	ts := newService(t, ctx, nil)
+	defer ts.Close()
+
+	ctx = icontext.SetAuthorizer(ctx, &ts.Auth)
+
+	createTask := func(name, after string) *influxdb.Task {
+		t.Helper()
+		task, err := ts.Service.CreateTask(ctx, influxdb.TaskCreate{
+			Flux:           taskScript(name, after),
+			OrganizationID: ts.Org.ID,
+			OwnerID:        ts.User.ID,
+		})
+		if err != nil {
+			t.Fatal("CreateTask", err)
+		}
+		return task
+	}
+
+	raw := createTask("raw", "")
+	minute := createTask("1m", raw.ID.String())
+	hour := createTask("1h", minute.ID.String())
+	if minute.UpstreamID != raw.ID || hour.UpstreamID != minute.ID {
+		t.Fatalf("unexpected upstream tasks %s and %s", minute.UpstreamID, hour.UpstreamID)
+	}
+
+	tasks, _, err := ts.Service.FindTasks(ctx, influxdb.TaskFilter{Upstream: &raw.ID})
+	if err != nil {
+		t.Fatal("FindTasks", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != minute.ID {
+		t.Fatalf("expected only task %s to run after task %s, got %v", minute.ID, raw.ID, tasks)
+	}
+
+	for _, after := range []influxdb.ID{raw.ID, hour.ID} {
+		flux := taskScript("raw", after.String())
+		if _, err := ts.Service.UpdateTask(ctx, raw.ID, influxdb.TaskUpdate{Flux: &flux}); err != influxdb.ErrTaskDependencyCycle {
+			t.Fatalf("expected a cycle running task %s after task %s, got %v", raw.ID, after, err)
+		}
+	}
+
+	if _, err := ts.Service.CreateTask(ctx, influxdb.TaskCreate{
+		Flux:           taskScript("missing", "020f755c3c082000"),
+		OrganizationID: ts.Org.ID,
+		OwnerID:        ts.User.ID,
+	}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected a missing upstream task to be invalid, got %v", err)
+	}
+
+	// removing the after option schedules the task on its own again.
+	flux := taskScript("1h", "")
+	updated, err := ts.Service.UpdateTask(ctx, hour.ID, influxdb.TaskUpdate{Flux: &flux})
+	if err != nil {
+		t.Fatal("UpdateTask", err)
+	}
+	if updated.UpstreamID.Valid() {
+		t.Fatalf("expected task %s to have no upstream task, got %s", hour.ID, updated.UpstreamID)
+	}
+}
+
+func taskScript(name, after string) string {
+	if after == "" {
+		return fmt.Sprintf(`option task = {name: %q, every: 1m} from(bucket:"test") |> range(start:-1h)`, name)
+	}
+	return fmt.Sprintf(`option task = {name: %q, every: 1m, after: %q} from(bucket:"test") |> range(start:-1h)`, name, after)
+}
+
 func TestTaskRunCancellation(t *testing.T) {
 	store, close, err := NewTestBoltStore(t)
 	if err != nil {
@@ -0,0 +1,38 @@
+package mock
+
+import (
+	"context"
+
+	platform "github.com/influxdata/influxdb"
+)
+
+var _ platform.AuditService = (*AuditService)(nil)
+
+// AuditService is a mock implementation of a platform.AuditService.
+type AuditService struct {
+	RecordAuditEventFn func(ctx context.Context, e *platform.AuditEvent) error
+	FindAuditEventsFn  func(ctx context.Context, filter platform.AuditEventFilter, opts ...platform.FindOptions) ([]*platform.AuditEvent, int, error)
+}
+
+// NewAuditService returns a mock AuditService where its methods will return
+// zero values.
+func NewAuditService() *AuditService {
+	return &AuditService{
+		RecordAuditEventFn: func(ctx context.Context, e *platform.AuditEvent) error {
+			return nil
+		},
+		FindAuditEventsFn: func(ctx context.Context, filter platform.AuditEventFilter, opts ...platform.FindOptions) ([]*platform.AuditEvent, int, error) {
+			return nil, 0, nil
+		},
+	}
+}
+
+// RecordAuditEvent records the audit event.
+func (s *AuditService) RecordAuditEvent(ctx context.Context, e *platform.AuditEvent) error {
+	return s.RecordAuditEventFn(ctx, e)
+}
+
+// FindAuditEvents returns the audit events matching the filter.
+func (s *AuditService) FindAuditEvents(ctx context.Context, filter platform.AuditEventFilter, opts ...platform.FindOptions) ([]*platform.AuditEvent, int, error) {
+	return s.FindAuditEventsFn(ctx, filter, opts...)
+}
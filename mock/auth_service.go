@@ -20,6 +20,9 @@ type AuthorizationService struct {
 	CreateAuthorizationFn      func(context.Context, *platform.Authorization) error
 	DeleteAuthorizationFn      func(context.Context, platform.ID) error
 	UpdateAuthorizationFn      func(context.Context, platform.ID, *platform.AuthorizationUpdate) (*platform.Authorization, error)
+
+	// Methods for a platform.ChildAuthorizationService
+	CreateChildAuthorizationFn func(context.Context, *platform.Authorization) error
 }
 
 // NewAuthorizationService returns a mock AuthorizationService where its methods will return
@@ -31,8 +34,9 @@ func NewAuthorizationService() *AuthorizationService {
 		FindAuthorizationsFn: func(context.Context, platform.AuthorizationFilter, ...platform.FindOptions) ([]*platform.Authorization, int, error) {
 			return nil, 0, nil
 		},
-		CreateAuthorizationFn: func(context.Context, *platform.Authorization) error { return nil },
-		DeleteAuthorizationFn: func(context.Context, platform.ID) error { return nil },
+		CreateAuthorizationFn:      func(context.Context, *platform.Authorization) error { return nil },
+		CreateChildAuthorizationFn: func(context.Context, *platform.Authorization) error { return nil },
+		DeleteAuthorizationFn:      func(context.Context, platform.ID) error { return nil },
 		UpdateAuthorizationFn: func(context.Context, platform.ID, *platform.AuthorizationUpdate) (*platform.Authorization, error) {
 			return nil, nil
 		},
@@ -58,6 +62,11 @@ func (s *AuthorizationService) CreateAuthorization(ctx context.Context, authoriz
 	return s.CreateAuthorizationFn(ctx, authorization)
 }
 
+// CreateChildAuthorization creates a child of the authorization on the context.
+func (s *AuthorizationService) CreateChildAuthorization(ctx context.Context, authorization *platform.Authorization) error {
+	return s.CreateChildAuthorizationFn(ctx, authorization)
+}
+
 // DeleteAuthorization removes a authorization by ID.
 func (s *AuthorizationService) DeleteAuthorization(ctx context.Context, id platform.ID) error {
 	return s.DeleteAuthorizationFn(ctx, id)
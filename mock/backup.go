@@ -0,0 +1,48 @@
+package mock
+
+import (
+	"context"
+	"io"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.BackupService = &BackupService{}
+
+// BackupService is a mock backup service.
+type BackupService struct {
+	CreateBackupF       func(ctx context.Context, filter influxdb.BackupFilter) (int, []string, error)
+	FetchBackupFileF    func(ctx context.Context, backupID int, backupFile string, w io.Writer) error
+	InternalBackupPathF func(backupID int) string
+}
+
+// NewBackupService returns a mock BackupService where its methods will return
+// zero values.
+func NewBackupService() *BackupService {
+	return &BackupService{
+		CreateBackupF: func(ctx context.Context, filter influxdb.BackupFilter) (int, []string, error) {
+			return 0, nil, nil
+		},
+		FetchBackupFileF: func(ctx context.Context, backupID int, backupFile string, w io.Writer) error {
+			return nil
+		},
+		InternalBackupPathF: func(backupID int) string {
+			return ""
+		},
+	}
+}
+
+// CreateBackup calls CreateBackupF.
+func (s *BackupService) CreateBackup(ctx context.Context, filter influxdb.BackupFilter) (int, []string, error) {
+	return s.CreateBackupF(ctx, filter)
+}
+
+// FetchBackupFile calls FetchBackupFileF.
+func (s *BackupService) FetchBackupFile(ctx context.Context, backupID int, backupFile string, w io.Writer) error {
+	return s.FetchBackupFileF(ctx, backupID, backupFile, w)
+}
+
+// InternalBackupPath calls InternalBackupPathF.
+func (s *BackupService) InternalBackupPath(backupID int) string {
+	return s.InternalBackupPathF(backupID)
+}
@@ -10,7 +10,8 @@ var _ influxdb.DeleteService = &DeleteService{}
 
 // DeleteService is a mock delete server.
 type DeleteService struct {
-	DeleteBucketRangePredicateF func(tx context.Context, orgID, bucketID influxdb.ID, min, max int64, pred influxdb.Predicate) error
+	DeleteBucketRangePredicateF         func(tx context.Context, orgID, bucketID influxdb.ID, min, max int64, pred influxdb.Predicate) error
+	EstimateDeleteBucketRangePredicateF func(tx context.Context, orgID, bucketID influxdb.ID, min, max int64, pred influxdb.Predicate) ([]influxdb.DeleteEstimate, error)
 }
 
 // NewDeleteService returns a mock DeleteService where its methods will return
@@ -20,6 +21,9 @@ func NewDeleteService() DeleteService {
 		DeleteBucketRangePredicateF: func(tx context.Context, orgID, bucketID influxdb.ID, min, max int64, pred influxdb.Predicate) error {
 			return nil
 		},
+		EstimateDeleteBucketRangePredicateF: func(tx context.Context, orgID, bucketID influxdb.ID, min, max int64, pred influxdb.Predicate) ([]influxdb.DeleteEstimate, error) {
+			return nil, nil
+		},
 	}
 }
 
@@ -27,3 +31,8 @@ func NewDeleteService() DeleteService {
 func (s DeleteService) DeleteBucketRangePredicate(ctx context.Context, orgID, bucketID influxdb.ID, min, max int64, pred influxdb.Predicate) error {
 	return s.DeleteBucketRangePredicateF(ctx, orgID, bucketID, min, max, pred)
 }
+
+// EstimateDeleteBucketRangePredicate calls EstimateDeleteBucketRangePredicateF.
+func (s DeleteService) EstimateDeleteBucketRangePredicate(ctx context.Context, orgID, bucketID influxdb.ID, min, max int64, pred influxdb.Predicate) ([]influxdb.DeleteEstimate, error) {
+	return s.EstimateDeleteBucketRangePredicateF(ctx, orgID, bucketID, min, max, pred)
+}
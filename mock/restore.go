@@ -0,0 +1,30 @@
+package mock
+
+import (
+	"context"
+	"io"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.RestoreService = &RestoreService{}
+
+// RestoreService is a mock restore service.
+type RestoreService struct {
+	RestoreBucketF func(ctx context.Context, req influxdb.RestoreBucketRequest, r io.Reader) error
+}
+
+// NewRestoreService returns a mock RestoreService where its methods will return
+// zero values.
+func NewRestoreService() *RestoreService {
+	return &RestoreService{
+		RestoreBucketF: func(ctx context.Context, req influxdb.RestoreBucketRequest, r io.Reader) error {
+			return nil
+		},
+	}
+}
+
+// RestoreBucket calls RestoreBucketF.
+func (s *RestoreService) RestoreBucket(ctx context.Context, req influxdb.RestoreBucketRequest, r io.Reader) error {
+	return s.RestoreBucketF(ctx, req, r)
+}
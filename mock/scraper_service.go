@@ -43,3 +43,21 @@ func (s *ScraperTargetStoreService) RemoveTarget(ctx context.Context, id platfor
 func (s *ScraperTargetStoreService) UpdateTarget(ctx context.Context, t *platform.ScraperTarget, userID platform.ID) (*platform.ScraperTarget, error) {
 	return s.UpdateTargetF(ctx, t, userID)
 }
+
+var _ platform.ScraperStatusService = &ScraperStatusService{}
+
+// ScraperStatusService is a mock implementation of a platform.ScraperStatusService.
+type ScraperStatusService struct {
+	GetTargetStatusF func(ctx context.Context, id platform.ID) (*platform.ScraperStatus, error)
+	PutTargetStatusF func(ctx context.Context, id platform.ID, status *platform.ScraperStatus) error
+}
+
+// GetTargetStatus returns the status of the last scrape of a target.
+func (s *ScraperStatusService) GetTargetStatus(ctx context.Context, id platform.ID) (*platform.ScraperStatus, error) {
+	return s.GetTargetStatusF(ctx, id)
+}
+
+// PutTargetStatus records the status of the last scrape of a target.
+func (s *ScraperStatusService) PutTargetStatus(ctx context.Context, id platform.ID, status *platform.ScraperStatus) error {
+	return s.PutTargetStatusF(ctx, id, status)
+}
@@ -129,19 +129,55 @@ func (s *TaskService) ForceRun(ctx context.Context, taskID influxdb.ID, schedule
 	return s.ForceRunFn(ctx, taskID, scheduledFor)
 }
 
+var _ influxdb.TaskBackfillService = (*TaskBackfillService)(nil)
+
+// TaskBackfillService is a mock implementation of influxdb.TaskBackfillService.
+type TaskBackfillService struct {
+	BackfillTaskFn     func(context.Context, influxdb.ID, time.Time, time.Time) (*influxdb.TaskBackfill, error)
+	FindBackfillByIDFn func(context.Context, influxdb.ID, influxdb.ID) (*influxdb.TaskBackfill, error)
+	CancelBackfillFn   func(context.Context, influxdb.ID, influxdb.ID) error
+}
+
+func (s *TaskBackfillService) BackfillTask(ctx context.Context, taskID influxdb.ID, start, stop time.Time) (*influxdb.TaskBackfill, error) {
+	return s.BackfillTaskFn(ctx, taskID, start, stop)
+}
+
+func (s *TaskBackfillService) FindBackfillByID(ctx context.Context, taskID, backfillID influxdb.ID) (*influxdb.TaskBackfill, error) {
+	return s.FindBackfillByIDFn(ctx, taskID, backfillID)
+}
+
+func (s *TaskBackfillService) CancelBackfill(ctx context.Context, taskID, backfillID influxdb.ID) error {
+	return s.CancelBackfillFn(ctx, taskID, backfillID)
+}
+
+var _ influxdb.TaskStatsService = (*TaskStatsService)(nil)
+
+// TaskStatsService is a mock implementation of influxdb.TaskStatsService.
+type TaskStatsService struct {
+	FindTaskStatsFn func(context.Context, influxdb.ID, time.Time, time.Time) (*influxdb.TaskStats, error)
+}
+
+func (s *TaskStatsService) FindTaskStats(ctx context.Context, taskID influxdb.ID, start, stop time.Time) (*influxdb.TaskStats, error) {
+	return s.FindTaskStatsFn(ctx, taskID, start, stop)
+}
+
 type TaskControlService struct {
-	CreateRunFn        func(ctx context.Context, taskID influxdb.ID, scheduledFor time.Time, runAt time.Time) (*influxdb.Run, error)
-	CurrentlyRunningFn func(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Run, error)
-	ManualRunsFn       func(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Run, error)
-	StartManualRunFn   func(ctx context.Context, taskID, runID influxdb.ID) (*influxdb.Run, error)
-	FinishRunFn        func(ctx context.Context, taskID, runID influxdb.ID) (*influxdb.Run, error)
-	UpdateRunStateFn   func(ctx context.Context, taskID, runID influxdb.ID, when time.Time, state influxdb.RunStatus) error
-	AddRunLogFn        func(ctx context.Context, taskID, runID influxdb.ID, when time.Time, log string) error
+	CreateRunFn           func(ctx context.Context, taskID influxdb.ID, scheduledFor time.Time, runAt time.Time) (*influxdb.Run, error)
+	CreateDownstreamRunFn func(ctx context.Context, taskID influxdb.ID, upstream *influxdb.Run) (*influxdb.Run, error)
+	CurrentlyRunningFn    func(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Run, error)
+	ManualRunsFn          func(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Run, error)
+	StartManualRunFn      func(ctx context.Context, taskID, runID influxdb.ID) (*influxdb.Run, error)
+	FinishRunFn           func(ctx context.Context, taskID, runID influxdb.ID) (*influxdb.Run, error)
+	UpdateRunStateFn      func(ctx context.Context, taskID, runID influxdb.ID, when time.Time, state influxdb.RunStatus) error
+	AddRunLogFn           func(ctx context.Context, taskID, runID influxdb.ID, when time.Time, log string) error
 }
 
 func (tcs *TaskControlService) CreateRun(ctx context.Context, taskID influxdb.ID, scheduledFor time.Time, runAt time.Time) (*influxdb.Run, error) {
 	return tcs.CreateRunFn(ctx, taskID, scheduledFor, runAt)
 }
+func (tcs *TaskControlService) CreateDownstreamRun(ctx context.Context, taskID influxdb.ID, upstream *influxdb.Run) (*influxdb.Run, error) {
+	return tcs.CreateDownstreamRunFn(ctx, taskID, upstream)
+}
 func (tcs *TaskControlService) CurrentlyRunning(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Run, error) {
 	return tcs.CurrentlyRunningFn(ctx, taskID)
 }
@@ -10,9 +10,33 @@ type Organization struct {
 	ID          ID     `json:"id,omitempty"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	// QueryQuotas override the quotas of the query controller for the queries of the organization.
+	QueryQuotas *OrgQueryQuotas `json:"queryQuotas,omitempty"`
 	CRUDLog
 }
 
+// OrgQueryQuotas are the quotas of the queries of an organization.
+// A quota that is not set leaves the quota of the query controller in place.
+type OrgQueryQuotas struct {
+	// ConcurrencyQuota is the number of queries of the organization that are allowed to execute concurrently.
+	ConcurrencyQuota int `json:"concurrencyQuota,omitempty"`
+	// MemoryBytesQuotaPerQuery is the maximum number of bytes a query of the organization is allowed to use.
+	MemoryBytesQuotaPerQuery int64 `json:"memoryBytesQuotaPerQuery,omitempty"`
+	// QueueSize is the number of queries of the organization that are allowed to be awaiting execution.
+	QueueSize int `json:"queueSize,omitempty"`
+}
+
+// Valid returns an error if a quota is negative.
+func (q *OrgQueryQuotas) Valid() error {
+	if q.ConcurrencyQuota < 0 || q.MemoryBytesQuotaPerQuery < 0 || q.QueueSize < 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "org query quotas must not be negative",
+		}
+	}
+	return nil
+}
+
 // errors of org
 var (
 	// ErrOrgNameisEmpty is error when org name is empty
@@ -60,7 +84,8 @@ type OrganizationService interface {
 // Only fields which are set are updated.
 type OrganizationUpdate struct {
 	Name        *string
-	Description *string `json:"description,omitempty"`
+	Description *string         `json:"description,omitempty"`
+	QueryQuotas *OrgQueryQuotas `json:"queryQuotas,omitempty"`
 }
 
 // ErrInvalidOrgFilter is the error indicate org filter is empty
@@ -11,6 +11,11 @@ func Duration(d time.Duration) *time.Duration {
 	return &d
 }
 
+// Float64 returns a pointer to its argument.
+func Float64(f float64) *float64 {
+	return &f
+}
+
 // Int returns a pointer to its argument.
 func Int(i int) *int {
 	return &i
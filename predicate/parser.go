@@ -1,8 +1,11 @@
 package predicate
 
 import (
+	"bufio"
 	"fmt"
+	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxql"
@@ -19,6 +22,7 @@ type buffer [3]struct {
 // such a statement `(a = "a" or b!="b") and c ! =~/efg/`
 // to the predicate node
 type parser struct {
+	r         *bufio.Reader
 	sc        *influxql.Scanner
 	i         int // buffer index
 	n         int // buffer size
@@ -67,85 +71,57 @@ func Parse(sts string) (n Node, err error) {
 	if sts == "" {
 		return nil, nil
 	}
+	return newParser(sts).parseLogicalNode()
+}
+
+// newParser returns a parser of the predicate statement.
+func newParser(sts string) *parser {
 	p := new(parser)
-	p.sc = influxql.NewScanner(strings.NewReader(sts))
-	return p.parseLogicalNode()
+	// The scanner uses the buffered reader as is, so it can be peeked
+	// before a regex is scanned.
+	p.r = bufio.NewReader(strings.NewReader(sts))
+	p.sc = influxql.NewScanner(p.r)
+	return p
 }
 
+// parseLogicalNode parses the expressions joined by OR up to
+// the end of the statement or the closing parenthesis.
 func (p *parser) parseLogicalNode() (Node, error) {
-	n := new(LogicalNode)
+	n, err := p.parseAndNode()
+	if err != nil {
+		return n, err
+	}
 	for {
 		tok, pos, _ := p.scanIgnoreWhitespace()
 		switch tok {
-		case influxql.NUMBER, influxql.INTEGER, influxql.NAME, influxql.IDENT:
-			p.unscan()
-			tr, err := p.parseTagRuleNode()
-			if err != nil {
-				return *n, err
-			}
-			if n.Children[0] == nil {
-				n.Children[0] = tr
-			} else {
-				n.Children[1] = tr
-			}
-		case influxql.AND:
-			n.Operator = LogicalAnd
-			if n.Children[1] == nil {
-				continue
-			}
-			var n1 Node
-			var err error
-			if tokNext := p.peekTok(); tokNext == influxql.LPAREN {
-				n1, err = p.parseLogicalNode()
-			} else {
-				n1, err = p.parseTagRuleNode()
-			}
-			if err != nil {
-				return *n, err
-			}
-			n = &LogicalNode{
-				Children: [2]Node{*n, n1},
-				Operator: LogicalAnd,
-			}
 		case influxql.OR:
-			return *n, &influxdb.Error{
-				Code: influxdb.EInvalid,
-				Msg:  fmt.Sprintf("the logical operator OR is not supported yet at position %d", pos.Char),
-			}
-		case influxql.LPAREN:
-			p.openParen++
-			currParen := p.openParen
-			n1, err := p.parseLogicalNode()
+			n1, err := p.parseAndNode()
 			if err != nil {
-				return *n, err
+				return n, err
 			}
-			if p.openParen != currParen-1 {
-				return *n, &influxdb.Error{
-					Code: influxdb.EInvalid,
-					Msg:  fmt.Sprintf("extra ( seen"),
-				}
-			}
-			if n.Children[0] == nil {
-				n.Children[0] = n1
-			} else {
-				n.Children[1] = n1
+			n = LogicalNode{
+				Children: [2]Node{n, n1},
+				Operator: LogicalOr,
 			}
 		case influxql.RPAREN:
 			p.openParen--
-			fallthrough
-		case influxql.EOF:
 			if p.openParen < 0 {
-				return *n, &influxdb.Error{
+				return n, &influxdb.Error{
 					Code: influxdb.EInvalid,
 					Msg:  fmt.Sprintf("extra ) seen"),
 				}
 			}
-			if n.Children[1] == nil {
-				return n.Children[0], nil
+			return n, nil
+		case influxql.EOF:
+			if p.openParen > 0 {
+				return n, &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  fmt.Sprintf("extra ( seen"),
+				}
 			}
-			return *n, nil
+			return n, nil
 		default:
-			return *n, &influxdb.Error{
+			return n, &influxdb.Error{
 				Code: influxdb.EInvalid,
 				Msg:  fmt.Sprintf("bad logical expression, at position %d", pos.Char),
 			}
@@ -153,6 +129,53 @@ func (p *parser) parseLogicalNode() (Node, error) {
 	}
 }
 
+// parseAndNode parses the expressions joined by AND, AND binds
+// tighter than OR.
+func (p *parser) parseAndNode() (Node, error) {
+	n, err := p.parseUnaryNode()
+	if err != nil {
+		return n, err
+	}
+	for p.peekTok() == influxql.AND {
+		p.scanIgnoreWhitespace()
+		n1, err := p.parseUnaryNode()
+		if err != nil {
+			return n, err
+		}
+		n = LogicalNode{
+			Children: [2]Node{n, n1},
+			Operator: LogicalAnd,
+		}
+	}
+	return n, nil
+}
+
+// parseUnaryNode parses a tag rule or an expression in parentheses.
+func (p *parser) parseUnaryNode() (Node, error) {
+	tok, pos, _ := p.scanIgnoreWhitespace()
+	switch tok {
+	case influxql.NUMBER, influxql.INTEGER, influxql.NAME, influxql.IDENT:
+		p.unscan()
+		return p.parseTagRuleNode()
+	case influxql.LPAREN:
+		p.openParen++
+		return p.parseLogicalNode()
+	case influxql.EOF:
+		if p.openParen > 0 {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("extra ( seen"),
+			}
+		}
+		fallthrough
+	default:
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("bad logical expression, at position %d", pos.Char),
+		}
+	}
+}
+
 func (p *parser) parseTagRuleNode() (TagRuleNode, error) {
 	n := new(TagRuleNode)
 	// scan the key
@@ -178,12 +201,11 @@ func (p *parser) parseTagRuleNode() (TagRuleNode, error) {
 		n.Operator = influxdb.NotEqual
 		goto scanRegularTagValue
 	case influxql.EQREGEX:
-		fallthrough
+		n.Operator = influxdb.RegexEqual
+		return p.scanRegexTagValue(n)
 	case influxql.NEQREGEX:
-		return *n, &influxdb.Error{
-			Code: influxdb.EInvalid,
-			Msg:  fmt.Sprintf("operator: %q at position: %d is not supported yet", tok.String(), pos.Char),
-		}
+		n.Operator = influxdb.NotRegexEqual
+		return p.scanRegexTagValue(n)
 	default:
 		return *n, &influxdb.Error{
 			Code: influxdb.EInvalid,
@@ -220,6 +242,41 @@ scanRegularTagValue:
 	}
 }
 
+// scanRegexTagValue scans the regex value of the tag rule.
+func (p *parser) scanRegexTagValue(n *TagRuleNode) (TagRuleNode, error) {
+	// The regex can't be read as tokens, so the whitespace before it is
+	// skipped by peeking the reader shared with the scanner.
+	if ch, _, err := p.r.ReadRune(); err == nil {
+		_ = p.r.UnreadRune()
+		if unicode.IsSpace(ch) {
+			p.sc.Scan()
+		}
+	}
+	tok, pos, lit := p.sc.ScanRegex()
+	switch tok {
+	case influxql.REGEX:
+	case influxql.BADESCAPE:
+		return *n, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("bad escape in regex, at position %d", pos.Char),
+		}
+	default:
+		return *n, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("bad regex, at position %d", pos.Char),
+		}
+	}
+	if _, err := regexp.Compile(lit); err != nil {
+		return *n, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("bad regex %q, at position %d", lit, pos.Char),
+			Err:  err,
+		}
+	}
+	n.Value = lit
+	return *n, nil
+}
+
 // peekRune returns the next rune that would be read by the scanner.
 func (p *parser) peekTok() influxql.Token {
 	tok, _, _ := p.scanIgnoreWhitespace()
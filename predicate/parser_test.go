@@ -2,13 +2,11 @@ package predicate
 
 import (
 	"fmt"
-	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/influxdb"
 	influxtesting "github.com/influxdata/influxdb/testing"
-	"github.com/influxdata/influxql"
 )
 
 func TestParseNode(t *testing.T) {
@@ -40,9 +38,39 @@ func TestParseNode(t *testing.T) {
 		},
 		{
 			str: ` abc="opq" Or gender="male" OR temp=1123`,
+			node: LogicalNode{Operator: LogicalOr, Children: [2]Node{
+				LogicalNode{Operator: LogicalOr, Children: [2]Node{
+					TagRuleNode{Tag: influxdb.Tag{Key: "abc", Value: "opq"}},
+					TagRuleNode{Tag: influxdb.Tag{Key: "gender", Value: "male"}},
+				}},
+				TagRuleNode{Tag: influxdb.Tag{Key: "temp", Value: "1123"}},
+			}},
+		},
+		{
+			str: `abc="opq" or gender="male" and temp=1123`,
+			node: LogicalNode{Operator: LogicalOr, Children: [2]Node{
+				TagRuleNode{Tag: influxdb.Tag{Key: "abc", Value: "opq"}},
+				LogicalNode{Operator: LogicalAnd, Children: [2]Node{
+					TagRuleNode{Tag: influxdb.Tag{Key: "gender", Value: "male"}},
+					TagRuleNode{Tag: influxdb.Tag{Key: "temp", Value: "1123"}},
+				}},
+			}},
+		},
+		{
+			str: `_measurement="cpu" and (_field="usage" or host=~/^web-\d+$/)`,
+			node: LogicalNode{Operator: LogicalAnd, Children: [2]Node{
+				TagRuleNode{Tag: influxdb.Tag{Key: "_measurement", Value: "cpu"}},
+				LogicalNode{Operator: LogicalOr, Children: [2]Node{
+					TagRuleNode{Tag: influxdb.Tag{Key: "_field", Value: "usage"}},
+					TagRuleNode{Tag: influxdb.Tag{Key: "host", Value: `^web-\d+$`}, Operator: influxdb.RegexEqual},
+				}},
+			}},
+		},
+		{
+			str: `abc="opq" or`,
 			err: &influxdb.Error{
 				Code: influxdb.EInvalid,
-				Msg:  "the logical operator OR is not supported yet at position 11",
+				Msg:  "bad logical expression, at position 13",
 			},
 		},
 		{
@@ -133,17 +161,32 @@ func TestParseTagRule(t *testing.T) {
 			node: TagRuleNode{Tag: influxdb.Tag{Key: "abc", Value: "false"}, Operator: influxdb.Equal},
 		},
 		{
-			str: `abc!~/^payments\./`,
+			str:  `abc!~/^payments\./`,
+			node: TagRuleNode{Tag: influxdb.Tag{Key: "abc", Value: `^payments\.`}, Operator: influxdb.NotRegexEqual},
+		},
+		{
+			str:  `abc =~ /^payments\/v[0-9]/`,
+			node: TagRuleNode{Tag: influxdb.Tag{Key: "abc", Value: `^payments/v[0-9]`}, Operator: influxdb.RegexEqual},
+		},
+		{
+			str: `abc=~/^payments(/`,
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  `bad regex "^payments(", at position 4`,
+			},
+		},
+		{
+			str: `abc=~/^payments`,
 			err: &influxdb.Error{
 				Code: influxdb.EInvalid,
-				Msg:  `operator: "!~" at position: 3 is not supported yet`,
+				Msg:  `bad regex, at position 4`,
 			},
 		},
 		{
-			str: `abc=~/^payments\./`,
+			str: `abc=~"payments"`,
 			err: &influxdb.Error{
 				Code: influxdb.EInvalid,
-				Msg:  `operator: "=~" at position: 3 is not supported yet`,
+				Msg:  `bad regex, at position 4`,
 			},
 		},
 		{
@@ -155,9 +198,7 @@ func TestParseTagRule(t *testing.T) {
 		},
 	}
 	for _, c := range cases {
-		p := new(parser)
-		p.sc = influxql.NewScanner(strings.NewReader(c.str))
-		tr, err := p.parseTagRuleNode()
+		tr, err := newParser(c.str).parseTagRuleNode()
 		influxtesting.ErrorsEqual(t, err, c.err)
 		if c.err == nil {
 			if diff := cmp.Diff(tr, c.node); diff != "" {
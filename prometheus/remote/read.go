@@ -0,0 +1,341 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/storage/reads"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+)
+
+// the keys of the measurement and field of the tags of the series of a result set.
+const (
+	measurementKey = "_measurement"
+	fieldKey       = "_field"
+
+	infBucket = "+Inf"
+)
+
+// Read reads the series of the bucket selected by the query from the store,
+// mapping the measurements, tags and fields back to the series they are recorded from by Points.
+// Only the series allowed by the data restrictions are read; nil restrictions allow every series.
+func Read(ctx context.Context, store reads.Store, orgID, bucketID influxdb.ID, q *Query, restrictions influxdb.DataRestrictions) (*QueryResult, error) {
+	ms, err := compileMatchers(q.Matchers)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := types.MarshalAny(store.GetSource(uint64(orgID), uint64(bucketID)))
+	if err != nil {
+		return nil, err
+	}
+
+	req := &datatypes.ReadFilterRequest{
+		ReadSource: src,
+		Range: datatypes.TimestampRange{
+			Start: q.StartTimestampMs * int64(time.Millisecond),
+			// the end of the query is inclusive, the end of the range exclusive.
+			End: q.EndTimestampMs*int64(time.Millisecond) + 1,
+		},
+		Predicate: predicate(ms),
+	}
+
+	rs, err := store.ReadFilter(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored []*storedSeries
+	if rs != nil {
+		stored, err = readSeries(rs, restrictions)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &QueryResult{Timeseries: timeSeries(stored, ms)}, nil
+}
+
+// storedSeries is a series of a measurement and field as stored.
+type storedSeries struct {
+	measurement string
+	field       string
+	tags        models.Tags
+	samples     []*Sample
+}
+
+// key returns the key of the measurement and tags of the series, shared by the fields of a histogram or summary.
+func (s *storedSeries) key() string {
+	return s.measurement + "," + string(s.tags.HashKey())
+}
+
+// readSeries reads the series of the result set allowed by the restrictions.
+func readSeries(rs reads.ResultSet, restrictions influxdb.DataRestrictions) ([]*storedSeries, error) {
+	defer rs.Close()
+
+	var series []*storedSeries
+	for rs.Next() {
+		tags := rs.Tags()
+		m := tags.GetString(measurementKey)
+		if !restrictions.Allows(m, tags.GetString) {
+			continue
+		}
+
+		s := &storedSeries{
+			measurement: m,
+			field:       tags.GetString(fieldKey),
+			tags:        make(models.Tags, 0, len(tags)),
+		}
+		for _, t := range tags {
+			if k := string(t.Key); k != measurementKey && k != fieldKey {
+				s.tags = append(s.tags, t.Clone())
+			}
+		}
+
+		samples, err := readSamples(rs.Cursor())
+		if err != nil {
+			return nil, err
+		}
+		if len(samples) == 0 {
+			continue
+		}
+		s.samples = samples
+		series = append(series, s)
+	}
+	return series, rs.Err()
+}
+
+// readSamples reads the numeric values of the cursor, the values of other types are not samples.
+func readSamples(cur cursors.Cursor) ([]*Sample, error) {
+	defer cur.Close()
+
+	var samples []*Sample
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			for i, t := range a.Timestamps {
+				samples = append(samples, &Sample{Value: a.Values[i], Timestamp: t / int64(time.Millisecond)})
+			}
+		}
+	case cursors.IntegerArrayCursor:
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			for i, t := range a.Timestamps {
+				samples = append(samples, &Sample{Value: float64(a.Values[i]), Timestamp: t / int64(time.Millisecond)})
+			}
+		}
+	case cursors.UnsignedArrayCursor:
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			for i, t := range a.Timestamps {
+				samples = append(samples, &Sample{Value: float64(a.Values[i]), Timestamp: t / int64(time.Millisecond)})
+			}
+		}
+	}
+	return samples, cur.Err()
+}
+
+// timeSeries returns the series the stored series are recorded from that match the matchers, sorted by their labels.
+func timeSeries(stored []*storedSeries, ms []*matcher) []*TimeSeries {
+	// the numeric fields of a histogram are its buckets, the largest being +Inf;
+	// the numeric fields of a summary are its quantiles.
+	histograms := make(map[string]bool)
+	for _, s := range stored {
+		if s.field == infBucket {
+			histograms[s.key()] = true
+		}
+	}
+
+	series := make([]*TimeSeries, 0, len(stored))
+	keys := make(map[*TimeSeries]string, len(stored))
+	for _, s := range stored {
+		labels := make(map[string]string, len(s.tags)+2)
+		for _, t := range s.tags {
+			labels[string(t.Key)] = string(t.Value)
+		}
+
+		switch s.field {
+		case valueField, "counter", "gauge":
+			labels[nameLabel] = s.measurement
+		case sumField:
+			labels[nameLabel] = s.measurement + sumSuffix
+		case countField:
+			labels[nameLabel] = s.measurement + countSuffix
+		default:
+			if histograms[s.key()] {
+				labels[nameLabel] = s.measurement + bucketSuffix
+				labels[bucketLabel] = s.field
+			} else {
+				labels[nameLabel] = s.measurement
+				labels[quantileLabel] = s.field
+			}
+		}
+
+		if !matchesAll(ms, labels) {
+			continue
+		}
+
+		ts := &TimeSeries{
+			Labels:  make([]*Label, 0, len(labels)),
+			Samples: s.samples,
+		}
+		for k, v := range labels {
+			ts.Labels = append(ts.Labels, &Label{Name: k, Value: v})
+		}
+		sort.Slice(ts.Labels, func(i, j int) bool { return ts.Labels[i].Name < ts.Labels[j].Name })
+
+		var key strings.Builder
+		for _, l := range ts.Labels {
+			key.WriteString(l.Name + "\xff" + l.Value + "\xfe")
+		}
+		keys[ts] = key.String()
+		series = append(series, ts)
+	}
+
+	sort.SliceStable(series, func(i, j int) bool { return keys[series[i]] < keys[series[j]] })
+	return series
+}
+
+// matcher is a label matcher with its compiled regular expression.
+type matcher struct {
+	*LabelMatcher
+	re *regexp.Regexp
+}
+
+func compileMatchers(lms []*LabelMatcher) ([]*matcher, error) {
+	ms := make([]*matcher, 0, len(lms))
+	for _, lm := range lms {
+		m := &matcher{LabelMatcher: lm}
+		switch lm.Type {
+		case MatchEqual, MatchNotEqual:
+		case MatchRegex, MatchNotRegex:
+			// the regular expressions of Prometheus are fully anchored.
+			re, err := regexp.Compile("^(?:" + lm.Value + ")$")
+			if err != nil {
+				return nil, &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Op:   "prometheus/remote/Read",
+					Msg:  fmt.Sprintf("invalid regular expression of label %s", lm.Name),
+					Err:  err,
+				}
+			}
+			m.re = re
+		default:
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Op:   "prometheus/remote/Read",
+				Msg:  fmt.Sprintf("unknown match type %d of label %s", lm.Type, lm.Name),
+			}
+		}
+		ms = append(ms, m)
+	}
+	return ms, nil
+}
+
+// matches returns whether the value of the label matches, a missing label having the empty value.
+func (m *matcher) matches(v string) bool {
+	switch m.Type {
+	case MatchEqual:
+		return v == m.Value
+	case MatchNotEqual:
+		return v != m.Value
+	case MatchRegex:
+		return m.re.MatchString(v)
+	case MatchNotRegex:
+		return !m.re.MatchString(v)
+	}
+	return false
+}
+
+func matchesAll(ms []*matcher, labels map[string]string) bool {
+	for _, m := range ms {
+		if !m.matches(labels[m.Name]) {
+			return false
+		}
+	}
+	return true
+}
+
+// predicate returns the predicate of the storage selecting the series the matchers may match.
+// Only the matchers that cannot match a missing label are pushed down to the storage,
+// the series read are matched again once mapped back to their labels.
+func predicate(ms []*matcher) *datatypes.Predicate {
+	var nodes []*datatypes.Node
+	for _, m := range ms {
+		if m.matches("") {
+			continue
+		}
+
+		switch m.Name {
+		case bucketLabel, quantileLabel:
+			// the bucket and quantile labels are fields.
+			continue
+		case nameLabel:
+			if m.Type != MatchEqual {
+				continue
+			}
+			// the metric may be recorded as a field of a histogram or summary.
+			names := []string{m.Value}
+			for _, suffix := range []string{bucketSuffix, sumSuffix, countSuffix} {
+				if strings.HasSuffix(m.Value, suffix) {
+					names = append(names, strings.TrimSuffix(m.Value, suffix))
+				}
+			}
+			or := &datatypes.Node{
+				NodeType: datatypes.NodeTypeLogicalExpression,
+				Value:    &datatypes.Node_Logical_{Logical: datatypes.LogicalOr},
+			}
+			for _, name := range names {
+				or.Children = append(or.Children, comparison(models.MeasurementTagKey, datatypes.ComparisonEqual, &datatypes.Node{
+					NodeType: datatypes.NodeTypeLiteral,
+					Value:    &datatypes.Node_StringValue{StringValue: name},
+				}))
+			}
+			nodes = append(nodes, or)
+		default:
+			switch m.Type {
+			case MatchEqual:
+				nodes = append(nodes, comparison(m.Name, datatypes.ComparisonEqual, &datatypes.Node{
+					NodeType: datatypes.NodeTypeLiteral,
+					Value:    &datatypes.Node_StringValue{StringValue: m.Value},
+				}))
+			case MatchRegex:
+				nodes = append(nodes, comparison(m.Name, datatypes.ComparisonRegex, &datatypes.Node{
+					NodeType: datatypes.NodeTypeLiteral,
+					Value:    &datatypes.Node_RegexValue{RegexValue: m.re.String()},
+				}))
+			}
+		}
+	}
+
+	switch len(nodes) {
+	case 0:
+		return nil
+	case 1:
+		return &datatypes.Predicate{Root: nodes[0]}
+	}
+	return &datatypes.Predicate{
+		Root: &datatypes.Node{
+			NodeType: datatypes.NodeTypeLogicalExpression,
+			Value:    &datatypes.Node_Logical_{Logical: datatypes.LogicalAnd},
+			Children: nodes,
+		},
+	}
+}
+
+// comparison returns the comparison of the tag to the literal.
+func comparison(tag string, op datatypes.Node_Comparison, literal *datatypes.Node) *datatypes.Node {
+	return &datatypes.Node{
+		NodeType: datatypes.NodeTypeComparisonExpression,
+		Value:    &datatypes.Node_Comparison_{Comparison: op},
+		Children: []*datatypes.Node{
+			{NodeType: datatypes.NodeTypeTagRef, Value: &datatypes.Node_TagRefValue{TagRefValue: tag}},
+			literal,
+		},
+	}
+}
@@ -0,0 +1,233 @@
+package remote_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/prometheus/remote"
+	"github.com/influxdata/influxdb/storage/reads"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+)
+
+func TestRead(t *testing.T) {
+	stored := []series{
+		{
+			tags:       models.ParseTags([]byte("m,_field=counter,_measurement=http_requests_total,code=200")),
+			timestamps: []int64{1575194400000000000, 1575194415000000000},
+			values:     []float64{10, 12},
+		},
+		{
+			tags:       models.ParseTags([]byte("m,_field=counter,_measurement=http_requests_total,code=500")),
+			timestamps: []int64{1575194400000000000},
+			values:     []float64{1},
+		},
+		{
+			tags:       models.ParseTags([]byte("m,_field=+Inf,_measurement=http_duration_seconds")),
+			timestamps: []int64{1575194400000000000},
+			values:     []float64{4},
+		},
+		{
+			tags:       models.ParseTags([]byte("m,_field=0.5,_measurement=http_duration_seconds")),
+			timestamps: []int64{1575194400000000000},
+			values:     []float64{3},
+		},
+		{
+			tags:       models.ParseTags([]byte("m,_field=count,_measurement=http_duration_seconds")),
+			timestamps: []int64{1575194400000000000},
+			values:     []float64{4},
+		},
+		{
+			tags:       models.ParseTags([]byte("m,_field=0.99,_measurement=gc_duration_seconds")),
+			timestamps: []int64{1575194400000000000},
+			values:     []float64{0.002},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		matchers      []*remote.LabelMatcher
+		restrictions  influxdb.DataRestrictions
+		wantPredicate string
+		want          []*remote.TimeSeries
+		wantErr       bool
+	}{
+		{
+			name: "metric",
+			matchers: []*remote.LabelMatcher{
+				{Type: remote.MatchEqual, Name: "__name__", Value: "http_requests_total"},
+				{Type: remote.MatchNotEqual, Name: "code", Value: "500"},
+			},
+			wantPredicate: "'\x00' = \"http_requests_total\"",
+			want: []*remote.TimeSeries{
+				{
+					Labels: []*remote.Label{{Name: "__name__", Value: "http_requests_total"}, {Name: "code", Value: "200"}},
+					Samples: []*remote.Sample{
+						{Value: 10, Timestamp: 1575194400000},
+						{Value: 12, Timestamp: 1575194415000},
+					},
+				},
+			},
+		},
+		{
+			name: "histogram buckets",
+			matchers: []*remote.LabelMatcher{
+				{Type: remote.MatchEqual, Name: "__name__", Value: "http_duration_seconds_bucket"},
+			},
+			wantPredicate: "'\x00' = \"http_duration_seconds_bucket\" OR '\x00' = \"http_duration_seconds\"",
+			want: []*remote.TimeSeries{
+				{
+					Labels:  []*remote.Label{{Name: "__name__", Value: "http_duration_seconds_bucket"}, {Name: "le", Value: "+Inf"}},
+					Samples: []*remote.Sample{{Value: 4, Timestamp: 1575194400000}},
+				},
+				{
+					Labels:  []*remote.Label{{Name: "__name__", Value: "http_duration_seconds_bucket"}, {Name: "le", Value: "0.5"}},
+					Samples: []*remote.Sample{{Value: 3, Timestamp: 1575194400000}},
+				},
+			},
+		},
+		{
+			name: "regular expressions",
+			matchers: []*remote.LabelMatcher{
+				{Type: remote.MatchRegex, Name: "__name__", Value: ".*_count|gc_.*"},
+				{Type: remote.MatchRegex, Name: "code", Value: ""},
+			},
+			wantPredicate: "[none]",
+			want: []*remote.TimeSeries{
+				{
+					Labels:  []*remote.Label{{Name: "__name__", Value: "gc_duration_seconds"}, {Name: "quantile", Value: "0.99"}},
+					Samples: []*remote.Sample{{Value: 0.002, Timestamp: 1575194400000}},
+				},
+				{
+					Labels:  []*remote.Label{{Name: "__name__", Value: "http_duration_seconds_count"}},
+					Samples: []*remote.Sample{{Value: 4, Timestamp: 1575194400000}},
+				},
+			},
+		},
+		{
+			name: "tag pushed down",
+			matchers: []*remote.LabelMatcher{
+				{Type: remote.MatchRegex, Name: "code", Value: "5.."},
+			},
+			wantPredicate: `'code' =~ /^(?:5..)$/`,
+			want: []*remote.TimeSeries{
+				{
+					Labels:  []*remote.Label{{Name: "__name__", Value: "http_requests_total"}, {Name: "code", Value: "500"}},
+					Samples: []*remote.Sample{{Value: 1, Timestamp: 1575194400000}},
+				},
+			},
+		},
+		{
+			name: "data restrictions",
+			matchers: []*remote.LabelMatcher{
+				{Type: remote.MatchRegex, Name: "__name__", Value: "http_.*"},
+			},
+			restrictions: influxdb.DataRestrictions{
+				{Measurements: []string{"http_requests_total"}, Tags: []influxdb.Tag{{Key: "code", Value: "500"}}},
+			},
+			wantPredicate: "[none]",
+			want: []*remote.TimeSeries{
+				{
+					Labels:  []*remote.Label{{Name: "__name__", Value: "http_requests_total"}, {Name: "code", Value: "500"}},
+					Samples: []*remote.Sample{{Value: 1, Timestamp: 1575194400000}},
+				},
+			},
+		},
+		{
+			name: "invalid regular expression",
+			matchers: []*remote.LabelMatcher{
+				{Type: remote.MatchRegex, Name: "code", Value: "("},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &seriesStore{series: stored}
+			q := &remote.Query{
+				StartTimestampMs: 1575194400000,
+				EndTimestampMs:   1575194415000,
+				Matchers:         tt.matchers,
+			}
+
+			got, err := remote.Read(context.Background(), store, 1, 2, q, tt.restrictions)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Read() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if g, w := store.req.Range, (datatypes.TimestampRange{Start: 1575194400000000000, End: 1575194415000000001}); g != w {
+				t.Errorf("got range %v, want %v", g, w)
+			}
+			if g, w := reads.PredicateToExprString(store.req.Predicate), tt.wantPredicate; g != w {
+				t.Errorf("got predicate %s, want %s", g, w)
+			}
+			if diff := cmp.Diff(tt.want, got.Timeseries); diff != "" {
+				t.Errorf("unexpected series -want/+got:\n%s", diff)
+			}
+		})
+	}
+}
+
+type series struct {
+	tags       models.Tags
+	timestamps []int64
+	values     []float64
+}
+
+// seriesStore is a store reading its series regardless of the request.
+type seriesStore struct {
+	reads.Store
+	series []series
+	req    *datatypes.ReadFilterRequest
+}
+
+func (s *seriesStore) ReadFilter(ctx context.Context, req *datatypes.ReadFilterRequest) (reads.ResultSet, error) {
+	s.req = req
+	return &seriesResultSet{series: s.series, i: -1}, nil
+}
+
+func (s *seriesStore) GetSource(orgID, bucketID uint64) proto.Message {
+	return &datatypes.ReadFilterRequest{}
+}
+
+type seriesResultSet struct {
+	series []series
+	i      int
+}
+
+func (rs *seriesResultSet) Next() bool {
+	rs.i++
+	return rs.i < len(rs.series)
+}
+
+func (rs *seriesResultSet) Cursor() cursors.Cursor {
+	s := rs.series[rs.i]
+	return &floatCursor{a: &cursors.FloatArray{Timestamps: s.timestamps, Values: s.values}}
+}
+
+func (rs *seriesResultSet) Tags() models.Tags          { return rs.series[rs.i].tags }
+func (rs *seriesResultSet) Close()                     {}
+func (rs *seriesResultSet) Err() error                 { return nil }
+func (rs *seriesResultSet) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+type floatCursor struct {
+	a *cursors.FloatArray
+}
+
+func (c *floatCursor) Next() *cursors.FloatArray {
+	a := c.a
+	c.a = &cursors.FloatArray{}
+	return a
+}
+
+func (c *floatCursor) Close()                     {}
+func (c *floatCursor) Err() error                 { return nil }
+func (c *floatCursor) Stats() cursors.CursorStats { return cursors.CursorStats{} }
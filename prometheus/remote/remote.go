@@ -0,0 +1,110 @@
+// Package remote implements the Prometheus remote read and write protocol,
+// mapping the Prometheus series to the measurements, tags and fields recorded by the scrapers.
+package remote
+
+import (
+	"github.com/gogo/protobuf/proto"
+)
+
+// The messages of the Prometheus remote read and write protocol, wire compatible with
+// the messages of the prompb package of Prometheus. They are written out by hand
+// rather than generated, as only the samples are exchanged.
+
+// WriteRequest is the body of a remote write, the series to write.
+type WriteRequest struct {
+	Timeseries []*TimeSeries `protobuf:"bytes,1,rep,name=timeseries,proto3"`
+}
+
+func (m *WriteRequest) Reset()         { *m = WriteRequest{} }
+func (m *WriteRequest) String() string { return proto.CompactTextString(m) }
+func (*WriteRequest) ProtoMessage()    {}
+
+// ReadRequest is the body of a remote read, the queries to read.
+type ReadRequest struct {
+	Queries []*Query `protobuf:"bytes,1,rep,name=queries,proto3"`
+}
+
+func (m *ReadRequest) Reset()         { *m = ReadRequest{} }
+func (m *ReadRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadRequest) ProtoMessage()    {}
+
+// ReadResponse is the response of a remote read, a result per query in the order of the queries.
+type ReadResponse struct {
+	Results []*QueryResult `protobuf:"bytes,1,rep,name=results,proto3"`
+}
+
+func (m *ReadResponse) Reset()         { *m = ReadResponse{} }
+func (m *ReadResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadResponse) ProtoMessage()    {}
+
+// Query selects the samples between the start and end timestamps, inclusive,
+// of the series matching all of the matchers.
+type Query struct {
+	StartTimestampMs int64           `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3"`
+	EndTimestampMs   int64           `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3"`
+	Matchers         []*LabelMatcher `protobuf:"bytes,3,rep,name=matchers,proto3"`
+}
+
+func (m *Query) Reset()         { *m = Query{} }
+func (m *Query) String() string { return proto.CompactTextString(m) }
+func (*Query) ProtoMessage()    {}
+
+// QueryResult is the series selected by a query.
+type QueryResult struct {
+	Timeseries []*TimeSeries `protobuf:"bytes,1,rep,name=timeseries,proto3"`
+}
+
+func (m *QueryResult) Reset()         { *m = QueryResult{} }
+func (m *QueryResult) String() string { return proto.CompactTextString(m) }
+func (*QueryResult) ProtoMessage()    {}
+
+// TimeSeries is a series identified by its labels, with its samples in time order.
+type TimeSeries struct {
+	Labels  []*Label  `protobuf:"bytes,1,rep,name=labels,proto3"`
+	Samples []*Sample `protobuf:"bytes,2,rep,name=samples,proto3"`
+}
+
+func (m *TimeSeries) Reset()         { *m = TimeSeries{} }
+func (m *TimeSeries) String() string { return proto.CompactTextString(m) }
+func (*TimeSeries) ProtoMessage()    {}
+
+// Label is a label of a series, the metric name being the __name__ label.
+type Label struct {
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *Label) Reset()         { *m = Label{} }
+func (m *Label) String() string { return proto.CompactTextString(m) }
+func (*Label) ProtoMessage()    {}
+
+// Sample is a value of a series at a timestamp in milliseconds.
+type Sample struct {
+	Value     float64 `protobuf:"fixed64,1,opt,name=value,proto3"`
+	Timestamp int64   `protobuf:"varint,2,opt,name=timestamp,proto3"`
+}
+
+func (m *Sample) Reset()         { *m = Sample{} }
+func (m *Sample) String() string { return proto.CompactTextString(m) }
+func (*Sample) ProtoMessage()    {}
+
+// MatchType is the comparison of a label matcher.
+type MatchType int32
+
+const (
+	MatchEqual    MatchType = 0
+	MatchNotEqual MatchType = 1
+	MatchRegex    MatchType = 2
+	MatchNotRegex MatchType = 3
+)
+
+// LabelMatcher matches the series whose label compares to the value.
+type LabelMatcher struct {
+	Type  MatchType `protobuf:"varint,1,opt,name=type,proto3"`
+	Name  string    `protobuf:"bytes,2,opt,name=name,proto3"`
+	Value string    `protobuf:"bytes,3,opt,name=value,proto3"`
+}
+
+func (m *LabelMatcher) Reset()         { *m = LabelMatcher{} }
+func (m *LabelMatcher) String() string { return proto.CompactTextString(m) }
+func (*LabelMatcher) ProtoMessage()    {}
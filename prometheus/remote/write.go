@@ -0,0 +1,119 @@
+package remote
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+)
+
+const (
+	nameLabel     = "__name__"
+	bucketLabel   = "le"
+	quantileLabel = "quantile"
+
+	bucketSuffix = "_bucket"
+	sumSuffix    = "_sum"
+	countSuffix  = "_count"
+
+	valueField = "value"
+	sumField   = "sum"
+	countField = "count"
+)
+
+// Points returns the points of the samples of the series of the write request,
+// recorded the way the Prometheus scrapers record the metrics:
+// the metric name is the measurement and the other labels are the tags.
+// The buckets of a histogram are fields of the histogram named by their upper bound,
+// the quantiles of a summary fields of the summary named by their quantile,
+// and the _sum and _count series the sum and count fields. The value of any other series
+// is the value field. Samples that are not a number or infinite are not written.
+func Points(req *WriteRequest) ([]models.Point, error) {
+	points := make([]models.Point, 0, len(req.Timeseries))
+	for _, ts := range req.Timeseries {
+		name, field, tags, err := seriesKey(ts.Labels)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range ts.Samples {
+			if math.IsNaN(s.Value) || math.IsInf(s.Value, 0) {
+				continue
+			}
+
+			pt, err := models.NewPoint(name, tags, models.Fields{field: s.Value}, time.Unix(0, s.Timestamp*int64(time.Millisecond)))
+			if err != nil {
+				return nil, &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Op:   "prometheus/remote/Points",
+					Msg:  fmt.Sprintf("invalid series %s", name),
+					Err:  err,
+				}
+			}
+			points = append(points, pt)
+		}
+	}
+	return points, nil
+}
+
+// seriesKey returns the measurement, field and tags the series is recorded as.
+func seriesKey(labels []*Label) (string, string, models.Tags, error) {
+	tags := make(map[string]string, len(labels))
+	for _, l := range labels {
+		if l.Value != "" {
+			tags[l.Name] = l.Value
+		}
+	}
+
+	name := tags[nameLabel]
+	if name == "" {
+		return "", "", nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Op:   "prometheus/remote/Points",
+			Msg:  "series has no metric name",
+		}
+	}
+	delete(tags, nameLabel)
+
+	field := valueField
+	switch le, quantile := tags[bucketLabel], tags[quantileLabel]; {
+	case strings.HasSuffix(name, bucketSuffix) && le != "":
+		f, err := boundField(le)
+		if err != nil {
+			return "", "", nil, err
+		}
+		name, field = strings.TrimSuffix(name, bucketSuffix), f
+		delete(tags, bucketLabel)
+	case quantile != "":
+		f, err := boundField(quantile)
+		if err != nil {
+			return "", "", nil, err
+		}
+		field = f
+		delete(tags, quantileLabel)
+	case strings.HasSuffix(name, sumSuffix):
+		name, field = strings.TrimSuffix(name, sumSuffix), sumField
+	case strings.HasSuffix(name, countSuffix):
+		name, field = strings.TrimSuffix(name, countSuffix), countField
+	}
+	return name, field, models.NewTags(tags), nil
+}
+
+// boundField returns the field of a bucket upper bound or a quantile,
+// formatted as the scrapers format them.
+func boundField(v string) (string, error) {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return "", &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Op:   "prometheus/remote/Points",
+			Msg:  fmt.Sprintf("invalid bucket or quantile %q", v),
+			Err:  err,
+		}
+	}
+	return fmt.Sprint(f), nil
+}
@@ -0,0 +1,133 @@
+package remote_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/prometheus/remote"
+)
+
+func TestPoints(t *testing.T) {
+	tests := []struct {
+		name    string
+		series  []*remote.TimeSeries
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "untyped metric",
+			series: []*remote.TimeSeries{
+				{
+					Labels: []*remote.Label{
+						{Name: "__name__", Value: "up"},
+						{Name: "job", Value: "node"},
+						{Name: "instance", Value: "localhost:9100"},
+					},
+					Samples: []*remote.Sample{
+						{Value: 1, Timestamp: 1575194400000},
+						{Value: 0, Timestamp: 1575194415000},
+					},
+				},
+			},
+			want: []string{
+				"up,instance=localhost:9100,job=node value=1 1575194400000000000",
+				"up,instance=localhost:9100,job=node value=0 1575194415000000000",
+			},
+		},
+		{
+			name: "histogram",
+			series: []*remote.TimeSeries{
+				{
+					Labels:  []*remote.Label{{Name: "__name__", Value: "http_duration_seconds_bucket"}, {Name: "le", Value: "0.50"}},
+					Samples: []*remote.Sample{{Value: 3, Timestamp: 1575194400000}},
+				},
+				{
+					Labels:  []*remote.Label{{Name: "__name__", Value: "http_duration_seconds_bucket"}, {Name: "le", Value: "+Inf"}},
+					Samples: []*remote.Sample{{Value: 4, Timestamp: 1575194400000}},
+				},
+				{
+					Labels:  []*remote.Label{{Name: "__name__", Value: "http_duration_seconds_sum"}},
+					Samples: []*remote.Sample{{Value: 1.25, Timestamp: 1575194400000}},
+				},
+				{
+					Labels:  []*remote.Label{{Name: "__name__", Value: "http_duration_seconds_count"}},
+					Samples: []*remote.Sample{{Value: 4, Timestamp: 1575194400000}},
+				},
+			},
+			want: []string{
+				"http_duration_seconds 0.5=3 1575194400000000000",
+				"http_duration_seconds +Inf=4 1575194400000000000",
+				"http_duration_seconds sum=1.25 1575194400000000000",
+				"http_duration_seconds count=4 1575194400000000000",
+			},
+		},
+		{
+			name: "summary",
+			series: []*remote.TimeSeries{
+				{
+					Labels:  []*remote.Label{{Name: "__name__", Value: "gc_duration_seconds"}, {Name: "quantile", Value: "0.99"}},
+					Samples: []*remote.Sample{{Value: 0.002, Timestamp: 1575194400000}},
+				},
+			},
+			want: []string{
+				"gc_duration_seconds 0.99=0.002 1575194400000000000",
+			},
+		},
+		{
+			name: "samples that are not numbers",
+			series: []*remote.TimeSeries{
+				{
+					Labels: []*remote.Label{{Name: "__name__", Value: "temperature"}},
+					Samples: []*remote.Sample{
+						{Value: math.NaN(), Timestamp: 1575194400000},
+						{Value: math.Inf(1), Timestamp: 1575194415000},
+						{Value: 21.5, Timestamp: 1575194430000},
+					},
+				},
+			},
+			want: []string{
+				"temperature value=21.5 1575194430000000000",
+			},
+		},
+		{
+			name: "no metric name",
+			series: []*remote.TimeSeries{
+				{
+					Labels:  []*remote.Label{{Name: "job", Value: "node"}},
+					Samples: []*remote.Sample{{Value: 1, Timestamp: 1575194400000}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid bucket",
+			series: []*remote.TimeSeries{
+				{
+					Labels:  []*remote.Label{{Name: "__name__", Value: "http_duration_seconds_bucket"}, {Name: "le", Value: "x"}},
+					Samples: []*remote.Sample{{Value: 1, Timestamp: 1575194400000}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points, err := remote.Points(&remote.WriteRequest{Timeseries: tt.series})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Points() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := make([]string, 0, len(points))
+			for _, pt := range points {
+				got = append(got, pt.String())
+			}
+			if !tt.wantErr {
+				if diff := cmp.Diff(tt.want, got); diff != "" {
+					t.Errorf("unexpected points -want/+got:\n%s", diff)
+				}
+			}
+		})
+	}
+}
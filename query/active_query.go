@@ -0,0 +1,41 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/flux"
+	platform "github.com/influxdata/influxdb"
+)
+
+// ActiveQuery is a query being compiled, queued or executed.
+type ActiveQuery struct {
+	// ID is an ephemeral ID, unique among the queries of a running server.
+	ID              platform.ID       `json:"id"`
+	OrganizationID  platform.ID       `json:"orgID"`
+	UserID          platform.ID       `json:"userID,omitempty"`
+	AuthorizationID platform.ID       `json:"authorizationID,omitempty"`
+	CompilerType    flux.CompilerType `json:"compilerType"`
+	Query           string            `json:"query"`
+	StartedAt       time.Time         `json:"startedAt"`
+	// MemoryAllocated is the number of bytes the query currently has allocated.
+	MemoryAllocated int64  `json:"memoryAllocated"`
+	State           string `json:"state"`
+}
+
+// ActiveQueryFilter represents a set of filters that restrict the returned active queries.
+type ActiveQueryFilter struct {
+	OrganizationID *platform.ID
+}
+
+// ActiveQueryService lists and cancels the active queries.
+type ActiveQueryService interface {
+	// FindActiveQueries returns the active queries matching the filter.
+	FindActiveQueries(ctx context.Context, filter ActiveQueryFilter) ([]*ActiveQuery, error)
+
+	// FindActiveQueryByID returns a single active query by ID.
+	FindActiveQueryByID(ctx context.Context, id platform.ID) (*ActiveQuery, error)
+
+	// CancelActiveQuery cancels the execution of the active query.
+	CancelActiveQuery(ctx context.Context, id platform.ID) error
+}
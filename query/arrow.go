@@ -0,0 +1,242 @@
+package query
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/iocounter"
+)
+
+const ArrowDialectType = "arrow"
+
+// ArrowDialect describes the output format of queries in the Apache Arrow IPC streaming format.
+type ArrowDialect struct{}
+
+func (d *ArrowDialect) Encoder() flux.MultiResultEncoder {
+	return &ArrowEncoder{alloc: memory.NewGoAllocator()}
+}
+
+func (d *ArrowDialect) DialectType() flux.DialectType {
+	return ArrowDialectType
+}
+
+func (d *ArrowDialect) SetHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/vnd.apache.arrow.stream")
+	w.Header().Set("Transfer-Encoding", "chunked")
+}
+
+// the metadata key of the fields of the columns, whether they are part of the group key.
+const arrowGroupKey = "group"
+
+// ArrowEncoder encodes the results as Arrow IPC streams of record batches,
+// a record batch for every buffer of the tables of the results.
+// The first fields of a record batch are the result and the table of its rows,
+// followed by the columns of the table, their metadata telling whether they are part of the group key.
+// An IPC stream has a single schema, so a new stream follows the end of the previous one
+// whenever the columns of the tables change; times are timestamps of nanoseconds.
+// An error of the query after some tables were encoded leaves the last stream without its end,
+// failing the readers of the stream.
+type ArrowEncoder struct {
+	alloc memory.Allocator
+}
+
+func (e *ArrowEncoder) Encode(w io.Writer, results flux.ResultIterator) (int64, error) {
+	defer results.Release()
+
+	wc := &iocounter.Writer{Writer: w}
+	var (
+		writer *ipc.Writer
+		schema *arrow.Schema
+	)
+	for results.More() {
+		res := results.Next()
+		tableID := 0
+		if err := res.Tables().Do(func(tbl flux.Table) error {
+			s, err := arrowSchema(tbl)
+			if err != nil {
+				return wrapEncoderError(err)
+			}
+			if !s.Equal(schema) {
+				if writer != nil {
+					if err := writer.Close(); err != nil {
+						return wrapEncoderError(err)
+					}
+				}
+				writer = ipc.NewWriter(wc, ipc.WithSchema(s), ipc.WithAllocator(e.alloc))
+				schema = s
+			}
+
+			if tbl.Empty() {
+				// an empty table is a record batch without rows.
+				rec := e.record(schema, tbl.Cols(), res.Name(), tableID, nil)
+				defer rec.Release()
+				if err := writer.Write(rec); err != nil {
+					return wrapEncoderError(err)
+				}
+			}
+			err = tbl.Do(func(cr flux.ColReader) error {
+				rec := e.record(schema, tbl.Cols(), res.Name(), tableID, cr)
+				defer rec.Release()
+				return wrapEncoderError(writer.Write(rec))
+			})
+			tableID++
+			return err
+		}); err != nil {
+			return wc.Count(), err
+		}
+	}
+
+	results.Release()
+	if err := results.Err(); err != nil {
+		return wc.Count(), err
+	}
+	if writer != nil {
+		if err := writer.Close(); err != nil {
+			return wc.Count(), wrapEncoderError(err)
+		}
+	}
+	return wc.Count(), nil
+}
+
+// arrowSchema returns the schema of the record batches of the table.
+func arrowSchema(tbl flux.Table) (*arrow.Schema, error) {
+	fields := []arrow.Field{
+		{Name: "result", Type: arrow.BinaryTypes.String},
+		{Name: "table", Type: arrow.PrimitiveTypes.Int64},
+	}
+	for _, c := range tbl.Cols() {
+		var typ arrow.DataType
+		switch c.Type {
+		case flux.TBool:
+			typ = arrow.FixedWidthTypes.Boolean
+		case flux.TInt:
+			typ = arrow.PrimitiveTypes.Int64
+		case flux.TUInt:
+			typ = arrow.PrimitiveTypes.Uint64
+		case flux.TFloat:
+			typ = arrow.PrimitiveTypes.Float64
+		case flux.TString:
+			typ = arrow.BinaryTypes.String
+		case flux.TTime:
+			typ = arrow.FixedWidthTypes.Timestamp_ns
+		default:
+			return nil, fmt.Errorf("unknown column type %v", c.Type)
+		}
+		fields = append(fields, arrow.Field{
+			Name:     c.Label,
+			Type:     typ,
+			Nullable: true,
+			Metadata: arrow.NewMetadata([]string{arrowGroupKey}, []string{strconv.FormatBool(tbl.Key().HasCol(c.Label))}),
+		})
+	}
+	return arrow.NewSchema(fields, nil), nil
+}
+
+// record returns the record batch of the rows of the column reader, without rows if it is nil.
+func (e *ArrowEncoder) record(schema *arrow.Schema, cols []flux.ColMeta, result string, tableID int, cr flux.ColReader) array.Record {
+	n := 0
+	if cr != nil {
+		n = cr.Len()
+	}
+
+	arrs := make([]array.Interface, 0, len(schema.Fields()))
+	defer func() {
+		for _, arr := range arrs {
+			arr.Release()
+		}
+	}()
+
+	rb := array.NewStringBuilder(e.alloc)
+	defer rb.Release()
+	tb := array.NewInt64Builder(e.alloc)
+	defer tb.Release()
+	for i := 0; i < n; i++ {
+		rb.Append(result)
+		tb.Append(int64(tableID))
+	}
+	arrs = append(arrs, rb.NewArray(), tb.NewArray())
+
+	for j, c := range cols {
+		arrs = append(arrs, e.column(c.Type, cr, j, n))
+	}
+	return array.NewRecord(schema, arrs, int64(n))
+}
+
+// column copies the column of the column reader to an array.
+func (e *ArrowEncoder) column(typ flux.ColType, cr flux.ColReader, j, n int) array.Interface {
+	switch typ {
+	case flux.TBool:
+		b := array.NewBooleanBuilder(e.alloc)
+		defer b.Release()
+		for i := 0; i < n; i++ {
+			if vs := cr.Bools(j); vs.IsValid(i) {
+				b.Append(vs.Value(i))
+			} else {
+				b.AppendNull()
+			}
+		}
+		return b.NewArray()
+	case flux.TInt:
+		b := array.NewInt64Builder(e.alloc)
+		defer b.Release()
+		for i := 0; i < n; i++ {
+			if vs := cr.Ints(j); vs.IsValid(i) {
+				b.Append(vs.Value(i))
+			} else {
+				b.AppendNull()
+			}
+		}
+		return b.NewArray()
+	case flux.TUInt:
+		b := array.NewUint64Builder(e.alloc)
+		defer b.Release()
+		for i := 0; i < n; i++ {
+			if vs := cr.UInts(j); vs.IsValid(i) {
+				b.Append(vs.Value(i))
+			} else {
+				b.AppendNull()
+			}
+		}
+		return b.NewArray()
+	case flux.TFloat:
+		b := array.NewFloat64Builder(e.alloc)
+		defer b.Release()
+		for i := 0; i < n; i++ {
+			if vs := cr.Floats(j); vs.IsValid(i) {
+				b.Append(vs.Value(i))
+			} else {
+				b.AppendNull()
+			}
+		}
+		return b.NewArray()
+	case flux.TString:
+		b := array.NewStringBuilder(e.alloc)
+		defer b.Release()
+		for i := 0; i < n; i++ {
+			if vs := cr.Strings(j); vs.IsValid(i) {
+				b.Append(vs.ValueString(i))
+			} else {
+				b.AppendNull()
+			}
+		}
+		return b.NewArray()
+	default:
+		b := array.NewTimestampBuilder(e.alloc, arrow.FixedWidthTypes.Timestamp_ns.(*arrow.TimestampType))
+		defer b.Release()
+		for i := 0; i < n; i++ {
+			if vs := cr.Times(j); vs.IsValid(i) {
+				b.Append(arrow.Timestamp(vs.Value(i)))
+			} else {
+				b.AppendNull()
+			}
+		}
+		return b.NewArray()
+	}
+}
@@ -0,0 +1,126 @@
+package query_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/influxdb/query"
+)
+
+func TestArrowDialect(t *testing.T) {
+	var buf bytes.Buffer
+	d := &query.ArrowDialect{}
+	if _, err := d.Encoder().Encode(&buf, flux.NewSliceResultIterator(testResults())); err != nil {
+		t.Fatal(err)
+	}
+
+	// a stream follows another whenever the schema changes.
+	var got []string
+	r := bytes.NewReader(buf.Bytes())
+	for r.Len() > 0 {
+		rdr, err := ipc.NewReader(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var fields []string
+		for _, f := range rdr.Schema().Fields() {
+			fields = append(fields, fmt.Sprintf("%s:%s%v", f.Name, f.Type, f.Metadata.Values()))
+		}
+		got = append(got, "schema "+strings.Join(fields, ","))
+		for rdr.Next() {
+			got = append(got, recordRows(rdr.Record())...)
+		}
+		if err := rdr.Err(); err != nil {
+			t.Fatal(err)
+		}
+		rdr.Release()
+	}
+
+	want := []string{
+		"schema result:utf8[],table:int64[],_time:timestamp[ns, tz=UTC][false],_measurement:utf8[true],_field:utf8[true],_value:float64[false],host:utf8[true]",
+		"[_result 0 1575194400000000000 cpu usage 1.5 a]",
+		"[_result 0 1575194410500000000 cpu usage (null) a]",
+		"[_result 1 1575194400000000000 cpu usage 2 b]",
+		"schema result:utf8[],table:int64[],_measurement:utf8[true],count:int64[false],ok:bool[false],n:uint64[false]",
+		"[_result 3 cpu 3 true 7]",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected records -want/+got:\n%s", diff)
+	}
+}
+
+func TestArrowDialect_Error(t *testing.T) {
+	results := []flux.Result{
+		&executetest.Result{
+			Nm: "_result",
+			Tbls: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{{Label: "_value", Type: flux.TFloat}},
+				Data:    [][]interface{}{{1.0}},
+			}},
+		},
+		&executetest.Result{
+			Nm: "failed",
+			Tbls: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{{Label: "_value", Type: flux.TFloat}},
+				Data:    [][]interface{}{{2.0}},
+				Err:     errors.New("expected error"),
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	d := &query.ArrowDialect{}
+	if _, err := d.Encoder().Encode(&buf, flux.NewSliceResultIterator(results)); err == nil || err.Error() != "expected error" {
+		t.Fatalf("got error %v, want expected error", err)
+	}
+
+	// the stream has no end for its readers to fail.
+	rdr, err := ipc.NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rdr.Release()
+	for rdr.Next() {
+	}
+	if rdr.Err() == nil {
+		t.Error("expected error reading the stream")
+	}
+}
+
+// recordRows returns the rows of the record formatted as strings.
+func recordRows(rec array.Record) []string {
+	rows := make([]string, rec.NumRows())
+	for i := range rows {
+		row := make([]string, rec.NumCols())
+		for j, col := range rec.Columns() {
+			if col.IsNull(i) {
+				row[j] = "(null)"
+				continue
+			}
+			switch col := col.(type) {
+			case *array.String:
+				row[j] = col.Value(i)
+			case *array.Int64:
+				row[j] = fmt.Sprint(col.Value(i))
+			case *array.Uint64:
+				row[j] = fmt.Sprint(col.Value(i))
+			case *array.Float64:
+				row[j] = fmt.Sprint(col.Value(i))
+			case *array.Boolean:
+				row[j] = fmt.Sprint(col.Value(i))
+			case *array.Timestamp:
+				row[j] = fmt.Sprint(col.Value(i))
+			}
+		}
+		rows[i] = fmt.Sprint(row)
+	}
+	return rows
+}
@@ -0,0 +1,101 @@
+package control
+
+import (
+	"context"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/query/influxql"
+	"github.com/influxdata/influxdb/query/promql"
+)
+
+var _ query.ActiveQueryService = (*Controller)(nil)
+
+// FindActiveQueries reports the active queries matching the filter.
+func (c *Controller) FindActiveQueries(ctx context.Context, filter query.ActiveQueryFilter) ([]*query.ActiveQuery, error) {
+	qs := c.Queries()
+	aqs := make([]*query.ActiveQuery, 0, len(qs))
+	for _, q := range qs {
+		aq := q.activeQuery()
+		if filter.OrganizationID != nil && *filter.OrganizationID != aq.OrganizationID {
+			continue
+		}
+		aqs = append(aqs, aq)
+	}
+	return aqs, nil
+}
+
+// FindActiveQueryByID reports the active query with the ID.
+func (c *Controller) FindActiveQueryByID(ctx context.Context, id influxdb.ID) (*query.ActiveQuery, error) {
+	q, err := c.findQuery(id)
+	if err != nil {
+		return nil, err
+	}
+	return q.activeQuery(), nil
+}
+
+// CancelActiveQuery cancels the execution of the active query with the ID.
+func (c *Controller) CancelActiveQuery(ctx context.Context, id influxdb.ID) error {
+	q, err := c.findQuery(id)
+	if err != nil {
+		return err
+	}
+	q.Cancel()
+	return nil
+}
+
+func (c *Controller) findQuery(id influxdb.ID) (*Query, error) {
+	c.queriesMu.RLock()
+	defer c.queriesMu.RUnlock()
+	q, ok := c.queries[QueryID(id)]
+	if !ok {
+		return nil, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  "active query not found",
+		}
+	}
+	return q, nil
+}
+
+// activeQuery describes the query and the request it was made with.
+func (q *Query) activeQuery() *query.ActiveQuery {
+	aq := &query.ActiveQuery{
+		ID:           influxdb.ID(q.id),
+		CompilerType: q.compilerType,
+		StartedAt:    q.startedAt,
+		State:        q.State().String(),
+	}
+	if req := query.RequestFromContext(q.parentCtx); req != nil {
+		aq.OrganizationID = req.OrganizationID
+		aq.Query = compilerQuery(req.Compiler)
+		if req.Authorization != nil {
+			aq.UserID = req.Authorization.UserID
+			aq.AuthorizationID = req.Authorization.ID
+		}
+	}
+
+	q.stateMu.RLock()
+	if q.alloc != nil {
+		aq.MemoryAllocated = q.alloc.Allocated()
+	}
+	q.stateMu.RUnlock()
+	return aq
+}
+
+// compilerQuery returns the text of the query compiled by the compiler, if it has any.
+func compilerQuery(c flux.Compiler) string {
+	switch c := c.(type) {
+	case lang.FluxCompiler:
+		return c.Query
+	case lang.ASTCompiler:
+		return ast.Format(c.AST)
+	case *influxql.Compiler:
+		return c.Query
+	case *promql.Compiler:
+		return c.Query
+	}
+	return ""
+}
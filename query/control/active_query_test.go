@@ -0,0 +1,84 @@
+package control_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/mock"
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/query/control"
+)
+
+func TestController_ActiveQueries(t *testing.T) {
+	ctrl, err := control.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer shutdown(t, ctrl)
+
+	executing := make(chan struct{})
+	compiler := &mock.Compiler{
+		CompileFn: func(ctx context.Context) (flux.Program, error) {
+			return &mock.Program{
+				ExecuteFn: func(ctx context.Context, q *mock.Query, alloc *memory.Allocator) {
+					close(executing)
+					<-ctx.Done()
+				},
+			}, nil
+		},
+	}
+
+	orgID, otherOrgID := platform.ID(1), platform.ID(2)
+	req := &query.Request{
+		Authorization:  &platform.Authorization{ID: 3, UserID: 4},
+		OrganizationID: orgID,
+		Compiler:       compiler,
+	}
+	q, err := ctrl.Query(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-executing
+
+	aqs, err := ctrl.FindActiveQueries(context.Background(), query.ActiveQueryFilter{OrganizationID: &orgID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(aqs) != 1 {
+		t.Fatalf("got %d active queries, want 1", len(aqs))
+	}
+	aq := aqs[0]
+	if aq.OrganizationID != orgID || aq.UserID != 4 || aq.AuthorizationID != 3 {
+		t.Errorf("unexpected active query scope: %+v", aq)
+	}
+	if aq.CompilerType != "mockCompiler" || aq.State != "executing" || aq.StartedAt.IsZero() {
+		t.Errorf("unexpected active query: %+v", aq)
+	}
+
+	aqs, err = ctrl.FindActiveQueries(context.Background(), query.ActiveQueryFilter{OrganizationID: &otherOrgID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(aqs) != 0 {
+		t.Errorf("got %d active queries of another org, want 0", len(aqs))
+	}
+
+	if err := ctrl.CancelActiveQuery(context.Background(), aq.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ctrl.FindActiveQueryByID(context.Background(), aq.ID); err != nil {
+		t.Fatal(err)
+	} else if got.State != "canceled" {
+		t.Errorf("got state %q for a canceled query, want canceled", got.State)
+	}
+	for range q.Results() {
+	}
+	q.Done()
+
+	if _, err := ctrl.FindActiveQueryByID(context.Background(), aq.ID); platform.ErrorCode(err) != platform.ENotFound {
+		t.Errorf("got error %v for a finished query, want not found", err)
+	}
+}
@@ -58,6 +58,11 @@ type Controller struct {
 	abort      chan struct{}
 	memory     *memoryManager
 
+	// orgs keeps track of the queries of the organizations with queue quotas.
+	orgsMu sync.Mutex
+	orgs   map[influxdb.ID]*orgQueries
+	orgSvc OrganizationService
+
 	metrics   *controllerMetrics
 	labelKeys []string
 
@@ -98,6 +103,11 @@ type Config struct {
 	// The context value must be a string or an implementation of the Stringer interface.
 	MetricLabelKeys []string
 
+	// OrganizationService finds the organizations whose query quotas override the
+	// ConcurrencyQuota, MemoryBytesQuotaPerQuery and QueueSize for their queries.
+	// If this is unset, the quotas apply to the queries of all organizations.
+	OrganizationService OrganizationService
+
 	ExecutorDependencies []flux.Dependency
 }
 
@@ -178,6 +188,8 @@ func New(config Config) (*Controller, error) {
 		done:         make(chan struct{}),
 		abort:        make(chan struct{}),
 		memory:       mm,
+		orgs:         make(map[influxdb.ID]*orgQueries),
+		orgSvc:       c.OrganizationService,
 		log:          logger,
 		metrics:      newControllerMetrics(c.MetricLabelKeys),
 		labelKeys:    c.MetricLabelKeys,
@@ -262,6 +274,15 @@ func (c *Controller) createQuery(ctx context.Context, ct flux.CompilerType) (*Qu
 	}
 	compileLabelValues[len(compileLabelValues)-1] = string(ct)
 
+	var (
+		orgID  influxdb.ID
+		quotas influxdb.OrgQueryQuotas
+	)
+	if req := query.RequestFromContext(ctx); req != nil {
+		orgID = req.OrganizationID
+		quotas = c.findOrgQuotas(ctx, orgID)
+	}
+
 	cctx, cancel := context.WithCancel(ctx)
 	parentSpan, parentCtx := StartSpanFromContext(
 		cctx,
@@ -273,6 +294,10 @@ func (c *Controller) createQuery(ctx context.Context, ct flux.CompilerType) (*Qu
 		id:                 id,
 		labelValues:        labelValues,
 		compileLabelValues: compileLabelValues,
+		compilerType:       ct,
+		startedAt:          time.Now(),
+		orgID:              orgID,
+		quotas:             quotas,
 		state:              Created,
 		c:                  c,
 		results:            make(chan flux.Result),
@@ -362,9 +387,14 @@ func (c *Controller) enqueueQuery(q *Query) error {
 		}
 	}
 
+	if err := c.queueOrgQuery(q); err != nil {
+		return err
+	}
+
 	select {
 	case c.queryQueue <- q:
 	default:
+		c.unqueueOrgQuery(q)
 		return &flux.Error{
 			Code: codes.ResourceExhausted,
 			Msg:  "queue length exceeded",
@@ -380,7 +410,14 @@ func (c *Controller) processQueryQueue() {
 		case <-c.done:
 			return
 		case q := <-c.queryQueue:
-			c.executeQuery(q)
+			if !c.startOrgQuery(q) {
+				// the query will be executed once a query of its organization finishes.
+				continue
+			}
+			for q != nil {
+				c.executeQuery(q)
+				q = c.finishOrgQuery(q)
+			}
 		}
 	}
 }
@@ -418,7 +455,10 @@ func (c *Controller) executeQuery(q *Query) {
 		return
 	}
 
+	// the allocator is guarded by the state lock as the active queries report its memory.
+	q.stateMu.Lock()
 	q.c.createAllocator(q)
+	q.stateMu.Unlock()
 	exec, err := q.program.Start(ctx, q.alloc)
 	if err != nil {
 		q.setErr(err)
@@ -511,6 +551,12 @@ type Query struct {
 
 	labelValues        []string
 	compileLabelValues []string
+	compilerType       flux.CompilerType
+	startedAt          time.Time
+
+	// orgID is the organization of the query, its quotas override the quotas of the controller.
+	orgID  influxdb.ID
+	quotas influxdb.OrgQueryQuotas
 
 	c *Controller
 
@@ -6,6 +6,7 @@ import (
 	"sync/atomic"
 
 	"github.com/influxdata/flux/memory"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type memoryManager struct {
@@ -30,10 +31,21 @@ type memoryManager struct {
 
 // createAllocator will construct an allocator and memory manager
 // for the given query.
+// The memory quota of the query's organization overrides the memory quota per query.
 func (c *Controller) createAllocator(q *Query) {
+	quota := c.memory.memoryBytesQuotaPerQuery
+	if q.quotas.MemoryBytesQuotaPerQuery > 0 {
+		quota = q.quotas.MemoryBytesQuotaPerQuery
+	}
+	limit := c.memory.initialBytesQuotaPerQuery
+	if limit > quota {
+		limit = quota
+	}
 	q.memoryManager = &queryMemoryManager{
-		m:     c.memory,
-		limit: c.memory.initialBytesQuotaPerQuery,
+		m:          c.memory,
+		limit:      limit,
+		quota:      quota,
+		rejections: c.quotaRejections(q, quotaMemoryBytesPerQuery),
 	}
 	q.alloc = &memory.Allocator{
 		// Use an anonymous function to ensure the value is copied.
@@ -47,6 +59,11 @@ type queryMemoryManager struct {
 	m     *memoryManager
 	limit int64
 	given int64
+
+	// quota is the maximum amount of memory that may be allocated to the query.
+	quota int64
+	// rejections counts the requests for memory over the quota.
+	rejections prometheus.Counter
 }
 
 // RequestMemory will determine if the query can be given more memory
@@ -61,7 +78,8 @@ type queryMemoryManager struct {
 func (q *queryMemoryManager) RequestMemory(want int64) (got int64, err error) {
 	// It can be determined statically if we are going to violate
 	// the memoryBytesQuotaPerQuery.
-	if q.limit+want > q.m.memoryBytesQuotaPerQuery {
+	if q.limit+want > q.quota {
+		q.rejections.Inc()
 		return 0, errors.New("query hit hard limit")
 	}
 
@@ -106,12 +124,12 @@ func (q *queryMemoryManager) RequestMemory(want int64) (got int64, err error) {
 func (q *queryMemoryManager) giveMemory(want, unused int64) int64 {
 	// If we can safely double the limit, then just do that.
 	if q.limit > want && q.limit < unused {
-		if q.limit*2 <= q.m.memoryBytesQuotaPerQuery {
+		if q.limit*2 <= q.quota {
 			return q.limit
 		}
 		// Doubling the limit sends us over the quota.
 		// Determine what would be our maximum amount.
-		max := q.m.memoryBytesQuotaPerQuery - q.limit
+		max := q.quota - q.limit
 		if max > want {
 			return max
 		}
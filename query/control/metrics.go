@@ -4,8 +4,9 @@ import "github.com/prometheus/client_golang/prometheus"
 
 // controllerMetrics holds metrics related to the query controller.
 type controllerMetrics struct {
-	requests  *prometheus.CounterVec
-	functions *prometheus.CounterVec
+	requests        *prometheus.CounterVec
+	functions       *prometheus.CounterVec
+	quotaRejections *prometheus.CounterVec
 
 	all       *prometheus.GaugeVec
 	compiling *prometheus.GaugeVec
@@ -27,6 +28,13 @@ const (
 	labelQueueError   = requestsLabel("queue_error")
 )
 
+type quotaLabel string
+
+const (
+	quotaOrgQueueSize        = quotaLabel("org_queue_size")
+	quotaMemoryBytesPerQuery = quotaLabel("memory_bytes_quota_per_query")
+)
+
 func newControllerMetrics(labels []string) *controllerMetrics {
 	const (
 		namespace = "query"
@@ -48,6 +56,13 @@ func newControllerMetrics(labels []string) *controllerMetrics {
 			Help:      "Count of functions in queries",
 		}, append(labels, "function")),
 
+		quotaRejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "quota_rejections_total",
+			Help:      "Count of the queries rejected and memory requests denied for exceeding a quota",
+		}, append(labels, "quota")),
+
 		all: prometheus.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: subsystem,
@@ -115,6 +130,7 @@ func (cm *controllerMetrics) PrometheusCollectors() []prometheus.Collector {
 	return []prometheus.Collector{
 		cm.requests,
 		cm.functions,
+		cm.quotaRejections,
 
 		cm.all,
 		cm.compiling,
@@ -0,0 +1,153 @@
+package control
+
+import (
+	"context"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/influxdb"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// OrganizationService finds the organizations the queries are made for.
+type OrganizationService interface {
+	FindOrganizationByID(ctx context.Context, id influxdb.ID) (*influxdb.Organization, error)
+}
+
+// orgQueries keeps track of the queries of an organization
+// with a concurrency or queue quota.
+type orgQueries struct {
+	// queued is the number of queries awaiting execution,
+	// in the query queue or pending.
+	queued int
+	// executing is the number of queries executing.
+	executing int
+	// pending are the queries taken off the query queue
+	// while the organization was at its concurrency quota.
+	pending []*Query
+}
+
+// findOrgQuotas returns the query quotas of the organization, the zero quotas
+// leave the quotas of the controller in place.
+func (c *Controller) findOrgQuotas(ctx context.Context, orgID influxdb.ID) influxdb.OrgQueryQuotas {
+	if c.orgSvc == nil || !orgID.Valid() {
+		return influxdb.OrgQueryQuotas{}
+	}
+
+	o, err := c.orgSvc.FindOrganizationByID(ctx, orgID)
+	if err != nil {
+		if influxdb.ErrorCode(err) != influxdb.ENotFound {
+			c.log.Info("Failed to find the query quotas of the organization", zap.String("org_id", orgID.String()), zap.Error(err))
+		}
+		return influxdb.OrgQueryQuotas{}
+	}
+	if o.QueryQuotas == nil {
+		return influxdb.OrgQueryQuotas{}
+	}
+	return *o.QueryQuotas
+}
+
+// hasOrgQueueQuotas reports whether the queue of the query is restricted by the quotas of its organization.
+func (q *Query) hasOrgQueueQuotas() bool {
+	return q.quotas.ConcurrencyQuota > 0 || q.quotas.QueueSize > 0
+}
+
+// queueOrgQuery counts the query as awaiting execution in its organization,
+// unless the organization has as many queries awaiting execution as its queue quota.
+func (c *Controller) queueOrgQuery(q *Query) error {
+	if !q.hasOrgQueueQuotas() {
+		return nil
+	}
+
+	c.orgsMu.Lock()
+	defer c.orgsMu.Unlock()
+
+	oq, ok := c.orgs[q.orgID]
+	if !ok {
+		oq = &orgQueries{}
+		c.orgs[q.orgID] = oq
+	}
+	if q.quotas.QueueSize > 0 && oq.queued >= q.quotas.QueueSize {
+		c.quotaRejections(q, quotaOrgQueueSize).Inc()
+		return &flux.Error{
+			Code: codes.ResourceExhausted,
+			Msg:  "queue length exceeded for the organization",
+		}
+	}
+	oq.queued++
+	return nil
+}
+
+// unqueueOrgQuery reverts queueOrgQuery for a query that could not be queued.
+func (c *Controller) unqueueOrgQuery(q *Query) {
+	if !q.hasOrgQueueQuotas() {
+		return
+	}
+
+	c.orgsMu.Lock()
+	defer c.orgsMu.Unlock()
+
+	oq := c.orgs[q.orgID]
+	oq.queued--
+	c.pruneOrgQueries(q.orgID, oq)
+}
+
+// startOrgQuery reports whether the query can be executed, or else keeps it pending
+// until a query of its organization finishes as the organization is at its concurrency quota.
+func (c *Controller) startOrgQuery(q *Query) bool {
+	if !q.hasOrgQueueQuotas() {
+		return true
+	}
+
+	c.orgsMu.Lock()
+	defer c.orgsMu.Unlock()
+
+	oq := c.orgs[q.orgID]
+	if q.quotas.ConcurrencyQuota > 0 && oq.executing >= q.quotas.ConcurrencyQuota {
+		oq.pending = append(oq.pending, q)
+		return false
+	}
+	oq.queued--
+	oq.executing++
+	return true
+}
+
+// finishOrgQuery hands the execution slot of the finished query over to
+// the next pending query of its organization, which is returned to be executed.
+func (c *Controller) finishOrgQuery(q *Query) *Query {
+	if !q.hasOrgQueueQuotas() {
+		return nil
+	}
+
+	c.orgsMu.Lock()
+	defer c.orgsMu.Unlock()
+
+	oq := c.orgs[q.orgID]
+	if len(oq.pending) > 0 {
+		next := oq.pending[0]
+		oq.pending = oq.pending[1:]
+		oq.queued--
+		return next
+	}
+	oq.executing--
+	c.pruneOrgQueries(q.orgID, oq)
+	return nil
+}
+
+// pruneOrgQueries stops keeping track of an organization without queries.
+// This must be called with the orgs lock.
+func (c *Controller) pruneOrgQueries(orgID influxdb.ID, oq *orgQueries) {
+	if oq.queued == 0 && oq.executing == 0 {
+		delete(c.orgs, orgID)
+	}
+}
+
+// quotaRejections returns the counter of the rejections of the query for exceeding the quota.
+func (c *Controller) quotaRejections(q *Query, quota quotaLabel) prometheus.Counter {
+	l := len(q.labelValues)
+	lvs := make([]string, l+1)
+	copy(lvs, q.labelValues)
+	lvs[l] = string(quota)
+	return c.metrics.quotaRejections.WithLabelValues(lvs...)
+}
@@ -0,0 +1,197 @@
+package control_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/mock"
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/query/control"
+)
+
+// orgService finds the organizations with query quotas.
+type orgService map[platform.ID]*platform.OrgQueryQuotas
+
+func (s orgService) FindOrganizationByID(ctx context.Context, id platform.ID) (*platform.Organization, error) {
+	return &platform.Organization{ID: id, QueryQuotas: s[id]}, nil
+}
+
+func makeOrgRequest(orgID platform.ID, c flux.Compiler) *query.Request {
+	return &query.Request{
+		OrganizationID: orgID,
+		Compiler:       c,
+	}
+}
+
+func TestController_OrgQueueQuotas(t *testing.T) {
+	const (
+		quotedOrgID = platform.ID(1)
+		otherOrgID  = platform.ID(2)
+	)
+
+	config := config
+	config.ConcurrencyQuota = 3
+	config.QueueSize = 10
+	config.OrganizationService = orgService{
+		quotedOrgID: {ConcurrencyQuota: 1, QueueSize: 2},
+	}
+	ctrl, err := control.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer shutdown(t, ctrl)
+	reg := setupPromRegistry(ctrl)
+
+	executing := make(chan platform.ID, 10)
+	compiler := func(orgID platform.ID) flux.Compiler {
+		return &mock.Compiler{
+			CompileFn: func(ctx context.Context) (flux.Program, error) {
+				return &mock.Program{
+					ExecuteFn: func(ctx context.Context, q *mock.Query, alloc *memory.Allocator) {
+						select {
+						case <-q.Canceled:
+						default:
+							executing <- orgID
+							<-q.Canceled
+						}
+					},
+				}, nil
+			},
+		}
+	}
+	start := func(orgID platform.ID) flux.Query {
+		t.Helper()
+		q, err := ctrl.Query(context.Background(), makeOrgRequest(orgID, compiler(orgID)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		go func() {
+			for range q.Results() {
+				// discard the results
+			}
+			q.Done()
+		}()
+		return q
+	}
+
+	// The first query of the org uses its concurrency quota.
+	first := start(quotedOrgID)
+	if orgID := <-executing; orgID != quotedOrgID {
+		t.Fatalf("got query of org %s executing, want %s", orgID, quotedOrgID)
+	}
+
+	// The next query of the org waits, without holding up the queries of other orgs.
+	start(quotedOrgID)
+	start(otherOrgID)
+	if orgID := <-executing; orgID != otherOrgID {
+		t.Fatalf("got query of org %s executing, want %s", orgID, otherOrgID)
+	}
+
+	// Fill up the queue of the org.
+	start(quotedOrgID)
+	if _, err := ctrl.Query(context.Background(), makeOrgRequest(quotedOrgID, compiler(quotedOrgID))); err == nil {
+		t.Fatal("expected an error about the queue length of the org exceeded")
+	}
+
+	metrics, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := FindMetric(metrics, "query_control_quota_rejections_total", map[string]string{
+		"org":   quotedOrgID.String(),
+		"quota": "org_queue_size",
+	})
+	if m == nil || m.Counter.GetValue() != 1 {
+		t.Errorf("expected a single rejection of the org queue quota, got: %v", m)
+	}
+
+	select {
+	case orgID := <-executing:
+		t.Fatalf("got query of org %s executing over its concurrency quota", orgID)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Once the first query finishes, the next query of the org executes.
+	first.Cancel()
+	select {
+	case orgID := <-executing:
+		if orgID != quotedOrgID {
+			t.Fatalf("got query of org %s executing, want %s", orgID, quotedOrgID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the pending query of the org to execute")
+	}
+}
+
+func TestController_OrgMemoryQuota(t *testing.T) {
+	const (
+		quotedOrgID = platform.ID(1)
+		otherOrgID  = platform.ID(2)
+	)
+
+	config := config
+	config.OrganizationService = orgService{
+		quotedOrgID: {MemoryBytesQuotaPerQuery: config.MemoryBytesQuotaPerQuery / 2},
+	}
+	ctrl, err := control.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer shutdown(t, ctrl)
+	reg := setupPromRegistry(ctrl)
+
+	compiler := &mock.Compiler{
+		CompileFn: func(ctx context.Context) (flux.Program, error) {
+			return &mock.Program{
+				ExecuteFn: func(ctx context.Context, q *mock.Query, alloc *memory.Allocator) {
+					defer func() {
+						if err, ok := recover().(error); ok && err != nil {
+							q.SetErr(err)
+						}
+					}()
+
+					// Allocate more than the quota of the org but less than the quota per query.
+					mem := arrow.NewAllocator(alloc)
+					b := mem.Allocate(int(config.MemoryBytesQuotaPerQuery/2 + 1))
+					mem.Free(b)
+				},
+			}, nil
+		},
+	}
+
+	run := func(orgID platform.ID) error {
+		q, err := ctrl.Query(context.Background(), makeOrgRequest(orgID, compiler))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for range q.Results() {
+			// discard the results
+		}
+		q.Done()
+		return q.Err()
+	}
+
+	if err := run(otherOrgID); err != nil {
+		t.Errorf("unexpected error for the query of an org without quotas: %v", err)
+	}
+	if err := run(quotedOrgID); err == nil {
+		t.Error("expected error about the memory quota of the org exceeded")
+	}
+
+	metrics, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := FindMetric(metrics, "query_control_quota_rejections_total", map[string]string{
+		"org":   quotedOrgID.String(),
+		"quota": "memory_bytes_quota_per_query",
+	})
+	if m == nil || m.Counter.GetValue() == 0 {
+		t.Errorf("expected a rejection of the org memory quota, got: %v", m)
+	}
+}
@@ -0,0 +1,356 @@
+package query
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/iocounter"
+	"github.com/influxdata/flux/values"
+)
+
+const CSVDialectType = "formatted-csv"
+
+// The formats of the times of the query results.
+const (
+	// RFC3339DateTimeFormat formats times as RFC3339 with as many fractional digits as required.
+	RFC3339DateTimeFormat = "RFC3339"
+	// RFC3339NanoDateTimeFormat formats times as RFC3339 with nine fractional digits.
+	RFC3339NanoDateTimeFormat = "RFC3339Nano"
+
+	rfc3339NanoFixed = "2006-01-02T15:04:05.000000000Z07:00"
+)
+
+// timeLayout returns the layout of the times of the date time format.
+func timeLayout(dateTimeFormat string) string {
+	if dateTimeFormat == RFC3339NanoDateTimeFormat {
+		return rfc3339NanoFixed
+	}
+	return time.RFC3339Nano
+}
+
+// timeDatatype returns the datatype of the times of the date time format.
+func timeDatatype(dateTimeFormat string) string {
+	if dateTimeFormat == RFC3339NanoDateTimeFormat {
+		return "dateTime:" + RFC3339NanoDateTimeFormat
+	}
+	return "dateTime:" + RFC3339DateTimeFormat
+}
+
+// datatype returns the datatype of the column type as annotated in CSV.
+func datatype(typ flux.ColType, dateTimeFormat string) (string, error) {
+	switch typ {
+	case flux.TBool:
+		return "boolean", nil
+	case flux.TInt:
+		return "long", nil
+	case flux.TUInt:
+		return "unsignedLong", nil
+	case flux.TFloat:
+		return "double", nil
+	case flux.TString:
+		return "string", nil
+	case flux.TTime:
+		return timeDatatype(dateTimeFormat), nil
+	default:
+		return "", fmt.Errorf("unknown column type %v", typ)
+	}
+}
+
+// CSVDialect describes the output format of queries in annotated CSV,
+// with the format of the times and the prefix of the annotation rows
+// that the csv dialect of flux does not support.
+type CSVDialect struct {
+	// Annotations is a list of annotations to include.
+	Annotations []string `json:"annotations,omitempty"`
+	// NoHeader indicates whether a header row should be omitted.
+	NoHeader bool `json:"noHeader,omitempty"`
+	// Delimiter is the character to delimit columns.
+	Delimiter rune `json:"delimiter"`
+	// DateTimeFormat is the format of the times, RFC3339 or RFC3339Nano.
+	DateTimeFormat string `json:"dateTimeFormat"`
+	// CommentPrefix is the prefix of the annotation rows, # if empty.
+	CommentPrefix string `json:"commentPrefix"`
+}
+
+func (d *CSVDialect) Encoder() flux.MultiResultEncoder {
+	return &flux.DelimitedMultiResultEncoder{
+		Delimiter: []byte("\r\n"),
+		Encoder:   NewCSVResultEncoder(*d),
+	}
+}
+
+func (d *CSVDialect) DialectType() flux.DialectType {
+	return CSVDialectType
+}
+
+func (d *CSVDialect) SetHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Transfer-Encoding", "chunked")
+}
+
+// CSVResultEncoder encodes results in annotated CSV as the csv result encoder of flux does,
+// formatting the times and prefixing the annotation rows as configured by the dialect.
+type CSVResultEncoder struct {
+	d       CSVDialect
+	written bool
+}
+
+// NewCSVResultEncoder creates a new encoder of the dialect.
+func NewCSVResultEncoder(d CSVDialect) *CSVResultEncoder {
+	if d.CommentPrefix == "" {
+		d.CommentPrefix = "#"
+	}
+	return &CSVResultEncoder{d: d}
+}
+
+func (e *CSVResultEncoder) csvWriter(w io.Writer) *csv.Writer {
+	writer := csv.NewWriter(w)
+	if e.d.Delimiter != 0 {
+		writer.Comma = e.d.Delimiter
+	}
+	writer.UseCRLF = true
+	return writer
+}
+
+// the columns of the table and result of the rows preceding the columns of the tables.
+const csvRecordStart = 3
+
+func (e *CSVResultEncoder) Encode(w io.Writer, result flux.Result) (int64, error) {
+	wc := &iocounter.Writer{Writer: w}
+	writer := e.csvWriter(wc)
+	layout := timeLayout(e.d.DateTimeFormat)
+
+	var (
+		tableID   int
+		lastCols  []flux.ColMeta
+		lastEmpty bool
+	)
+	err := result.Tables().Do(func(tbl flux.Table) error {
+		e.written = true
+		cols := tbl.Cols()
+		tableIDStr := strconv.Itoa(tableID)
+		row := make([]string, csvRecordStart+len(cols))
+
+		if lastEmpty || tbl.Empty() || !equalCols(cols, lastCols) {
+			if lastCols != nil {
+				// an empty line separates the tables of different schemas.
+				writer.Write(nil)
+			}
+			if err := e.writeSchema(writer, row, cols, tbl, result.Name(), tableIDStr); err != nil {
+				return wrapEncoderError(err)
+			}
+		}
+
+		row[0] = ""
+		row[1] = result.Name()
+		if execute.ContainsStr(e.d.Annotations, "default") {
+			// the result is the default of the rows.
+			row[1] = ""
+		}
+		row[2] = tableIDStr
+
+		if err := tbl.Do(func(cr flux.ColReader) error {
+			record := row[csvRecordStart:]
+			for i, l := 0, cr.Len(); i < l; i++ {
+				for j, c := range cols {
+					v, err := encodeValueFrom(cr, i, j, c.Type, layout)
+					if err != nil {
+						return wrapEncoderError(err)
+					}
+					record[j] = v
+				}
+				writer.Write(row)
+			}
+			writer.Flush()
+			return wrapEncoderError(writer.Error())
+		}); err != nil {
+			return err
+		}
+
+		tableID++
+		lastCols = cols
+		lastEmpty = tbl.Empty()
+		writer.Flush()
+		return wrapEncoderError(writer.Error())
+	})
+	return wc.Count(), err
+}
+
+// writeSchema writes the annotations and the header of the table.
+func (e *CSVResultEncoder) writeSchema(writer *csv.Writer, row []string, cols []flux.ColMeta, tbl flux.Table, resultName, tableID string) error {
+	key := tbl.Key()
+	for _, annotation := range e.d.Annotations {
+		row[0] = e.d.CommentPrefix + annotation
+		switch annotation {
+		case "datatype":
+			row[1], row[2] = "string", "long"
+			for j, c := range cols {
+				dt, err := datatype(c.Type, e.d.DateTimeFormat)
+				if err != nil {
+					return err
+				}
+				row[csvRecordStart+j] = dt
+			}
+		case "group":
+			row[1], row[2] = "false", "false"
+			for j, c := range cols {
+				row[csvRecordStart+j] = strconv.FormatBool(key.HasCol(c.Label))
+			}
+		case "default":
+			row[1], row[2] = resultName, ""
+			for j := range cols {
+				row[csvRecordStart+j] = ""
+			}
+			// the defaults of an empty table are its group key.
+			if tbl.Empty() {
+				row[2] = tableID
+				for j, c := range cols {
+					if kj := execute.ColIdx(c.Label, key.Cols()); kj >= 0 {
+						v, err := encodeValue(key.Value(kj), c.Type, timeLayout(e.d.DateTimeFormat))
+						if err != nil {
+							return err
+						}
+						row[csvRecordStart+j] = v
+					}
+				}
+			}
+		default:
+			return fmt.Errorf("unsupported annotation %q", annotation)
+		}
+		writer.Write(row)
+	}
+
+	if !e.d.NoHeader {
+		row[0], row[1], row[2] = "", "result", "table"
+		for j, c := range cols {
+			row[csvRecordStart+j] = c.Label
+		}
+		writer.Write(row)
+	}
+	return writer.Error()
+}
+
+// EncodeError encodes the error as a table of the error and its reference.
+func (e *CSVResultEncoder) EncodeError(w io.Writer, err error) error {
+	writer := e.csvWriter(w)
+	if e.written {
+		writer.Write(nil)
+	}
+
+	for _, annotation := range e.d.Annotations {
+		switch annotation {
+		case "datatype":
+			writer.Write([]string{e.d.CommentPrefix + annotation, "string", "string"})
+		case "group":
+			writer.Write([]string{e.d.CommentPrefix + annotation, "true", "true"})
+		case "default":
+			writer.Write([]string{e.d.CommentPrefix + annotation, "", ""})
+		}
+	}
+	writer.Write([]string{"", "error", "reference"})
+	writer.Write([]string{"", err.Error(), ""})
+	writer.Flush()
+	return writer.Error()
+}
+
+func equalCols(a, b []flux.ColMeta) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for j := range a {
+		if a[j] != b[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// encodeValueFrom encodes the value of the column of the row, the empty string if it is null.
+func encodeValueFrom(cr flux.ColReader, i, j int, typ flux.ColType, layout string) (string, error) {
+	switch typ {
+	case flux.TBool:
+		if vs := cr.Bools(j); vs.IsValid(i) {
+			return strconv.FormatBool(vs.Value(i)), nil
+		}
+	case flux.TInt:
+		if vs := cr.Ints(j); vs.IsValid(i) {
+			return strconv.FormatInt(vs.Value(i), 10), nil
+		}
+	case flux.TUInt:
+		if vs := cr.UInts(j); vs.IsValid(i) {
+			return strconv.FormatUint(vs.Value(i), 10), nil
+		}
+	case flux.TFloat:
+		if vs := cr.Floats(j); vs.IsValid(i) {
+			return strconv.FormatFloat(vs.Value(i), 'f', -1, 64), nil
+		}
+	case flux.TString:
+		if vs := cr.Strings(j); vs.IsValid(i) {
+			return vs.ValueString(i), nil
+		}
+	case flux.TTime:
+		if vs := cr.Times(j); vs.IsValid(i) {
+			return execute.Time(vs.Value(i)).Time().Format(layout), nil
+		}
+	default:
+		return "", fmt.Errorf("unknown column type %v", typ)
+	}
+	return "", nil
+}
+
+// encodeValue encodes the value, the empty string if it is null.
+func encodeValue(v values.Value, typ flux.ColType, layout string) (string, error) {
+	if v.IsNull() {
+		return "", nil
+	}
+	switch typ {
+	case flux.TBool:
+		return strconv.FormatBool(v.Bool()), nil
+	case flux.TInt:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case flux.TUInt:
+		return strconv.FormatUint(v.UInt(), 10), nil
+	case flux.TFloat:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	case flux.TString:
+		return v.Str(), nil
+	case flux.TTime:
+		return v.Time().Time().Format(layout), nil
+	default:
+		return "", fmt.Errorf("unknown column type %v", typ)
+	}
+}
+
+// encoderError is an error of the encoding of the results rather than of the query,
+// which the delimited encoders of flux do not encode in the response.
+type encoderError struct {
+	err error
+}
+
+func (e *encoderError) Error() string {
+	return fmt.Sprintf("encoder error: %s", e.err.Error())
+}
+
+func (e *encoderError) IsEncoderError() bool {
+	return true
+}
+
+func (e *encoderError) Unwrap() error {
+	return e.err
+}
+
+func wrapEncoderError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*encoderError); ok {
+		return err
+	}
+	return &encoderError{err: err}
+}
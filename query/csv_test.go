@@ -0,0 +1,198 @@
+package query_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/influxdb/query"
+)
+
+// testResults returns the results of the encoder tests,
+// a result of two tables of the same schema, an empty table and a table of another schema.
+func testResults() []flux.Result {
+	cols := []flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "_measurement", Type: flux.TString},
+		{Label: "_field", Type: flux.TString},
+		{Label: "_value", Type: flux.TFloat},
+		{Label: "host", Type: flux.TString},
+	}
+	key := []string{"_measurement", "_field", "host"}
+	r := executetest.NewResult([]*executetest.Table{
+		{
+			KeyCols: key,
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{execute.Time(1575194400000000000), "cpu", "usage", 1.5, "a"},
+				{execute.Time(1575194410500000000), "cpu", "usage", nil, "a"},
+			},
+		},
+		{
+			KeyCols: key,
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{execute.Time(1575194400000000000), "cpu", "usage", 2.0, "b"},
+			},
+		},
+		{
+			KeyCols:   key,
+			KeyValues: []interface{}{"cpu", "usage", "c"},
+			ColMeta:   cols,
+		},
+		{
+			KeyCols: []string{"_measurement"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_measurement", Type: flux.TString},
+				{Label: "count", Type: flux.TInt},
+				{Label: "ok", Type: flux.TBool},
+				{Label: "n", Type: flux.TUInt},
+			},
+			Data: [][]interface{}{
+				{"cpu", int64(3), true, uint64(7)},
+			},
+		},
+	})
+	r.Nm = "_result"
+	return []flux.Result{r}
+}
+
+func TestCSVDialect(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect query.CSVDialect
+		results []flux.Result
+		want    string
+	}{
+		{
+			name: "times and comment prefix",
+			dialect: query.CSVDialect{
+				Annotations:    []string{"datatype", "group", "default"},
+				Delimiter:      ',',
+				DateTimeFormat: query.RFC3339NanoDateTimeFormat,
+				CommentPrefix:  "@",
+			},
+			results: testResults()[:1],
+			want: toCRLF(`@datatype,string,long,dateTime:RFC3339Nano,string,string,double,string
+@group,false,false,false,true,true,false,true
+@default,_result,,,,,,
+,result,table,_time,_measurement,_field,_value,host
+,,0,2019-12-01T10:00:00.000000000Z,cpu,usage,1.5,a
+,,0,2019-12-01T10:00:10.500000000Z,cpu,usage,,a
+,,1,2019-12-01T10:00:00.000000000Z,cpu,usage,2,b
+
+@datatype,string,long,dateTime:RFC3339Nano,string,string,double,string
+@group,false,false,false,true,true,false,true
+@default,_result,2,,cpu,usage,,c
+,result,table,_time,_measurement,_field,_value,host
+
+@datatype,string,long,string,long,boolean,unsignedLong
+@group,false,false,true,false,false,false
+@default,_result,,,,,
+,result,table,_measurement,count,ok,n
+,,3,cpu,3,true,7
+
+`),
+		},
+		{
+			name: "no annotations",
+			dialect: query.CSVDialect{
+				Delimiter:      ';',
+				NoHeader:       true,
+				DateTimeFormat: query.RFC3339DateTimeFormat,
+				CommentPrefix:  "@",
+			},
+			results: testResults()[:1],
+			want: toCRLF(`;_result;0;2019-12-01T10:00:00Z;cpu;usage;1.5;a
+;_result;0;2019-12-01T10:00:10.5Z;cpu;usage;;a
+;_result;1;2019-12-01T10:00:00Z;cpu;usage;2;b
+
+
+;_result;3;cpu;3;true;7
+
+`),
+		},
+		{
+			name: "error",
+			dialect: query.CSVDialect{
+				Annotations:   []string{"datatype", "group", "default"},
+				CommentPrefix: "@",
+			},
+			results: []flux.Result{
+				&executetest.Result{
+					Nm: "_result",
+					Tbls: []*executetest.Table{{
+						KeyCols: []string{"_measurement"},
+						ColMeta: []flux.ColMeta{{Label: "_measurement", Type: flux.TString}},
+						Data:    [][]interface{}{{"cpu"}},
+					}},
+				},
+				&executetest.Result{
+					Nm: "failed",
+					Tbls: []*executetest.Table{{
+						ColMeta: []flux.ColMeta{{Label: "_measurement", Type: flux.TString}},
+						Data:    [][]interface{}{{"cpu"}},
+						Err:     errors.New("expected error"),
+					}},
+				},
+			},
+			want: toCRLF(`@datatype,string,long,string
+@group,false,false,true
+@default,_result,,
+,result,table,_measurement
+,,0,cpu
+
+
+@datatype,string,string
+@group,true,true
+@default,,
+,error,reference
+,expected error,
+`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if _, err := tt.dialect.Encoder().Encode(&buf, flux.NewSliceResultIterator(tt.results)); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, buf.String()); diff != "" {
+				t.Errorf("unexpected csv -want/+got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCSVDialect_Compatibility(t *testing.T) {
+	// the default comment prefix and date time format encode the results as the csv dialect of flux.
+	var want bytes.Buffer
+	d := csv.DefaultDialect()
+	if _, err := d.Encoder().Encode(&want, flux.NewSliceResultIterator(testResults())); err != nil {
+		t.Fatal(err)
+	}
+
+	var got bytes.Buffer
+	qd := &query.CSVDialect{
+		Annotations:    d.Annotations,
+		Delimiter:      ',',
+		DateTimeFormat: query.RFC3339DateTimeFormat,
+	}
+	if _, err := qd.Encoder().Encode(&got, flux.NewSliceResultIterator(testResults())); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want.String(), got.String()); diff != "" {
+		t.Errorf("unexpected csv -want/+got:\n%s", diff)
+	}
+}
+
+func toCRLF(data string) string {
+	return strings.Replace(data, "\n", "\r\n", -1)
+}
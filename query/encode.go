@@ -13,15 +13,36 @@ const (
 	NoContentWErrDialectType = "no-content-with-error"
 )
 
-// AddDialectMappings adds the mappings for the no-content dialects.
+// AddDialectMappings adds the mappings for the no-content dialects
+// and the dialects of the response formats.
 func AddDialectMappings(mappings flux.DialectMappings) error {
 	if err := mappings.Add(NoContentDialectType, func() flux.Dialect {
 		return NewNoContentDialect()
 	}); err != nil {
 		return err
 	}
-	return mappings.Add(NoContentWErrDialectType, func() flux.Dialect {
+	if err := mappings.Add(NoContentWErrDialectType, func() flux.Dialect {
 		return NewNoContentWithErrorDialect()
+	}); err != nil {
+		return err
+	}
+	if err := mappings.Add(CSVDialectType, func() flux.Dialect {
+		return &CSVDialect{}
+	}); err != nil {
+		return err
+	}
+	if err := mappings.Add(JSONDialectType, func() flux.Dialect {
+		return &JSONDialect{}
+	}); err != nil {
+		return err
+	}
+	if err := mappings.Add(ArrowDialectType, func() flux.Dialect {
+		return &ArrowDialect{}
+	}); err != nil {
+		return err
+	}
+	return mappings.Add(LineProtocolDialectType, func() flux.Dialect {
+		return &LineProtocolDialect{}
 	})
 }
 
@@ -1,7 +1,10 @@
 package influxql
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/flux/execute"
@@ -30,22 +33,151 @@ type varRefCursor struct {
 	ref  *influxql.VarRef
 }
 
-// createVarRefCursor creates a new cursor from a variable reference using the sources
-// in the transpilerState.
-func createVarRefCursor(t *transpilerState, ref *influxql.VarRef) (cursor, error) {
-	if len(t.stmt.Sources) != 1 {
-		// TODO(jsternberg): Support multiple sources.
-		return nil, errors.New("unimplemented: only one source is allowed")
+// createVarRefCursor creates a new cursor from the variable references using the sources
+// in the transpilerState. If more than one field is referenced, the fields are pivoted
+// into their own columns so they can be accessed within the same row.
+func createVarRefCursor(ctx context.Context, t *transpilerState, refs ...*influxql.VarRef) (cursor, error) {
+	// Read each of the fields only once.
+	fields := make([]*influxql.VarRef, 0, len(refs))
+	for _, ref := range refs {
+		if !containsField(fields, ref.Val) {
+			fields = append(fields, ref)
+		}
 	}
 
-	// Only support a direct measurement. Subqueries are not supported yet.
-	mm, ok := t.stmt.Sources[0].(*influxql.Measurement)
-	if !ok {
-		return nil, errors.New("unimplemented: source must be a measurement")
+	if len(t.stmt.Sources) == 1 {
+		if src, ok := t.stmt.Sources[0].(*influxql.SubQuery); ok {
+			if len(fields) > 1 {
+				return createSubQueryFieldsCursor(ctx, t, src, fields)
+			}
+			return createSubQueryCursor(ctx, t, src, fields[0])
+		}
+	}
+
+	// Group the measurements by the bucket they are stored in.
+	var (
+		buckets      []string
+		measurements = make(map[string][]*influxql.Measurement)
+	)
+	for _, src := range t.stmt.Sources {
+		switch src := src.(type) {
+		case *influxql.Measurement:
+			key := src.Database + "." + src.RetentionPolicy
+			if _, ok := measurements[key]; !ok {
+				buckets = append(buckets, key)
+			}
+			measurements[key] = append(measurements[key], src)
+		case *influxql.SubQuery:
+			return nil, errors.New("unimplemented: subqueries cannot be combined with other sources")
+		default:
+			return nil, fmt.Errorf("unimplemented: source must be a measurement or subquery, got %T", src)
+		}
+	}
+
+	tables := make([]ast.Expression, 0, len(buckets))
+	for _, key := range buckets {
+		expr, err := t.readFields(ctx, measurements[key], fields)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, expr)
+	}
+
+	// Combine the measurements from each of the buckets.
+	expr := tables[0]
+	if len(tables) > 1 {
+		for i, table := range tables {
+			tables[i] = t.assignment(table)
+		}
+		expr = &ast.CallExpression{
+			Callee: &ast.Identifier{
+				Name: "union",
+			},
+			Arguments: []ast.Expression{
+				&ast.ObjectExpression{
+					Properties: []*ast.Property{{
+						Key: &ast.Identifier{
+							Name: "tables",
+						},
+						Value: &ast.ArrayExpression{
+							Elements: tables,
+						},
+					}},
+				},
+			},
+		}
+	}
+
+	if len(fields) == 1 {
+		return &varRefCursor{
+			expr: expr,
+			ref:  fields[0],
+		}, nil
+	}
+
+	// Pivot the fields so each field is in its own column.
+	expr = &ast.PipeExpression{
+		Argument: expr,
+		Call: &ast.CallExpression{
+			Callee: &ast.Identifier{
+				Name: "pivot",
+			},
+			Arguments: []ast.Expression{
+				&ast.ObjectExpression{
+					Properties: []*ast.Property{
+						{
+							Key: &ast.Identifier{
+								Name: "rowKey",
+							},
+							Value: &ast.ArrayExpression{
+								Elements: []ast.Expression{
+									&ast.StringLiteral{Value: execute.DefaultTimeColLabel},
+								},
+							},
+						},
+						{
+							Key: &ast.Identifier{
+								Name: "columnKey",
+							},
+							Value: &ast.ArrayExpression{
+								Elements: []ast.Expression{
+									&ast.StringLiteral{Value: "_field"},
+								},
+							},
+						},
+						{
+							Key: &ast.Identifier{
+								Name: "valueColumn",
+							},
+							Value: &ast.StringLiteral{Value: execute.DefaultValueColLabel},
+						},
+					},
+				},
+			},
+		},
+	}
+	return &fieldsCursor{
+		expr:   expr,
+		fields: fields,
+	}, nil
+}
+
+// rangeStop returns the stop of the range for the time range of a condition.
+// The maximum time of the condition is inclusive while the stop of the range
+// is exclusive, so the stop is the nanosecond after a maximum that was set
+// by the condition.
+func rangeStop(tr influxql.TimeRange) time.Time {
+	stop := tr.MaxTime()
+	if !tr.Max.IsZero() && tr.MaxTimeNano() < influxql.MaxTime {
+		stop = stop.Add(time.Nanosecond)
 	}
+	return stop.UTC()
+}
 
+// readFields reads the fields from the measurements within the same bucket.
+func (t *transpilerState) readFields(ctx context.Context, measurements []*influxql.Measurement, fields []*influxql.VarRef) (ast.Expression, error) {
 	// Create the from spec and add it to the list of operations.
-	from, err := t.from(mm)
+	from, err := t.from(ctx, measurements[0])
 	if err != nil {
 		return nil, err
 	}
@@ -58,9 +190,16 @@ func createVarRefCursor(t *transpilerState, ref *influxql.VarRef) (cursor, error
 
 	// If the maximum is not set and we have a windowing function, then
 	// the end time will be set to now.
-	if tr.Max.IsZero() {
-		if window, err := t.stmt.GroupByInterval(); err == nil && window > 0 {
-			tr.Max = t.config.Now
+	start, stop := tr.MinTime().UTC(), rangeStop(tr)
+	if window, err := t.stmt.GroupByInterval(); err == nil && window > 0 {
+		if tr.Max.IsZero() {
+			stop = t.config.Now.UTC()
+		}
+
+		// The intervals needed to compute the first interval of a
+		// transformation are read before the start of the time range.
+		if n := extraIntervals(t.stmt); n > 0 && !tr.Min.IsZero() {
+			start = start.Add(-time.Duration(n) * window)
 		}
 	}
 
@@ -78,7 +217,7 @@ func createVarRefCursor(t *transpilerState, ref *influxql.VarRef) (cursor, error
 								Name: "start",
 							},
 							Value: &ast.DateTimeLiteral{
-								Value: tr.MinTime().UTC(),
+								Value: start,
 							},
 						},
 						{
@@ -86,7 +225,7 @@ func createVarRefCursor(t *transpilerState, ref *influxql.VarRef) (cursor, error
 								Name: "stop",
 							},
 							Value: &ast.DateTimeLiteral{
-								Value: tr.MaxTime().UTC(),
+								Value: stop,
 							},
 						},
 					},
@@ -95,7 +234,68 @@ func createVarRefCursor(t *transpilerState, ref *influxql.VarRef) (cursor, error
 		},
 	}
 
-	expr := &ast.PipeExpression{
+	// Filter by each of the measurements and any of the fields.
+	var measurementExpr ast.Expression
+	for _, mm := range measurements {
+		var e ast.Expression
+		if mm.Regex != nil {
+			e = &ast.BinaryExpression{
+				Operator: ast.RegexpMatchOperator,
+				Left: &ast.MemberExpression{
+					Object:   &ast.Identifier{Name: "r"},
+					Property: &ast.Identifier{Name: "_measurement"},
+				},
+				Right: &ast.RegexpLiteral{
+					Value: mm.Regex.Val,
+				},
+			}
+		} else {
+			e = &ast.BinaryExpression{
+				Operator: ast.EqualOperator,
+				Left: &ast.MemberExpression{
+					Object:   &ast.Identifier{Name: "r"},
+					Property: &ast.Identifier{Name: "_measurement"},
+				},
+				Right: &ast.StringLiteral{
+					Value: mm.Name,
+				},
+			}
+		}
+		if measurementExpr == nil {
+			measurementExpr = e
+		} else {
+			measurementExpr = &ast.LogicalExpression{
+				Operator: ast.OrOperator,
+				Left:     measurementExpr,
+				Right:    e,
+			}
+		}
+	}
+
+	var fieldExpr ast.Expression
+	for _, f := range fields {
+		e := &ast.BinaryExpression{
+			Operator: ast.EqualOperator,
+			Left: &ast.MemberExpression{
+				Object:   &ast.Identifier{Name: "r"},
+				Property: &ast.Identifier{Name: "_field"},
+			},
+			Right: &ast.StringLiteral{
+				Value: f.Val,
+			},
+		}
+		if fieldExpr == nil {
+			fieldExpr = e
+		} else {
+			fieldExpr = &ast.LogicalExpression{
+				Operator: ast.OrOperator,
+				Left:     fieldExpr,
+				Right:    e,
+			}
+		}
+	}
+
+	return &ast.PipeExpression{
 		Argument: range_,
 		Call: &ast.CallExpression{
 			Callee: &ast.Identifier{
@@ -116,26 +316,8 @@ func createVarRefCursor(t *transpilerState, ref *influxql.VarRef) (cursor, error
 								}},
 								Body: &ast.LogicalExpression{
 									Operator: ast.AndOperator,
-									Left: &ast.BinaryExpression{
-										Operator: ast.EqualOperator,
-										Left: &ast.MemberExpression{
-											Object:   &ast.Identifier{Name: "r"},
-											Property: &ast.Identifier{Name: "_measurement"},
-										},
-										Right: &ast.StringLiteral{
-											Value: mm.Name,
-										},
-									},
-									Right: &ast.BinaryExpression{
-										Operator: ast.EqualOperator,
-										Left: &ast.MemberExpression{
-											Object:   &ast.Identifier{Name: "r"},
-											Property: &ast.Identifier{Name: "_field"},
-										},
-										Right: &ast.StringLiteral{
-											Value: ref.Val,
-										},
-									},
+									Left:     measurementExpr,
+									Right:    fieldExpr,
 								},
 							},
 						},
@@ -143,13 +325,19 @@ func createVarRefCursor(t *transpilerState, ref *influxql.VarRef) (cursor, error
 				},
 			},
 		},
-	}
-	return &varRefCursor{
-		expr: expr,
-		ref:  ref,
 	}, nil
 }
 
+// containsField returns true if one of the variable references is for the field.
+func containsField(fields []*influxql.VarRef, name string) bool {
+	for _, f := range fields {
+		if f.Val == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *varRefCursor) Expr() ast.Expression {
 	return c.expr
 }
@@ -171,6 +359,33 @@ func (c *varRefCursor) Value(expr influxql.Expr) (string, bool) {
 	return "", false
 }
 
+// fieldsCursor contains a cursor for multiple fields. Each field is stored
+// in the column with the same name as the field.
+type fieldsCursor struct {
+	expr   ast.Expression
+	fields []*influxql.VarRef
+}
+
+func (c *fieldsCursor) Expr() ast.Expression {
+	return c.expr
+}
+
+func (c *fieldsCursor) Keys() []influxql.Expr {
+	keys := make([]influxql.Expr, 0, len(c.fields))
+	for _, f := range c.fields {
+		keys = append(keys, f)
+	}
+	return keys
+}
+
+func (c *fieldsCursor) Value(expr influxql.Expr) (string, bool) {
+	ref, ok := expr.(*influxql.VarRef)
+	if !ok || !containsField(c.fields, ref.Val) {
+		return "", false
+	}
+	return ref.Val, true
+}
+
 // pipeCursor wraps a cursor with a new expression while delegating all calls to the
 // wrapped cursor.
 type pipeCursor struct {
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/influxdata/flux"
@@ -50,91 +51,29 @@ func init() {
 }
 
 var skipTests = map[string]string{
-	"hardcoded_literal_1":      "transpiler count query is off by 1 https://github.com/influxdata/influxdb/issues/10744",
-	"hardcoded_literal_3":      "transpiler count query is off by 1 https://github.com/influxdata/influxdb/issues/10744",
-	"fuzz_join_within_cursor":  "transpiler does not implement joining fields within a cursor https://github.com/influxdata/influxdb/issues/10743",
-	"derivative_count":         "add derivative support to the transpiler https://github.com/influxdata/influxdb/issues/10759",
-	"derivative_first":         "add derivative support to the transpiler https://github.com/influxdata/influxdb/issues/10759",
-	"derivative_last":          "add derivative support to the transpiler https://github.com/influxdata/influxdb/issues/10759",
-	"derivative_max":           "add derivative support to the transpiler https://github.com/influxdata/influxdb/issues/10759",
-	"derivative_mean":          "add derivative support to the transpiler https://github.com/influxdata/influxdb/issues/10759",
-	"derivative_median":        "add derivative support to the transpiler https://github.com/influxdata/influxdb/issues/10759",
-	"derivative_min":           "add derivative support to the transpiler https://github.com/influxdata/influxdb/issues/10759",
-	"derivative_mode":          "add derivative support to the transpiler https://github.com/influxdata/influxdb/issues/10759",
-	"derivative_percentile_10": "add derivative support to the transpiler https://github.com/influxdata/influxdb/issues/10759",
-	"derivative_percentile_50": "add derivative support to the transpiler https://github.com/influxdata/influxdb/issues/10759",
-	"derivative_percentile_90": "add derivative support to the transpiler https://github.com/influxdata/influxdb/issues/10759",
-	"derivative_sum":           "add derivative support to the transpiler https://github.com/influxdata/influxdb/issues/10759",
-	"regex_measurement_0":      "Transpiler: regex on measurements not evaluated https://github.com/influxdata/influxdb/issues/10740",
-	"regex_measurement_1":      "Transpiler: regex on measurements not evaluated https://github.com/influxdata/influxdb/issues/10740",
-	"regex_measurement_2":      "Transpiler: regex on measurements not evaluated https://github.com/influxdata/influxdb/issues/10740",
-	"regex_measurement_3":      "Transpiler: regex on measurements not evaluated https://github.com/influxdata/influxdb/issues/10740",
-	"regex_measurement_4":      "Transpiler: regex on measurements not evaluated https://github.com/influxdata/influxdb/issues/10740",
-	"regex_measurement_5":      "Transpiler: regex on measurements not evaluated https://github.com/influxdata/influxdb/issues/10740",
-	"regex_tag_0":              "Transpiler: Returns results in wrong sort order for regex filter on tags https://github.com/influxdata/influxdb/issues/10739",
-	"regex_tag_1":              "Transpiler: Returns results in wrong sort order for regex filter on tags https://github.com/influxdata/influxdb/issues/10739",
-	"regex_tag_2":              "Transpiler: Returns results in wrong sort order for regex filter on tags https://github.com/influxdata/influxdb/issues/10739",
-	"regex_tag_3":              "Transpiler: Returns results in wrong sort order for regex filter on tags https://github.com/influxdata/influxdb/issues/10739",
-	"explicit_type_0":          "Transpiler should remove _start column https://github.com/influxdata/influxdb/issues/10742",
-	"explicit_type_1":          "Transpiler should remove _start column https://github.com/influxdata/influxdb/issues/10742",
-	"fills_0":                  "need fill/Interpolate function https://github.com/influxdata/flux/issues/436",
-	"random_math_0":            "transpiler does not implement joining fields within a cursor https://github.com/influxdata/influxdb/issues/10743",
-	"selector_0":               "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"selector_1":               "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"selector_2":               "Transpiler: first function uses different series than influxQL https://github.com/influxdata/influxdb/issues/10737",
-	"selector_6":               "Transpiler: first function uses different series than influxQL https://github.com/influxdata/influxdb/issues/10737",
-	"selector_7":               "Transpiler: first function uses different series than influxQL https://github.com/influxdata/influxdb/issues/10737",
-	"series_agg_3":             "Transpiler: Implement elapsed https://github.com/influxdata/influxdb/issues/10733",
-	"series_agg_4":             "Transpiler: Implement cumulative_sum https://github.com/influxdata/influxdb/issues/10732",
-	"series_agg_5":             "add derivative support to the transpiler https://github.com/influxdata/influxdb/issues/10759",
-	"series_agg_6":             "Transpiler: Implement non_negative_derivative https://github.com/influxdata/influxdb/issues/10731",
-	"Subquery_0":               "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
-	"Subquery_1":               "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
-	"Subquery_2":               "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
-	"Subquery_3":               "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
-	"Subquery_4":               "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
-	"Subquery_5":               "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
-	"NestedSubquery_0":         "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
-	"NestedSubquery_1":         "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
-	"NestedSubquery_2":         "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
-	"NestedSubquery_3":         "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
-	"SimulatedHTTP_0":          "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
-	"SimulatedHTTP_1":          "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
-	"SimulatedHTTP_2":          "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
-	"SimulatedHTTP_3":          "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
-	"SimulatedHTTP_4":          "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
-	"SelectorMath_0":           "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_1":           "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_2":           "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_3":           "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_4":           "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_5":           "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_6":           "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_7":           "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_8":           "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_9":           "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_10":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_11":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_12":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_13":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_14":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_15":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_16":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_17":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_18":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_19":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_20":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_21":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_22":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_23":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_24":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_25":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_26":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_27":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_28":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_29":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_30":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
-	"SelectorMath_31":          "Transpiler: unimplemented functions: top and bottom https://github.com/influxdata/influxdb/issues/10738",
+	"fuzz_join_within_cursor": "the input stores the t tag as a field so points from different series with the same time are joined into one row",
+	"regex_measurement_0":     "Transpiler: regex on measurements not evaluated https://github.com/influxdata/influxdb/issues/10740",
+	"regex_measurement_1":     "Transpiler: regex on measurements not evaluated https://github.com/influxdata/influxdb/issues/10740",
+	"regex_measurement_2":     "Transpiler: regex on measurements not evaluated https://github.com/influxdata/influxdb/issues/10740",
+	"regex_measurement_3":     "Transpiler: regex on measurements not evaluated https://github.com/influxdata/influxdb/issues/10740",
+	"regex_measurement_4":     "Transpiler: regex on measurements not evaluated https://github.com/influxdata/influxdb/issues/10740",
+	"regex_measurement_5":     "Transpiler: regex on measurements not evaluated https://github.com/influxdata/influxdb/issues/10740",
+	"explicit_type_0":         "Transpiler should remove _start column https://github.com/influxdata/influxdb/issues/10742",
+	"explicit_type_1":         "Transpiler should remove _start column https://github.com/influxdata/influxdb/issues/10742",
+	"fills_0":                 "need fill/Interpolate function https://github.com/influxdata/flux/issues/436",
+	"selector_2":              "Transpiler: first function uses different series than influxQL https://github.com/influxdata/influxdb/issues/10737",
+	"series_agg_3":            "Transpiler: Implement elapsed https://github.com/influxdata/influxdb/issues/10733",
+	"Subquery_0":              "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
+	"Subquery_1":              "flux sums the points in a different order than influxQL so the mean differs in the last digit",
+	"Subquery_3":              "flux sums the points in a different order than influxQL so the mean differs in the last digit",
+	"NestedSubquery_2":        "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
+	"NestedSubquery_3":        "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
+	"SimulatedHTTP_0":         "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
+	"SimulatedHTTP_1":         "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
+	"SimulatedHTTP_2":         "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
+	"SimulatedHTTP_3":         "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
+	"SimulatedHTTP_4":         "Implement subqueries in the transpiler https://github.com/influxdata/influxdb/issues/10660",
+	"SelectorMath_12":         "Transpiler: first function uses different series than influxQL https://github.com/influxdata/influxdb/issues/10737",
 }
 
 var querier = fluxquerytest.NewQuerier()
@@ -196,6 +135,17 @@ func testGeneratedInfluxQL(t testing.TB, prefix, queryExt string) {
 	}
 
 	res, err := resultsFromQuerier(querier, influxQLCompiler(string(q), inFile))
+	if expErr := out.Err(); expErr != nil {
+		// The statement is expected to fail. The transpiler fails the
+		// whole query instead of returning the error with the statement.
+		if err == nil {
+			res.Release()
+			t.Fatalf("expected query to fail with %q", expErr)
+		} else if !strings.Contains(err.Error(), expErr.Error()) {
+			t.Fatalf("unexpected error: want %q, got %q", expErr, err)
+		}
+		return
+	}
 	if err != nil {
 		t.Fatalf("failed to run query: %v", err)
 	}
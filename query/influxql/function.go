@@ -13,14 +13,51 @@ import (
 func isTransformation(expr influxql.Expr) bool {
 	if call, ok := expr.(*influxql.Call); ok {
 		switch call.Name {
-		// TODO(ethan): more to be added here.
-		case "difference", "derivative", "cumulative_sum", "elapsed":
+		case "difference", "non_negative_difference", "derivative", "non_negative_derivative",
+			"moving_average", "cumulative_sum", "elapsed", "holt_winters", "holt_winters_with_fit":
 			return true
 		}
 	}
 	return false
 }
 
+// extraIntervals returns the number of intervals before the start of the time
+// range that are needed to compute the transformations of an aggregate for the
+// first interval of the time range.
+func extraIntervals(stmt *influxql.SelectStatement) int {
+	n := 0
+	influxql.WalkFunc(stmt.Fields, func(node influxql.Node) {
+		if call, ok := node.(*influxql.Call); ok {
+			if m := lookbackIntervals(call); m > n {
+				n = m
+			}
+		}
+	})
+	return n
+}
+
+// lookbackIntervals returns the number of previous intervals that are used
+// by a transformation of an aggregate to compute the value of an interval.
+func lookbackIntervals(call *influxql.Call) int {
+	if len(call.Args) == 0 {
+		return 0
+	} else if _, ok := call.Args[0].(*influxql.Call); !ok {
+		return 0
+	}
+
+	switch call.Name {
+	case "difference", "non_negative_difference", "derivative", "non_negative_derivative":
+		return 1
+	case "moving_average":
+		if len(call.Args) == 2 {
+			if lit, ok := call.Args[1].(*influxql.IntegerLiteral); ok && lit.Val > 1 {
+				return int(lit.Val) - 1
+			}
+		}
+	}
+	return 0
+}
+
 // function contains the prototype for invoking a function.
 // TODO(jsternberg): This should do a lot more heavy lifting, but it mostly just
 // pre-validates that we know the function exists. The cursor creation should be
@@ -31,115 +68,353 @@ type function struct {
 }
 
 // parseFunction parses a call AST and creates the function for it.
-func parseFunction(expr *influxql.Call) (*function, error) {
+// The arguments are validated the same way as influxdb 1.x so the
+// same error messages are returned for an invalid call.
+func parseFunction(expr *influxql.Call, stmt *influxql.SelectStatement) (*function, error) {
+	// The error for an invalid dimension is returned when the dimensions are evaluated.
+	interval, _ := stmt.GroupByInterval()
+
+	var (
+		ref *influxql.VarRef
+		err error
+	)
 	switch expr.Name {
 	case "count":
 		if exp, got := 1, len(expr.Args); exp != got {
 			return nil, fmt.Errorf("invalid number of arguments for %s, expected %d, got %d", expr.Name, exp, got)
 		}
 
-		switch ref := expr.Args[0].(type) {
-		case *influxql.VarRef:
-			return &function{
-				Ref:  ref,
-				call: expr,
-			}, nil
-		case *influxql.Call:
-			if ref.Name == "distinct" {
-				return nil, errors.New("unimplemented: count(distinct)")
-			}
-			return nil, fmt.Errorf("expected field argument in %s()", expr.Name)
-		case *influxql.Distinct:
-			return nil, errors.New("unimplemented: count(distinct)")
-		case *influxql.Wildcard:
-			return nil, errors.New("unimplemented: wildcard function")
-		case *influxql.RegexLiteral:
-			return nil, errors.New("unimplemented: wildcard regex function")
-		default:
-			return nil, fmt.Errorf("expected field argument in %s()", expr.Name)
+		// The count may be of the distinct values of a field.
+		if arg0, ok := expr.Args[0].(*influxql.Call); ok && arg0.Name == "distinct" {
+			ref, err = parseDistinct(arg0)
+		} else {
+			ref, err = parseFieldArg(expr.Name, expr.Args[0])
 		}
-	case "min", "max", "sum", "first", "last", "mean", "median", "difference", "stddev", "spread":
+	case "min", "max", "sum", "first", "last", "mean", "median", "mode", "difference", "non_negative_difference", "stddev", "spread", "cumulative_sum":
 		if exp, got := 1, len(expr.Args); exp != got {
 			return nil, fmt.Errorf("invalid number of arguments for %s, expected %d, got %d", expr.Name, exp, got)
 		}
 
-		switch ref := expr.Args[0].(type) {
-		case *influxql.VarRef:
-			return &function{
-				Ref:  ref,
-				call: expr,
-			}, nil
-		case *influxql.Wildcard:
-			return nil, errors.New("unimplemented: wildcard function")
-		case *influxql.RegexLiteral:
-			return nil, errors.New("unimplemented: wildcard regex function")
-		default:
-			return nil, fmt.Errorf("expected field argument in %s()", expr.Name)
+		if isTransformation(expr) {
+			ref, err = parseTransformationArg(expr.Name, expr.Args[0], interval, stmt)
+		} else {
+			ref, err = parseFieldArg(expr.Name, expr.Args[0])
 		}
+	case "distinct":
+		ref, err = parseDistinct(expr)
 	case "percentile":
 		if exp, got := 2, len(expr.Args); exp != got {
 			return nil, fmt.Errorf("invalid number of arguments for %s, expected %d, got %d", expr.Name, exp, got)
 		}
 
-		var functionRef *influxql.VarRef
-
-		switch ref := expr.Args[0].(type) {
-		case *influxql.VarRef:
-			functionRef = ref
-		case *influxql.Wildcard:
-			return nil, errors.New("unimplemented: wildcard function")
-		case *influxql.RegexLiteral:
-			return nil, errors.New("unimplemented: wildcard regex function")
-		default:
-			return nil, fmt.Errorf("expected field argument in %s()", expr.Name)
-		}
-
 		switch expr.Args[1].(type) {
 		case *influxql.IntegerLiteral:
 		case *influxql.NumberLiteral:
 		default:
 			return nil, fmt.Errorf("expected float argument in %s()", expr.Name)
 		}
+		ref, err = parseFieldArg(expr.Name, expr.Args[0])
+	case "sample":
+		if exp, got := 2, len(expr.Args); exp != got {
+			return nil, fmt.Errorf("invalid number of arguments for %s, expected %d, got %d", expr.Name, exp, got)
+		}
 
-		return &function{
-			Ref:  functionRef,
-			call: expr,
-		}, nil
-	default:
+		switch arg1 := expr.Args[1].(type) {
+		case *influxql.IntegerLiteral:
+			if arg1.Val <= 0 {
+				return nil, fmt.Errorf("sample window must be greater than 1, got %d", arg1.Val)
+			}
+		default:
+			return nil, fmt.Errorf("expected integer argument in %s()", expr.Name)
+		}
+		ref, err = parseFieldArg(expr.Name, expr.Args[0])
+	case "top", "bottom":
+		if exp, got := 2, len(expr.Args); got < exp {
+			return nil, fmt.Errorf("invalid number of arguments for %s, expected at least %d, got %d", expr.Name, exp, got)
+		}
+
+		limit, ok := expr.Args[len(expr.Args)-1].(*influxql.IntegerLiteral)
+		if !ok {
+			return nil, fmt.Errorf("expected integer as last argument in %s(), found %s", expr.Name, expr.Args[len(expr.Args)-1])
+		} else if limit.Val <= 0 {
+			return nil, fmt.Errorf("limit (%d) in %s function must be at least 1", limit.Val, expr.Name)
+		} else if stmt.Limit > 0 && int(limit.Val) > stmt.Limit {
+			return nil, fmt.Errorf("limit (%d) in %s function can not be larger than the LIMIT (%d) in the select statement", limit.Val, expr.Name, stmt.Limit)
+		}
+
+		ref, ok = expr.Args[0].(*influxql.VarRef)
+		if !ok {
+			return nil, fmt.Errorf("expected first argument to be a field in %s(), found %s", expr.Name, expr.Args[0])
+		}
+		for _, arg := range expr.Args[1 : len(expr.Args)-1] {
+			if _, ok := arg.(*influxql.VarRef); !ok {
+				return nil, fmt.Errorf("only fields or tags are allowed in %s(), found %s", expr.Name, arg)
+			}
+		}
+	case "derivative", "non_negative_derivative", "elapsed":
+		if min, max, got := 1, 2, len(expr.Args); got > max || got < min {
+			return nil, fmt.Errorf("invalid number of arguments for %s, expected at least %d but no more than %d, got %d", expr.Name, min, max, got)
+		}
+
+		if len(expr.Args) == 2 {
+			switch arg1 := expr.Args[1].(type) {
+			case *influxql.DurationLiteral:
+				if arg1.Val <= 0 {
+					return nil, fmt.Errorf("duration argument must be positive, got %s", influxql.FormatDuration(arg1.Val))
+				}
+			default:
+				return nil, fmt.Errorf("second argument to %s must be a duration, got %T", expr.Name, expr.Args[1])
+			}
+		}
+		ref, err = parseTransformationArg(expr.Name, expr.Args[0], interval, stmt)
+	case "moving_average":
+		if exp, got := 2, len(expr.Args); exp != got {
+			return nil, fmt.Errorf("invalid number of arguments for %s, expected %d, got %d", expr.Name, exp, got)
+		}
+
+		arg1, ok := expr.Args[1].(*influxql.IntegerLiteral)
+		if !ok {
+			return nil, fmt.Errorf("second argument for %s must be an integer, got %T", expr.Name, expr.Args[1])
+		} else if arg1.Val <= 1 {
+			return nil, fmt.Errorf("%s window must be greater than 1, got %d", expr.Name, arg1.Val)
+		}
+		ref, err = parseTransformationArg(expr.Name, expr.Args[0], interval, stmt)
+	case "integral":
+		if min, max, got := 1, 2, len(expr.Args); got > max || got < min {
+			return nil, fmt.Errorf("invalid number of arguments for %s, expected at least %d but no more than %d, got %d", expr.Name, min, max, got)
+		}
+
+		if len(expr.Args) == 2 {
+			switch arg1 := expr.Args[1].(type) {
+			case *influxql.DurationLiteral:
+				if arg1.Val <= 0 {
+					return nil, fmt.Errorf("duration argument must be positive, got %s", influxql.FormatDuration(arg1.Val))
+				}
+			default:
+				return nil, errors.New("second argument must be a duration")
+			}
+		}
+		ref, err = parseFieldArg(expr.Name, expr.Args[0])
+	case "holt_winters", "holt_winters_with_fit":
+		if exp, got := 3, len(expr.Args); exp != got {
+			return nil, fmt.Errorf("invalid number of arguments for %s, expected %d, got %d", expr.Name, exp, got)
+		}
+
+		if n, ok := expr.Args[1].(*influxql.IntegerLiteral); !ok {
+			return nil, fmt.Errorf("expected integer argument as second arg in %s", expr.Name)
+		} else if n.Val <= 0 {
+			return nil, fmt.Errorf("second arg to %s must be greater than 0, got %d", expr.Name, n.Val)
+		}
+		if s, ok := expr.Args[2].(*influxql.IntegerLiteral); !ok {
+			return nil, fmt.Errorf("expected integer argument as third arg in %s", expr.Name)
+		} else if s.Val < 0 {
+			return nil, fmt.Errorf("third arg to %s cannot be negative, got %d", expr.Name, s.Val)
+		}
+
+		if _, ok := expr.Args[0].(*influxql.Call); !ok {
+			return nil, fmt.Errorf("must use aggregate function with %s", expr.Name)
+		}
+		ref, err = parseTransformationArg(expr.Name, expr.Args[0], interval, stmt)
+	case "chande_momentum_oscillator", "exponential_moving_average", "double_exponential_moving_average",
+		"triple_exponential_moving_average", "triple_exponential_derivative", "relative_strength_index",
+		"kaufmans_efficiency_ratio", "kaufmans_adaptive_moving_average":
 		return nil, fmt.Errorf("unimplemented function: %q", expr.Name)
+	default:
+		return nil, fmt.Errorf("undefined function %s()", expr.Name)
 	}
 
+	if err != nil {
+		return nil, err
+	}
+	return &function{
+		Ref:  ref,
+		call: expr,
+	}, nil
+}
+
+// parseFieldArg parses an argument to a function that must be a field.
+func parseFieldArg(name string, arg influxql.Expr) (*influxql.VarRef, error) {
+	switch arg := arg.(type) {
+	case *influxql.VarRef:
+		return arg, nil
+	case *influxql.Wildcard:
+		return nil, errors.New("unimplemented: wildcard function")
+	case *influxql.RegexLiteral:
+		return nil, errors.New("unimplemented: wildcard regex function")
+	default:
+		return nil, fmt.Errorf("expected field argument in %s()", name)
+	}
+}
+
+// parseDistinct parses a call to distinct. This is used both for the
+// distinct function and for the distinct values that are counted.
+func parseDistinct(expr *influxql.Call) (*influxql.VarRef, error) {
+	if len(expr.Args) == 0 {
+		return nil, errors.New("distinct function requires at least one argument")
+	} else if len(expr.Args) != 1 {
+		return nil, errors.New("distinct function can only have one argument")
+	}
+
+	ref, ok := expr.Args[0].(*influxql.VarRef)
+	if !ok {
+		return nil, errors.New("expected field argument in distinct()")
+	}
+	return ref, nil
+}
+
+// parseTransformationArg parses the argument to a transformation. A transformation
+// is applied to the raw values of a field or, when grouping by an interval, to the
+// output of an aggregate on each interval.
+func parseTransformationArg(name string, arg influxql.Expr, interval time.Duration, stmt *influxql.SelectStatement) (*influxql.VarRef, error) {
+	call, ok := arg.(*influxql.Call)
+	if !ok {
+		if interval > 0 {
+			return nil, fmt.Errorf("aggregate function required inside the call to %s", name)
+		}
+		return parseFieldArg(name, arg)
+	}
+
+	if interval == 0 {
+		return nil, fmt.Errorf("%s aggregate requires a GROUP BY interval", name)
+	}
+	fn, err := parseFunction(call, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	// The aggregate is evaluated for each interval and then the transformation
+	// is applied to the aggregated values. Nesting another transformation or
+	// a selector that returns multiple points for each interval is not supported.
+	if _, ok := call.Args[0].(*influxql.VarRef); !ok || isTransformation(call) {
+		return nil, fmt.Errorf("unimplemented: %s() within %s()", call.Name, name)
+	}
+	switch call.Name {
+	case "top", "bottom", "sample", "distinct":
+		return nil, fmt.Errorf("unimplemented: %s() within %s()", call.Name, name)
+	}
+	return fn.Ref, nil
 }
 
 // createFunctionCursor creates a new cursor that calls a function on one of the columns
-// and returns the result.
-func createFunctionCursor(t *transpilerState, call *influxql.Call, in cursor, normalize bool) (cursor, error) {
+// and returns the result. The group key contains the columns the cursor was grouped by
+// and is nil when the cursor was not regrouped because of a wildcard dimension.
+func createFunctionCursor(t *transpilerState, call *influxql.Call, in cursor, groupKey []ast.Expression, normalize bool) (cursor, error) {
+	// The function is invoked on its first argument. When counting the distinct
+	// values of a field, the argument to distinct is used instead.
+	arg := call.Args[0]
+	if c, ok := arg.(*influxql.Call); ok && call.Name == "count" && c.Name == "distinct" {
+		arg = c.Args[0]
+	}
+	value, ok := in.Value(arg)
+	if !ok {
+		return nil, fmt.Errorf("undefined variable: %s", arg)
+	}
+
+	// err checked in caller
+	interval, _ := t.stmt.GroupByInterval()
+
 	cur := &functionCursor{
-		call:   call,
-		parent: in,
+		call:    call,
+		value:   value,
+		exclude: map[influxql.Expr]struct{}{arg: {}},
+		parent:  in,
 	}
-	switch call.Name {
-	case "count", "min", "max", "sum", "first", "last", "mean", "difference", "stddev", "spread":
-		value, ok := in.Value(call.Args[0])
-		if !ok {
-			return nil, fmt.Errorf("undefined variable: %s", call.Args[0])
+	// When the field is read with other fields, it is pivoted into its own
+	// column and the function must be told which column to use.
+	var column, columns []*ast.Property
+	if value != execute.DefaultValueColLabel {
+		column = []*ast.Property{
+			property("column", &ast.StringLiteral{Value: value}),
 		}
-		cur.expr = &ast.PipeExpression{
-			Argument: in.Expr(),
-			Call: &ast.CallExpression{
-				Callee: &ast.Identifier{
-					Name: call.Name,
+		columns = []*ast.Property{
+			property("columns", &ast.ArrayExpression{
+				Elements: []ast.Expression{
+					&ast.StringLiteral{Value: value},
 				},
-			},
+			}),
+		}
+	}
+
+	switch call.Name {
+	case "count":
+		expr := in.Expr()
+		if arg != call.Args[0] {
+			// The distinct values are always written to the value column.
+			expr = pipeCall(expr, "distinct", column...)
+			cur.value, column = execute.DefaultValueColLabel, nil
+		}
+		cur.expr = pipeCall(expr, "count", column...)
+	case "first", "last":
+		// When points from more than one series have the same time, the point
+		// with the highest value is selected. The points are sorted by value
+		// and the first point with the minimum or maximum time is selected.
+		selector := "min"
+		if call.Name == "last" {
+			selector = "max"
+		}
+		expr := pipeCall(in.Expr(), "sort",
+			property("columns", stringArray(value)),
+			property("desc", &ast.BooleanLiteral{Value: true}),
+		)
+		cur.expr = pipeCall(expr, selector, property("column", &ast.StringLiteral{Value: execute.DefaultTimeColLabel}))
+	case "min", "max", "sum", "mean", "stddev", "spread":
+		cur.expr = pipeCall(in.Expr(), call.Name, column...)
+	case "mode":
+		cur.expr = createMode(in.Expr(), value, groupKey)
+	case "distinct":
+		cur.expr = pipeCall(in.Expr(), call.Name, column...)
+		cur.value = execute.DefaultValueColLabel
+	case "difference":
+		cur.expr = pipeCall(in.Expr(), call.Name, columns...)
+	case "non_negative_difference":
+		cur.expr = pipeCall(in.Expr(), "difference", append([]*ast.Property{
+			property("nonNegative", &ast.BooleanLiteral{Value: true}),
+		}, columns...)...)
+	case "cumulative_sum":
+		cur.expr = pipeCall(in.Expr(), "cumulativeSum", columns...)
+	case "derivative", "non_negative_derivative":
+		// The derivative defaults to the rate of change per second or per
+		// interval when the statement groups by time.
+		unit := time.Second
+		if len(call.Args) == 2 {
+			unit = call.Args[1].(*influxql.DurationLiteral).Val
+		} else if interval > 0 {
+			unit = interval
+		}
+		cur.expr = pipeCall(in.Expr(), "derivative", append([]*ast.Property{
+			property("unit", &ast.DurationLiteral{Values: durationLiteral(unit)}),
+			property("nonNegative", &ast.BooleanLiteral{Value: call.Name == "non_negative_derivative"}),
+		}, columns...)...)
+	case "moving_average":
+		// The moving average can only be computed on the value column.
+		if value != execute.DefaultValueColLabel {
+			return nil, errors.New("unimplemented: moving_average() cannot be combined with other fields")
 		}
-		cur.value = value
-		cur.exclude = map[influxql.Expr]struct{}{call.Args[0]: {}}
+		cur.expr = pipeCall(in.Expr(), "movingAverage",
+			property("n", &ast.IntegerLiteral{Value: call.Args[1].(*influxql.IntegerLiteral).Val}),
+		)
+	case "integral":
+		unit := time.Second
+		if len(call.Args) == 2 {
+			unit = call.Args[1].(*influxql.DurationLiteral).Val
+		}
+		cur.expr = pipeCall(in.Expr(), "integral", append([]*ast.Property{
+			property("unit", &ast.DurationLiteral{Values: durationLiteral(unit)}),
+		}, column...)...)
+	case "holt_winters", "holt_winters_with_fit":
+		// The predicted points are spaced by the interval of the aggregate.
+		cur.expr = pipeCall(in.Expr(), "holtWinters", append([]*ast.Property{
+			property("n", &ast.IntegerLiteral{Value: call.Args[1].(*influxql.IntegerLiteral).Val}),
+			property("seasonality", &ast.IntegerLiteral{Value: call.Args[2].(*influxql.IntegerLiteral).Val}),
+			property("interval", &ast.DurationLiteral{Values: durationLiteral(interval)}),
+			property("withFit", &ast.BooleanLiteral{Value: call.Name == "holt_winters_with_fit"}),
+		}, column...)...)
+	case "sample":
+		cur.expr = createSample(call, in.Expr())
+	case "top", "bottom":
+		cur.expr = createTopBottom(call, in.Expr(), column, columns, groupKey)
 	case "elapsed":
 		// TODO(ethan): https://github.com/influxdata/influxdb/issues/10733 to enable this.
-		value, ok := in.Value(call.Args[0])
-		if !ok {
-			return nil, fmt.Errorf("undefined variable: %s", call.Args[0])
-		}
 		unit := []ast.Duration{{
 			Magnitude: 1,
 			Unit:      "ns",
@@ -176,12 +451,7 @@ func createFunctionCursor(t *transpilerState, call *influxql.Call, in cursor, no
 				},
 			},
 		}
-		cur.value = value
 	case "median":
-		value, ok := in.Value(call.Args[0])
-		if !ok {
-			return nil, fmt.Errorf("undefined variable: %s", call.Args[0])
-		}
 		cur.expr = &ast.PipeExpression{
 			Argument: in.Expr(),
 			Call: &ast.CallExpression{
@@ -190,7 +460,7 @@ func createFunctionCursor(t *transpilerState, call *influxql.Call, in cursor, no
 				},
 				Arguments: []ast.Expression{
 					&ast.ObjectExpression{
-						Properties: []*ast.Property{
+						Properties: append([]*ast.Property{
 							{
 								Key: &ast.Identifier{
 									Name: "method",
@@ -199,23 +469,16 @@ func createFunctionCursor(t *transpilerState, call *influxql.Call, in cursor, no
 									Value: "exact_mean",
 								},
 							},
-						},
+						}, column...),
 					},
 				},
 			},
 		}
-		cur.value = value
-		cur.exclude = map[influxql.Expr]struct{}{call.Args[0]: {}}
 	case "percentile":
 		if len(call.Args) != 2 {
 			return nil, errors.New("percentile function requires two arguments field_key and N")
 		}
 
-		fieldName, ok := in.Value(call.Args[0])
-		if !ok {
-			return nil, fmt.Errorf("undefined variable: %s", call.Args[0])
-		}
-
 		var percentile float64
 		switch arg := call.Args[1].(type) {
 		case *influxql.NumberLiteral:
@@ -248,18 +511,7 @@ func createFunctionCursor(t *transpilerState, call *influxql.Call, in cursor, no
 				},
 			},
 		}
-		if fieldName != execute.DefaultValueColLabel {
-			args = append(args, &ast.Property{
-				Key: &ast.Identifier{
-					Name: "columns",
-				},
-				Value: &ast.ArrayExpression{
-					Elements: []ast.Expression{
-						&ast.StringLiteral{Value: fieldName},
-					},
-				},
-			})
-		}
+		args = append(args, column...)
 		cur.expr = &ast.PipeExpression{
 			Argument: in.Expr(),
 			Call: &ast.CallExpression{
@@ -273,8 +525,6 @@ func createFunctionCursor(t *transpilerState, call *influxql.Call, in cursor, no
 				},
 			},
 		}
-		cur.value = fieldName
-		cur.exclude = map[influxql.Expr]struct{}{call.Args[0]: {}}
 	default:
 		return nil, fmt.Errorf("unimplemented function: %q", call.Name)
 	}
@@ -305,8 +555,6 @@ func createFunctionCursor(t *transpilerState, call *influxql.Call, in cursor, no
 				},
 			}
 		}
-		// err checked in caller
-		interval, _ := t.stmt.GroupByInterval()
 		var timeValue ast.Expression
 		if interval > 0 {
 			timeValue = &ast.MemberExpression{
@@ -380,6 +628,223 @@ func createFunctionCursor(t *transpilerState, call *influxql.Call, in cursor, no
 	return cur, nil
 }
 
+// createTopBottom creates the expression that selects the top or bottom points.
+// When tags are passed to the function, the point with the maximum or minimum value
+// is selected for each distinct set of tag values before selecting the top or bottom points.
+// The selected points are returned in time order.
+// The column arguments select the field when it was pivoted into its own column.
+func createTopBottom(call *influxql.Call, expr ast.Expression, column, columns []*ast.Property, groupKey []ast.Expression) ast.Expression {
+	n := call.Args[len(call.Args)-1].(*influxql.IntegerLiteral).Val
+	if tags := call.Args[1 : len(call.Args)-1]; len(tags) > 0 {
+		selector := "max"
+		if call.Name == "bottom" {
+			selector = "min"
+		}
+
+		// When grouping by a wildcard, every tag is already part of the group key
+		// so there is only one distinct set of tag values in each table.
+		if groupKey == nil {
+			return pipeCall(expr, selector, column...)
+		}
+
+		columns := make([]ast.Expression, len(groupKey), len(groupKey)+len(tags))
+		copy(columns, groupKey)
+		for _, tag := range tags {
+			if name := tag.(*influxql.VarRef).Val; !containsColumn(columns, name) {
+				columns = append(columns, &ast.StringLiteral{Value: name})
+			}
+		}
+		expr = pipeCall(expr, "group",
+			property("columns", &ast.ArrayExpression{Elements: columns}),
+			property("mode", &ast.StringLiteral{Value: "by"}),
+		)
+		expr = pipeCall(expr, selector, column...)
+		expr = pipeCall(expr, "group",
+			property("columns", &ast.ArrayExpression{Elements: groupKey}),
+			property("mode", &ast.StringLiteral{Value: "by"}),
+		)
+		expr = moveTagColumns(expr, tags)
+	}
+	expr = pipeCall(expr, call.Name, append([]*ast.Property{
+		property("n", &ast.IntegerLiteral{Value: n}),
+	}, columns...)...)
+	return pipeCall(expr, "sort",
+		property("columns", &ast.ArrayExpression{
+			Elements: []ast.Expression{
+				&ast.StringLiteral{Value: execute.DefaultTimeColLabel},
+			},
+		}),
+	)
+}
+
+// moveTagColumns moves the tag columns passed to top() or bottom() to the end
+// of the table so they follow the selected value, in the order they were passed.
+// The columns are copied to temporary columns which are renamed back once
+// the originals have been dropped.
+func moveTagColumns(expr ast.Expression, tags []influxql.Expr) ast.Expression {
+	names := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		if name := tag.(*influxql.VarRef).Val; !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+
+	renames := make([]*ast.Property, 0, len(names))
+	for _, name := range names {
+		tmp := "_tag_" + name
+		expr = pipeCall(expr, "duplicate",
+			property("column", &ast.StringLiteral{Value: name}),
+			property("as", &ast.StringLiteral{Value: tmp}),
+		)
+		renames = append(renames, &ast.Property{
+			Key:   &ast.StringLiteral{Value: tmp},
+			Value: &ast.StringLiteral{Value: name},
+		})
+	}
+	expr = pipeCall(expr, "drop", property("columns", stringArray(names...)))
+	return pipeCall(expr, "rename", property("columns", &ast.ObjectExpression{Properties: renames}))
+}
+
+// modeKeyColumn is the column createMode counts the distinct values by.
+const modeKeyColumn = "_mode"
+
+// createMode selects the value that occurs the most in each table. The mode
+// function in flux returns every value that occurs the most or null when each
+// value occurs the same number of times. When values occur the same number
+// of times, influxql selects the smallest of them.
+//
+// The number of times each value occurs is counted by grouping on a copy of
+// the value. The first count with the maximum is selected after the values
+// are sorted so the smallest value is selected.
+func createMode(expr ast.Expression, value string, groupKey []ast.Expression) ast.Expression {
+	expr = pipeCall(expr, "duplicate",
+		property("column", &ast.StringLiteral{Value: value}),
+		property("as", &ast.StringLiteral{Value: modeKeyColumn}),
+	)
+	if groupKey != nil {
+		columns := make([]ast.Expression, len(groupKey), len(groupKey)+1)
+		copy(columns, groupKey)
+		expr = pipeCall(expr, "group",
+			property("columns", &ast.ArrayExpression{
+				Elements: append(columns, &ast.StringLiteral{Value: modeKeyColumn}),
+			}),
+			property("mode", &ast.StringLiteral{Value: "by"}),
+		)
+	} else {
+		// When grouping by a wildcard, every column other than the
+		// time and value is part of the group key.
+		expr = pipeCall(expr, "group",
+			property("columns", stringArray(execute.DefaultTimeColLabel, value)),
+			property("mode", &ast.StringLiteral{Value: "except"}),
+		)
+	}
+	expr = pipeCall(expr, "count", property("column", &ast.StringLiteral{Value: value}))
+	if groupKey != nil {
+		expr = pipeCall(expr, "group",
+			property("columns", &ast.ArrayExpression{Elements: groupKey}),
+			property("mode", &ast.StringLiteral{Value: "by"}),
+		)
+	} else {
+		expr = pipeCall(expr, "group",
+			property("columns", stringArray(modeKeyColumn)),
+			property("mode", &ast.StringLiteral{Value: "except"}),
+		)
+	}
+	expr = pipeCall(expr, "sort", property("columns", stringArray(modeKeyColumn)))
+	expr = pipeCall(expr, "max", property("column", &ast.StringLiteral{Value: value}))
+	expr = pipeCall(expr, "drop", property("columns", stringArray(value)))
+	return pipeCall(expr, "rename", property("columns", &ast.ObjectExpression{
+		Properties: []*ast.Property{{
+			Key:   &ast.StringLiteral{Value: modeKeyColumn},
+			Value: &ast.StringLiteral{Value: value},
+		}},
+	}))
+}
+
+// sampleKeyColumn is the column createSample sorts the points by.
+const sampleKeyColumn = "_sample"
+
+// createSample selects N points from each table at random. Flux has no random
+// number function and its sample function selects every Nth point, so each point
+// is keyed by a multiplicative hash of its time and the N points with the lowest
+// keys are selected. The points are then sorted by time again.
+//
+// Unlike the reservoir sampling of v1, the same points are selected each time
+// the query is run.
+func createSample(call *influxql.Call, expr ast.Expression) ast.Expression {
+	expr = pipeCall(expr, "map",
+		property("fn", &ast.FunctionExpression{
+			Params: []*ast.Property{{
+				Key: &ast.Identifier{Name: "r"},
+			}},
+			Body: &ast.ObjectExpression{
+				With: &ast.Identifier{Name: "r"},
+				Properties: []*ast.Property{
+					property(sampleKeyColumn, &ast.BinaryExpression{
+						Operator: ast.ModuloOperator,
+						Left: &ast.BinaryExpression{
+							Operator: ast.MultiplicationOperator,
+							Left: &ast.CallExpression{
+								Callee: &ast.Identifier{Name: "int"},
+								Arguments: []ast.Expression{
+									&ast.ObjectExpression{
+										Properties: []*ast.Property{
+											property("v", &ast.MemberExpression{
+												Object:   &ast.Identifier{Name: "r"},
+												Property: &ast.Identifier{Name: execute.DefaultTimeColLabel},
+											}),
+										},
+									},
+								},
+							},
+							Right: &ast.IntegerLiteral{Value: 2654435761},
+						},
+						Right: &ast.IntegerLiteral{Value: 1 << 32},
+					}),
+				},
+			},
+		}),
+	)
+	expr = pipeCall(expr, "sort", property("columns", stringArray(sampleKeyColumn)))
+	expr = pipeCall(expr, "limit",
+		property("n", &ast.IntegerLiteral{Value: call.Args[1].(*influxql.IntegerLiteral).Val}),
+	)
+	expr = pipeCall(expr, "sort", property("columns", stringArray(execute.DefaultTimeColLabel)))
+	return pipeCall(expr, "drop", property("columns", stringArray(sampleKeyColumn)))
+}
+
+// pipeCall pipes the expression into a call of the named function.
+func pipeCall(expr ast.Expression, name string, args ...*ast.Property) ast.Expression {
+	call := &ast.CallExpression{
+		Callee: &ast.Identifier{
+			Name: name,
+		},
+	}
+	if len(args) > 0 {
+		call.Arguments = []ast.Expression{
+			&ast.ObjectExpression{
+				Properties: args,
+			},
+		}
+	}
+	return &ast.PipeExpression{
+		Argument: expr,
+		Call:     call,
+	}
+}
+
+// property creates a property with the given key for a function argument or an object.
+func property(key string, value ast.Expression) *ast.Property {
+	return &ast.Property{
+		Key: &ast.Identifier{
+			Name: key,
+		},
+		Value: value,
+	}
+}
+
 type functionCursor struct {
 	expr    ast.Expression
 	call    *influxql.Call
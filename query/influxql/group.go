@@ -1,6 +1,7 @@
 package influxql
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -13,11 +14,14 @@ import (
 
 type groupInfo struct {
 	call              *influxql.Call
+	ref               *influxql.VarRef
 	refs              []*influxql.VarRef
+	groupKey          []ast.Expression
 	needNormalization bool
 }
 
 type groupVisitor struct {
+	stmt  *influxql.SelectStatement
 	calls []*function
 	refs  []*influxql.VarRef
 	err   error
@@ -31,8 +35,16 @@ func (v *groupVisitor) Visit(n influxql.Node) influxql.Visitor {
 	// TODO(jsternberg): Identify duplicates so they are a single common instance.
 	switch expr := n.(type) {
 	case *influxql.Call:
-		// TODO(jsternberg): Identify math functions so we visit their arguments instead of recording them.
-		fn, err := parseFunction(expr)
+		// Math functions are evaluated when the columns are mapped so we visit
+		// their arguments instead of recording them.
+		if isMathFunction(expr) {
+			if err := validateMathFunction(expr); err != nil {
+				v.err = err
+				return nil
+			}
+			return v
+		}
+		fn, err := parseFunction(expr, v.stmt)
 		if err != nil {
 			v.err = err
 			return nil
@@ -60,12 +72,33 @@ func (v *groupVisitor) Visit(n influxql.Node) influxql.Visitor {
 
 // identifyGroups will identify the groups for creating data access cursors.
 func identifyGroups(stmt *influxql.SelectStatement) ([]*groupInfo, error) {
-	v := &groupVisitor{}
+	v := &groupVisitor{stmt: stmt}
 	influxql.Walk(v, stmt.Fields)
 	if v.err != nil {
 		return nil, v.err
 	}
 
+	for _, f := range stmt.Fields {
+		if !hasVariable(f.Expr) {
+			return nil, errors.New("field must contain at least one variable")
+		}
+	}
+
+	// The functions that return multiple points for each interval cannot be
+	// combined with other functions.
+	for _, fn := range v.calls {
+		switch fn.call.Name {
+		case "distinct":
+			if len(v.calls) > 1 || len(v.refs) > 0 {
+				return nil, errors.New("aggregate function distinct() cannot be combined with other functions or fields")
+			}
+		case "top", "bottom":
+			if len(v.calls) > 1 {
+				return nil, fmt.Errorf("selector function %s() cannot be combined with other functions", fn.call.Name)
+			}
+		}
+	}
+
 	// Attempt to take the calls and variables and put them into groups.
 	if len(v.refs) > 0 {
 		// If any of the calls are not selectors, we have an error message.
@@ -81,12 +114,16 @@ func identifyGroups(stmt *influxql.SelectStatement) ([]*groupInfo, error) {
 		}
 
 		// Otherwise, we create a single group.
-		var call *influxql.Call
+		var (
+			call *influxql.Call
+			ref  *influxql.VarRef
+		)
 		if len(v.calls) == 1 {
-			call = v.calls[0].call
+			call, ref = v.calls[0].call, v.calls[0].Ref
 		}
 		return []*groupInfo{{
 			call:              call,
+			ref:               ref,
 			refs:              v.refs,
 			needNormalization: false, // Always a selector if we are here.
 		}}, nil
@@ -96,7 +133,7 @@ func identifyGroups(stmt *influxql.SelectStatement) ([]*groupInfo, error) {
 	// its own group.
 	groups := make([]*groupInfo, 0, len(v.calls))
 	for _, fn := range v.calls {
-		groups = append(groups, &groupInfo{call: fn.call})
+		groups = append(groups, &groupInfo{call: fn.call, ref: fn.Ref})
 	}
 
 	// If there is exactly one group and that contains a selector or a transformation function,
@@ -107,33 +144,17 @@ func identifyGroups(stmt *influxql.SelectStatement) ([]*groupInfo, error) {
 	return groups, nil
 }
 
-func (gr *groupInfo) createCursor(t *transpilerState) (cursor, error) {
-	// Create all of the cursors for every variable reference.
-	// TODO(jsternberg): Determine which of these cursors are from fields and which are tags.
-	var cursors []cursor
+func (gr *groupInfo) createCursor(ctx context.Context, t *transpilerState) (cursor, error) {
+	// Collect the variable references that need to be read.
+	// TODO(jsternberg): Determine which of these are from fields and which are tags.
+	refs := make([]*influxql.VarRef, 0, len(gr.refs)+1)
 	if gr.call != nil {
-		ref, ok := gr.call.Args[0].(*influxql.VarRef)
-		if !ok {
-			// TODO(jsternberg): This should be validated and figured out somewhere else.
-			return nil, fmt.Errorf("first argument to %q must be a variable", gr.call.Name)
-		}
-		cur, err := createVarRefCursor(t, ref)
-		if err != nil {
-			return nil, err
-		}
-		cursors = append(cursors, cur)
-	}
-
-	for _, ref := range gr.refs {
-		cur, err := createVarRefCursor(t, ref)
-		if err != nil {
-			return nil, err
-		}
-		cursors = append(cursors, cur)
+		refs = append(refs, gr.ref)
 	}
+	refs = append(refs, gr.refs...)
 
 	// TODO(jsternberg): Establish which variables in the condition are tags and which are fields.
-	// We need to create the references to fields here so they can be joined.
+	// We need to read the fields here so they are available before we evaluate the condition.
 	var (
 		tags map[influxql.VarRef]struct{}
 		cond influxql.Expr
@@ -148,51 +169,37 @@ func (gr *groupInfo) createCursor(t *transpilerState) (cursor, error) {
 
 			// Walk through the condition for every variable reference. There will be no function
 			// calls here.
-			var condErr error
 			influxql.WalkFunc(cond, func(node influxql.Node) {
-				if condErr != nil {
-					return
-				}
 				ref, ok := node.(*influxql.VarRef)
 				if !ok {
 					return
 				}
 
-				// If the variable reference is in any of the cursors, it is definitely
+				// If the variable reference is already being read, it is definitely
 				// a field and we do not have to inspect it further.
-				for _, cur := range cursors {
-					if _, ok := cur.Value(ref); ok {
-						return
-					}
+				if containsField(refs, ref.Val) {
+					return
 				}
 
-				// This may be a field or a tag. If it is a field, we need to create the cursor
-				// and add it to the listing of cursors so it can be joined before we evaluate the condition.
+				// This may be a field or a tag. If it is a field, we need to read it
+				// with the other fields so it is available when we evaluate the condition.
 				switch typ := t.mapType(ref); typ {
 				case influxql.Tag:
 					// Add this variable name to the listing of tags.
 					tags[*ref] = struct{}{}
 				default:
-					cur, err := createVarRefCursor(t, ref)
-					if err != nil {
-						condErr = err
-						return
-					}
-					cursors = append(cursors, cur)
+					refs = append(refs, ref)
 				}
 			})
 		}
 	}
 
-	// Join the cursors using an inner join.
-	// TODO(jsternberg): We need to differentiate between various join types and this needs to be
-	// except: ["_field"] rather than joining on the _measurement. This also needs to specify what the time
-	// column should be.
-	if len(cursors) > 1 {
-		return nil, errors.New("unimplemented: joining fields within a cursor")
+	// Read all of the fields with a single cursor. Multiple fields are pivoted
+	// into their own columns so they are joined by their time.
+	cur, err := createVarRefCursor(ctx, t, refs...)
+	if err != nil {
+		return nil, err
 	}
-
-	cur := Join(t, cursors, []string{"_measurement"})
 	if len(tags) > 0 {
 		cur = &tagsCursor{cursor: cur, tags: tags}
 	}
@@ -244,7 +251,17 @@ func (gr *groupInfo) createCursor(t *transpilerState) (cursor, error) {
 
 	// If a function call is present, evaluate the function call.
 	if gr.call != nil {
-		c, err := createFunctionCursor(t, gr.call, cur, gr.needNormalization || interval > 0)
+		// A transformation of an aggregate is evaluated after the aggregate
+		// has been evaluated for each window and the windows have been combined.
+		call, transform := gr.call, (*influxql.Call)(nil)
+		if inner, ok := call.Args[0].(*influxql.Call); ok && isTransformation(call) {
+			call, transform = inner, call
+		}
+
+		// The points selected by top() and bottom() keep their own time
+		// instead of the start of the interval.
+		normalize := gr.needNormalization || (interval > 0 && call.Name != "top" && call.Name != "bottom")
+		c, err := createFunctionCursor(t, call, cur, gr.groupKey, normalize)
 		if err != nil {
 			return nil, err
 		}
@@ -271,6 +288,41 @@ func (gr *groupInfo) createCursor(t *transpilerState) (cursor, error) {
 				cursor: cur,
 			}
 		}
+
+		if transform != nil {
+			c, err := createFunctionCursor(t, transform, cur, gr.groupKey, false)
+			if err != nil {
+				return nil, err
+			}
+			cur = c
+
+			// The empty intervals are kept so the transformation can use them,
+			// but the points that were not computed are removed.
+			if interval > 0 && lookbackIntervals(gr.call) > 0 {
+				value, _ := cur.Value(transform)
+				var key ast.PropertyKey = &ast.StringLiteral{Value: value}
+				if strings.HasPrefix(value, "_") {
+					key = &ast.Identifier{Name: value}
+				}
+				cur = &pipeCursor{
+					expr: pipeCall(cur.Expr(), "filter",
+						property("fn", &ast.FunctionExpression{
+							Params: []*ast.Property{{
+								Key: &ast.Identifier{Name: "r"},
+							}},
+							Body: &ast.UnaryExpression{
+								Operator: ast.ExistsOperator,
+								Argument: &ast.MemberExpression{
+									Object:   &ast.Identifier{Name: "r"},
+									Property: key,
+								},
+							},
+						}),
+					),
+					cursor: cur,
+				}
+			}
+		}
 	} else {
 		// If we do not have a function, but we have a field option,
 		// return the appropriate error message if there is something wrong with the flux.
@@ -286,6 +338,14 @@ func (gr *groupInfo) createCursor(t *transpilerState) (cursor, error) {
 		case influxql.LinearFill:
 			return nil, errors.New("fill(linear) must be used with a function")
 		}
+
+		// The points of the series in each group are returned in time order.
+		cur = &pipeCursor{
+			expr: pipeCall(cur.Expr(), "sort",
+				property("columns", stringArray(execute.DefaultTimeColLabel)),
+			),
+			cursor: cur,
+		}
 	}
 	return cur, nil
 }
@@ -384,6 +444,7 @@ func (gr *groupInfo) group(t *transpilerState, in cursor) (cursor, error) {
 	// Perform the grouping by the tags we found. There is always a group by because
 	// there is always something to group in influxql.
 	// TODO(jsternberg): A wildcard will skip this step.
+	gr.groupKey = tags
 	in = &pipeCursor{
 		expr: &ast.PipeExpression{
 			Argument: in.Expr(),
@@ -432,9 +493,9 @@ func (gr *groupInfo) group(t *transpilerState, in cursor) (cursor, error) {
 								Name: "columns",
 							},
 							Value: &ast.ArrayExpression{
-								Elements: append(tags,
-									&ast.StringLiteral{Value: execute.DefaultTimeColLabel},
-									&ast.StringLiteral{Value: execute.DefaultValueColLabel}),
+								Elements: append(append(gr.keepColumns(tags),
+									&ast.StringLiteral{Value: execute.DefaultTimeColLabel}),
+									valueColumns(in)...),
 							},
 						}},
 					},
@@ -463,6 +524,12 @@ func (gr *groupInfo) group(t *transpilerState, in cursor) (cursor, error) {
 				},
 			})
 		}
+		// A transformation that uses the previous intervals is computed with
+		// the empty intervals in the same way influxql fills them. The count of
+		// an empty interval is zero and any other aggregate is null.
+		if gr.call != nil && lookbackIntervals(gr.call) > 0 {
+			args = append(args, property("createEmpty", &ast.BooleanLiteral{Value: true}))
+		}
 		in = &pipeCursor{
 			expr: &ast.PipeExpression{
 				Argument: in.Expr(),
@@ -483,6 +550,63 @@ func (gr *groupInfo) group(t *transpilerState, in cursor) (cursor, error) {
 	return in, nil
 }
 
+// keepColumns returns the tag columns that are kept after grouping. The tags
+// passed to top() and bottom() are kept in addition to the group key so
+// they can be returned with the selected points.
+func (gr *groupInfo) keepColumns(tags []ast.Expression) []ast.Expression {
+	if gr.call == nil || (gr.call.Name != "top" && gr.call.Name != "bottom") {
+		return tags
+	}
+
+	columns := make([]ast.Expression, len(tags), len(tags)+len(gr.call.Args))
+	copy(columns, tags)
+	for _, arg := range gr.call.Args[1 : len(gr.call.Args)-1] {
+		ref := arg.(*influxql.VarRef)
+		if !containsColumn(columns, ref.Val) {
+			columns = append(columns, &ast.StringLiteral{Value: ref.Val})
+		}
+	}
+	return columns
+}
+
+// valueColumns returns the columns that contain the values of the cursor.
+func valueColumns(cur cursor) []ast.Expression {
+	var columns []ast.Expression
+	for _, k := range cur.Keys() {
+		if name, ok := cur.Value(k); ok && !containsColumn(columns, name) {
+			columns = append(columns, &ast.StringLiteral{Value: name})
+		}
+	}
+	return columns
+}
+
+// containsColumn returns true if the column name is in the list of columns.
+func containsColumn(columns []ast.Expression, name string) bool {
+	for _, col := range columns {
+		if lit, ok := col.(*ast.StringLiteral); ok && lit.Value == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasVariable returns true if the expression references a variable
+// or calls a function other than a math function.
+func hasVariable(expr influxql.Expr) bool {
+	found := false
+	influxql.WalkFunc(expr, func(n influxql.Node) {
+		switch n := n.(type) {
+		case *influxql.VarRef:
+			found = true
+		case *influxql.Call:
+			if !isMathFunction(n) {
+				found = true
+			}
+		}
+	})
+	return found
+}
+
 // tagsCursor is a pseudo-cursor that can be used to access tags within the cursor.
 type tagsCursor struct {
 	cursor
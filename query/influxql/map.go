@@ -2,6 +2,7 @@ package influxql
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -30,34 +31,176 @@ func (c *mapCursor) Value(expr influxql.Expr) (string, bool) {
 }
 
 // mapFields will take the list of symbols and maps each of the operations
-// using the column names.
+// using the column names. Fields that refer to a symbol are renamed and any
+// other expressions are evaluated with a map before the symbols are renamed.
 func (t *transpilerState) mapFields(in cursor) (cursor, error) {
 	columns := t.stmt.ColumnNames()
-	if len(columns) != len(t.stmt.Fields) {
+	if len(columns) != numColumns(t.stmt.Fields) {
 		// TODO(jsternberg): This scenario should not be possible. Replace the use of ColumnNames with a more
 		// statically verifiable list of columns when we process the fields from the select statement instead
 		// of doing this in the future.
 		panic("number of columns does not match the number of fields")
 	}
 
-	properties := make([]*ast.Property, 0, len(t.stmt.Fields))
-	for i, f := range t.stmt.Fields {
+	var (
+		properties []*ast.Property
+		evaluated  []*ast.Property
+		duplicates []*ast.Property
+		renamed    = make(map[string]struct{})
+	)
+	i := 0
+	for _, f := range t.stmt.Fields {
+		name := columns[i]
+		i += numColumns(influxql.Fields{f})
 		if ref, ok := f.Expr.(*influxql.VarRef); ok && ref.Val == "time" {
 			// Skip past any time columns.
 			continue
 		}
-		fieldName, err := t.mapField(f.Expr, in, false)
+		value, err := t.mapField(f.Expr, in, false)
 		if err != nil {
 			return nil, err
 		}
-		properties = append(properties, &ast.Property{
-			Key:   fieldName.(ast.PropertyKey),
-			Value: &ast.StringLiteral{Value: columns[i]},
-		})
+		switch key := value.(type) {
+		case *ast.Identifier:
+			// A symbol can only be renamed once. Any other field that refers
+			// to the same symbol is copied into its own column instead.
+			if _, ok := renamed[key.Name]; ok {
+				duplicates = append(duplicates, &ast.Property{
+					Key:   &ast.StringLiteral{Value: key.Name},
+					Value: &ast.StringLiteral{Value: name},
+				})
+				continue
+			}
+			properties = append(properties, &ast.Property{
+				Key:   key,
+				Value: &ast.StringLiteral{Value: name},
+			})
+			renamed[key.Name] = struct{}{}
+		case *ast.StringLiteral:
+			if _, ok := renamed[key.Value]; ok {
+				duplicates = append(duplicates, &ast.Property{
+					Key:   key,
+					Value: &ast.StringLiteral{Value: name},
+				})
+				continue
+			}
+			// A field that was pivoted into its own column may already
+			// have the name of the column.
+			if key.Value != name {
+				properties = append(properties, &ast.Property{
+					Key:   key,
+					Value: &ast.StringLiteral{Value: name},
+				})
+			}
+			renamed[key.Value] = struct{}{}
+		default:
+			evaluated = append(evaluated, &ast.Property{
+				Key:   &ast.StringLiteral{Value: name},
+				Value: value,
+			})
+		}
+	}
+
+	expr := in.Expr()
+	if len(evaluated) > 0 {
+		expr = &ast.PipeExpression{
+			Argument: expr,
+			Call: &ast.CallExpression{
+				Callee: &ast.Identifier{
+					Name: "map",
+				},
+				Arguments: []ast.Expression{
+					&ast.ObjectExpression{
+						Properties: []*ast.Property{{
+							Key: &ast.Identifier{
+								Name: "fn",
+							},
+							Value: &ast.FunctionExpression{
+								Params: []*ast.Property{{
+									Key: &ast.Identifier{Name: "r"},
+								}},
+								Body: &ast.ObjectExpression{
+									With:       &ast.Identifier{Name: "r"},
+									Properties: evaluated,
+								},
+							},
+						}},
+					},
+				},
+			},
+		}
+
+		// Drop the symbols that were only used to evaluate the expressions.
+		var drop []string
+		for _, k := range in.Keys() {
+			if sym, ok := in.Value(k); ok {
+				if _, ok := renamed[sym]; !ok {
+					renamed[sym] = struct{}{}
+					drop = append(drop, sym)
+				}
+			}
+		}
+		if len(drop) > 0 {
+			sort.Strings(drop)
+			elements := make([]ast.Expression, 0, len(drop))
+			for _, sym := range drop {
+				elements = append(elements, &ast.StringLiteral{Value: sym})
+			}
+			expr = &ast.PipeExpression{
+				Argument: expr,
+				Call: &ast.CallExpression{
+					Callee: &ast.Identifier{
+						Name: "drop",
+					},
+					Arguments: []ast.Expression{
+						&ast.ObjectExpression{
+							Properties: []*ast.Property{{
+								Key: &ast.Identifier{
+									Name: "columns",
+								},
+								Value: &ast.ArrayExpression{
+									Elements: elements,
+								},
+							}},
+						},
+					},
+				},
+			}
+		}
+	}
+
+	for _, dup := range duplicates {
+		expr = &ast.PipeExpression{
+			Argument: expr,
+			Call: &ast.CallExpression{
+				Callee: &ast.Identifier{
+					Name: "duplicate",
+				},
+				Arguments: []ast.Expression{
+					&ast.ObjectExpression{
+						Properties: []*ast.Property{
+							{
+								Key: &ast.Identifier{
+									Name: "column",
+								},
+								Value: dup.Key.(*ast.StringLiteral),
+							},
+							{
+								Key: &ast.Identifier{
+									Name: "as",
+								},
+								Value: dup.Value,
+							},
+						},
+					},
+				},
+			},
+		}
 	}
-	return &mapCursor{
-		expr: &ast.PipeExpression{
-			Argument: in.Expr(),
+
+	if len(properties) > 0 {
+		expr = &ast.PipeExpression{
+			Argument: expr,
 			Call: &ast.CallExpression{
 				Callee: &ast.Identifier{
 					Name: "rename",
@@ -75,8 +218,26 @@ func (t *transpilerState) mapFields(in cursor) (cursor, error) {
 					},
 				},
 			},
-		},
-	}, nil
+		}
+	}
+	return &mapCursor{expr: expr}, nil
+}
+
+// numColumns returns the number of columns produced by the fields.
+// The tags passed to top() and bottom() are returned as their own columns.
+func numColumns(fields influxql.Fields) int {
+	n := 0
+	for _, f := range fields {
+		n++
+		if call, ok := f.Expr.(*influxql.Call); ok && (call.Name == "top" || call.Name == "bottom") {
+			for _, arg := range call.Args[1:] {
+				if _, ok := arg.(*influxql.VarRef); ok {
+					n++
+				}
+			}
+		}
+	}
+	return n
 }
 
 func (t *transpilerState) mapField(expr influxql.Expr, in cursor, returnMemberExpr bool) (ast.Expression, error) {
@@ -99,7 +260,7 @@ func (t *transpilerState) mapField(expr influxql.Expr, in cursor, returnMemberEx
 	switch expr := expr.(type) {
 	case *influxql.Call:
 		if isMathFunction(expr) {
-			return nil, fmt.Errorf("unimplemented math function: %q", expr.Name)
+			return t.mathCall(expr, in)
 		}
 		return nil, fmt.Errorf("missing symbol for %s", expr)
 	case *influxql.VarRef:
@@ -154,6 +315,12 @@ func (t *transpilerState) evalBinaryExpr(expr *influxql.BinaryExpr, in cursor) (
 			return b.eval(ast.AdditionOperator)
 		case influxql.SUB:
 			return b.eval(ast.SubtractionOperator)
+		case influxql.MUL:
+			return b.eval(ast.MultiplicationOperator)
+		case influxql.DIV:
+			return b.eval(ast.DivisionOperator)
+		case influxql.MOD:
+			return b.eval(ast.ModuloOperator)
 		case influxql.AND:
 			return b.logical(ast.AndOperator)
 		case influxql.OR:
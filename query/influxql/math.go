@@ -1,6 +1,11 @@
 package influxql
 
-import "github.com/influxdata/influxql"
+import (
+	"fmt"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/influxql"
+)
 
 // isMathFunction returns true if the call is a math function.
 func isMathFunction(expr *influxql.Call) bool {
@@ -10,3 +15,96 @@ func isMathFunction(expr *influxql.Call) bool {
 	}
 	return false
 }
+
+// validateMathFunction validates the number of arguments to a math function.
+func validateMathFunction(expr *influxql.Call) error {
+	exp := 1
+	switch expr.Name {
+	case "atan2", "log", "pow":
+		exp = 2
+	}
+	if got := len(expr.Args); exp != got {
+		return fmt.Errorf("invalid number of arguments for %s, expected %d, got %d", expr.Name, exp, got)
+	}
+	return nil
+}
+
+// mathCall translates a math function into a call to the equivalent function
+// in the flux math package. The math package only accepts floats so each of the
+// arguments is converted to a float.
+func (t *transpilerState) mathCall(expr *influxql.Call, in cursor) (ast.Expression, error) {
+	args := make([]ast.Expression, len(expr.Args))
+	for i, arg := range expr.Args {
+		v, err := t.mapField(arg, in, true)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = toFloat(v)
+	}
+
+	pkg := t.requireImport("math")
+	switch expr.Name {
+	case "ln":
+		return mathFunc(pkg, "log", property("x", args[0])), nil
+	case "log":
+		// The logarithm with an arbitrary base is the ratio of the natural logarithms.
+		return &ast.BinaryExpression{
+			Operator: ast.DivisionOperator,
+			Left:     mathFunc(pkg, "log", property("x", args[0])),
+			Right:    mathFunc(pkg, "log", property("x", args[1])),
+		}, nil
+	case "atan2", "pow":
+		// The flux atan2 function passes x and y to the go function in the
+		// order they are named so x is the y coordinate like the first argument
+		// to atan2 in influxql.
+		return mathFunc(pkg, expr.Name, property("x", args[0]), property("y", args[1])), nil
+	default:
+		return mathFunc(pkg, expr.Name, property("x", args[0])), nil
+	}
+}
+
+// mathFunc creates a call to a function in the math package.
+func mathFunc(pkg *ast.Identifier, name string, args ...*ast.Property) ast.Expression {
+	return &ast.CallExpression{
+		Callee: &ast.MemberExpression{
+			Object: pkg,
+			Property: &ast.Identifier{
+				Name: name,
+			},
+		},
+		Arguments: []ast.Expression{
+			&ast.ObjectExpression{
+				Properties: args,
+			},
+		},
+	}
+}
+
+// toFloat converts the expression to a float. Literals are converted directly
+// and the result of another math function is already a float.
+func toFloat(expr ast.Expression) ast.Expression {
+	switch expr := expr.(type) {
+	case *ast.FloatLiteral:
+		return expr
+	case *ast.IntegerLiteral:
+		return &ast.FloatLiteral{Value: float64(expr.Value)}
+	case *ast.CallExpression:
+		if callee, ok := expr.Callee.(*ast.MemberExpression); ok {
+			if pkg, ok := callee.Object.(*ast.Identifier); ok && pkg.Name == "math" {
+				return expr
+			}
+		}
+	}
+	return &ast.CallExpression{
+		Callee: &ast.Identifier{
+			Name: "float",
+		},
+		Arguments: []ast.Expression{
+			&ast.ObjectExpression{
+				Properties: []*ast.Property{
+					property("v", expr),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,684 @@
+package influxql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxql"
+	"github.com/pkg/errors"
+)
+
+// internalKeys are the columns in the group key of a series that are not tags.
+var internalKeys = []string{
+	execute.DefaultStartColLabel,
+	execute.DefaultStopColLabel,
+	"_field",
+	"_measurement",
+}
+
+// metaCursor is a cursor for the series that are read by a meta query.
+// Every variable in the condition of a meta query refers to a tag.
+type metaCursor struct {
+	expr ast.Expression
+}
+
+func (c *metaCursor) Expr() ast.Expression {
+	return c.expr
+}
+
+func (c *metaCursor) Keys() []influxql.Expr {
+	return nil
+}
+
+func (c *metaCursor) Value(expr influxql.Expr) (string, bool) {
+	ref, ok := expr.(*influxql.VarRef)
+	if !ok {
+		return "", false
+	} else if ref.Val == "_name" {
+		return "_measurement", true
+	}
+	return ref.Val, true
+}
+
+func (t *transpilerState) transpileShowMeasurements(ctx context.Context, stmt *influxql.ShowMeasurementsStatement) (ast.Expression, error) {
+	var sources influxql.Sources
+	if stmt.Source != nil {
+		sources = influxql.Sources{stmt.Source}
+	}
+	expr, err := t.metaSeries(ctx, stmt.Database, sources, stmt.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the distinct values of the measurement tag. This is the same pattern
+	// used by v1.measurements() so it can be pushed down to the storage engine.
+	expr = pipeCall(expr, "keep",
+		property("columns", stringArray("_measurement")),
+	)
+	expr = pipeCall(expr, "group")
+	expr = pipeCall(expr, "distinct",
+		property("column", &ast.StringLiteral{Value: "_measurement"}),
+	)
+	expr = pipeCall(expr, "sort")
+	if expr, err = limitOffset(expr, stmt.Limit, stmt.Offset); err != nil {
+		return nil, err
+	}
+	expr = renameValue(expr, "name")
+	return setMeasurement(expr, "measurements"), nil
+}
+
+func (t *transpilerState) transpileShowTagKeys(ctx context.Context, stmt *influxql.ShowTagKeysStatement) (ast.Expression, error) {
+	if stmt.SLimit > 0 || stmt.SOffset > 0 {
+		return nil, errors.New("unimplemented: SLIMIT and SOFFSET in SHOW TAG KEYS")
+	}
+
+	// The tag keys of each measurement are read separately so every read
+	// can be pushed down to the storage engine.
+	if name, ok := singleMeasurement(stmt.Sources); ok {
+		return t.showTagKeys(ctx, stmt, stmt.Sources, name)
+	}
+	names, err := t.metaMeasurements(ctx, stmt.Database, stmt.Sources, stmt.Condition)
+	if err != nil {
+		return nil, err
+	}
+	return t.metaUnion(stmt.Database, stmt.Sources, names, func(sources influxql.Sources, name string) (ast.Expression, error) {
+		return t.showTagKeys(ctx, stmt, sources, name)
+	})
+}
+
+// showTagKeys reads the tag keys of the series in the sources with the same
+// pattern as v1.tagKeys() so they can be pushed down to the storage engine.
+// The tag keys are grouped by the measurement name if it is set.
+func (t *transpilerState) showTagKeys(ctx context.Context, stmt *influxql.ShowTagKeysStatement, sources influxql.Sources, name string) (ast.Expression, error) {
+	expr, err := t.metaSeries(ctx, stmt.Database, sources, stmt.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	expr = pipeCall(expr, "keys")
+	expr = pipeCall(expr, "keep",
+		property("columns", stringArray(execute.DefaultValueColLabel)),
+	)
+	expr = pipeCall(expr, "distinct")
+	expr = pipeCall(expr, "filter",
+		property("fn", &ast.FunctionExpression{
+			Params: []*ast.Property{{
+				Key: &ast.Identifier{Name: "r"},
+			}},
+			Body: &ast.UnaryExpression{
+				Operator: ast.NotOperator,
+				Argument: &ast.CallExpression{
+					Callee: &ast.Identifier{Name: "contains"},
+					Arguments: []ast.Expression{
+						&ast.ObjectExpression{
+							Properties: []*ast.Property{
+								property("value", &ast.MemberExpression{
+									Object:   &ast.Identifier{Name: "r"},
+									Property: &ast.Identifier{Name: execute.DefaultValueColLabel},
+								}),
+								property("set", stringArray(internalKeys...)),
+							},
+						},
+					},
+				},
+			},
+		}),
+	)
+	expr = pipeCall(expr, "sort")
+	if expr, err = limitOffset(expr, stmt.Limit, stmt.Offset); err != nil {
+		return nil, err
+	}
+	expr = renameValue(expr, "tagKey")
+	if name != "" {
+		expr = setMeasurement(expr, name)
+	}
+	return expr, nil
+}
+
+func (t *transpilerState) transpileShowFieldKeys(ctx context.Context, stmt *influxql.ShowFieldKeysStatement) (ast.Expression, error) {
+	// The field keys of each measurement are read separately so every read
+	// can be pushed down to the storage engine and the type of each field
+	// can be read from the schema of its measurement.
+	if name, ok := singleMeasurement(stmt.Sources); ok {
+		return t.showFieldKeys(ctx, stmt, stmt.Sources, name)
+	}
+	names, err := t.metaMeasurements(ctx, stmt.Database, stmt.Sources, nil)
+	if err != nil {
+		return nil, err
+	}
+	return t.metaUnion(stmt.Database, stmt.Sources, names, func(sources influxql.Sources, name string) (ast.Expression, error) {
+		return t.showFieldKeys(ctx, stmt, sources, name)
+	})
+}
+
+// showFieldKeys reads the field keys of the series in the sources with the same
+// pattern as v1.tagValues() so they can be pushed down to the storage engine.
+// The field keys are grouped by the measurement name if it is set.
+func (t *transpilerState) showFieldKeys(ctx context.Context, stmt *influxql.ShowFieldKeysStatement, sources influxql.Sources, name string) (ast.Expression, error) {
+	expr, err := t.metaSeries(ctx, stmt.Database, sources, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	expr = pipeCall(expr, "keep",
+		property("columns", stringArray("_field")),
+	)
+	expr = pipeCall(expr, "group")
+	expr = pipeCall(expr, "distinct",
+		property("column", &ast.StringLiteral{Value: "_field"}),
+	)
+	expr = pipeCall(expr, "sort")
+	if expr, err = limitOffset(expr, stmt.Limit, stmt.Offset); err != nil {
+		return nil, err
+	}
+	expr = renameValue(expr, "fieldKey")
+	if name == "" {
+		return expr, nil
+	}
+
+	s, _, err := t.metaSchema(ctx, stmt.Database, sources, nil)
+	if err != nil {
+		return nil, err
+	}
+	types, err := s.MeasurementFields(ctx, s.orgID, s.bucketID, name, s.start, s.end)
+	if err != nil {
+		return nil, err
+	}
+	expr = setFieldType(expr, types)
+	return setMeasurement(expr, name), nil
+}
+
+// setFieldType sets the fieldType column to the type of each field.
+// A field that was not in the schema when the query was transpiled
+// is reported as a float.
+func setFieldType(expr ast.Expression, types map[string]influxql.DataType) ast.Expression {
+	fields := make([]string, 0, len(types))
+	for field, typ := range types {
+		if typ != influxql.Float {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return pipeCall(expr, "set",
+			property("key", &ast.StringLiteral{Value: "fieldType"}),
+			property("value", &ast.StringLiteral{Value: influxql.Float.String()}),
+		)
+	}
+	sort.Strings(fields)
+
+	var fieldType ast.Expression = &ast.StringLiteral{Value: influxql.Float.String()}
+	for i := len(fields) - 1; i >= 0; i-- {
+		fieldType = &ast.ConditionalExpression{
+			Test: &ast.BinaryExpression{
+				Operator: ast.EqualOperator,
+				Left: &ast.MemberExpression{
+					Object:   &ast.Identifier{Name: "r"},
+					Property: &ast.Identifier{Name: "fieldKey"},
+				},
+				Right: &ast.StringLiteral{Value: fields[i]},
+			},
+			Consequent: &ast.StringLiteral{Value: types[fields[i]].String()},
+			Alternate:  fieldType,
+		}
+	}
+	return pipeCall(expr, "map",
+		property("fn", &ast.FunctionExpression{
+			Params: []*ast.Property{{
+				Key: &ast.Identifier{Name: "r"},
+			}},
+			Body: &ast.ObjectExpression{
+				With: &ast.Identifier{Name: "r"},
+				Properties: []*ast.Property{
+					property("fieldType", fieldType),
+				},
+			},
+		}),
+	)
+}
+
+func (t *transpilerState) transpileShowSeries(ctx context.Context, stmt *influxql.ShowSeriesStatement) (ast.Expression, error) {
+	// The tag keys are read from the schema so the series key can be constructed
+	// from the tags of each series.
+	tags, err := t.seriesTagKeys(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	expr, err := t.metaSeries(ctx, stmt.Database, stmt.Sources, stmt.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group the series by their tags so the grouping can be pushed down
+	// to the storage engine and select a single row from each group
+	// so there is one row for every series key.
+	expr = pipeCall(expr, "group",
+		property("columns", stringArray(append([]string{"_measurement"}, tags...)...)),
+		property("mode", &ast.StringLiteral{Value: "by"}),
+	)
+	expr = pipeCall(expr, "distinct",
+		property("column", &ast.StringLiteral{Value: "_measurement"}),
+	)
+	expr = pipeCall(expr, "group")
+
+	// Construct the series key from the measurement and each of the tags.
+	// A tag is only included in the key when the series has that tag.
+	key := &ast.MemberExpression{
+		Object:   &ast.Identifier{Name: "r"},
+		Property: &ast.Identifier{Name: "key"},
+	}
+	expr = mapKey(expr, &ast.MemberExpression{
+		Object:   &ast.Identifier{Name: "r"},
+		Property: &ast.Identifier{Name: "_measurement"},
+	})
+	for _, tag := range tags {
+		value := &ast.MemberExpression{
+			Object:   &ast.Identifier{Name: "r"},
+			Property: &ast.StringLiteral{Value: tag},
+		}
+		expr = mapKey(expr, &ast.ConditionalExpression{
+			Test: &ast.UnaryExpression{
+				Operator: ast.ExistsOperator,
+				Argument: value,
+			},
+			Consequent: &ast.BinaryExpression{
+				Operator: ast.AdditionOperator,
+				Left: &ast.BinaryExpression{
+					Operator: ast.AdditionOperator,
+					Left:     key,
+					Right:    &ast.StringLiteral{Value: fmt.Sprintf(",%s=", tag)},
+				},
+				Right: value,
+			},
+			Alternate: key,
+		})
+	}
+	expr = pipeCall(expr, "keep",
+		property("columns", stringArray("key")),
+	)
+	expr = pipeCall(expr, "sort",
+		property("columns", stringArray("key")),
+	)
+	return limitOffset(expr, stmt.Limit, stmt.Offset)
+}
+
+// metaUnion combines the results of a meta query for each of the named
+// measurements. If there are no measurements, the meta query reads the
+// sources without a measurement name so it returns no results.
+func (t *transpilerState) metaUnion(database string, sources influxql.Sources, names []string, fn func(sources influxql.Sources, name string) (ast.Expression, error)) (ast.Expression, error) {
+	if len(names) == 0 {
+		return fn(sources, "")
+	}
+
+	mm, err := metaMeasurement(database, sources)
+	if err != nil {
+		return nil, err
+	}
+	tables := make([]ast.Expression, 0, len(names))
+	for _, name := range names {
+		m := &influxql.Measurement{
+			Database:        mm.Database,
+			RetentionPolicy: mm.RetentionPolicy,
+			Name:            name,
+		}
+		expr, err := fn(influxql.Sources{m}, name)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, expr)
+	}
+	if len(tables) == 1 {
+		return tables[0], nil
+	}
+
+	for i, table := range tables {
+		tables[i] = t.assignment(table)
+	}
+	return &ast.CallExpression{
+		Callee: &ast.Identifier{Name: "union"},
+		Arguments: []ast.Expression{
+			&ast.ObjectExpression{
+				Properties: []*ast.Property{
+					property("tables", &ast.ArrayExpression{Elements: tables}),
+				},
+			},
+		},
+	}, nil
+}
+
+// metaSeries creates the expression that reads the series in the sources
+// that match the condition. The time range is read from the condition and
+// every other variable in the condition is a tag.
+func (t *transpilerState) metaSeries(ctx context.Context, database string, sources influxql.Sources, cond influxql.Expr) (ast.Expression, error) {
+	mm, err := t.metaBucket(database, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := t.from(ctx, mm)
+	if err != nil {
+		return nil, err
+	}
+
+	valuer := influxql.NowValuer{Now: t.config.Now}
+	cond, tr, err := influxql.ConditionExpr(cond, &valuer)
+	if err != nil {
+		return nil, err
+	}
+	expr := pipeCall(from, "range",
+		property("start", &ast.DateTimeLiteral{Value: tr.MinTime().UTC()}),
+		property("stop", &ast.DateTimeLiteral{Value: rangeStop(tr)}),
+	)
+
+	// Filter the series by the measurements in the sources and the condition.
+	var filterExpr ast.Expression
+	for i := len(sources) - 1; i >= 0; i-- {
+		m := sources[i].(*influxql.Measurement)
+		var e ast.Expression
+		if m.Regex != nil {
+			e = &ast.BinaryExpression{
+				Operator: ast.RegexpMatchOperator,
+				Left: &ast.MemberExpression{
+					Object:   &ast.Identifier{Name: "r"},
+					Property: &ast.Identifier{Name: "_measurement"},
+				},
+				Right: &ast.RegexpLiteral{Value: m.Regex.Val},
+			}
+		} else {
+			e = &ast.BinaryExpression{
+				Operator: ast.EqualOperator,
+				Left: &ast.MemberExpression{
+					Object:   &ast.Identifier{Name: "r"},
+					Property: &ast.Identifier{Name: "_measurement"},
+				},
+				Right: &ast.StringLiteral{Value: m.Name},
+			}
+		}
+		if filterExpr == nil {
+			filterExpr = e
+		} else {
+			filterExpr = &ast.LogicalExpression{
+				Operator: ast.OrOperator,
+				Left:     e,
+				Right:    filterExpr,
+			}
+		}
+	}
+
+	if cond != nil {
+		e, err := t.mapField(cond, &metaCursor{expr: expr}, true)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to evaluate condition")
+		}
+		if filterExpr == nil {
+			filterExpr = e
+		} else {
+			filterExpr = &ast.LogicalExpression{
+				Operator: ast.AndOperator,
+				Left:     filterExpr,
+				Right:    e,
+			}
+		}
+	}
+
+	if filterExpr != nil {
+		expr = pipeCall(expr, "filter",
+			property("fn", &ast.FunctionExpression{
+				Params: []*ast.Property{{
+					Key: &ast.Identifier{Name: "r"},
+				}},
+				Body: filterExpr,
+			}),
+		)
+	}
+	return expr, nil
+}
+
+// metaMeasurement returns a measurement with the database and retention policy
+// that the meta query reads from. The database in a source takes precedence over
+// the database of the statement.
+func metaMeasurement(database string, sources influxql.Sources) (*influxql.Measurement, error) {
+	mm := &influxql.Measurement{Database: database}
+	for _, source := range sources {
+		m, ok := source.(*influxql.Measurement)
+		if !ok {
+			return nil, fmt.Errorf("unsupported source type: %T", source)
+		}
+		if m.Database != "" {
+			mm.Database = m.Database
+		}
+		if m.RetentionPolicy != "" {
+			mm.RetentionPolicy = m.RetentionPolicy
+		}
+	}
+	return mm, nil
+}
+
+// metaBucket returns the measurement with the database and retention policy
+// that the meta query reads from. The default database is used when the
+// statement and the sources do not have a database.
+func (t *transpilerState) metaBucket(database string, sources influxql.Sources) (*influxql.Measurement, error) {
+	mm, err := metaMeasurement(database, sources)
+	if err != nil {
+		return nil, err
+	} else if mm.Database == "" {
+		if t.config.DefaultDatabase == "" {
+			return nil, errDatabaseNameRequired
+		}
+		mm.Database = t.config.DefaultDatabase
+	}
+	return mm, nil
+}
+
+var errMetaSchemaUnavailable = errors.New("unable to read measurements: bucket schema is unavailable")
+
+// bucketSchema reads the schema of the bucket that a meta query reads from
+// within the time range of the condition.
+type bucketSchema struct {
+	SchemaReader
+	orgID, bucketID influxdb.ID
+	start, end      int64
+}
+
+// metaSchema returns the schema of the bucket that the meta query reads from
+// and the condition without the time range.
+func (t *transpilerState) metaSchema(ctx context.Context, database string, sources influxql.Sources, cond influxql.Expr) (*bucketSchema, influxql.Expr, error) {
+	deps := GetDependencies(ctx)
+	if deps.SchemaReader == nil {
+		return nil, nil, errMetaSchemaUnavailable
+	}
+
+	mm, err := t.metaBucket(database, sources)
+	if err != nil {
+		return nil, nil, err
+	}
+	orgID, bucketID, err := t.lookupBucket(ctx, mm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	valuer := influxql.NowValuer{Now: t.config.Now}
+	cond, tr, err := influxql.ConditionExpr(cond, &valuer)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &bucketSchema{
+		SchemaReader: deps.SchemaReader,
+		orgID:        orgID,
+		bucketID:     bucketID,
+		start:        tr.MinTimeNano(),
+		end:          tr.MaxTimeNano(),
+	}, cond, nil
+}
+
+// metaMeasurements reads the sorted names of the measurements in the sources
+// with series that match the condition.
+func (t *transpilerState) metaMeasurements(ctx context.Context, database string, sources influxql.Sources, cond influxql.Expr) ([]string, error) {
+	s, cond, err := t.metaSchema(ctx, database, sources, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	// The measurement is referred to as _name in the condition of a meta query.
+	predicate := measurementPredicate(sources)
+	if cond != nil {
+		cond = influxql.RewriteExpr(influxql.CloneExpr(cond), func(expr influxql.Expr) influxql.Expr {
+			if ref, ok := expr.(*influxql.VarRef); ok && ref.Val == "_name" {
+				return &influxql.VarRef{Val: models.MeasurementTagKey}
+			}
+			return expr
+		})
+		if predicate == nil {
+			predicate = cond
+		} else {
+			predicate = &influxql.BinaryExpr{
+				Op:  influxql.AND,
+				LHS: &influxql.ParenExpr{Expr: predicate},
+				RHS: &influxql.ParenExpr{Expr: cond},
+			}
+		}
+	}
+
+	itr, err := s.TagValues(ctx, s.orgID, s.bucketID, models.MeasurementTagKey, s.start, s.end, predicate)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for itr.Next() {
+		names = append(names, itr.Value())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// seriesTagKeys reads the sorted tag keys of the measurements in the sources.
+func (t *transpilerState) seriesTagKeys(ctx context.Context, stmt *influxql.ShowSeriesStatement) ([]string, error) {
+	s, _, err := t.metaSchema(ctx, stmt.Database, stmt.Sources, stmt.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	itr, err := s.TagKeys(ctx, s.orgID, s.bucketID, s.start, s.end, measurementPredicate(stmt.Sources))
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	for itr.Next() {
+		switch k := itr.Value(); k {
+		case models.MeasurementTagKey, models.FieldKeyTagKey:
+		default:
+			tags = append(tags, k)
+		}
+	}
+	sort.Strings(tags)
+	return tags, nil
+}
+
+// measurementPredicate creates a predicate that restricts the series
+// to the measurements in the sources.
+func measurementPredicate(sources influxql.Sources) influxql.Expr {
+	var predicate influxql.Expr
+	for _, source := range sources {
+		m := source.(*influxql.Measurement)
+		e := &influxql.BinaryExpr{
+			Op:  influxql.EQ,
+			LHS: &influxql.VarRef{Val: models.MeasurementTagKey},
+			RHS: &influxql.StringLiteral{Val: m.Name},
+		}
+		if m.Regex != nil {
+			e.Op, e.RHS = influxql.EQREGEX, &influxql.RegexLiteral{Val: m.Regex.Val}
+		}
+		if predicate == nil {
+			predicate = e
+		} else {
+			predicate = &influxql.BinaryExpr{Op: influxql.OR, LHS: predicate, RHS: e}
+		}
+	}
+	return predicate
+}
+
+// singleMeasurement returns the name of the measurement if the sources
+// contain a single measurement that is not a regex.
+func singleMeasurement(sources influxql.Sources) (string, bool) {
+	if len(sources) != 1 {
+		return "", false
+	}
+	m, ok := sources[0].(*influxql.Measurement)
+	if !ok || m.Regex != nil {
+		return "", false
+	}
+	return m.Name, true
+}
+
+// limitOffset limits the number of rows in each table.
+func limitOffset(expr ast.Expression, limit, offset int) (ast.Expression, error) {
+	if limit == 0 {
+		if offset > 0 {
+			return nil, errors.New("unimplemented: OFFSET without LIMIT")
+		}
+		return expr, nil
+	}
+
+	args := []*ast.Property{
+		property("n", &ast.IntegerLiteral{Value: int64(limit)}),
+	}
+	if offset > 0 {
+		args = append(args, property("offset", &ast.IntegerLiteral{Value: int64(offset)}))
+	}
+	return pipeCall(expr, "limit", args...), nil
+}
+
+// renameValue renames the value column.
+func renameValue(expr ast.Expression, name string) ast.Expression {
+	return pipeCall(expr, "rename",
+		property("columns", &ast.ObjectExpression{
+			Properties: []*ast.Property{
+				property(execute.DefaultValueColLabel, &ast.StringLiteral{Value: name}),
+			},
+		}),
+	)
+}
+
+// setMeasurement sets the measurement column and groups by it
+// so the measurement is used as the name of the series in the results.
+func setMeasurement(expr ast.Expression, name string) ast.Expression {
+	expr = pipeCall(expr, "set",
+		property("key", &ast.StringLiteral{Value: "_measurement"}),
+		property("value", &ast.StringLiteral{Value: name}),
+	)
+	return pipeCall(expr, "group",
+		property("columns", stringArray("_measurement")),
+		property("mode", &ast.StringLiteral{Value: "by"}),
+	)
+}
+
+// mapKey sets the key column to the value of the expression.
+func mapKey(expr ast.Expression, value ast.Expression) ast.Expression {
+	return pipeCall(expr, "map",
+		property("fn", &ast.FunctionExpression{
+			Params: []*ast.Property{{
+				Key: &ast.Identifier{Name: "r"},
+			}},
+			Body: &ast.ObjectExpression{
+				With: &ast.Identifier{Name: "r"},
+				Properties: []*ast.Property{
+					property("key", value),
+				},
+			},
+		}),
+	)
+}
+
+// stringArray creates an array of string literals.
+func stringArray(values ...string) *ast.ArrayExpression {
+	elements := make([]ast.Expression, 0, len(values))
+	for _, v := range values {
+		elements = append(elements, &ast.StringLiteral{Value: v})
+	}
+	return &ast.ArrayExpression{Elements: elements}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
 	"time"
 
@@ -175,6 +176,7 @@ func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (i
 			results.Release()
 			break
 		}
+		sortSeries(result.Series)
 		resp.Results = append(resp.Results, result)
 	}
 
@@ -185,6 +187,40 @@ func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (i
 	err := json.NewEncoder(wc).Encode(resp)
 	return wc.Count(), err
 }
+
+// sortSeries sorts the series by measurement name and then by the tag values in
+// tag key order, which is the order InfluxQL returns the series of a statement in.
+// Series with the same name and tags keep their order.
+func sortSeries(series []*Row) {
+	sort.SliceStable(series, func(i, j int) bool {
+		if series[i].Name != series[j].Name {
+			return series[i].Name < series[j].Name
+		}
+		return tagsLess(series[i].Tags, series[j].Tags)
+	})
+}
+
+// tagsLess compares the tag values of two series in tag key order.
+func tagsLess(a, b map[string]string) bool {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if a[k] != b[k] {
+			return a[k] < b[k]
+		}
+	}
+	return false
+}
+
 func NewMultiResultEncoder() *MultiResultEncoder {
 	return new(MultiResultEncoder)
 }
@@ -40,6 +40,91 @@ func TestMultiResultEncoder_Encode(t *testing.T) {
 			),
 			out: `{"results":[{"statement_id":0,"series":[{"name":"m0","tags":{"host":"server01"},"columns":["time","value"],"values":[["2018-05-24T09:00:00Z",2]]}]}]}`,
 		},
+		{
+			name: "Multiple Measurements",
+			in: flux.NewSliceResultIterator(
+				[]flux.Result{&executetest.Result{
+					Nm: "0",
+					Tbls: []*executetest.Table{
+						{
+							KeyCols: []string{"_measurement", "host"},
+							ColMeta: []flux.ColMeta{
+								{Label: "_time", Type: flux.TTime},
+								{Label: "_measurement", Type: flux.TString},
+								{Label: "host", Type: flux.TString},
+								{Label: "value", Type: flux.TFloat},
+							},
+							Data: [][]interface{}{
+								{ts("2018-05-24T09:00:00Z"), "m1", "server01", float64(3)},
+							},
+						},
+						{
+							KeyCols: []string{"_measurement", "host"},
+							ColMeta: []flux.ColMeta{
+								{Label: "_time", Type: flux.TTime},
+								{Label: "_measurement", Type: flux.TString},
+								{Label: "host", Type: flux.TString},
+								{Label: "value", Type: flux.TFloat},
+							},
+							Data: [][]interface{}{
+								{ts("2018-05-24T09:00:00Z"), "m0", "server01", float64(2)},
+							},
+						},
+					},
+				}},
+			),
+			out: `{"results":[{"statement_id":0,"series":[{"name":"m0","tags":{"host":"server01"},"columns":["time","value"],"values":[["2018-05-24T09:00:00Z",2]]},{"name":"m1","tags":{"host":"server01"},"columns":["time","value"],"values":[["2018-05-24T09:00:00Z",3]]}]}]}`,
+		},
+		{
+			name: "Group By Tags",
+			in: flux.NewSliceResultIterator(
+				[]flux.Result{&executetest.Result{
+					Nm: "0",
+					Tbls: []*executetest.Table{
+						{
+							KeyCols: []string{"_measurement", "host", "region"},
+							ColMeta: []flux.ColMeta{
+								{Label: "_time", Type: flux.TTime},
+								{Label: "_measurement", Type: flux.TString},
+								{Label: "host", Type: flux.TString},
+								{Label: "region", Type: flux.TString},
+								{Label: "value", Type: flux.TFloat},
+							},
+							Data: [][]interface{}{
+								{ts("2018-05-24T09:00:00Z"), "m0", "server02", "us-east", float64(3)},
+							},
+						},
+						{
+							KeyCols: []string{"_measurement", "host", "region"},
+							ColMeta: []flux.ColMeta{
+								{Label: "_time", Type: flux.TTime},
+								{Label: "_measurement", Type: flux.TString},
+								{Label: "host", Type: flux.TString},
+								{Label: "region", Type: flux.TString},
+								{Label: "value", Type: flux.TFloat},
+							},
+							Data: [][]interface{}{
+								{ts("2018-05-24T09:00:00Z"), "m0", "server01", "us-west", float64(2)},
+							},
+						},
+						{
+							KeyCols: []string{"_measurement", "host", "region"},
+							ColMeta: []flux.ColMeta{
+								{Label: "_time", Type: flux.TTime},
+								{Label: "_measurement", Type: flux.TString},
+								{Label: "host", Type: flux.TString},
+								{Label: "region", Type: flux.TString},
+								{Label: "value", Type: flux.TFloat},
+							},
+							Data: [][]interface{}{
+								{ts("2018-05-24T09:00:00Z"), "m0", "server01", "us-east", float64(1)},
+							},
+						},
+					},
+				}},
+			),
+			out: `{"results":[{"statement_id":0,"series":[{"name":"m0","tags":{"host":"server01","region":"us-east"},"columns":["time","value"],"values":[["2018-05-24T09:00:00Z",1]]},{"name":"m0","tags":{"host":"server01","region":"us-west"},"columns":["time","value"],"values":[["2018-05-24T09:00:00Z",2]]},{"name":"m0","tags":{"host":"server02","region":"us-east"},"columns":["time","value"],"values":[["2018-05-24T09:00:00Z",3]]}]}]}`,
+		},
 		{
 			name: "No _time column",
 			in: flux.NewSliceResultIterator(
@@ -0,0 +1,244 @@
+package influxql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+	"github.com/influxdata/influxql"
+)
+
+// SchemaReader reads the tag keys and tag values of a bucket.
+// The measurement names are read as the values of the special measurement
+// tag key, and the fields of a measurement are read with their types.
+// It is implemented by the storage engine.
+type SchemaReader interface {
+	TagKeys(ctx context.Context, orgID, bucketID influxdb.ID, start, end int64, predicate influxql.Expr) (cursors.StringIterator, error)
+	TagValues(ctx context.Context, orgID, bucketID influxdb.ID, tagKey string, start, end int64, predicate influxql.Expr) (cursors.StringIterator, error)
+	MeasurementFields(ctx context.Context, orgID, bucketID influxdb.ID, measurement string, start, end int64) (map[string]influxql.DataType, error)
+}
+
+// BucketLookup finds buckets that are accessible to the query.
+type BucketLookup interface {
+	Lookup(ctx context.Context, orgID influxdb.ID, name string) (influxdb.ID, bool)
+	LookupName(ctx context.Context, orgID, id influxdb.ID) string
+}
+
+type key int
+
+const dependenciesKey key = iota
+
+// Dependencies are the services the transpiler uses to read the schema
+// of a bucket when expanding wildcards and regular expressions.
+type Dependencies struct {
+	SchemaReader SchemaReader
+	BucketLookup BucketLookup
+}
+
+// Inject adds the dependencies to the context.
+func (d Dependencies) Inject(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dependenciesKey, d)
+}
+
+// GetDependencies retrieves the dependencies from the context.
+// If the dependencies were never injected, the zero value is returned.
+func GetDependencies(ctx context.Context) Dependencies {
+	deps, _ := ctx.Value(dependenciesKey).(Dependencies)
+	return deps
+}
+
+var errSchemaUnavailable = errors.New("unable to expand wildcard: bucket schema is unavailable")
+
+// measurementSchema contains the field and tag keys for a measurement.
+type measurementSchema struct {
+	fields map[string]influxql.DataType
+	tags   map[string]struct{}
+}
+
+// schemaMapper implements influxql.FieldMapper by reading the schema
+// of the bucket a measurement is stored in. The types of the fields are
+// read from the storage engine, so a wildcard within a function is only
+// expanded to the fields the function supports.
+type schemaMapper struct {
+	ctx        context.Context
+	t          *transpilerState
+	start, end int64
+	cache      map[string]*measurementSchema
+}
+
+func (m *schemaMapper) FieldDimensions(mm *influxql.Measurement) (fields map[string]influxql.DataType, dimensions map[string]struct{}, err error) {
+	s, err := m.schema(mm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Copy the schema since the caller will modify the maps.
+	fields = make(map[string]influxql.DataType, len(s.fields))
+	for k, typ := range s.fields {
+		fields[k] = typ
+	}
+	dimensions = make(map[string]struct{}, len(s.tags))
+	for k := range s.tags {
+		dimensions[k] = struct{}{}
+	}
+	return fields, dimensions, nil
+}
+
+func (m *schemaMapper) MapType(mm *influxql.Measurement, field string) influxql.DataType {
+	s, err := m.schema(mm)
+	if err != nil {
+		return influxql.Unknown
+	}
+	if typ, ok := s.fields[field]; ok {
+		return typ
+	} else if _, ok := s.tags[field]; ok {
+		return influxql.Tag
+	}
+	return influxql.Unknown
+}
+
+func (m *schemaMapper) CallType(name string, args []influxql.DataType) (influxql.DataType, error) {
+	switch name {
+	case "count", "elapsed":
+		return influxql.Integer, nil
+	case "first", "last", "min", "max", "mode", "distinct", "sample", "top", "bottom", "percentile":
+		// Selectors return the values of the field.
+		if len(args) > 0 {
+			return args[0], nil
+		}
+	}
+	return influxql.Float, nil
+}
+
+func (m *schemaMapper) schema(mm *influxql.Measurement) (*measurementSchema, error) {
+	if mm.Regex != nil {
+		return nil, errors.New("unimplemented: wildcard with a regex measurement")
+	}
+
+	key := mm.String()
+	if s, ok := m.cache[key]; ok {
+		return s, nil
+	}
+
+	deps := GetDependencies(m.ctx)
+	if deps.SchemaReader == nil {
+		return nil, errSchemaUnavailable
+	}
+	orgID, bucketID, err := m.t.lookupBucket(m.ctx, mm)
+	if err != nil {
+		return nil, err
+	}
+
+	// Restrict the schema to the series in the measurement.
+	predicate := &influxql.BinaryExpr{
+		Op:  influxql.EQ,
+		LHS: &influxql.VarRef{Val: models.MeasurementTagKey},
+		RHS: &influxql.StringLiteral{Val: mm.Name},
+	}
+
+	fields, err := deps.SchemaReader.MeasurementFields(m.ctx, orgID, bucketID, mm.Name, m.start, m.end)
+	if err != nil {
+		return nil, err
+	}
+	s := &measurementSchema{
+		fields: fields,
+		tags:   make(map[string]struct{}),
+	}
+
+	itr, err := deps.SchemaReader.TagKeys(m.ctx, orgID, bucketID, m.start, m.end, predicate)
+	if err != nil {
+		return nil, err
+	}
+	for itr.Next() {
+		switch k := itr.Value(); k {
+		case models.MeasurementTagKey, models.FieldKeyTagKey:
+		default:
+			s.tags[k] = struct{}{}
+		}
+	}
+
+	if m.cache == nil {
+		m.cache = make(map[string]*measurementSchema)
+	}
+	m.cache[key] = s
+	return s, nil
+}
+
+// expandWildcards rewrites the wildcards and regular expressions in the
+// fields and dimensions of the statement using the schema of the measurements
+// being queried. The schema is read for the time range of the statement.
+func (t *transpilerState) expandWildcards(ctx context.Context, stmt *influxql.SelectStatement) (*influxql.SelectStatement, error) {
+	valuer := influxql.NowValuer{Now: t.config.Now}
+	_, tr, err := influxql.ConditionExpr(stmt.Condition, &valuer)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &schemaMapper{
+		ctx:   ctx,
+		t:     t,
+		start: tr.MinTimeNano(),
+		end:   tr.MaxTimeNano(),
+	}
+	return stmt.RewriteFields(m)
+}
+
+// lookupBucket finds the organization and bucket ids for the measurement.
+// The bucket in the configuration is resolved within the organization of
+// the query request. Otherwise, the dbrp mapping is used.
+func (t *transpilerState) lookupBucket(ctx context.Context, m *influxql.Measurement) (orgID, bucketID influxdb.ID, err error) {
+	if t.config.Bucket != "" {
+		return t.lookupBucketByName(ctx, t.config.Bucket)
+	}
+
+	mapping, bucket, err := t.findMapping(ctx, m)
+	if err != nil {
+		return 0, 0, err
+	} else if mapping == nil {
+		return t.lookupBucketByName(ctx, bucket)
+	}
+
+	// Ensure the bucket in the mapping is accessible before its schema is read.
+	deps := GetDependencies(ctx)
+	if deps.BucketLookup == nil {
+		return 0, 0, errSchemaUnavailable
+	} else if name := deps.BucketLookup.LookupName(ctx, mapping.OrganizationID, mapping.BucketID); name == "" {
+		return 0, 0, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  fmt.Sprintf("bucket %q not found", mapping.BucketID),
+		}
+	}
+	return mapping.OrganizationID, mapping.BucketID, nil
+}
+
+func (t *transpilerState) lookupBucketByName(ctx context.Context, name string) (orgID, bucketID influxdb.ID, err error) {
+	req := query.RequestFromContext(ctx)
+	deps := GetDependencies(ctx)
+	if req == nil || deps.BucketLookup == nil {
+		return 0, 0, errSchemaUnavailable
+	}
+
+	bucketID, ok := deps.BucketLookup.Lookup(ctx, req.OrganizationID, name)
+	if !ok {
+		return 0, 0, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  fmt.Sprintf("bucket %q not found", name),
+		}
+	}
+	return req.OrganizationID, bucketID, nil
+}
+
+// hasDimensionRegex returns true if the statement is grouped by a regular expression.
+// A wildcard dimension groups by every tag and does not need the schema to be expanded.
+func hasDimensionRegex(stmt *influxql.SelectStatement) bool {
+	for _, d := range stmt.Dimensions {
+		if _, ok := d.Expr.(*influxql.RegexLiteral); ok {
+			return true
+		}
+	}
+	return false
+}
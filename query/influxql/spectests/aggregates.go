@@ -43,5 +43,27 @@ func init() {
 	|> yield(name: "0")
 `
 		}),
+		NewFixture(
+			`SELECT mode(value) FROM db0..cpu`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "_time", "_value"])
+	|> duplicate(column: "_value", as: "_mode")
+	|> group(columns: ["_measurement", "_start", "_stop", "_field", "_mode"], mode: "by")
+	|> count(column: "_value")
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> sort(columns: ["_mode"])
+	|> max(column: "_value")
+	|> drop(columns: ["_value"])
+	|> rename(columns: {"_mode": "_value"})
+	|> map(fn: (r) => ({r with _time: 1970-01-01T00:00:00Z}))
+	|> rename(columns: {_value: "mode"})
+	|> yield(name: "0")
+`,
+		),
 	)
 }
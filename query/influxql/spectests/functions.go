@@ -0,0 +1,241 @@
+package spectests
+
+import "fmt"
+
+func init() {
+	RegisterFixture(
+		NewFixture(
+			`SELECT distinct(value) FROM db0..cpu`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "_time", "_value"])
+	|> distinct()
+	|> map(fn: (r) => ({r with _time: 1970-01-01T00:00:00Z}))
+	|> rename(columns: {_value: "distinct"})
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SELECT count(distinct(value)) FROM db0..cpu`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "_time", "_value"])
+	|> distinct()
+	|> count()
+	|> map(fn: (r) => ({r with _time: 1970-01-01T00:00:00Z}))
+	|> rename(columns: {_value: "count"})
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SELECT top(value, 2) FROM db0..cpu`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "_time", "_value"])
+	|> top(n: 2)
+	|> sort(columns: ["_time"])
+	|> rename(columns: {_value: "top"})
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SELECT bottom(value, host, 2) FROM db0..cpu`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "host", "_time", "_value"])
+	|> group(columns: ["_measurement", "_start", "_stop", "_field", "host"], mode: "by")
+	|> min()
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> duplicate(column: "host", as: "_tag_host")
+	|> drop(columns: ["host"])
+	|> rename(columns: {"_tag_host": "host"})
+	|> bottom(n: 2)
+	|> sort(columns: ["_time"])
+	|> rename(columns: {_value: "bottom"})
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SELECT non_negative_derivative(value, 1m) FROM db0..cpu`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "_time", "_value"])
+	|> derivative(unit: 1m, nonNegative: true)
+	|> rename(columns: {_value: "non_negative_derivative"})
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SELECT derivative(mean(value)) FROM db0..cpu WHERE time >= now() - 10m GROUP BY time(1m)`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 2010-09-15T08:49:00Z, stop: 2010-09-15T09:00:00Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "_time", "_value"])
+	|> window(every: 1m, createEmpty: true)
+	|> mean()
+	|> map(fn: (r) => ({r with _time: r._start}))
+	|> window(every: inf)
+	|> derivative(unit: 1m, nonNegative: false)
+	|> filter(fn: (r) => exists r._value)
+	|> rename(columns: {_value: "derivative"})
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SELECT non_negative_difference(value) FROM db0..cpu`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "_time", "_value"])
+	|> difference(nonNegative: true)
+	|> rename(columns: {_value: "non_negative_difference"})
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SELECT moving_average(value, 3) FROM db0..cpu`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "_time", "_value"])
+	|> movingAverage(n: 3)
+	|> rename(columns: {_value: "moving_average"})
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SELECT cumulative_sum(max(value)) FROM db0..cpu WHERE time >= now() - 10m GROUP BY time(1m)`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 2010-09-15T08:50:00Z, stop: 2010-09-15T09:00:00Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "_time", "_value"])
+	|> window(every: 1m)
+	|> max()
+	|> drop(columns: ["_time"])
+	|> map(fn: (r) => ({r with _time: r._start}))
+	|> window(every: inf)
+	|> cumulativeSum()
+	|> rename(columns: {_value: "cumulative_sum"})
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SELECT holt_winters(mean(value), 10, 4) FROM db0..cpu WHERE time >= now() - 1h GROUP BY time(10m)`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 2010-09-15T08:00:00Z, stop: 2010-09-15T09:00:00Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "_time", "_value"])
+	|> window(every: 10m)
+	|> mean()
+	|> map(fn: (r) => ({r with _time: r._start}))
+	|> window(every: inf)
+	|> holtWinters(n: 10, seasonality: 4, interval: 10m, withFit: false)
+	|> rename(columns: {_value: "holt_winters"})
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SELECT integral(value, 1m) FROM db0..cpu`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "_time", "_value"])
+	|> integral(unit: 1m)
+	|> map(fn: (r) => ({r with _time: 1970-01-01T00:00:00Z}))
+	|> rename(columns: {_value: "integral"})
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SELECT sample(value, 5) FROM db0..cpu`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "_time", "_value"])
+	|> map(fn: (r) => ({r with _sample: int(v: r._time) * 2654435761 % 4294967296}))
+	|> sort(columns: ["_sample"])
+	|> limit(n: 5)
+	|> sort(columns: ["_time"])
+	|> drop(columns: ["_sample"])
+	|> rename(columns: {_value: "sample"})
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SELECT pow(value, 2) FROM db0..cpu`,
+			`package main
+
+import math "math"
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "_time", "_value"])
+	|> sort(columns: ["_time"])
+	|> map(fn: (r) => ({r with "pow": math.pow(x: float(v: r._value), y: 2.0)}))
+	|> drop(columns: ["_value"])
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SELECT abs(mean(value)) FROM db0..cpu`,
+			`package main
+
+import math "math"
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "_time", "_value"])
+	|> mean()
+	|> map(fn: (r) => ({r with _time: 1970-01-01T00:00:00Z}))
+	|> map(fn: (r) => ({r with "abs": math.abs(x: float(v: r._value))}))
+	|> drop(columns: ["_value"])
+	|> yield(name: "0")
+`,
+		),
+	)
+}
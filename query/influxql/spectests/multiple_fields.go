@@ -0,0 +1,39 @@
+package spectests
+
+import "fmt"
+
+func init() {
+	RegisterFixture(
+		NewFixture(
+			`SELECT usage_user + usage_idle FROM db0..system`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "system" and (r._field == "usage_user" or r._field == "usage_idle"))
+	|> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "_time", "usage_user", "usage_idle"])
+	|> sort(columns: ["_time"])
+	|> map(fn: (r) => ({r with "usage_user_usage_idle": r["usage_user"] + r["usage_idle"]}))
+	|> drop(columns: ["usage_idle", "usage_user"])
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SELECT max(usage_user), usage_idle FROM db0..system`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "system" and (r._field == "usage_user" or r._field == "usage_idle"))
+	|> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "_time", "usage_user", "usage_idle"])
+	|> max(column: "usage_user")
+	|> rename(columns: {"usage_user": "max"})
+	|> yield(name: "0")
+`,
+		),
+	)
+}
@@ -12,6 +12,7 @@ from(bucketID: "")
 	|> filter(fn: (r) => r["host"] == "server01")
 	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
 	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "_time", "_value"])
+	|> sort(columns: ["_time"])
 	|> rename(columns: {_value: "value"})
 	|> yield(name: "0")
 `,
@@ -13,6 +13,7 @@ func init() {
 	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
 	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
 	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "_time", "_value"])
+	|> sort(columns: ["_time"])
 	|> rename(columns: {_value: "value"})
 	|> yield(name: "0")
 `,
@@ -0,0 +1,149 @@
+package spectests
+
+import (
+	"context"
+	"sort"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/query/influxql"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+	influxqlpkg "github.com/influxdata/influxql"
+)
+
+// measurementSchema is the set of fields and tags within a measurement.
+type measurementSchema struct {
+	fields map[string]influxqlpkg.DataType
+	tags   []string
+}
+
+// schema is the static schema of the buckets that are used by the fixtures.
+var schema = map[string]measurementSchema{
+	"cpu": {
+		fields: map[string]influxqlpkg.DataType{
+			"value": influxqlpkg.Float,
+		},
+		tags: []string{"host", "region"},
+	},
+	"system": {
+		fields: map[string]influxqlpkg.DataType{
+			"load1":      influxqlpkg.Float,
+			"usage_idle": influxqlpkg.Float,
+			"usage_user": influxqlpkg.Float,
+		},
+		tags: []string{"host", "region"},
+	},
+	"syslog": {
+		fields: map[string]influxqlpkg.DataType{
+			"message":  influxqlpkg.String,
+			"severity": influxqlpkg.Integer,
+		},
+		tags: []string{"host"},
+	},
+}
+
+// schemaReader implements influxql.SchemaReader using the static schema.
+// The predicate is expected to only refer to the measurement.
+type schemaReader struct{}
+
+func (schemaReader) TagKeys(ctx context.Context, orgID, bucketID platform.ID, start, end int64, predicate influxqlpkg.Expr) (cursors.StringIterator, error) {
+	set := map[string]struct{}{
+		models.MeasurementTagKey: {},
+		models.FieldKeyTagKey:    {},
+	}
+	for name, s := range schema {
+		if predicate != nil && !influxqlpkg.EvalBool(predicate, map[string]interface{}{models.MeasurementTagKey: name}) {
+			continue
+		}
+		for _, tag := range s.tags {
+			set[tag] = struct{}{}
+		}
+	}
+
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return cursors.NewStringSliceIterator(keys), nil
+}
+
+func (schemaReader) TagValues(ctx context.Context, orgID, bucketID platform.ID, tagKey string, start, end int64, predicate influxqlpkg.Expr) (cursors.StringIterator, error) {
+	if tagKey == models.MeasurementTagKey {
+		var names []string
+		for name := range schema {
+			if matchMeasurement(predicate, name) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		return cursors.NewStringSliceIterator(names), nil
+	} else if tagKey != models.FieldKeyTagKey {
+		return cursors.NewStringSliceIterator(nil), nil
+	}
+	s := schema[measurementName(predicate)]
+	fields := make([]string, 0, len(s.fields))
+	for f := range s.fields {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	return cursors.NewStringSliceIterator(fields), nil
+}
+
+func (schemaReader) MeasurementFields(ctx context.Context, orgID, bucketID platform.ID, measurement string, start, end int64) (map[string]influxqlpkg.DataType, error) {
+	return schema[measurement].fields, nil
+}
+
+// matchMeasurement evaluates the parts of the predicate that refer to the measurement.
+// The static schema does not have tag values so every other comparison is true.
+func matchMeasurement(predicate influxqlpkg.Expr, name string) bool {
+	switch expr := predicate.(type) {
+	case nil:
+		return true
+	case *influxqlpkg.ParenExpr:
+		return matchMeasurement(expr.Expr, name)
+	case *influxqlpkg.BinaryExpr:
+		switch expr.Op {
+		case influxqlpkg.AND:
+			return matchMeasurement(expr.LHS, name) && matchMeasurement(expr.RHS, name)
+		case influxqlpkg.OR:
+			return matchMeasurement(expr.LHS, name) || matchMeasurement(expr.RHS, name)
+		}
+		if ref, ok := expr.LHS.(*influxqlpkg.VarRef); ok && ref.Val == models.MeasurementTagKey {
+			return influxqlpkg.EvalBool(expr, map[string]interface{}{models.MeasurementTagKey: name})
+		}
+	}
+	return true
+}
+
+func measurementName(predicate influxqlpkg.Expr) string {
+	expr, ok := predicate.(*influxqlpkg.BinaryExpr)
+	if !ok {
+		return ""
+	}
+	lit, ok := expr.RHS.(*influxqlpkg.StringLiteral)
+	if !ok {
+		return ""
+	}
+	return lit.Val
+}
+
+// bucketLookup finds every bucket that is used by the fixtures.
+type bucketLookup struct{}
+
+func (bucketLookup) Lookup(ctx context.Context, orgID platform.ID, name string) (platform.ID, bool) {
+	return bucketID, true
+}
+
+func (bucketLookup) LookupName(ctx context.Context, orgID, id platform.ID) string {
+	return "db0/autogen"
+}
+
+// Dependencies returns the dependencies for transpiling the fixtures.
+// The schema of the measurements used by the fixtures is static.
+func Dependencies() influxql.Dependencies {
+	return influxql.Dependencies{
+		SchemaReader: schemaReader{},
+		BucketLookup: bucketLookup{},
+	}
+}
@@ -7,8 +7,6 @@ import (
 )
 
 var selectorFuncNames = []string{
-	"first",
-	"last",
 	"max",
 	"min",
 }
@@ -43,5 +41,35 @@ func init() {
 	|> yield(name: "0")
 `
 		}),
+		NewFixture(
+			`SELECT first(value) FROM db0..cpu`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "_time", "_value"])
+	|> sort(columns: ["_value"], desc: true)
+	|> min(column: "_time")
+	|> rename(columns: {_value: "first"})
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SELECT last(value) FROM db0..cpu`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> group(columns: ["_measurement", "_start", "_stop", "_field"], mode: "by")
+	|> keep(columns: ["_measurement", "_start", "_stop", "_field", "_time", "_value"])
+	|> sort(columns: ["_value"], desc: true)
+	|> max(column: "_time")
+	|> rename(columns: {_value: "last"})
+	|> yield(name: "0")
+`,
+		),
 	)
 }
@@ -0,0 +1,85 @@
+package spectests
+
+import "fmt"
+
+func init() {
+	RegisterFixture(
+		NewFixture(
+			`SHOW FIELD KEYS FROM cpu`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu")
+	|> keep(columns: ["_field"])
+	|> group()
+	|> distinct(column: "_field")
+	|> sort()
+	|> rename(columns: {_value: "fieldKey"})
+	|> set(key: "fieldType", value: "float")
+	|> set(key: "_measurement", value: "cpu")
+	|> group(columns: ["_measurement"], mode: "by")
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SHOW FIELD KEYS FROM syslog`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "syslog")
+	|> keep(columns: ["_field"])
+	|> group()
+	|> distinct(column: "_field")
+	|> sort()
+	|> rename(columns: {_value: "fieldKey"})
+	|> map(fn: (r) => ({r with fieldType: if r.fieldKey == "message" then "string" else if r.fieldKey == "severity" then "integer" else "float"}))
+	|> set(key: "_measurement", value: "syslog")
+	|> group(columns: ["_measurement"], mode: "by")
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SHOW FIELD KEYS FROM cpu, /sys.*/`,
+			`package main
+
+t0 = `+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu")
+	|> keep(columns: ["_field"])
+	|> group()
+	|> distinct(column: "_field")
+	|> sort()
+	|> rename(columns: {_value: "fieldKey"})
+	|> set(key: "fieldType", value: "float")
+	|> set(key: "_measurement", value: "cpu")
+	|> group(columns: ["_measurement"], mode: "by")
+t1 = `+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "syslog")
+	|> keep(columns: ["_field"])
+	|> group()
+	|> distinct(column: "_field")
+	|> sort()
+	|> rename(columns: {_value: "fieldKey"})
+	|> map(fn: (r) => ({r with fieldType: if r.fieldKey == "message" then "string" else if r.fieldKey == "severity" then "integer" else "float"}))
+	|> set(key: "_measurement", value: "syslog")
+	|> group(columns: ["_measurement"], mode: "by")
+t2 = `+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "system")
+	|> keep(columns: ["_field"])
+	|> group()
+	|> distinct(column: "_field")
+	|> sort()
+	|> rename(columns: {_value: "fieldKey"})
+	|> set(key: "fieldType", value: "float")
+	|> set(key: "_measurement", value: "system")
+	|> group(columns: ["_measurement"], mode: "by")
+union(tables: [t0, t1, t2])
+	|> yield(name: "0")
+`,
+		),
+	)
+}
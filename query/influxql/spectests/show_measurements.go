@@ -0,0 +1,42 @@
+package spectests
+
+import "fmt"
+
+func init() {
+	RegisterFixture(
+		NewFixture(
+			`SHOW MEASUREMENTS`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> keep(columns: ["_measurement"])
+	|> group()
+	|> distinct(column: "_measurement")
+	|> sort()
+	|> rename(columns: {_value: "name"})
+	|> set(key: "_measurement", value: "measurements")
+	|> group(columns: ["_measurement"], mode: "by")
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SHOW MEASUREMENTS ON db0 WITH MEASUREMENT =~ /cp.*/ WHERE host = 'server01' LIMIT 5 OFFSET 10`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement =~ /cp.*/ and r["host"] == "server01")
+	|> keep(columns: ["_measurement"])
+	|> group()
+	|> distinct(column: "_measurement")
+	|> sort()
+	|> limit(n: 5, offset: 10)
+	|> rename(columns: {_value: "name"})
+	|> set(key: "_measurement", value: "measurements")
+	|> group(columns: ["_measurement"], mode: "by")
+	|> yield(name: "0")
+`,
+		),
+	)
+}
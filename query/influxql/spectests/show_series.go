@@ -0,0 +1,27 @@
+package spectests
+
+import "fmt"
+
+func init() {
+	RegisterFixture(
+		NewFixture(
+			`SHOW SERIES FROM cpu WHERE region = 'west' LIMIT 10`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r["region"] == "west")
+	|> group(columns: ["_measurement", "host", "region"], mode: "by")
+	|> distinct(column: "_measurement")
+	|> group()
+	|> map(fn: (r) => ({r with key: r._measurement}))
+	|> map(fn: (r) => ({r with key: if exists r["host"] then r.key + ",host=" + r["host"] else r.key}))
+	|> map(fn: (r) => ({r with key: if exists r["region"] then r.key + ",region=" + r["region"] else r.key}))
+	|> keep(columns: ["key"])
+	|> sort(columns: ["key"])
+	|> limit(n: 10)
+	|> yield(name: "0")
+`,
+		),
+	)
+}
@@ -0,0 +1,70 @@
+package spectests
+
+import "fmt"
+
+func init() {
+	RegisterFixture(
+		NewFixture(
+			`SHOW TAG KEYS FROM cpu`,
+			`package main
+
+`+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu")
+	|> keys()
+	|> keep(columns: ["_value"])
+	|> distinct()
+	|> filter(fn: (r) => not contains(value: r._value, set: ["_start", "_stop", "_field", "_measurement"]))
+	|> sort()
+	|> rename(columns: {_value: "tagKey"})
+	|> set(key: "_measurement", value: "cpu")
+	|> group(columns: ["_measurement"], mode: "by")
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SHOW TAG KEYS WHERE host = 'server01' AND time >= now() - 1h LIMIT 1`,
+			`package main
+
+t0 = `+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 2010-09-15T08:00:00Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r["host"] == "server01")
+	|> keys()
+	|> keep(columns: ["_value"])
+	|> distinct()
+	|> filter(fn: (r) => not contains(value: r._value, set: ["_start", "_stop", "_field", "_measurement"]))
+	|> sort()
+	|> limit(n: 1)
+	|> rename(columns: {_value: "tagKey"})
+	|> set(key: "_measurement", value: "cpu")
+	|> group(columns: ["_measurement"], mode: "by")
+t1 = `+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 2010-09-15T08:00:00Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "syslog" and r["host"] == "server01")
+	|> keys()
+	|> keep(columns: ["_value"])
+	|> distinct()
+	|> filter(fn: (r) => not contains(value: r._value, set: ["_start", "_stop", "_field", "_measurement"]))
+	|> sort()
+	|> limit(n: 1)
+	|> rename(columns: {_value: "tagKey"})
+	|> set(key: "_measurement", value: "syslog")
+	|> group(columns: ["_measurement"], mode: "by")
+t2 = `+fmt.Sprintf(`from(bucketID: "%s")`, bucketID.String())+`
+	|> range(start: 2010-09-15T08:00:00Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "system" and r["host"] == "server01")
+	|> keys()
+	|> keep(columns: ["_value"])
+	|> distinct()
+	|> filter(fn: (r) => not contains(value: r._value, set: ["_start", "_stop", "_field", "_measurement"]))
+	|> sort()
+	|> limit(n: 1)
+	|> rename(columns: {_value: "tagKey"})
+	|> set(key: "_measurement", value: "system")
+	|> group(columns: ["_measurement"], mode: "by")
+union(tables: [t0, t1, t2])
+	|> yield(name: "0")
+`,
+		),
+	)
+}
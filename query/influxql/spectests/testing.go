@@ -103,7 +103,8 @@ func (f *fixture) Run(t *testing.T) {
 				Now:             Now(),
 			},
 		)
-		pkg, err := transpiler.Transpile(context.Background(), f.stmt)
+		ctx := Dependencies().Inject(context.Background())
+		pkg, err := transpiler.Transpile(ctx, f.stmt)
 		if err != nil {
 			t.Fatalf("%s:%d: unexpected error: %s", f.file, f.line, err)
 		}
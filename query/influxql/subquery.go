@@ -0,0 +1,183 @@
+package influxql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/influxql"
+)
+
+// subquery holds the transpiled result of a subquery that is used as a source.
+// The result is assigned to a variable so multiple cursors can read from it
+// without re-evaluating the inner statement.
+type subquery struct {
+	ident   *ast.Identifier
+	columns map[string]struct{}
+}
+
+// createSubQueryCursor creates a cursor for a variable reference that reads from
+// the output of a subquery. The subquery output has one column for each of its fields
+// so the referenced column is renamed back to the value column. This allows the outer
+// statement to treat the subquery the same way it would treat a measurement.
+func createSubQueryCursor(ctx context.Context, t *transpilerState, src *influxql.SubQuery, ref *influxql.VarRef) (cursor, error) {
+	sq, err := t.subquery(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := sq.columns[ref.Val]; !ok {
+		return nil, fmt.Errorf("undefined variable: %s", ref)
+	}
+
+	expr := &ast.PipeExpression{
+		Argument: sq.ident,
+		Call: &ast.CallExpression{
+			Callee: &ast.Identifier{
+				Name: "rename",
+			},
+			Arguments: []ast.Expression{
+				&ast.ObjectExpression{
+					Properties: []*ast.Property{{
+						Key: &ast.Identifier{
+							Name: "columns",
+						},
+						Value: &ast.ObjectExpression{
+							Properties: []*ast.Property{{
+								Key:   &ast.StringLiteral{Value: ref.Val},
+								Value: &ast.StringLiteral{Value: "_value"},
+							}},
+						},
+					}},
+				},
+			},
+		},
+	}
+	return &varRefCursor{
+		expr: expr,
+		ref:  ref,
+	}, nil
+}
+
+// createSubQueryFieldsCursor creates a cursor for multiple variable references that
+// read from the output of a subquery. Each field is already in its own column so the
+// columns are read directly.
+func createSubQueryFieldsCursor(ctx context.Context, t *transpilerState, src *influxql.SubQuery, fields []*influxql.VarRef) (cursor, error) {
+	sq, err := t.subquery(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ref := range fields {
+		if _, ok := sq.columns[ref.Val]; !ok {
+			return nil, fmt.Errorf("undefined variable: %s", ref)
+		}
+	}
+	return &fieldsCursor{
+		expr:   sq.ident,
+		fields: fields,
+	}, nil
+}
+
+// subquery transpiles the subquery and assigns its result to a variable.
+// The subquery is only transpiled once for each source in the statement.
+func (t *transpilerState) subquery(ctx context.Context, src *influxql.SubQuery) (*subquery, error) {
+	if sq, ok := t.subqueries[src]; ok {
+		return sq, nil
+	}
+
+	stmt := src.Statement.Clone()
+	if len(stmt.SortFields) == 0 {
+		stmt.SortFields = t.stmt.SortFields
+	} else if stmt.TimeAscending() != t.stmt.TimeAscending() {
+		return nil, errors.New("subqueries must be ordered in the same direction as the query itself")
+	}
+
+	// A subquery that calls a function without grouping by an interval
+	// inherits the interval of the outer statement.
+	if interval, err := stmt.GroupByInterval(); err == nil && interval == 0 && hasFunction(stmt) {
+		for _, d := range t.stmt.Dimensions {
+			if call, ok := d.Expr.(*influxql.Call); ok && call.Name == "time" {
+				stmt.Dimensions = append(stmt.Dimensions, &influxql.Dimension{
+					Expr: influxql.CloneExpr(call),
+				})
+			}
+		}
+	}
+
+	// The time range of the outer statement restricts the time range of the subquery.
+	// Add the time range to the condition of the subquery so it is intersected with
+	// any time range the subquery specifies itself.
+	valuer := influxql.NowValuer{Now: t.config.Now}
+	_, tr, err := influxql.ConditionExpr(t.stmt.Condition, &valuer)
+	if err != nil {
+		return nil, err
+	}
+	if !tr.Min.IsZero() {
+		stmt.Condition = andExpr(stmt.Condition, &influxql.BinaryExpr{
+			Op:  influxql.GTE,
+			LHS: &influxql.VarRef{Val: "time"},
+			RHS: &influxql.TimeLiteral{Val: tr.Min},
+		})
+	}
+	if !tr.Max.IsZero() {
+		stmt.Condition = andExpr(stmt.Condition, &influxql.BinaryExpr{
+			Op:  influxql.LTE,
+			LHS: &influxql.VarRef{Val: "time"},
+			RHS: &influxql.TimeLiteral{Val: tr.Max},
+		})
+	}
+
+	// Transpile the subquery with its own state, but share the file so
+	// any assignments made by the subquery are part of the same program.
+	inner := &transpilerState{
+		config:         t.config,
+		file:           t.file,
+		assignments:    t.assignments,
+		dbrpMappingSvc: t.dbrpMappingSvc,
+	}
+	cur, err := inner.transpileSelect(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]struct{})
+	for _, name := range inner.stmt.ColumnNames() {
+		columns[name] = struct{}{}
+	}
+	sq := &subquery{
+		ident:   t.assignment(cur.Expr()),
+		columns: columns,
+	}
+	if t.subqueries == nil {
+		t.subqueries = make(map[*influxql.SubQuery]*subquery)
+	}
+	t.subqueries[src] = sq
+	return sq, nil
+}
+
+// andExpr combines two conditions with AND. If the left side is nil,
+// the right side is returned.
+func andExpr(lhs, rhs influxql.Expr) influxql.Expr {
+	if lhs == nil {
+		return rhs
+	}
+	return &influxql.BinaryExpr{
+		Op:  influxql.AND,
+		LHS: &influxql.ParenExpr{Expr: lhs},
+		RHS: rhs,
+	}
+}
+
+// hasFunction returns true if any of the fields in the statement
+// call a function other than a math function.
+func hasFunction(stmt *influxql.SelectStatement) bool {
+	found := false
+	influxql.WalkFunc(stmt.Fields, func(n influxql.Node) {
+		if call, ok := n.(*influxql.Call); ok && !isMathFunction(call) {
+			found = true
+		}
+	})
+	return found
+}
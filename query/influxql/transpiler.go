@@ -56,6 +56,7 @@ type transpilerState struct {
 	config         Config
 	file           *ast.File
 	assignments    map[string]ast.Expression
+	subqueries     map[*influxql.SubQuery]*subquery
 	dbrpMappingSvc influxdb.DBRPMappingService
 }
 
@@ -119,6 +120,14 @@ func (t *transpilerState) transpile(ctx context.Context, s influxql.Statement) (
 		return cur.Expr(), nil
 	case *influxql.ShowTagValuesStatement:
 		return t.transpileShowTagValues(ctx, stmt)
+	case *influxql.ShowMeasurementsStatement:
+		return t.transpileShowMeasurements(ctx, stmt)
+	case *influxql.ShowTagKeysStatement:
+		return t.transpileShowTagKeys(ctx, stmt)
+	case *influxql.ShowFieldKeysStatement:
+		return t.transpileShowFieldKeys(ctx, stmt)
+	case *influxql.ShowSeriesStatement:
+		return t.transpileShowSeries(ctx, stmt)
 	case *influxql.ShowDatabasesStatement:
 		return t.transpileShowDatabases(ctx, stmt)
 	case *influxql.ShowRetentionPoliciesStatement:
@@ -140,7 +149,7 @@ func (t *transpilerState) transpileShowTagValues(ctx context.Context, stmt *infl
 		stmt.Database = t.config.DefaultDatabase
 	}
 
-	expr, err := t.from(&influxql.Measurement{Database: stmt.Database})
+	expr, err := t.from(ctx, &influxql.Measurement{Database: stmt.Database})
 	if err != nil {
 		return nil, err
 	}
@@ -618,6 +627,25 @@ func (t *transpilerState) transpileSelect(ctx context.Context, stmt *influxql.Se
 	t.stmt = stmt.Clone()
 	t.stmt.OmitTime = true
 
+	// Expand any wildcards or regular expressions using the schema of the bucket.
+	if t.stmt.HasFieldWildcard() || hasDimensionRegex(t.stmt) {
+		s, err := t.expandWildcards(ctx, t.stmt)
+		if err != nil {
+			return nil, err
+		}
+		t.stmt = s
+	}
+
+	// Rewrite the distinct keyword into a call to the distinct function.
+	for _, f := range t.stmt.Fields {
+		f.Expr = influxql.RewriteExpr(f.Expr, func(expr influxql.Expr) influxql.Expr {
+			if d, ok := expr.(*influxql.Distinct); ok {
+				return d.NewCall()
+			}
+			return expr
+		})
+	}
+
 	groups, err := identifyGroups(t.stmt)
 	if err != nil {
 		return nil, err
@@ -630,7 +658,7 @@ func (t *transpilerState) transpileSelect(ctx context.Context, stmt *influxql.Se
 
 	cursors := make([]cursor, 0, len(groups))
 	for _, gr := range groups {
-		cur, err := gr.createCursor(t)
+		cur, err := gr.createCursor(ctx, t)
 		if err != nil {
 			return nil, err
 		}
@@ -654,7 +682,7 @@ func (t *transpilerState) mapType(ref *influxql.VarRef) influxql.DataType {
 	return influxql.Tag
 }
 
-func (t *transpilerState) from(m *influxql.Measurement) (ast.Expression, error) {
+func (t *transpilerState) from(ctx context.Context, m *influxql.Measurement) (ast.Expression, error) {
 	var args []ast.Expression
 	// Use the bucket inteasd of dbrp mapping if it exists.
 	if t.config.Bucket != "" {
@@ -673,43 +701,11 @@ func (t *transpilerState) from(m *influxql.Measurement) (ast.Expression, error)
 			},
 		}
 	} else {
-		if t.dbrpMappingSvc == nil {
-			return nil, &influxdb.Error{
-				Code: influxdb.EInternal,
-				Msg:  "unable to transpile: db and rp mappings need to be created by some way",
-			}
-		}
-		db, rp := m.Database, m.RetentionPolicy
-		if db == "" {
-			if t.config.DefaultDatabase == "" {
-				return nil, &influxdb.Error{
-					Code: influxdb.EInvalid,
-					Msg:  "unable to transpile: database is required",
-				}
-			}
-			db = t.config.DefaultDatabase
-		}
-		if rp == "" {
-			if t.config.DefaultRetentionPolicy != "" {
-				rp = t.config.DefaultRetentionPolicy
-			}
-		}
-
-		var filter influxdb.DBRPMappingFilter
-		filter.Cluster = &t.config.Cluster
-		if db != "" {
-			filter.Database = &db
-		}
-		if rp != "" {
-			filter.RetentionPolicy = &rp
-		}
-		defaultRP := rp == ""
-		filter.Default = &defaultRP
-		mapping, err := t.dbrpMappingSvc.Find(context.TODO(), filter)
+		mapping, bucket, err := t.findMapping(ctx, m)
 		if err != nil {
-			if !t.config.FallbackToDBRP {
-				return nil, err
-			}
+			return nil, err
+		}
+		if mapping == nil {
 			// use `db/rp` naming convention
 			args = []ast.Expression{
 				&ast.ObjectExpression{
@@ -719,7 +715,7 @@ func (t *transpilerState) from(m *influxql.Measurement) (ast.Expression, error)
 								Name: "bucket",
 							},
 							Value: &ast.StringLiteral{
-								Value: fmt.Sprintf("%s/%s", db, rp),
+								Value: bucket,
 							},
 						},
 					},
@@ -752,6 +748,52 @@ func (t *transpilerState) from(m *influxql.Measurement) (ast.Expression, error)
 	}, nil
 }
 
+// findMapping finds the dbrp mapping for the measurement. If there is no mapping
+// and the config allows falling back to the `db/rp` naming convention, a nil mapping
+// is returned with the name of the bucket.
+func (t *transpilerState) findMapping(ctx context.Context, m *influxql.Measurement) (*influxdb.DBRPMapping, string, error) {
+	if t.dbrpMappingSvc == nil {
+		return nil, "", &influxdb.Error{
+			Code: influxdb.EInternal,
+			Msg:  "unable to transpile: db and rp mappings need to be created by some way",
+		}
+	}
+	db, rp := m.Database, m.RetentionPolicy
+	if db == "" {
+		if t.config.DefaultDatabase == "" {
+			return nil, "", &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "unable to transpile: database is required",
+			}
+		}
+		db = t.config.DefaultDatabase
+	}
+	if rp == "" {
+		if t.config.DefaultRetentionPolicy != "" {
+			rp = t.config.DefaultRetentionPolicy
+		}
+	}
+
+	var filter influxdb.DBRPMappingFilter
+	filter.Cluster = &t.config.Cluster
+	if db != "" {
+		filter.Database = &db
+	}
+	if rp != "" {
+		filter.RetentionPolicy = &rp
+	}
+	defaultRP := rp == ""
+	filter.Default = &defaultRP
+	mapping, err := t.dbrpMappingSvc.Find(ctx, filter)
+	if err != nil {
+		if !t.config.FallbackToDBRP {
+			return nil, "", err
+		}
+		return nil, fmt.Sprintf("%s/%s", db, rp), nil
+	}
+	return mapping, "", nil
+}
+
 func (t *transpilerState) assignment(expr ast.Expression) *ast.Identifier {
 	for i := 0; ; i++ {
 		key := fmt.Sprintf("t%d", i)
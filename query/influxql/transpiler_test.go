@@ -2,6 +2,7 @@ package influxql_test
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -394,7 +395,8 @@ func TestTranspiler_Compile(t *testing.T) {
 					DefaultDatabase: "db0",
 				},
 			)
-			if _, err := transpiler.Transpile(context.Background(), tt.s); err != nil {
+			ctx := spectests.Dependencies().Inject(context.Background())
+			if _, err := transpiler.Transpile(ctx, tt.s); err != nil {
 				if got, want := err.Error(), tt.err; got != want {
 					if cause := errors.Cause(err); strings.HasPrefix(cause.Error(), "unimplemented") {
 						t.Skip(got)
@@ -407,3 +409,26 @@ func TestTranspiler_Compile(t *testing.T) {
 		})
 	}
 }
+
+// TestTranspiler_WildcardWithoutSchema verifies that a wildcard cannot be expanded
+// when the transpiler does not have access to the schema of the bucket.
+func TestTranspiler_WildcardWithoutSchema(t *testing.T) {
+	for _, s := range []string{
+		`SELECT * FROM cpu`,
+		`SELECT mean(/val/) FROM cpu`,
+		`SELECT max(value) FROM cpu GROUP BY /host/`,
+	} {
+		t.Run(s, func(t *testing.T) {
+			transpiler := influxql.NewTranspilerWithConfig(
+				dbrpMappingSvc,
+				influxql.Config{
+					DefaultDatabase: "db0",
+				},
+			)
+			_, err := transpiler.Transpile(context.Background(), s)
+			if got, want := fmt.Sprint(err), "unable to expand wildcard: bucket schema is unavailable"; got != want {
+				t.Errorf("unexpected error: got=%q want=%q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/iocounter"
+)
+
+const JSONDialectType = "json"
+
+// JSONDialect describes the output format of queries in JSON,
+// an array of an object for every table of the results.
+type JSONDialect struct {
+	// DateTimeFormat is the format of the times, RFC3339 or RFC3339Nano.
+	DateTimeFormat string `json:"dateTimeFormat"`
+}
+
+func (d *JSONDialect) Encoder() flux.MultiResultEncoder {
+	return &JSONEncoder{layout: timeLayout(d.DateTimeFormat), dateTimeFormat: d.DateTimeFormat}
+}
+
+func (d *JSONDialect) DialectType() flux.DialectType {
+	return JSONDialectType
+}
+
+func (d *JSONDialect) SetHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Transfer-Encoding", "chunked")
+}
+
+// jsonTable is a table of a result encoded in JSON.
+type jsonTable struct {
+	Result  string          `json:"result"`
+	Table   int             `json:"table"`
+	Columns []jsonColumn    `json:"columns"`
+	Data    [][]interface{} `json:"data"`
+}
+
+type jsonColumn struct {
+	Label    string `json:"label"`
+	Datatype string `json:"datatype"`
+	Group    bool   `json:"group"`
+}
+
+// jsonError is an error of the query encoded in JSON after the tables read before it.
+type jsonError struct {
+	Error string `json:"error"`
+}
+
+// JSONEncoder encodes the results as a JSON array of their tables,
+// the columns of a table listing their label, their datatype as annotated in CSV
+// and whether they are part of the group key, and its data being an array of rows.
+// Null values are encoded as null, floats that are not numbers as the strings NaN, +Inf and -Inf.
+// An error of the query after some tables were encoded ends the array as an object of the error.
+type JSONEncoder struct {
+	layout         string
+	dateTimeFormat string
+}
+
+func (e *JSONEncoder) Encode(w io.Writer, results flux.ResultIterator) (int64, error) {
+	defer results.Release()
+
+	wc := &iocounter.Writer{Writer: w}
+	tables := 0
+	writeObject := func(v interface{}) error {
+		octets, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		delim := ",\n"
+		if tables == 0 {
+			delim = "["
+		}
+		tables++
+		if _, err := io.WriteString(wc, delim); err != nil {
+			return err
+		}
+		_, err = wc.Write(octets)
+		return err
+	}
+
+	err := func() error {
+		for results.More() {
+			res := results.Next()
+			tableID := 0
+			if err := res.Tables().Do(func(tbl flux.Table) error {
+				t, err := e.table(res.Name(), tableID, tbl)
+				if err != nil {
+					return err
+				}
+				tableID++
+				return wrapEncoderError(writeObject(t))
+			}); err != nil {
+				return err
+			}
+		}
+		results.Release()
+		return results.Err()
+	}()
+	if err != nil {
+		if _, ok := err.(*encoderError); ok || wc.Count() == 0 {
+			return wc.Count(), err
+		}
+		if err := writeObject(jsonError{Error: err.Error()}); err != nil {
+			return wc.Count(), err
+		}
+	}
+
+	end := "\n]\n"
+	if tables == 0 {
+		end = "[]\n"
+	}
+	_, err = io.WriteString(wc, end)
+	return wc.Count(), err
+}
+
+// table reads the table of the result.
+func (e *JSONEncoder) table(result string, id int, tbl flux.Table) (*jsonTable, error) {
+	cols := tbl.Cols()
+	t := &jsonTable{
+		Result:  result,
+		Table:   id,
+		Columns: make([]jsonColumn, len(cols)),
+		Data:    [][]interface{}{},
+	}
+	for j, c := range cols {
+		dt, err := datatype(c.Type, e.dateTimeFormat)
+		if err != nil {
+			return nil, wrapEncoderError(err)
+		}
+		t.Columns[j] = jsonColumn{
+			Label:    c.Label,
+			Datatype: dt,
+			Group:    tbl.Key().HasCol(c.Label),
+		}
+	}
+
+	err := tbl.Do(func(cr flux.ColReader) error {
+		for i, l := 0, cr.Len(); i < l; i++ {
+			row := make([]interface{}, len(cols))
+			for j, c := range cols {
+				v, err := e.value(cr, i, j, c.Type)
+				if err != nil {
+					return wrapEncoderError(err)
+				}
+				row[j] = v
+			}
+			t.Data = append(t.Data, row)
+		}
+		return nil
+	})
+	return t, err
+}
+
+// value returns the value of the column of the row encoded in JSON, nil if it is null.
+func (e *JSONEncoder) value(cr flux.ColReader, i, j int, typ flux.ColType) (interface{}, error) {
+	switch typ {
+	case flux.TBool:
+		if vs := cr.Bools(j); vs.IsValid(i) {
+			return vs.Value(i), nil
+		}
+	case flux.TInt:
+		if vs := cr.Ints(j); vs.IsValid(i) {
+			return vs.Value(i), nil
+		}
+	case flux.TUInt:
+		if vs := cr.UInts(j); vs.IsValid(i) {
+			return vs.Value(i), nil
+		}
+	case flux.TFloat:
+		if vs := cr.Floats(j); vs.IsValid(i) {
+			v := vs.Value(i)
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				// JSON has no representation of the floats that are not numbers.
+				return strconv.FormatFloat(v, 'f', -1, 64), nil
+			}
+			return v, nil
+		}
+	case flux.TString:
+		if vs := cr.Strings(j); vs.IsValid(i) {
+			return vs.ValueString(i), nil
+		}
+	case flux.TTime:
+		if vs := cr.Times(j); vs.IsValid(i) {
+			return execute.Time(vs.Value(i)).Time().Format(e.layout), nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown column type %v", typ)
+	}
+	return nil, nil
+}
@@ -0,0 +1,159 @@
+package query_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/influxdb/query"
+)
+
+func TestJSONDialect(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect query.JSONDialect
+		results []flux.Result
+		want    string
+	}{
+		{
+			name:    "tables",
+			dialect: query.JSONDialect{DateTimeFormat: query.RFC3339NanoDateTimeFormat},
+			results: testResults(),
+			want: `[
+  {
+    "result": "_result",
+    "table": 0,
+    "columns": [
+      {"label": "_time", "datatype": "dateTime:RFC3339Nano", "group": false},
+      {"label": "_measurement", "datatype": "string", "group": true},
+      {"label": "_field", "datatype": "string", "group": true},
+      {"label": "_value", "datatype": "double", "group": false},
+      {"label": "host", "datatype": "string", "group": true}
+    ],
+    "data": [
+      ["2019-12-01T10:00:00.000000000Z", "cpu", "usage", 1.5, "a"],
+      ["2019-12-01T10:00:10.500000000Z", "cpu", "usage", null, "a"]
+    ]
+  },
+  {
+    "result": "_result",
+    "table": 1,
+    "columns": [
+      {"label": "_time", "datatype": "dateTime:RFC3339Nano", "group": false},
+      {"label": "_measurement", "datatype": "string", "group": true},
+      {"label": "_field", "datatype": "string", "group": true},
+      {"label": "_value", "datatype": "double", "group": false},
+      {"label": "host", "datatype": "string", "group": true}
+    ],
+    "data": [
+      ["2019-12-01T10:00:00.000000000Z", "cpu", "usage", 2, "b"]
+    ]
+  },
+  {
+    "result": "_result",
+    "table": 2,
+    "columns": [
+      {"label": "_time", "datatype": "dateTime:RFC3339Nano", "group": false},
+      {"label": "_measurement", "datatype": "string", "group": true},
+      {"label": "_field", "datatype": "string", "group": true},
+      {"label": "_value", "datatype": "double", "group": false},
+      {"label": "host", "datatype": "string", "group": true}
+    ],
+    "data": []
+  },
+  {
+    "result": "_result",
+    "table": 3,
+    "columns": [
+      {"label": "_measurement", "datatype": "string", "group": true},
+      {"label": "count", "datatype": "long", "group": false},
+      {"label": "ok", "datatype": "boolean", "group": false},
+      {"label": "n", "datatype": "unsignedLong", "group": false}
+    ],
+    "data": [
+      ["cpu", 3, true, 7]
+    ]
+  }
+]`,
+		},
+		{
+			name: "floats that are not numbers",
+			results: []flux.Result{
+				&executetest.Result{
+					Nm: "_result",
+					Tbls: []*executetest.Table{{
+						ColMeta: []flux.ColMeta{{Label: "_value", Type: flux.TFloat}},
+						Data:    [][]interface{}{{math.NaN()}, {math.Inf(1)}, {math.Inf(-1)}},
+					}},
+				},
+			},
+			want: `[
+  {
+    "result": "_result",
+    "table": 0,
+    "columns": [{"label": "_value", "datatype": "double", "group": false}],
+    "data": [["NaN"], ["+Inf"], ["-Inf"]]
+  }
+]`,
+		},
+		{
+			name: "error",
+			results: []flux.Result{
+				&executetest.Result{
+					Nm: "_result",
+					Tbls: []*executetest.Table{{
+						ColMeta: []flux.ColMeta{{Label: "_time", Type: flux.TTime}},
+						Data:    [][]interface{}{{execute.Time(1575194400000000000)}},
+					}},
+				},
+				&executetest.Result{
+					Nm: "failed",
+					Tbls: []*executetest.Table{{
+						ColMeta: []flux.ColMeta{{Label: "_value", Type: flux.TFloat}},
+						Data:    [][]interface{}{{1.0}},
+						Err:     errors.New("expected error"),
+					}},
+				},
+			},
+			want: `[
+  {
+    "result": "_result",
+    "table": 0,
+    "columns": [{"label": "_time", "datatype": "dateTime:RFC3339", "group": false}],
+    "data": [["2019-12-01T10:00:00Z"]]
+  },
+  {"error": "expected error"}
+]`,
+		},
+		{
+			name: "no tables",
+			want: `[]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if _, err := tt.dialect.Encoder().Encode(&buf, flux.NewSliceResultIterator(tt.results)); err != nil {
+				t.Fatal(err)
+			}
+
+			var got, want interface{}
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("invalid json %s: %v", buf.String(), err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("unexpected json -want/+got:\n%s", diff)
+			}
+		})
+	}
+}
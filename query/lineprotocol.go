@@ -0,0 +1,155 @@
+package query
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/iocounter"
+	"github.com/influxdata/influxdb/models"
+)
+
+const LineProtocolDialectType = "line-protocol"
+
+// LineProtocolDialect describes the output format of queries in line protocol,
+// to write the results of the query into another bucket.
+type LineProtocolDialect struct{}
+
+func (d *LineProtocolDialect) Encoder() flux.MultiResultEncoder {
+	return &flux.DelimitedMultiResultEncoder{
+		Encoder: &LineProtocolResultEncoder{},
+	}
+}
+
+func (d *LineProtocolDialect) DialectType() flux.DialectType {
+	return LineProtocolDialectType
+}
+
+func (d *LineProtocolDialect) SetHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Transfer-Encoding", "chunked")
+}
+
+// LineProtocolResultEncoder encodes every row of the tables of a result as a point,
+// the way the to function stores them: the measurement, field, value and time of a point
+// are the _measurement, _field, _value and _time columns of the row,
+// and its tags the other string columns. Rows without a value are skipped.
+type LineProtocolResultEncoder struct{}
+
+func (e *LineProtocolResultEncoder) Encode(w io.Writer, result flux.Result) (int64, error) {
+	wc := &iocounter.Writer{Writer: w}
+	bw := bufio.NewWriter(wc)
+	err := result.Tables().Do(func(tbl flux.Table) error {
+		cols := tbl.Cols()
+		idx := make(map[string]int, 4)
+		for _, label := range []string{execute.DefaultTimeColLabel, execute.DefaultValueColLabel, "_measurement", "_field"} {
+			j := execute.ColIdx(label, cols)
+			if j < 0 {
+				return &encoderError{err: fmt.Errorf("table has no %s column to encode in line protocol", label)}
+			}
+			idx[label] = j
+		}
+		if typ := cols[idx["_measurement"]].Type; typ != flux.TString {
+			return &encoderError{err: fmt.Errorf("_measurement column must be a string, found %v", typ)}
+		}
+		if typ := cols[idx["_field"]].Type; typ != flux.TString {
+			return &encoderError{err: fmt.Errorf("_field column must be a string, found %v", typ)}
+		}
+		if typ := cols[idx[execute.DefaultTimeColLabel]].Type; typ != flux.TTime {
+			return &encoderError{err: fmt.Errorf("_time column must be a time, found %v", typ)}
+		}
+
+		var tagCols []int
+		for j, c := range cols {
+			if c.Type == flux.TString && c.Label != "_measurement" && c.Label != "_field" && c.Label != execute.DefaultValueColLabel {
+				tagCols = append(tagCols, j)
+			}
+		}
+
+		var buf []byte
+		if err := tbl.Do(func(cr flux.ColReader) error {
+			for i, l := 0, cr.Len(); i < l; i++ {
+				pt, err := linePoint(cr, i, idx, tagCols)
+				if err != nil {
+					return &encoderError{err: err}
+				}
+				if pt == nil {
+					continue
+				}
+				buf = append(pt.AppendString(buf[:0]), '\n')
+				if _, err := bw.Write(buf); err != nil {
+					return &encoderError{err: err}
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		return wrapEncoderError(bw.Flush())
+	})
+	if err != nil {
+		bw.Flush()
+	}
+	return wc.Count(), err
+}
+
+// linePoint returns the point of the row,
+// nil if the row misses its measurement, field, time or value, or its value is not a number.
+func linePoint(cr flux.ColReader, i int, idx map[string]int, tagCols []int) (models.Point, error) {
+	measurement, field := cr.Strings(idx["_measurement"]), cr.Strings(idx["_field"])
+	times := cr.Times(idx[execute.DefaultTimeColLabel])
+	if !measurement.IsValid(i) || !field.IsValid(i) || !times.IsValid(i) {
+		return nil, nil
+	}
+
+	v := execute.ValueForRow(cr, i, idx[execute.DefaultValueColLabel])
+	if v.IsNull() {
+		return nil, nil
+	}
+	var value interface{}
+	switch typ := cr.Cols()[idx[execute.DefaultValueColLabel]].Type; typ {
+	case flux.TBool:
+		value = v.Bool()
+	case flux.TInt:
+		value = v.Int()
+	case flux.TUInt:
+		value = v.UInt()
+	case flux.TFloat:
+		// line protocol has no floats that are not numbers.
+		if f := v.Float(); math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, nil
+		}
+		value = v.Float()
+	case flux.TString:
+		value = v.Str()
+	default:
+		return nil, fmt.Errorf("_value column of type %v cannot be encoded in line protocol", typ)
+	}
+
+	tags := make(map[string]string, len(tagCols))
+	for _, j := range tagCols {
+		// line protocol has no empty tags.
+		if vs := cr.Strings(j); vs.IsValid(i) && vs.ValueLen(i) > 0 {
+			tags[cr.Cols()[j].Label] = vs.ValueString(i)
+		}
+	}
+
+	return models.NewPoint(
+		measurement.ValueString(i),
+		models.NewTags(tags),
+		models.Fields{field.ValueString(i): value},
+		execute.Time(times.Value(i)).Time(),
+	)
+}
+
+// EncodeError encodes the error as a comment, which is not a point to write.
+func (e *LineProtocolResultEncoder) EncodeError(w io.Writer, err error) error {
+	msg := strings.Replace(err.Error(), "\n", " ", -1)
+	_, werr := fmt.Fprintf(w, "# error: %s\n", msg)
+	return werr
+}
@@ -0,0 +1,154 @@
+package query_test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/query"
+)
+
+func TestLineProtocolDialect(t *testing.T) {
+	cols := []flux.ColMeta{
+		{Label: "_start", Type: flux.TTime},
+		{Label: "_time", Type: flux.TTime},
+		{Label: "_measurement", Type: flux.TString},
+		{Label: "_field", Type: flux.TString},
+		{Label: "_value", Type: flux.TFloat},
+		{Label: "host", Type: flux.TString},
+		{Label: "region", Type: flux.TString},
+	}
+
+	tests := []struct {
+		name    string
+		results []flux.Result
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "points",
+			results: []flux.Result{
+				&executetest.Result{
+					Nm: "_result",
+					Tbls: []*executetest.Table{
+						{
+							KeyCols: []string{"_measurement", "_field"},
+							ColMeta: cols,
+							Data: [][]interface{}{
+								{execute.Time(0), execute.Time(1575194400000000000), "cpu", "usage", 1.5, "a", "west"},
+								{execute.Time(0), execute.Time(1575194410000000000), "cpu", "usage", nil, "a", "west"},
+								{execute.Time(0), execute.Time(1575194420000000000), "cpu", "usage", math.NaN(), "a", "west"},
+								{execute.Time(0), execute.Time(1575194430000000000), "cpu", "usage", 2.5, "host a", nil},
+							},
+						},
+						{
+							KeyCols: []string{"_measurement", "_field"},
+							ColMeta: []flux.ColMeta{
+								{Label: "_time", Type: flux.TTime},
+								{Label: "_measurement", Type: flux.TString},
+								{Label: "_field", Type: flux.TString},
+								{Label: "_value", Type: flux.TString},
+							},
+							Data: [][]interface{}{
+								{execute.Time(1575194400000000000), "syslog", "message", `say "hi"`},
+							},
+						},
+					},
+				},
+				&executetest.Result{
+					Nm: "counts",
+					Tbls: []*executetest.Table{{
+						ColMeta: []flux.ColMeta{
+							{Label: "_time", Type: flux.TTime},
+							{Label: "_measurement", Type: flux.TString},
+							{Label: "_field", Type: flux.TString},
+							{Label: "_value", Type: flux.TInt},
+						},
+						Data: [][]interface{}{
+							{execute.Time(1575194400000000000), "cpu", "count", int64(3)},
+						},
+					}},
+				},
+			},
+			want: `cpu,host=a,region=west usage=1.5 1575194400000000000
+cpu,host=host\ a usage=2.5 1575194430000000000
+syslog message="say \"hi\"" 1575194400000000000
+cpu count=3i 1575194400000000000
+`,
+		},
+		{
+			name: "error",
+			results: []flux.Result{
+				&executetest.Result{
+					Nm: "_result",
+					Tbls: []*executetest.Table{{
+						ColMeta: cols[1:5],
+						Data: [][]interface{}{
+							{execute.Time(1575194400000000000), "cpu", "usage", 1.5},
+						},
+					}},
+				},
+				&executetest.Result{
+					Nm: "failed",
+					Tbls: []*executetest.Table{{
+						ColMeta: cols[1:5],
+						Data: [][]interface{}{
+							{execute.Time(1575194400000000000), "cpu", "usage", 2.5},
+						},
+						Err: errors.New("expected\nerror"),
+					}},
+				},
+			},
+			want: `cpu usage=1.5 1575194400000000000
+# error: expected error
+`,
+		},
+		{
+			name: "missing field column",
+			results: []flux.Result{
+				&executetest.Result{
+					Nm: "_result",
+					Tbls: []*executetest.Table{{
+						ColMeta: []flux.ColMeta{
+							{Label: "_time", Type: flux.TTime},
+							{Label: "_measurement", Type: flux.TString},
+							{Label: "_value", Type: flux.TFloat},
+						},
+						Data: [][]interface{}{
+							{execute.Time(1575194400000000000), "cpu", 1.5},
+						},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			d := &query.LineProtocolDialect{}
+			_, err := d.Encoder().Encode(&buf, flux.NewSliceResultIterator(tt.results))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tt.want, buf.String()); diff != "" {
+				t.Errorf("unexpected line protocol -want/+got:\n%s", diff)
+			}
+
+			// the response is line protocol to write into a bucket, its errors being comments.
+			if _, err := models.ParsePointsString(buf.String(), "m"); err != nil {
+				t.Errorf("invalid line protocol: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package mock
+
+import (
+	"context"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/query"
+)
+
+var _ query.ActiveQueryService = (*ActiveQueryService)(nil)
+
+// ActiveQueryService mocks the query.ActiveQueryService for testing.
+type ActiveQueryService struct {
+	FindActiveQueriesF   func(ctx context.Context, filter query.ActiveQueryFilter) ([]*query.ActiveQuery, error)
+	FindActiveQueryByIDF func(ctx context.Context, id platform.ID) (*query.ActiveQuery, error)
+	CancelActiveQueryF   func(ctx context.Context, id platform.ID) error
+}
+
+// FindActiveQueries returns the active queries matching the filter.
+func (s *ActiveQueryService) FindActiveQueries(ctx context.Context, filter query.ActiveQueryFilter) ([]*query.ActiveQuery, error) {
+	return s.FindActiveQueriesF(ctx, filter)
+}
+
+// FindActiveQueryByID returns a single active query by ID.
+func (s *ActiveQueryService) FindActiveQueryByID(ctx context.Context, id platform.ID) (*query.ActiveQuery, error) {
+	return s.FindActiveQueryByIDF(ctx, id)
+}
+
+// CancelActiveQuery cancels the active query.
+func (s *ActiveQueryService) CancelActiveQuery(ctx context.Context, id platform.ID) error {
+	return s.CancelActiveQueryF(ctx, id)
+}
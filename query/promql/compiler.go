@@ -0,0 +1,257 @@
+package promql
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/lang"
+)
+
+const CompilerType = "promql"
+
+// LookbackDelta is how far back an instant vector selector looks for the latest sample of a series.
+const LookbackDelta = 5 * time.Minute
+
+// ResultType is the type of the results of a PromQL query.
+type ResultType string
+
+const (
+	// VectorResult is the result of an instant query, a single sample per series.
+	VectorResult ResultType = "vector"
+	// MatrixResult is the result of a range query or of a range vector selector, a range of samples per series.
+	MatrixResult ResultType = "matrix"
+)
+
+// valueFields are the fields written by the scrapers for the value of the counters, gauges and untyped metrics.
+var valueFields = []string{"counter", "gauge", "value"}
+
+// Compiler compiles a PromQL query into a Flux program reading the metrics written by the scrapers,
+// with the metric name as the measurement and the labels as tags.
+//
+// An instant query is evaluated at End. A range query is evaluated from Start to End every Step,
+// each step taking the last sample since the previous step.
+type Compiler struct {
+	Query  string        `json:"query"`
+	Bucket string        `json:"bucket"`
+	Start  time.Time     `json:"start,omitempty"`
+	End    time.Time     `json:"end"`
+	Step   time.Duration `json:"step,omitempty"`
+}
+
+var _ flux.Compiler = &Compiler{}
+
+// Compile transpiles the query into a Program.
+func (c *Compiler) Compile(ctx context.Context) (flux.Program, error) {
+	q, err := c.Transpile()
+	if err != nil {
+		return nil, err
+	}
+	return lang.Compile(q, c.End)
+}
+
+func (c *Compiler) CompilerType() flux.CompilerType {
+	return CompilerType
+}
+
+// ResultType returns the type of the results of the query.
+func (c *Compiler) ResultType() (ResultType, error) {
+	parsed, err := ParsePromQL(c.Query)
+	if err != nil {
+		return "", err
+	}
+	if sel, ok := parsed.(*Selector); (ok && sel.Range > 0) || c.Step > 0 {
+		return MatrixResult, nil
+	}
+	return VectorResult, nil
+}
+
+// Transpile returns the Flux query of the PromQL query.
+func (c *Compiler) Transpile() (string, error) {
+	parsed, err := ParsePromQL(c.Query)
+	if err != nil {
+		return "", err
+	}
+
+	switch expr := parsed.(type) {
+	case *Selector:
+		if expr.Range > 0 && c.Step > 0 {
+			return "", fmt.Errorf("invalid expression type range vector for range query, must be an instant vector")
+		}
+		return c.selector(expr)
+	case *AggregateExpr:
+		if expr.Selector.Range > 0 {
+			return "", fmt.Errorf("expected type instant vector in aggregation expression, got range vector")
+		}
+		return c.aggregate(expr)
+	default:
+		return "", fmt.Errorf("unable to transpile %T into a flux query", parsed)
+	}
+}
+
+// selector reads the samples of the series selected by the selector,
+// the last sample of every step for the instant vectors and all the samples for the range vectors.
+func (c *Compiler) selector(sel *Selector) (string, error) {
+	pred, err := predicate(sel)
+	if err != nil {
+		return "", err
+	}
+
+	start, stop := c.Start, c.End
+	switch {
+	case sel.Range > 0:
+		start = c.End.Add(-sel.Range)
+	case c.Step == 0:
+		start = c.End.Add(-LookbackDelta)
+	}
+	start, stop = start.Add(-sel.Offset), stop.Add(-sel.Offset)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "from(bucket: %s)\n", ast.Format(&ast.StringLiteral{Value: c.Bucket}))
+	// the stop of the range is exclusive and the samples at the evaluation time are selected.
+	fmt.Fprintf(&b, "\t|> range(start: %s, stop: %s)\n",
+		ast.Format(&ast.DateTimeLiteral{Value: start}),
+		ast.Format(&ast.DateTimeLiteral{Value: stop.Add(time.Nanosecond)}))
+	fmt.Fprintf(&b, "\t|> filter(fn: (r) => %s)\n", pred)
+	switch {
+	case sel.Range > 0:
+	case c.Step > 0:
+		fmt.Fprintf(&b, "\t|> aggregateWindow(every: %s, fn: last, createEmpty: false)\n", durationLiteral(c.Step))
+	default:
+		b.WriteString("\t|> last()\n")
+	}
+	if sel.Offset > 0 {
+		fmt.Fprintf(&b, "\t|> timeShift(duration: %s)\n", durationLiteral(sel.Offset))
+	}
+	return b.String(), nil
+}
+
+// aggregateFuncs are the Flux functions of the PromQL aggregation operators.
+var aggregateFuncs = map[OperatorKind]string{
+	SumKind:   "sum",
+	CountKind: "count",
+	MinKind:   "min",
+	MaxKind:   "max",
+	AvgKind:   "mean",
+	StdevKind: "stddev",
+}
+
+// aggregate aggregates the selected series by the labels of the aggregation, at every step of range queries.
+func (c *Compiler) aggregate(expr *AggregateExpr) (string, error) {
+	fn, ok := aggregateFuncs[expr.Op.Kind]
+	if !ok {
+		return "", fmt.Errorf("unsupported aggregation operator in %q", c.Query)
+	}
+
+	var labels []string
+	if expr.Aggregate != nil {
+		if expr.Aggregate.Without {
+			return "", fmt.Errorf("unable to aggregate using `without`")
+		}
+		for _, l := range expr.Aggregate.Labels {
+			labels = append(labels, label(l.Name))
+		}
+	}
+
+	q, err := c.selector(expr.Selector)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString(q)
+	if c.Step > 0 {
+		// the samples of a step are aggregated together, then regrouped by series.
+		fmt.Fprintf(&b, "\t|> group(columns: %s)\n", columnsLiteral(append(labels, "_time")))
+		fmt.Fprintf(&b, "\t|> %s()\n", fn)
+		fmt.Fprintf(&b, "\t|> group(columns: %s)\n", columnsLiteral(labels))
+		b.WriteString("\t|> sort(columns: [\"_time\"])\n")
+	} else {
+		fmt.Fprintf(&b, "\t|> group(columns: %s)\n", columnsLiteral(labels))
+		fmt.Fprintf(&b, "\t|> %s()\n", fn)
+	}
+	return b.String(), nil
+}
+
+// predicate returns the filter of the samples of the series selected by the selector.
+func predicate(sel *Selector) (string, error) {
+	exprs := []string{
+		fmt.Sprintf("r._measurement == %s", ast.Format(&ast.StringLiteral{Value: sel.Name})),
+	}
+
+	fields := make([]string, len(valueFields))
+	for i, f := range valueFields {
+		fields[i] = fmt.Sprintf("r._field == %s", ast.Format(&ast.StringLiteral{Value: f}))
+	}
+	exprs = append(exprs, "("+strings.Join(fields, " or ")+")")
+
+	for _, m := range sel.LabelMatchers {
+		ref := fmt.Sprintf("r[%s]", ast.Format(&ast.StringLiteral{Value: label(m.Name)}))
+		var value string
+		switch v := m.Value.Value().(type) {
+		case string:
+			value = v
+		case float64:
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+
+		switch m.Kind {
+		case Equal:
+			exprs = append(exprs, fmt.Sprintf("%s == %s", ref, ast.Format(&ast.StringLiteral{Value: value})))
+		case NotEqual:
+			exprs = append(exprs, fmt.Sprintf("%s != %s", ref, ast.Format(&ast.StringLiteral{Value: value})))
+		case RegexMatch, RegexNoMatch:
+			op := "=~"
+			if m.Kind == RegexNoMatch {
+				op = "!~"
+			}
+			// PromQL regular expressions are anchored to the whole label value.
+			re, err := regexp.Compile("^(?:" + value + ")$")
+			if err != nil {
+				return "", fmt.Errorf("invalid regular expression for label %s: %v", m.Name, err)
+			}
+			exprs = append(exprs, fmt.Sprintf("%s %s %s", ref, op, ast.Format(&ast.RegexpLiteral{Value: re})))
+		}
+	}
+	return strings.Join(exprs, " and "), nil
+}
+
+// label returns the column of a PromQL label.
+func label(name string) string {
+	if name == "__name__" {
+		return "_measurement"
+	}
+	return name
+}
+
+func columnsLiteral(columns []string) string {
+	cols := make([]string, len(columns))
+	for i, c := range columns {
+		cols[i] = ast.Format(&ast.StringLiteral{Value: c})
+	}
+	return "[" + strings.Join(cols, ", ") + "]"
+}
+
+// durationLiteral formats the duration in its largest whole unit.
+func durationLiteral(d time.Duration) string {
+	for _, u := range []struct {
+		unit string
+		d    time.Duration
+	}{
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+		{"ms", time.Millisecond},
+		{"us", time.Microsecond},
+	} {
+		if d%u.d == 0 {
+			return strconv.FormatInt(int64(d/u.d), 10) + u.unit
+		}
+	}
+	return strconv.FormatInt(int64(d), 10) + "ns"
+}
@@ -0,0 +1,149 @@
+package promql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	_ "github.com/influxdata/flux/builtin"
+	"github.com/influxdata/influxdb/query/promql"
+	_ "github.com/influxdata/influxdb/query/stdlib"
+)
+
+func TestCompiler_Transpile(t *testing.T) {
+	end := time.Date(2019, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		compiler promql.Compiler
+		want     string
+		wantType promql.ResultType
+		wantErr  bool
+	}{
+		{
+			name: "instant vector",
+			compiler: promql.Compiler{
+				Query: `http_requests_total{code="200",handler=~"/api/.*"}`,
+			},
+			want: `from(bucket: "metrics")
+	|> range(start: 2019-12-01T09:55:00Z, stop: 2019-12-01T10:00:00.000000001Z)
+	|> filter(fn: (r) => r._measurement == "http_requests_total" and (r._field == "counter" or r._field == "gauge" or r._field == "value") and r["code"] == "200" and r["handler"] =~ /^(?:\/api\/.*)$/)
+	|> last()
+`,
+			wantType: promql.VectorResult,
+		},
+		{
+			name: "range vector with offset",
+			compiler: promql.Compiler{
+				Query: `go_goroutines[10m] offset 1h`,
+			},
+			want: `from(bucket: "metrics")
+	|> range(start: 2019-12-01T08:50:00Z, stop: 2019-12-01T09:00:00.000000001Z)
+	|> filter(fn: (r) => r._measurement == "go_goroutines" and (r._field == "counter" or r._field == "gauge" or r._field == "value"))
+	|> timeShift(duration: 1h)
+`,
+			wantType: promql.MatrixResult,
+		},
+		{
+			name: "range query",
+			compiler: promql.Compiler{
+				Query: `go_goroutines{job!="influxd"}`,
+				Start: end.Add(-time.Hour),
+				Step:  15 * time.Second,
+			},
+			want: `from(bucket: "metrics")
+	|> range(start: 2019-12-01T09:00:00Z, stop: 2019-12-01T10:00:00.000000001Z)
+	|> filter(fn: (r) => r._measurement == "go_goroutines" and (r._field == "counter" or r._field == "gauge" or r._field == "value") and r["job"] != "influxd")
+	|> aggregateWindow(every: 15s, fn: last, createEmpty: false)
+`,
+			wantType: promql.MatrixResult,
+		},
+		{
+			name: "instant aggregation",
+			compiler: promql.Compiler{
+				Query: `sum by (code) (http_requests_total)`,
+			},
+			want: `from(bucket: "metrics")
+	|> range(start: 2019-12-01T09:55:00Z, stop: 2019-12-01T10:00:00.000000001Z)
+	|> filter(fn: (r) => r._measurement == "http_requests_total" and (r._field == "counter" or r._field == "gauge" or r._field == "value"))
+	|> last()
+	|> group(columns: ["code"])
+	|> sum()
+`,
+			wantType: promql.VectorResult,
+		},
+		{
+			name: "range aggregation",
+			compiler: promql.Compiler{
+				Query: `avg(go_goroutines)`,
+				Start: end.Add(-time.Hour),
+				Step:  time.Minute,
+			},
+			want: `from(bucket: "metrics")
+	|> range(start: 2019-12-01T09:00:00Z, stop: 2019-12-01T10:00:00.000000001Z)
+	|> filter(fn: (r) => r._measurement == "go_goroutines" and (r._field == "counter" or r._field == "gauge" or r._field == "value"))
+	|> aggregateWindow(every: 1m, fn: last, createEmpty: false)
+	|> group(columns: ["_time"])
+	|> mean()
+	|> group(columns: [])
+	|> sort(columns: ["_time"])
+`,
+			wantType: promql.MatrixResult,
+		},
+		{
+			name: "range vector in a range query",
+			compiler: promql.Compiler{
+				Query: `go_goroutines[5m]`,
+				Start: end.Add(-time.Hour),
+				Step:  time.Minute,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported aggregation",
+			compiler: promql.Compiler{
+				Query: `topk(3, go_goroutines)`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid regular expression",
+			compiler: promql.Compiler{
+				Query: `go_goroutines{job=~"("}`,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.compiler
+			c.Bucket = "metrics"
+			c.End = end
+
+			got, err := c.Transpile()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Transpile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unexpected flux query -want/+got:\n%s", diff)
+			}
+
+			rt, err := c.ResultType()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rt != tt.wantType {
+				t.Errorf("got result type %s, want %s", rt, tt.wantType)
+			}
+
+			if _, err := c.Compile(context.Background()); err != nil {
+				t.Errorf("unexpected error compiling the flux query: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package promql
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/influxdata/flux"
+)
+
+const DialectType = "promql"
+
+// Dialect describes the output format of PromQL queries, the JSON format of the Prometheus HTTP API.
+type Dialect struct {
+	ResultType ResultType // ResultType is the type of the results; a vector or a matrix.
+	Time       time.Time  // Time is the evaluation time of the samples of a vector.
+}
+
+func (d *Dialect) SetHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+}
+
+func (d *Dialect) Encoder() flux.MultiResultEncoder {
+	return NewMultiResultEncoder(d.ResultType, d.Time)
+}
+
+func (d *Dialect) DialectType() flux.DialectType {
+	return DialectType
+}
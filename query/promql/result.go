@@ -0,0 +1,218 @@
+package promql
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/iocounter"
+)
+
+// Response is the response of the Prometheus HTTP API to a query.
+type Response struct {
+	Status    string `json:"status"`
+	Data      *Data  `json:"data,omitempty"`
+	ErrorType string `json:"errorType,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+func (r *Response) error(err error) {
+	r.Status = "error"
+	r.Data = nil
+	r.ErrorType = "execution"
+	r.Error = err.Error()
+}
+
+// Data is the result of a query.
+type Data struct {
+	ResultType ResultType `json:"resultType"`
+	Result     []*Series  `json:"result"`
+}
+
+// Series is a series of the result, with its single sample in a vector or its samples in a matrix.
+type Series struct {
+	Metric map[string]string `json:"metric"`
+	Value  *Sample           `json:"value,omitempty"`
+	Values []Sample          `json:"values,omitempty"`
+}
+
+// Sample is a value of a series at a time, encoded as [<unix seconds>, "<value>"].
+type Sample struct {
+	Time  time.Time
+	Value float64
+}
+
+func (s Sample) MarshalJSON() ([]byte, error) {
+	ts := strconv.FormatFloat(float64(s.Time.UnixNano()/int64(time.Millisecond))/1e3, 'f', -1, 64)
+	return []byte(fmt.Sprintf("[%s,%q]", ts, formatValue(s.Value))), nil
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// MultiResultEncoder encodes results in the JSON format of the Prometheus HTTP API.
+type MultiResultEncoder struct {
+	resultType ResultType
+	time       time.Time
+}
+
+// NewMultiResultEncoder returns an encoder of results of the type,
+// the samples of a vector are at the evaluation time.
+func NewMultiResultEncoder(resultType ResultType, t time.Time) *MultiResultEncoder {
+	return &MultiResultEncoder{
+		resultType: resultType,
+		time:       t,
+	}
+}
+
+// Encode writes the results as a single result of the Prometheus HTTP API.
+// Every table is a series, labeled with the string columns of its group key,
+// the _measurement as the metric name. The _field is not a label.
+func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (int64, error) {
+	resp := Response{
+		Status: "success",
+		Data: &Data{
+			ResultType: e.resultType,
+			Result:     []*Series{},
+		},
+	}
+	wc := &iocounter.Writer{Writer: w}
+
+	for results.More() {
+		res := results.Next()
+		if err := res.Tables().Do(func(tbl flux.Table) error {
+			s, err := e.series(tbl)
+			if err != nil {
+				return err
+			}
+			if s != nil {
+				resp.Data.Result = append(resp.Data.Result, s)
+			}
+			return nil
+		}); err != nil {
+			resp.error(err)
+			results.Release()
+			break
+		}
+	}
+
+	if err := results.Err(); err != nil && resp.Error == "" {
+		resp.error(err)
+	}
+	if resp.Data != nil {
+		sortSeries(resp.Data.Result)
+	}
+
+	err := json.NewEncoder(wc).Encode(resp)
+	return wc.Count(), err
+}
+
+// series returns the series of the table, nil if the table has no samples.
+func (e *MultiResultEncoder) series(tbl flux.Table) (*Series, error) {
+	s := &Series{
+		Metric: make(map[string]string),
+	}
+	for j, c := range tbl.Key().Cols() {
+		if c.Type != flux.TString {
+			continue
+		}
+		switch c.Label {
+		case "_measurement":
+			s.Metric["__name__"] = tbl.Key().ValueString(j)
+		case "_field":
+		default:
+			s.Metric[c.Label] = tbl.Key().ValueString(j)
+		}
+	}
+
+	timeIdx := execute.ColIdx(execute.DefaultTimeColLabel, tbl.Cols())
+	valueIdx := execute.ColIdx(execute.DefaultValueColLabel, tbl.Cols())
+	if valueIdx < 0 {
+		return nil, fmt.Errorf("table has no %s column", execute.DefaultValueColLabel)
+	}
+	if e.resultType == MatrixResult && timeIdx < 0 {
+		return nil, fmt.Errorf("table has no %s column", execute.DefaultTimeColLabel)
+	}
+
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		for i := 0; i < cr.Len(); i++ {
+			v, ok, err := floatValue(cr, valueIdx, i)
+			if err != nil {
+				return err
+			} else if !ok {
+				continue
+			}
+
+			if e.resultType == VectorResult {
+				// the sample of a vector is the last value, at the evaluation time.
+				s.Value = &Sample{Time: e.time, Value: v}
+				continue
+			}
+
+			ts := cr.Times(timeIdx)
+			if !ts.IsValid(i) {
+				continue
+			}
+			s.Values = append(s.Values, Sample{
+				Time:  execute.Time(ts.Value(i)).Time(),
+				Value: v,
+			})
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if s.Value == nil && len(s.Values) == 0 {
+		return nil, nil
+	}
+	return s, nil
+}
+
+// floatValue returns the value of the row of the column as a float.
+func floatValue(cr flux.ColReader, j, i int) (float64, bool, error) {
+	switch typ := cr.Cols()[j].Type; typ {
+	case flux.TFloat:
+		vs := cr.Floats(j)
+		return vs.Value(i), vs.IsValid(i), nil
+	case flux.TInt:
+		vs := cr.Ints(j)
+		return float64(vs.Value(i)), vs.IsValid(i), nil
+	case flux.TUInt:
+		vs := cr.UInts(j)
+		return float64(vs.Value(i)), vs.IsValid(i), nil
+	default:
+		return 0, false, fmt.Errorf("unsupported value type: %s", typ)
+	}
+}
+
+// sortSeries sorts the series by their labels.
+func sortSeries(series []*Series) {
+	key := func(s *Series) string {
+		labels := make([]string, 0, len(s.Metric))
+		for k, v := range s.Metric {
+			labels = append(labels, k+"\xff"+v)
+		}
+		sort.Strings(labels)
+		return strings.Join(labels, "\xfe")
+	}
+	sort.SliceStable(series, func(i, j int) bool {
+		return key(series[i]) < key(series[j])
+	})
+}
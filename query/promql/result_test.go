@@ -0,0 +1,149 @@
+package promql_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/influxdb/query/promql"
+)
+
+func TestMultiResultEncoder_Encode(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		resultType promql.ResultType
+		in         flux.ResultIterator
+		out        string
+	}{
+		{
+			name:       "Vector",
+			resultType: promql.VectorResult,
+			in: flux.NewSliceResultIterator(
+				[]flux.Result{&executetest.Result{
+					Nm: "_result",
+					Tbls: []*executetest.Table{
+						{
+							KeyCols: []string{"_start", "_stop", "_measurement", "_field", "code"},
+							ColMeta: []flux.ColMeta{
+								{Label: "_start", Type: flux.TTime},
+								{Label: "_stop", Type: flux.TTime},
+								{Label: "_time", Type: flux.TTime},
+								{Label: "_measurement", Type: flux.TString},
+								{Label: "_field", Type: flux.TString},
+								{Label: "code", Type: flux.TString},
+								{Label: "_value", Type: flux.TFloat},
+							},
+							Data: [][]interface{}{
+								{ts("2019-12-01T09:55:00Z"), ts("2019-12-01T10:00:00Z"), ts("2019-12-01T09:59:30Z"), "reqs", "counter", "500", float64(7)},
+							},
+						},
+						{
+							KeyCols: []string{"_start", "_stop", "_measurement", "_field", "code"},
+							ColMeta: []flux.ColMeta{
+								{Label: "_start", Type: flux.TTime},
+								{Label: "_stop", Type: flux.TTime},
+								{Label: "_time", Type: flux.TTime},
+								{Label: "_measurement", Type: flux.TString},
+								{Label: "_field", Type: flux.TString},
+								{Label: "code", Type: flux.TString},
+								{Label: "_value", Type: flux.TFloat},
+							},
+							Data: [][]interface{}{
+								{ts("2019-12-01T09:55:00Z"), ts("2019-12-01T10:00:00Z"), ts("2019-12-01T09:59:45Z"), "reqs", "counter", "200", 2.5},
+							},
+						},
+					},
+				}},
+			),
+			out: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"__name__":"reqs","code":"200"},"value":[1575194400,"2.5"]},{"metric":{"__name__":"reqs","code":"500"},"value":[1575194400,"7"]}]}}`,
+		},
+		{
+			name:       "Matrix",
+			resultType: promql.MatrixResult,
+			in: flux.NewSliceResultIterator(
+				[]flux.Result{&executetest.Result{
+					Nm: "_result",
+					Tbls: []*executetest.Table{{
+						KeyCols: []string{"code"},
+						ColMeta: []flux.ColMeta{
+							{Label: "_time", Type: flux.TTime},
+							{Label: "code", Type: flux.TString},
+							{Label: "_value", Type: flux.TInt},
+						},
+						Data: [][]interface{}{
+							{ts("2019-12-01T09:59:00.5Z"), "200", int64(1)},
+							{ts("2019-12-01T10:00:00Z"), "200", int64(3)},
+						},
+					}},
+				}},
+			),
+			out: `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{"code":"200"},"values":[[1575194340.5,"1"],[1575194400,"3"]]}]}}`,
+		},
+		{
+			name:       "No results",
+			resultType: promql.VectorResult,
+			in:         flux.NewSliceResultIterator(nil),
+			out:        `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+		},
+		{
+			name:       "Error",
+			resultType: promql.MatrixResult,
+			in:         &resultErrorIterator{Error: "expected"},
+			out:        `{"status":"error","errorType":"execution","error":"expected"}`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			// Add expected newline to end of output
+			tt.out += "\n"
+
+			var buf bytes.Buffer
+			enc := promql.NewMultiResultEncoder(tt.resultType, mustParseTime("2019-12-01T10:00:00Z"))
+			n, err := enc.Encode(&buf, tt.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got, exp := buf.String(), tt.out; got != exp {
+				t.Fatalf("unexpected output:\nexp=%s\ngot=%s", exp, got)
+			}
+			if g, w := n, int64(len(tt.out)); g != w {
+				t.Errorf("unexpected encoding count -want/+got:\n%s", cmp.Diff(w, g))
+			}
+		})
+	}
+}
+
+type resultErrorIterator struct {
+	Error string
+}
+
+func (*resultErrorIterator) Statistics() flux.Statistics {
+	return flux.Statistics{}
+}
+
+func (*resultErrorIterator) Release()          {}
+func (*resultErrorIterator) More() bool        { return false }
+func (*resultErrorIterator) Next() flux.Result { panic("no results") }
+
+func (ri *resultErrorIterator) Err() error {
+	return errors.New(ri.Error)
+}
+
+func mustParseTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// ts takes an RFC3339 time string and returns an execute.Time from it using the unix timestamp.
+func ts(s string) execute.Time {
+	return execute.Time(mustParseTime(s).UnixNano())
+}
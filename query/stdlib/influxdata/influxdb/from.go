@@ -4,11 +4,13 @@ import (
 	"fmt"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/flux/codes"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
 	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/query"
 )
 
 const FromKind = "influxDBFrom"
@@ -152,3 +154,101 @@ func (s *FromProcedureSpec) PostPhysicalValidate(id plan.NodeID) error {
 		Msg:  fmt.Sprintf("cannot submit unbounded read to %q; try bounding 'from' with a call to 'range'", bucket),
 	}
 }
+
+// restrictPredicate restricts the predicate of a read from a bucket to the data
+// the authorization of the query is allowed to read, when its permissions restrict the data of the bucket.
+func restrictPredicate(req *query.Request, orgID, bucketID platform.ID, predicate *semantic.FunctionExpression) (*semantic.FunctionExpression, error) {
+	if req.Authorization == nil {
+		return predicate, nil
+	}
+
+	p, err := platform.NewPermissionAtID(bucketID, platform.ReadAction, platform.BucketsResourceType, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := platform.AuthorizerDataRestrictions(req.Authorization, *p)
+	if rs == nil {
+		return predicate, nil
+	}
+
+	paramName := "r"
+	if predicate != nil {
+		paramName = predicate.Block.Parameters.List[0].Key.Name
+	}
+
+	restriction := dataRestrictionsExpr(paramName, rs)
+	if restriction == nil {
+		return predicate, nil
+	}
+
+	if predicate == nil {
+		return &semantic.FunctionExpression{
+			Block: &semantic.FunctionBlock{
+				Parameters: &semantic.FunctionParameters{
+					List: []*semantic.FunctionParameter{{Key: &semantic.Identifier{Name: paramName}}},
+				},
+				Body: restriction,
+			},
+		}, nil
+	}
+
+	restricted := predicate.Copy().(*semantic.FunctionExpression)
+	restricted.Block.Body = semantic.ExprsToConjunction(restricted.Block.Body.(semantic.Expression), restriction)
+	return restricted, nil
+}
+
+// dataRestrictionsExpr returns the predicate on the records named paramName that matches the data allowed by rs,
+// nil if rs allows all the data.
+func dataRestrictionsExpr(paramName string, rs platform.DataRestrictions) semantic.Expression {
+	var allowed []semantic.Expression
+	for _, r := range rs {
+		var exprs []semantic.Expression
+
+		var measurements []semantic.Expression
+		for _, m := range r.Measurements {
+			measurements = append(measurements, equalExpr(paramName, "_measurement", m))
+		}
+		if len(measurements) > 0 {
+			exprs = append(exprs, exprsToDisjunction(measurements...))
+		}
+
+		for _, t := range r.Tags {
+			exprs = append(exprs, equalExpr(paramName, t.Key, t.Value))
+		}
+
+		if len(exprs) == 0 {
+			// r allows all the data.
+			return nil
+		}
+		allowed = append(allowed, semantic.ExprsToConjunction(exprs...))
+	}
+	return exprsToDisjunction(allowed...)
+}
+
+func equalExpr(paramName, key, value string) semantic.Expression {
+	return &semantic.BinaryExpression{
+		Operator: ast.EqualOperator,
+		Left: &semantic.MemberExpression{
+			Object:   &semantic.IdentifierExpression{Name: paramName},
+			Property: key,
+		},
+		Right: &semantic.StringLiteral{Value: value},
+	}
+}
+
+func exprsToDisjunction(exprs ...semantic.Expression) semantic.Expression {
+	if len(exprs) == 0 {
+		return nil
+	}
+
+	expr := exprs[0]
+	for _, e := range exprs[1:] {
+		expr = &semantic.LogicalExpression{
+			Left:     expr,
+			Right:    e,
+			Operator: ast.OrOperator,
+		}
+	}
+	return expr
+}
@@ -0,0 +1,117 @@
+package influxdb
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/semantic/semantictest"
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/query"
+)
+
+func TestRestrictPredicate(t *testing.T) {
+	var (
+		orgID    = platform.ID(1)
+		bucketID = platform.ID(2)
+		otherID  = platform.ID(3)
+	)
+
+	fn := func(param string, body semantic.Expression) *semantic.FunctionExpression {
+		return &semantic.FunctionExpression{
+			Block: &semantic.FunctionBlock{
+				Parameters: &semantic.FunctionParameters{
+					List: []*semantic.FunctionParameter{{Key: &semantic.Identifier{Name: param}}},
+				},
+				Body: body,
+			},
+		}
+	}
+	auth := func(rs ...*platform.DataRestriction) *platform.Authorization {
+		a := &platform.Authorization{Status: platform.Active}
+		for _, r := range rs {
+			a.Permissions = append(a.Permissions, platform.Permission{
+				Action:      platform.ReadAction,
+				Resource:    platform.Resource{Type: platform.BucketsResourceType, OrgID: &orgID, ID: &bucketID},
+				Restriction: r,
+			})
+		}
+		return a
+	}
+
+	tests := []struct {
+		name      string
+		auth      *platform.Authorization
+		bucketID  platform.ID
+		predicate *semantic.FunctionExpression
+		want      *semantic.FunctionExpression
+	}{
+		{
+			name:     "no authorization",
+			bucketID: bucketID,
+		},
+		{
+			name:      "unrestricted permission",
+			auth:      auth(nil),
+			bucketID:  bucketID,
+			predicate: fn("r", equalExpr("r", "host", "a")),
+			want:      fn("r", equalExpr("r", "host", "a")),
+		},
+		{
+			name:     "restriction on another bucket",
+			auth:     auth(&platform.DataRestriction{Measurements: []string{"cpu"}}),
+			bucketID: otherID,
+		},
+		{
+			name:     "restricted measurements",
+			auth:     auth(&platform.DataRestriction{Measurements: []string{"cpu", "mem"}}),
+			bucketID: bucketID,
+			want: fn("r", &semantic.LogicalExpression{
+				Operator: ast.OrOperator,
+				Left:     equalExpr("r", "_measurement", "cpu"),
+				Right:    equalExpr("r", "_measurement", "mem"),
+			}),
+		},
+		{
+			name: "restricted measurements and tags of a filter",
+			auth: auth(
+				&platform.DataRestriction{Measurements: []string{"cpu"}, Tags: []platform.Tag{{Key: "team", Value: "a"}}},
+				&platform.DataRestriction{Tags: []platform.Tag{{Key: "team", Value: "b"}}},
+			),
+			bucketID:  bucketID,
+			predicate: fn("row", equalExpr("row", "host", "a")),
+			want: fn("row", &semantic.LogicalExpression{
+				Operator: ast.AndOperator,
+				Left:     equalExpr("row", "host", "a"),
+				Right: &semantic.LogicalExpression{
+					Operator: ast.OrOperator,
+					Left: &semantic.LogicalExpression{
+						Operator: ast.AndOperator,
+						Left:     equalExpr("row", "_measurement", "cpu"),
+						Right:    equalExpr("row", "team", "a"),
+					},
+					Right: equalExpr("row", "team", "b"),
+				},
+			}),
+		},
+		{
+			name:     "restricted and unrestricted permissions",
+			auth:     auth(&platform.DataRestriction{Measurements: []string{"cpu"}}, nil),
+			bucketID: bucketID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &query.Request{OrganizationID: orgID, Authorization: tt.auth}
+			got, err := restrictPredicate(req, orgID, tt.bucketID, tt.predicate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(tt.want, got, semantictest.CmpOptions...) {
+				t.Errorf("unexpected predicate -want/+got:\n%s", cmp.Diff(tt.want, got, semantictest.CmpOptions...))
+			}
+		})
+	}
+}
@@ -185,6 +185,10 @@ func createReadFilterSource(s plan.ProcedureSpec, id execute.DatasetID, a execut
 	if spec.FilterSet {
 		filter = spec.Filter
 	}
+	filter, err = restrictPredicate(req, orgID, bucketID, filter)
+	if err != nil {
+		return nil, err
+	}
 	return ReadFilterSource(
 		id,
 		deps.Reader,
@@ -262,6 +266,10 @@ func createReadGroupSource(s plan.ProcedureSpec, id execute.DatasetID, a execute
 	if spec.FilterSet {
 		filter = spec.Filter
 	}
+	filter, err = restrictPredicate(req, orgID, bucketID, filter)
+	if err != nil {
+		return nil, err
+	}
 	return ReadGroupSource(
 		id,
 		deps.Reader,
@@ -301,6 +309,10 @@ func createReadTagKeysSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID,
 	if spec.FilterSet {
 		filter = spec.Filter
 	}
+	filter, err = restrictPredicate(req, orgID, bucketID, filter)
+	if err != nil {
+		return nil, err
+	}
 
 	bounds := a.StreamContext().Bounds()
 	return ReadTagKeysSource(
@@ -370,6 +382,10 @@ func createReadTagValuesSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID
 	if spec.FilterSet {
 		filter = spec.Filter
 	}
+	filter, err = restrictPredicate(req, orgID, bucketID, filter)
+	if err != nil {
+		return nil, err
+	}
 
 	bounds := a.StreamContext().Bounds()
 	return ReadTagValuesSource(
@@ -0,0 +1,42 @@
+package influxdb
+
+import (
+	"context"
+	"io"
+)
+
+// RestoreService represents the data restore functions of InfluxDB.
+type RestoreService interface {
+	// RestoreBucket restores the TSM data of a bucket from a backup into a running instance.
+	// r is a tar archive of the TSM files and tombstone files of the backup.
+	RestoreBucket(ctx context.Context, req RestoreBucketRequest, r io.Reader) error
+}
+
+// RestoreBucketRequest identifies the bucket to restore from a backup and
+// the bucket the data is restored into.
+type RestoreBucketRequest struct {
+	// SourceOrgID and SourceBucketID identify the bucket in the backup.
+	SourceOrgID    ID `json:"sourceOrgID"`
+	SourceBucketID ID `json:"sourceBucketID"`
+
+	// OrgID and BucketID identify the bucket the data is restored into.
+	OrgID    ID `json:"orgID"`
+	BucketID ID `json:"bucketID"`
+}
+
+// Valid returns an error if the request is invalid.
+func (r RestoreBucketRequest) Valid() error {
+	if !r.SourceOrgID.Valid() || !r.SourceBucketID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "restore requires the organization and bucket of the backup",
+		}
+	}
+	if !r.OrgID.Valid() || !r.BucketID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "restore requires the organization and bucket to restore into",
+		}
+	}
+	return nil
+}
@@ -2,11 +2,18 @@ package influxdb
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 )
 
 // ErrScraperTargetNotFound is the error msg for a missing scraper target.
 const ErrScraperTargetNotFound = "scraper target not found"
 
+// ErrScraperStatusNotFound is the error msg for a scraper target that
+// wasn't scraped yet.
+const ErrScraperStatusNotFound = "scraper target status not found"
+
 // ops for ScraperTarget Store
 const (
 	OpListTargets   = "ListTargets"
@@ -24,6 +31,202 @@ type ScraperTarget struct {
 	URL      string      `json:"url"`
 	OrgID    ID          `json:"orgID,omitempty"`
 	BucketID ID          `json:"bucketID,omitempty"`
+	// Auth authenticates the scrape requests, it is not set if the
+	// target doesn't require authentication.
+	Auth *ScraperAuth `json:"auth,omitempty"`
+	// TLS is the TLS configuration of the scrape requests.
+	TLS *ScraperTLSConfig `json:"tls,omitempty"`
+	// Interval is the time between two scrapes of the target.
+	// The interval of the scheduler is used if it is zero.
+	Interval Duration `json:"interval"`
+	// Timeout is the maximum duration of a scrape of the target.
+	// The timeout of the scheduler is used if it is zero.
+	Timeout Duration `json:"timeout"`
+	// Labels are the static tags added to every collected point.
+	// They replace the tags of the same key read from the target.
+	Labels map[string]string `json:"labels,omitempty"`
+	// JSON maps the documents of a json target to points.
+	JSON *ScraperJSONConfig `json:"json,omitempty"`
+}
+
+// Scraper auth methods
+const (
+	// ScraperAuthBasic sends the username and the password secret
+	// with basic authentication.
+	ScraperAuthBasic = "basic"
+	// ScraperAuthBearer sends the token secret as a bearer token.
+	ScraperAuthBearer = "bearer"
+	// ScraperAuthHeader sends the secret as the value of a header.
+	ScraperAuthHeader = "header"
+)
+
+// ScraperAuth is the authentication of the scrape requests. The credentials
+// are secrets of the organization of the target.
+type ScraperAuth struct {
+	// Method is one of basic, bearer or header.
+	Method string `json:"method"`
+	// Username is the user of the basic authentication.
+	Username string `json:"username,omitempty"`
+	// Header is the name of the header set by the header method.
+	Header string `json:"header,omitempty"`
+	// SecretKey is the key of the secret that holds the password,
+	// the token or the header value.
+	SecretKey string `json:"secretKey"`
+}
+
+// ScraperTLSConfig is the TLS configuration of the scrape requests.
+type ScraperTLSConfig struct {
+	// CACert is the PEM encoded certificate of the CA that signed the
+	// certificate of the target. The system roots are used if it is empty.
+	CACert string `json:"caCert,omitempty"`
+	// Cert is the PEM encoded client certificate.
+	Cert string `json:"cert,omitempty"`
+	// KeySecretKey is the key of the secret that holds the PEM encoded
+	// private key of the client certificate.
+	KeySecretKey string `json:"keySecretKey,omitempty"`
+	// ServerName overrides the host name used to verify the certificate
+	// of the target.
+	ServerName string `json:"serverName,omitempty"`
+	// InsecureSkipVerify disables the verification of the certificate of
+	// the target.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// ScraperJSONConfig maps the values of the documents of a json target to
+// points. Paths are JSONPath expressions made of $, .key, ['key'], [n] and
+// [*] selectors, they are evaluated against each record.
+type ScraperJSONConfig struct {
+	// Records is the path of the records that become points, the whole
+	// document is the only record if it is empty.
+	Records string `json:"records,omitempty"`
+	// Measurement is the name of the measurement of the points, or the
+	// path of the name in the record if it starts with $.
+	Measurement string `json:"measurement"`
+	// Tags maps the tag keys to the paths of their values.
+	Tags map[string]string `json:"tags,omitempty"`
+	// Fields maps the field keys to the paths of their values.
+	Fields map[string]string `json:"fields"`
+	// Time is the path of the time of the points, either an RFC3339 string
+	// or a number of seconds since the epoch. The scrape time is used if it
+	// is empty.
+	Time string `json:"time,omitempty"`
+}
+
+// Valid returns an error if the scrape settings of the target are invalid.
+func (t ScraperTarget) Valid() error {
+	if !ValidScraperType(string(t.Type)) {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("invalid scraper target type %q", t.Type),
+		}
+	}
+	if t.Interval.Duration < 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "scraper target interval must not be negative",
+		}
+	}
+	if t.Timeout.Duration < 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "scraper target timeout must not be negative",
+		}
+	}
+	if a := t.Auth; a != nil {
+		switch a.Method {
+		case ScraperAuthBasic, ScraperAuthBearer:
+		case ScraperAuthHeader:
+			if a.Header == "" {
+				return &Error{
+					Code: EInvalid,
+					Msg:  "scraper target auth header is empty",
+				}
+			}
+		default:
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("invalid scraper target auth method %q", a.Method),
+			}
+		}
+		if a.SecretKey == "" {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "scraper target auth secret key is empty",
+			}
+		}
+	}
+	if c := t.TLS; c != nil && (c.Cert == "") != (c.KeySecretKey == "") {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "scraper target TLS client certificate requires both cert and keySecretKey",
+		}
+	}
+	for k := range t.Labels {
+		if k == "" {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "scraper target label key is empty",
+			}
+		}
+	}
+	if t.Type == JSONScraperType {
+		return t.JSON.valid()
+	}
+	return nil
+}
+
+func (c *ScraperJSONConfig) valid() error {
+	if c == nil {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "json scraper target requires a json config",
+		}
+	}
+	if c.Measurement == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "json scraper target measurement is empty",
+		}
+	}
+	if len(c.Fields) == 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "json scraper target requires at least one field",
+		}
+	}
+	var paths []string
+	for _, p := range []string{c.Records, c.Time} {
+		if p != "" {
+			paths = append(paths, p)
+		}
+	}
+	for k, p := range c.Tags {
+		if k == "" {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "json scraper target tag key is empty",
+			}
+		}
+		paths = append(paths, p)
+	}
+	for k, p := range c.Fields {
+		if k == "" {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "json scraper target field key is empty",
+			}
+		}
+		paths = append(paths, p)
+	}
+	for _, p := range paths {
+		if !strings.HasPrefix(p, "$") {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("json scraper target path %q must start with $", p),
+			}
+		}
+	}
+	return nil
 }
 
 // ScraperTargetStoreService defines the crud service for ScraperTarget.
@@ -37,6 +240,27 @@ type ScraperTargetStoreService interface {
 	UpdateTarget(ctx context.Context, t *ScraperTarget, userID ID) (*ScraperTarget, error)
 }
 
+// ScraperStatus is the result of the last scrape of a target.
+type ScraperStatus struct {
+	// LastScrape is the start time of the last scrape.
+	LastScrape time.Time `json:"lastScrape"`
+	// Duration is the time the last scrape took.
+	Duration Duration `json:"duration"`
+	// Samples is the number of values collected by the last scrape.
+	Samples int `json:"samples"`
+	// LastError is the error of the last scrape, it is empty if the
+	// scrape succeeded.
+	LastError string `json:"lastError,omitempty"`
+}
+
+// ScraperStatusService records the status of the scraper targets.
+type ScraperStatusService interface {
+	// GetTargetStatus returns the status of the last scrape of a target.
+	GetTargetStatus(ctx context.Context, id ID) (*ScraperStatus, error)
+	// PutTargetStatus records the status of the last scrape of a target.
+	PutTargetStatus(ctx context.Context, id ID, status *ScraperStatus) error
+}
+
 // ScraperTargetFilter represents a set of filter that restrict the returned results.
 type ScraperTargetFilter struct {
 	IDs   map[ID]bool `json:"ids"`
@@ -52,12 +276,18 @@ type ScraperType string
 const (
 	// PrometheusScraperType parses metrics from a prometheus endpoint.
 	PrometheusScraperType = "prometheus"
+	// OpenMetricsScraperType parses metrics from an OpenMetrics text endpoint.
+	OpenMetricsScraperType = "openmetrics"
+	// LineProtocolScraperType reads points from a line protocol endpoint.
+	LineProtocolScraperType = "lineprotocol"
+	// JSONScraperType maps the values of a json endpoint to points.
+	JSONScraperType = "json"
 )
 
 // ValidScraperType returns true is the type string is valid
 func ValidScraperType(s string) bool {
 	switch s {
-	case PrometheusScraperType:
+	case PrometheusScraperType, OpenMetricsScraperType, LineProtocolScraperType, JSONScraperType:
 		return true
 	default:
 		return false
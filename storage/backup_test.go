@@ -0,0 +1,326 @@
+package storage_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+)
+
+func TestEngine_CreateBackup(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	otherBucket := influxdb.ID(0x3333333333333333)
+	engine.MustWriteFloat(t, engine.org, engine.bucket, "A", 1, 1)
+	engine.MustWriteFloat(t, engine.org, otherBucket, "A", 1, 10)
+
+	dir := mustTempDir(t)
+	defer os.RemoveAll(dir)
+
+	manifest := engine.MustBackup(t, dir, influxdb.BackupFilter{OrgID: &engine.org, BucketID: &engine.bucket})
+	if exp, got := 1, len(manifest.Files); exp != got {
+		t.Fatalf("unexpected number of TSM files: got %d, exp %d", got, exp)
+	}
+	if exp, got := engine.bucket, *manifest.BucketID; exp != got {
+		t.Fatalf("unexpected bucket: got %s, exp %s", got, exp)
+	}
+
+	file := manifest.Files[0]
+	if exp, got := manifest.Name(), file.Manifest; exp != got {
+		t.Fatalf("unexpected manifest of file: got %s, exp %s", got, exp)
+	}
+	if exp, got := []string{influxdb.BackupFilePrefix(manifest.Name()) + file.FileName}, file.Files; !reflect.DeepEqual(exp, got) {
+		t.Fatalf("unexpected backup files: got %v, exp %v", got, exp)
+	}
+}
+
+func TestEngine_CreateBackup_Incremental(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	otherBucket := influxdb.ID(0x3333333333333333)
+	engine.MustWriteFloat(t, engine.org, engine.bucket, "A", 1, 1)
+	engine.MustWriteFloat(t, engine.org, otherBucket, "A", 1, 10)
+
+	dir := mustTempDir(t)
+	defer os.RemoveAll(dir)
+
+	full := engine.MustBackup(t, dir, influxdb.BackupFilter{})
+	if exp, got := 1, len(full.Files); exp != got {
+		t.Fatalf("unexpected number of TSM files: got %d, exp %d", got, exp)
+	}
+
+	// The file of the full backup is skipped by the incremental backup.
+	engine.MustWriteFloat(t, engine.org, engine.bucket, "A", 2, 2)
+	incremental := engine.MustBackup(t, dir, influxdb.BackupFilter{Since: full})
+	if exp, got := full.Name(), incremental.Parent; exp != got {
+		t.Fatalf("unexpected parent: got %s, exp %s", got, exp)
+	}
+	if exp, got := 2, len(incremental.Files); exp != got {
+		t.Fatalf("unexpected number of TSM files: got %d, exp %d", got, exp)
+	}
+	if exp, got := full.Files[0], incremental.Files[0]; !reflect.DeepEqual(exp, got) {
+		t.Fatalf("unexpected skipped file: got %+v, exp %+v", got, exp)
+	}
+	if exp, got := incremental.Name(), incremental.Files[1].Manifest; exp != got {
+		t.Fatalf("unexpected manifest of new file: got %s, exp %s", got, exp)
+	}
+
+	// A delete adds a tombstone to the file of the full backup, so the file is backed up
+	// again without overwriting the file of the full backup.
+	if err := engine.DeleteBucketRange(context.Background(), engine.org, otherBucket, math.MinInt64, math.MaxInt64); err != nil {
+		t.Fatal(err)
+	}
+	tombstoned := engine.MustBackup(t, dir, influxdb.BackupFilter{Since: incremental})
+	if exp, got := tombstoned.Name(), tombstoned.Files[0].Manifest; exp != got {
+		t.Fatalf("unexpected manifest of tombstoned file: got %s, exp %s", got, exp)
+	}
+	if exp, got := incremental.Files[1], tombstoned.Files[1]; !reflect.DeepEqual(exp, got) {
+		t.Fatalf("unexpected skipped file: got %+v, exp %+v", got, exp)
+	}
+
+	// All of the backups in the directory can still be restored.
+	for _, m := range []*influxdb.BackupManifest{full, incremental, tombstoned} {
+		for _, f := range m.Files {
+			for _, name := range f.Files {
+				if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+					t.Fatalf("file %s of backup %s: %v", name, m.Name(), err)
+				}
+			}
+		}
+	}
+}
+
+func TestEngine_RestoreBucket_Incremental(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	dir := mustTempDir(t)
+	defer os.RemoveAll(dir)
+
+	engine.MustWriteFloat(t, engine.org, engine.bucket, "A", 1, 1)
+	full := engine.MustBackup(t, dir, influxdb.BackupFilter{})
+	engine.MustWriteFloat(t, engine.org, engine.bucket, "A", 2, 2)
+	incremental := engine.MustBackup(t, dir, influxdb.BackupFilter{Since: full})
+
+	// Restore the chain of backups into a bucket of a new engine.
+	restored := NewDefaultEngine()
+	defer restored.Close()
+	restored.MustOpen()
+
+	var files []string
+	for _, f := range incremental.Files {
+		files = append(files, f.Files...)
+	}
+
+	newBucket := influxdb.ID(0x3434343434343434)
+	req := influxdb.RestoreBucketRequest{
+		SourceOrgID:    engine.org,
+		SourceBucketID: engine.bucket,
+		OrgID:          engine.org,
+		BucketID:       newBucket,
+	}
+	if err := restored.RestoreBucket(context.Background(), req, MustTarFiles(t, dir, files)); err != nil {
+		t.Fatal(err)
+	}
+
+	if exp, got := []float64{1, 2}, restored.MustReadFloats(t, engine.org, newBucket, "A"); !reflect.DeepEqual(exp, got) {
+		t.Fatalf("unexpected restored values: got %v, exp %v", got, exp)
+	}
+	if got := restored.MustReadFloats(t, engine.org, engine.bucket, "A"); len(got) != 0 {
+		t.Fatalf("unexpected values in source bucket: %v", got)
+	}
+}
+
+func TestEngine_RestoreBucket_IncrementalOverwrite(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	dir := mustTempDir(t)
+	defer os.RemoveAll(dir)
+
+	otherBucket := influxdb.ID(0x3333333333333333)
+	engine.MustWriteFloat(t, engine.org, engine.bucket, "A", 1, 1)
+	engine.MustWriteFloat(t, engine.org, otherBucket, "A", 1, 10)
+	full := engine.MustBackup(t, dir, influxdb.BackupFilter{})
+
+	// The point is overwritten in a newer file.
+	engine.MustWriteFloat(t, engine.org, engine.bucket, "A", 1, 2)
+	incremental := engine.MustBackup(t, dir, influxdb.BackupFilter{Since: full})
+
+	// The delete adds a tombstone to the older file, so the older file is backed up
+	// again by a later backup than the newer file.
+	if err := engine.DeleteBucketRange(context.Background(), engine.org, otherBucket, math.MinInt64, math.MaxInt64); err != nil {
+		t.Fatal(err)
+	}
+	tombstoned := engine.MustBackup(t, dir, influxdb.BackupFilter{Since: incremental})
+	if exp, got := tombstoned.Name(), tombstoned.Files[0].Manifest; exp != got {
+		t.Fatalf("unexpected manifest of tombstoned file: got %s, exp %s", got, exp)
+	}
+
+	restored := NewDefaultEngine()
+	defer restored.Close()
+	restored.MustOpen()
+
+	var files []string
+	for _, f := range tombstoned.Files {
+		files = append(files, f.Files...)
+	}
+
+	newBucket := influxdb.ID(0x3434343434343434)
+	req := influxdb.RestoreBucketRequest{
+		SourceOrgID:    engine.org,
+		SourceBucketID: engine.bucket,
+		OrgID:          engine.org,
+		BucketID:       newBucket,
+	}
+	if err := restored.RestoreBucket(context.Background(), req, MustTarFiles(t, dir, files)); err != nil {
+		t.Fatal(err)
+	}
+
+	// The value of the newer file replaces the value of the older file.
+	if exp, got := []float64{2}, restored.MustReadFloats(t, engine.org, newBucket, "A"); !reflect.DeepEqual(exp, got) {
+		t.Fatalf("unexpected restored values: got %v, exp %v", got, exp)
+	}
+}
+
+// floatTags returns the tags of the cpu series of the host with a float value field.
+func floatTags(host string) models.Tags {
+	return models.NewTags(map[string]string{
+		models.MeasurementTagKey: "cpu",
+		models.FieldKeyTagKey:    "value",
+		"host":                   host,
+	})
+}
+
+// MustWriteFloat writes a value of the cpu series of the host to the bucket.
+func (e *Engine) MustWriteFloat(t *testing.T, orgID, bucketID influxdb.ID, host string, ts int64, v float64) {
+	t.Helper()
+	pt := models.MustNewPoint(
+		tsdb.EncodeNameString(orgID, bucketID),
+		floatTags(host),
+		map[string]interface{}{"value": v},
+		time.Unix(0, ts),
+	)
+	if err := e.Engine.WritePoints(context.Background(), []models.Point{pt}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// MustReadFloats reads the values of the cpu series of the host in the bucket.
+func (e *Engine) MustReadFloats(t *testing.T, orgID, bucketID influxdb.ID, host string) []float64 {
+	t.Helper()
+	ctx := context.Background()
+	iter, err := e.CreateCursorIterator(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name := tsdb.EncodeName(orgID, bucketID)
+	cur, err := iter.Next(ctx, &cursors.CursorRequest{
+		Name:      name[:],
+		Tags:      floatTags(host),
+		Field:     "value",
+		Ascending: true,
+		StartTime: math.MinInt64,
+		EndTime:   math.MaxInt64,
+	})
+	if err != nil {
+		t.Fatal(err)
+	} else if cur == nil {
+		return nil
+	}
+	defer cur.Close()
+
+	var values []float64
+	fc := cur.(cursors.FloatArrayCursor)
+	for a := fc.Next(); a.Len() > 0; a = fc.Next() {
+		values = append(values, a.Values...)
+	}
+	return values
+}
+
+// MustBackup creates a backup with the filter, downloads the files to dir and
+// returns the manifest of the backup.
+func (e *Engine) MustBackup(t *testing.T, dir string, filter influxdb.BackupFilter) *influxdb.BackupManifest {
+	t.Helper()
+	ctx := context.Background()
+	id, files, err := e.CreateBackup(ctx, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var manifest *influxdb.BackupManifest
+	for _, name := range files {
+		var buf bytes.Buffer
+		if err := e.FetchBackupFile(ctx, id, name, &buf); err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			t.Fatalf("backup file %s overwrites an existing file", name)
+		}
+		if err := ioutil.WriteFile(path, buf.Bytes(), 0666); err != nil {
+			t.Fatal(err)
+		}
+
+		if strings.HasSuffix(name, influxdb.BackupManifestExtension) {
+			if manifest, err = influxdb.ReadBackupManifest(path); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if manifest == nil {
+		t.Fatal("backup has no manifest")
+	}
+	return manifest
+}
+
+// MustTarFiles returns a tar archive of the files in dir.
+func MustTarFiles(t *testing.T, dir string, files []string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, name := range files {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0666, Size: int64(len(data))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func mustTempDir(t *testing.T) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "storage-backup-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
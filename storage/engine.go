@@ -1,14 +1,17 @@
 package storage
 
 import (
+	"archive/tar"
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"math"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
@@ -656,6 +659,25 @@ func (e *Engine) DeleteBucketRangePredicate(ctx context.Context, orgID, bucketID
 	return e.deleteBucketRangeLocked(ctx, orgID, bucketID, min, max, pred)
 }
 
+// EstimateDeleteBucketRangePredicate estimates the data within a bucket that
+// DeleteBucketRangePredicate would delete with the same arguments. Nothing is deleted
+// and the delete is not added to the WAL.
+func (e *Engine) EstimateDeleteBucketRangePredicate(ctx context.Context, orgID, bucketID platform.ID, min, max int64, pred platform.Predicate) ([]platform.DeleteEstimate, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return nil, ErrEngineClosed
+	}
+
+	encoded := tsdb.EncodeName(orgID, bucketID)
+	name := models.EscapeMeasurement(encoded[:])
+
+	return e.engine.EstimateDeletePrefixRange(ctx, name, min, max, pred)
+}
+
 // deleteBucketRangeLocked does the work of deleting a bucket range and must be called under
 // some sort of lock.
 func (e *Engine) deleteBucketRangeLocked(ctx context.Context, orgID, bucketID platform.ID, min, max int64, pred tsm1.Predicate) error {
@@ -667,14 +689,22 @@ func (e *Engine) deleteBucketRangeLocked(ctx context.Context, orgID, bucketID pl
 	return e.engine.DeletePrefixRange(ctx, name, min, max, pred)
 }
 
-// CreateBackup creates a "snapshot" of all TSM data in the Engine.
+// CreateBackup creates a "snapshot" of the TSM data in the Engine that matches the filter.
 //   1) Snapshot the cache to ensure the backup includes all data written before now.
-//   2) Create hard links to all TSM files, in a new directory within the engine root directory.
-//   3) Return a unique backup ID (invalid after the process terminates) and list of files.
-func (e *Engine) CreateBackup(ctx context.Context) (int, []string, error) {
+//   2) Create hard links to the TSM files, in a new directory within the engine root directory.
+//      TSM files that contain data outside of the filter are rewritten with only the matching data.
+//      For an incremental backup, the TSM files in the previous backup are skipped.
+//   3) Prefix the names of the files with the name of the backup, and write a manifest
+//      describing the TSM data in the backup.
+//   4) Return a unique backup ID (invalid after the process terminates) and list of files.
+func (e *Engine) CreateBackup(ctx context.Context, filter influxdb.BackupFilter) (int, []string, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx)
 	defer span.Finish()
 
+	if err := filter.Valid(); err != nil {
+		return 0, nil, err
+	}
+
 	if e.closing == nil {
 		return 0, nil, ErrEngineClosed
 	}
@@ -683,23 +713,96 @@ func (e *Engine) CreateBackup(ctx context.Context) (int, []string, error) {
 		return 0, nil, err
 	}
 
-	id, snapshotPath, err := e.engine.FileStore.CreateSnapshot(ctx)
-	if err != nil {
-		return 0, nil, err
+	manifest := influxdb.BackupManifest{
+		Created:  time.Now().UTC(),
+		OrgID:    filter.OrgID,
+		BucketID: filter.BucketID,
+		Start:    filter.Start,
+		End:      filter.End,
+	}
+
+	snapshotFilter := tsm1.NewSnapshotFilter()
+	if filter.BucketID != nil {
+		encoded := tsdb.EncodeName(*filter.OrgID, *filter.BucketID)
+		snapshotFilter.Prefix = models.EscapeMeasurement(encoded[:])
+	} else if filter.OrgID != nil {
+		encoded := tsdb.EncodeOrgName(*filter.OrgID)
+		snapshotFilter.Prefix = models.EscapeMeasurement(encoded[:])
+	}
+	if filter.Start != nil {
+		snapshotFilter.MinTime = filter.Start.UnixNano()
+	}
+	if filter.End != nil {
+		snapshotFilter.MaxTime = filter.End.UnixNano()
 	}
 
-	fileInfos, err := ioutil.ReadDir(snapshotPath)
+	// An incremental backup skips the files that have not changed since the previous backup.
+	previous := make(map[string]influxdb.BackupManifestFile)
+	if filter.Since != nil {
+		manifest.Parent = filter.Since.Name()
+		for _, f := range filter.Since.Files {
+			previous[f.FileName] = f
+		}
+		snapshotFilter.Skip = func(file tsm1.SnapshotFile) bool {
+			f, ok := previous[file.Name]
+			return ok && f.Size == file.Size && f.TombstoneSize == file.TombstoneSize
+		}
+	}
+
+	id, snapshotPath, snapshot, err := e.engine.FileStore.CreateFilteredSnapshot(ctx, snapshotFilter)
 	if err != nil {
 		return 0, nil, err
 	}
-	filenames := make([]string, len(fileInfos))
-	for i, fi := range fileInfos {
-		filenames[i] = fi.Name()
+
+	// The files are renamed with the prefix of the backup, so they do not overwrite the
+	// files of previous backups when they are downloaded to the same directory.
+	prefix := influxdb.BackupFilePrefix(manifest.Name())
+	var filenames []string
+	for _, f := range snapshot {
+		file := influxdb.BackupManifestFile{
+			FileName:      f.Name,
+			Size:          f.Size,
+			TombstoneSize: f.TombstoneSize,
+			Manifest:      manifest.Name(),
+		}
+		if len(f.Files) == 0 {
+			// The file was skipped so the data is in the previous backup.
+			file.Files = previous[f.Name].Files
+			file.Manifest = previous[f.Name].Manifest
+		}
+		for _, name := range f.Files {
+			if err := os.Rename(filepath.Join(snapshotPath, name), filepath.Join(snapshotPath, prefix+name)); err != nil {
+				return 0, nil, multierr.Append(err, os.RemoveAll(snapshotPath))
+			}
+			file.Files = append(file.Files, prefix+name)
+			filenames = append(filenames, prefix+name)
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	if err := writeBackupManifest(filepath.Join(snapshotPath, manifest.Name()), &manifest); err != nil {
+		return 0, nil, multierr.Append(err, os.RemoveAll(snapshotPath))
 	}
+	filenames = append(filenames, manifest.Name())
 
 	return id, filenames, nil
 }
 
+// writeBackupManifest writes the manifest to path as JSON.
+func writeBackupManifest(path string, manifest *influxdb.BackupManifest) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return multierr.Append(err, f.Close())
+	}
+	return f.Close()
+}
+
 // FetchBackupFile writes a given backup file to the provided writer.
 // After a successful write, the internal copy is removed.
 func (e *Engine) FetchBackupFile(ctx context.Context, backupID int, backupFile string, w io.Writer) error {
@@ -770,6 +873,137 @@ func (e *Engine) InternalBackupPath(backupID int) string {
 	return e.engine.FileStore.InternalBackupPath(backupID)
 }
 
+// RestoreBucket imports the data of a bucket from the TSM files in a backup into the
+// running engine.
+//   1) Extract the TSM files and tombstone files in the tar archive r to a temporary
+//      directory within the engine directory.
+//   2) Import the keys of the source bucket in each TSM file into a new TSM file with the
+//      key prefix of the target bucket. Deleted data is not imported.
+//   3) Add the series of the imported keys to the index and series file.
+//
+// The restored data is merged with any existing data in the target bucket.
+func (e *Engine) RestoreBucket(ctx context.Context, req influxdb.RestoreBucketRequest, r io.Reader) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if err := req.Valid(); err != nil {
+		return err
+	}
+
+	// The files are extracted without holding the lock, so a slow upload of the
+	// archive does not block the engine.
+	dir, err := ioutil.TempDir(e.path, "restore")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	paths, err := extractRestoreFiles(dir, r)
+	if err != nil {
+		return err
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return ErrEngineClosed
+	}
+
+	src := tsdb.EncodeName(req.SourceOrgID, req.SourceBucketID)
+	dst := tsdb.EncodeName(req.OrgID, req.BucketID)
+	prefix, newPrefix := models.EscapeMeasurement(src[:]), models.EscapeMeasurement(dst[:])
+	for _, path := range paths {
+		if err := e.engine.ImportTSMFile(ctx, path, prefix, newPrefix); err != nil {
+			return errors.WithMessagef(err, "failed to restore TSM file %s", filepath.Base(path))
+		}
+	}
+
+	e.logger.Info("Restored bucket",
+		zap.String("source_org_id", req.SourceOrgID.String()),
+		zap.String("source_bucket_id", req.SourceBucketID.String()),
+		zap.String("org_id", req.OrgID.String()),
+		zap.String("bucket_id", req.BucketID.String()),
+		zap.Int("tsm_files", len(paths)))
+	return nil
+}
+
+// extractRestoreFiles writes the TSM files and tombstone files in the tar archive r to dir
+// and returns the paths of the TSM files in the order they were written by the engine of
+// the backup. Other files in the archive are ignored.
+func extractRestoreFiles(dir string, r io.Reader) ([]string, error) {
+	var paths []string
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "invalid restore archive",
+				Err:  err,
+			}
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// Only the base name is used so the files can not be written outside of dir.
+		name := filepath.Base(hdr.Name)
+		ext := filepath.Ext(name)
+		if ext != "."+tsm1.TSMFileExtension && ext != "."+tsm1.TombstoneFileExtension {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0666)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.Copy(f, tr); err != nil {
+			return nil, multierr.Append(err, f.Close())
+		}
+		if err := f.Close(); err != nil {
+			return nil, err
+		}
+
+		if ext == "."+tsm1.TSMFileExtension {
+			paths = append(paths, path)
+		}
+	}
+
+	// The files are imported in the order they were written by the engine of the backup,
+	// so the values of a newer file replace the values of an older file with the same time.
+	// The names begin with the prefix of the backup that wrote them, which is not the
+	// order of the files when an older file is backed up again by an incremental backup.
+	type restoreFile struct {
+		path                 string
+		generation, sequence int
+	}
+	files := make([]restoreFile, 0, len(paths))
+	for _, path := range paths {
+		generation, sequence, err := tsm1.DefaultParseFileName(influxdb.BackupEngineFileName(filepath.Base(path)))
+		if err != nil {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "invalid restore archive",
+				Err:  err,
+			}
+		}
+		files = append(files, restoreFile{path: path, generation: generation, sequence: sequence})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].generation != files[j].generation {
+			return files[i].generation < files[j].generation
+		}
+		return files[i].sequence < files[j].sequence
+	})
+	for i := range files {
+		paths[i] = files[i].path
+	}
+	return paths, nil
+}
+
 // SeriesCardinality returns the number of series in the engine.
 func (e *Engine) SeriesCardinality() int64 {
 	e.mu.RLock()
@@ -36,3 +36,15 @@ func (e *Engine) TagValues(ctx context.Context, orgID, bucketID influxdb.ID, tag
 
 	return e.engine.TagValues(ctx, orgID, bucketID, tagKey, start, end, predicate)
 }
+
+// MeasurementFields returns the data type of each field of measurement in the
+// given bucket that has data within the time range [start, end].
+func (e *Engine) MeasurementFields(ctx context.Context, orgID, bucketID influxdb.ID, measurement string, start, end int64) (map[string]influxql.DataType, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return nil, nil
+	}
+
+	return e.engine.MeasurementFields(ctx, orgID, bucketID, measurement, start, end)
+}
@@ -57,6 +57,7 @@ type Task struct {
 	Every           string                 `json:"every,omitempty"`
 	Cron            string                 `json:"cron,omitempty"`
 	Offset          time.Duration          `json:"offset,omitempty"`
+	UpstreamID      ID                     `json:"upstreamID,omitempty"` // UpstreamID is the task whose successful runs trigger the runs of this task
 	LatestCompleted time.Time              `json:"latestCompleted,omitempty"`
 	LatestScheduled time.Time              `json:"latestScheduled,omitempty"`
 	LastRunStatus   string                 `json:"lastRunStatus,omitempty"`
@@ -83,15 +84,17 @@ func (t *Task) EffectiveCron() string {
 
 // Run is a record createId when a run of a task is scheduled.
 type Run struct {
-	ID           ID        `json:"id,omitempty"`
-	TaskID       ID        `json:"taskID"`
-	Status       string    `json:"status"`
-	ScheduledFor time.Time `json:"scheduledFor"`          // ScheduledFor is the Now time used in the task's query
-	RunAt        time.Time `json:"runAt"`                 // RunAt is the time the task is scheduled to be run, which is ScheduledFor + Offset
-	StartedAt    time.Time `json:"startedAt,omitempty"`   // StartedAt is the time the executor begins running the task
-	FinishedAt   time.Time `json:"finishedAt,omitempty"`  // FinishedAt is the time the executor finishes running the task
-	RequestedAt  time.Time `json:"requestedAt,omitempty"` // RequestedAt is the time the coordinator told the scheduler to schedule the task
-	Log          []Log     `json:"log,omitempty"`
+	ID            ID        `json:"id,omitempty"`
+	TaskID        ID        `json:"taskID"`
+	Status        string    `json:"status"`
+	ScheduledFor  time.Time `json:"scheduledFor"`            // ScheduledFor is the Now time used in the task's query
+	RunAt         time.Time `json:"runAt"`                   // RunAt is the time the task is scheduled to be run, which is ScheduledFor + Offset
+	StartedAt     time.Time `json:"startedAt,omitempty"`     // StartedAt is the time the executor begins running the task
+	FinishedAt    time.Time `json:"finishedAt,omitempty"`    // FinishedAt is the time the executor finishes running the task
+	RequestedAt   time.Time `json:"requestedAt,omitempty"`   // RequestedAt is the time the coordinator told the scheduler to schedule the task
+	UpstreamRunID ID        `json:"upstreamRunID,omitempty"` // UpstreamRunID is the successful run of the upstream task that triggered this run
+	Attempts      int       `json:"attempts,omitempty"`      // Attempts is the number of times the executor has started running the task
+	Log           []Log     `json:"log,omitempty"`
 }
 
 // Log represents a link to a log resource
@@ -144,6 +147,84 @@ type TaskService interface {
 	ForceRun(ctx context.Context, taskID ID, scheduledFor int64) (*Run, error)
 }
 
+// Backfill statuses.
+const (
+	BackfillRunning   = "running"
+	BackfillCompleted = "completed"
+	BackfillFailed    = "failed"
+	BackfillCanceled  = "canceled"
+)
+
+// TaskBackfill is the progress of running a task for every time
+// it is scheduled for in a time range.
+type TaskBackfill struct {
+	ID     ID        `json:"id"`
+	TaskID ID        `json:"taskID"`
+	Start  time.Time `json:"start"`
+	Stop   time.Time `json:"stop"`
+	Status string    `json:"status"`
+	// Total is the number of times the task is scheduled for in the range.
+	Total int `json:"total"`
+	// Queued is the number of runs queued so far, finished ones included.
+	Queued int `json:"queued"`
+	// Skipped is the number of scheduled times that already had a queued run.
+	Skipped    int       `json:"skipped"`
+	Succeeded  int       `json:"succeeded"`
+	Failed     int       `json:"failed"`
+	Canceled   int       `json:"canceled"`
+	Error      string    `json:"error,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+	FinishedAt time.Time `json:"finishedAt,omitempty"`
+}
+
+// Done returns true if the backfill does not queue or wait for runs anymore.
+func (b *TaskBackfill) Done() bool {
+	return b.Status != BackfillRunning
+}
+
+// TaskBackfillService runs tasks over historical time ranges.
+type TaskBackfillService interface {
+	// BackfillTask starts queueing a run of the task for every time in [start, stop]
+	// the task is scheduled for, and returns the progress of the backfill.
+	BackfillTask(ctx context.Context, taskID ID, start, stop time.Time) (*TaskBackfill, error)
+
+	// FindBackfillByID returns the progress of a backfill of a task.
+	FindBackfillByID(ctx context.Context, taskID, backfillID ID) (*TaskBackfill, error)
+
+	// CancelBackfill stops queueing the runs of a backfill and cancels its unfinished runs.
+	CancelBackfill(ctx context.Context, taskID, backfillID ID) error
+}
+
+// TaskStats are statistics about the finished runs of a task that started in a window of time.
+type TaskStats struct {
+	TaskID ID        `json:"taskID"`
+	Start  time.Time `json:"start"`
+	Stop   time.Time `json:"stop"`
+	// Runs is the number of finished runs that started in the window.
+	Runs      int `json:"runs"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	Canceled  int `json:"canceled"`
+	// SuccessRate is the fraction of the runs that succeeded, 0 without runs.
+	SuccessRate float64 `json:"successRate"`
+	// Duration is the time between the runs starting and finishing.
+	Duration TaskStatsPercentiles `json:"duration"`
+	// Lag is the time between the times the runs are scheduled for and their start.
+	Lag TaskStatsPercentiles `json:"lag"`
+}
+
+// TaskStatsPercentiles are percentiles of a duration, in seconds.
+type TaskStatsPercentiles struct {
+	P50 float64 `json:"p50"`
+	P95 float64 `json:"p95"`
+}
+
+// TaskStatsService computes statistics about the run history of tasks.
+type TaskStatsService interface {
+	// FindTaskStats computes statistics over the finished runs of a task that started in [start, stop).
+	FindTaskStats(ctx context.Context, taskID ID, start, stop time.Time) (*TaskStats, error)
+}
+
 // TaskCreate is the set of values to create a task.
 type TaskCreate struct {
 	Type           string                 `json:"type,omitempty"`
@@ -418,6 +499,8 @@ type TaskFilter struct {
 	User           *ID
 	Limit          int
 	Status         *string
+	// Upstream only matches the tasks that run after the task with this ID.
+	Upstream *ID
 }
 
 // QueryParams Converts TaskFilter fields to url query params.
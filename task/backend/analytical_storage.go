@@ -21,6 +21,8 @@ const (
 	startedAtField    = "startedAt"
 	finishedAtField   = "finishedAt"
 	requestedAtField  = "requestedAt"
+	upstreamRunField  = "upstreamRunID"
+	attemptsField     = "attempts"
 	logField          = "logs"
 
 	taskIDTag = "taskID"
@@ -152,9 +154,8 @@ func (as *AnalyticalStorage) FindRuns(ctx context.Context, filter influxdb.RunFi
 		filterPart = fmt.Sprintf(`|> filter(fn: (r) => r.runID > %q)`, filter.After.String())
 	}
 
-	// the data will be stored for 7 days in the system bucket so pulling 14d's is sufficient.
 	runsScript := fmt.Sprintf(`from(bucketID: %q)
-	  |> range(start: -14d)
+	  |> range(start: %s)
 	  |> filter(fn: (r) => r._field != "status")
 	  |> filter(fn: (r) => r._measurement == "runs" and r.taskID == %q)
 	  %s
@@ -163,27 +164,11 @@ func (as *AnalyticalStorage) FindRuns(ctx context.Context, filter influxdb.RunFi
 	  |> sort(columns:["scheduledFor"], desc: true)
 	  |> limit(n:%d)
 
-	  `, sb.ID.String(), filter.Task.String(), filterPart, filter.Limit-len(runs))
+	  `, sb.ID.String(), runHistoryStart(sb), filter.Task.String(), filterPart, filter.Limit-len(runs))
 
 	// At this point we are behind authorization
 	// so we are faking a read only permission to the org's system bucket
-	runSystemBucketID := sb.ID
-	runAuth := &influxdb.Authorization{
-		Status: influxdb.Active,
-		ID:     sb.ID,
-		OrgID:  task.OrganizationID,
-		Permissions: []influxdb.Permission{
-			{
-				Action: influxdb.ReadAction,
-				Resource: influxdb.Resource{
-					Type:  influxdb.BucketsResourceType,
-					OrgID: &task.OrganizationID,
-					ID:    &runSystemBucketID,
-				},
-			},
-		},
-	}
-	request := &query.Request{Authorization: runAuth, OrganizationID: task.OrganizationID, Compiler: lang.FluxCompiler{Query: runsScript}}
+	request := &query.Request{Authorization: systemBucketAuth(task.OrganizationID, sb.ID), OrganizationID: task.OrganizationID, Compiler: lang.FluxCompiler{Query: runsScript}}
 
 	ittr, err := as.qs.Query(ctx, request)
 	if err != nil {
@@ -207,6 +192,37 @@ func (as *AnalyticalStorage) FindRuns(ctx context.Context, filter influxdb.RunFi
 	return runs, len(runs), err
 }
 
+// runHistoryStart returns the start of the range to query the run history in the org's system bucket sb from.
+// The retention period of the system bucket is the retention period of the run history of the org.
+func runHistoryStart(sb *influxdb.Bucket) string {
+	if sb.RetentionPeriod == influxdb.InfiniteRetention {
+		return "1970-01-01T00:00:00Z"
+	}
+	// points outlive the retention period until their whole shard group expires,
+	// so pulling twice the retention period is sufficient.
+	return fmt.Sprintf("-%ds", int64(2*sb.RetentionPeriod/time.Second))
+}
+
+// systemBucketAuth returns a read only permission to the org's system bucket,
+// for querying the bucket from behind authorization.
+func systemBucketAuth(orgID, bucketID influxdb.ID) *influxdb.Authorization {
+	return &influxdb.Authorization{
+		Status: influxdb.Active,
+		ID:     bucketID,
+		OrgID:  orgID,
+		Permissions: []influxdb.Permission{
+			{
+				Action: influxdb.ReadAction,
+				Resource: influxdb.Resource{
+					Type:  influxdb.BucketsResourceType,
+					OrgID: &orgID,
+					ID:    &bucketID,
+				},
+			},
+		},
+	}
+}
+
 // remove any kv runs that exist in the list of completed runs
 func (as *AnalyticalStorage) combineRuns(currentRuns, completeRuns []*influxdb.Run) []*influxdb.Run {
 	crMap := map[influxdb.ID]int{}
@@ -251,35 +267,18 @@ func (as *AnalyticalStorage) FindRunByID(ctx context.Context, taskID, runID infl
 		return run, err
 	}
 
-	// the data will be stored for 7 days in the system bucket so pulling 14d's is sufficient.
 	findRunScript := fmt.Sprintf(`from(bucketID: %q)
-	|> range(start: -14d)
+	|> range(start: %s)
 	|> filter(fn: (r) => r._field != "status")
 	|> filter(fn: (r) => r._measurement == "runs" and r.taskID == %q)
 	|> pivot(rowKey:["_time"], columnKey: ["_field"], valueColumn: "_value")
 	|> group(columns: ["taskID"])
 	|> filter(fn: (r) => r.runID == %q)
-	  `, sb.ID.String(), taskID.String(), runID.String())
+	  `, sb.ID.String(), runHistoryStart(sb), taskID.String(), runID.String())
 
 	// At this point we are behind authorization
 	// so we are faking a read only permission to the org's system bucket
-	runSystemBucketID := sb.ID
-	runAuth := &influxdb.Authorization{
-		ID:     sb.ID,
-		Status: influxdb.Active,
-		OrgID:  task.OrganizationID,
-		Permissions: []influxdb.Permission{
-			{
-				Action: influxdb.ReadAction,
-				Resource: influxdb.Resource{
-					Type:  influxdb.BucketsResourceType,
-					OrgID: &task.OrganizationID,
-					ID:    &runSystemBucketID,
-				},
-			},
-		},
-	}
-	request := &query.Request{Authorization: runAuth, OrganizationID: task.OrganizationID, Compiler: lang.FluxCompiler{Query: findRunScript}}
+	request := &query.Request{Authorization: systemBucketAuth(task.OrganizationID, sb.ID), OrganizationID: task.OrganizationID, Compiler: lang.FluxCompiler{Query: findRunScript}}
 
 	ittr, err := as.qs.Query(ctx, request)
 	if err != nil {
@@ -399,6 +398,19 @@ func (re *runReader) readRuns(cr flux.ColReader) error {
 					continue
 				}
 				r.FinishedAt = finished.UTC()
+			case upstreamRunField:
+				if cr.Strings(j).ValueString(i) != "" {
+					id, err := influxdb.IDFromString(cr.Strings(j).ValueString(i))
+					if err != nil {
+						re.log.Info("Failed to parse upstreamRunID", zap.Error(err))
+						continue
+					}
+					r.UpstreamRunID = *id
+				}
+			case attemptsField:
+				if col.Type == flux.TInt && cr.Ints(j).IsValid(i) {
+					r.Attempts = int(cr.Ints(j).Value(i))
+				}
 			case logField:
 				logBytes := bytes.TrimSpace(cr.Strings(j).Value(i))
 				if len(logBytes) != 0 {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io/ioutil"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 
@@ -109,6 +110,80 @@ func TestDeduplicateRuns(t *testing.T) {
 	}
 }
 
+func TestFindTaskStats(t *testing.T) {
+	svc := kv.NewService(zaptest.NewLogger(t), inmem.NewKVStore())
+	if err := svc.Initialize(context.Background()); err != nil {
+		t.Fatalf("error initializing kv service: %v", err)
+	}
+
+	ab := newAnalyticalBackend(t, svc, svc)
+	defer ab.Close(t)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	run := func(id influxdb.ID, status string, scheduledFor time.Time, lag, duration time.Duration) *influxdb.Run {
+		return &influxdb.Run{
+			ID:           id,
+			TaskID:       1,
+			Status:       status,
+			ScheduledFor: scheduledFor,
+			StartedAt:    scheduledFor.Add(lag),
+			FinishedAt:   scheduledFor.Add(lag + duration),
+		}
+	}
+	runs := []*influxdb.Run{
+		run(2, "success", now.Add(-10*time.Minute), time.Second, 2*time.Second),
+		run(3, "success", now.Add(-9*time.Minute), 2*time.Second, 4*time.Second),
+		run(4, "failed", now.Add(-8*time.Minute), 3*time.Second, 6*time.Second),
+		run(5, "success", now.Add(-7*time.Minute), 4*time.Second, 8*time.Second),
+		// started before the window.
+		run(6, "failed", now.Add(-2*time.Hour), time.Second, time.Second),
+	}
+
+	mockTS := &mock.TaskService{
+		FindTaskByIDFn: func(context.Context, influxdb.ID) (*influxdb.Task, error) {
+			return &influxdb.Task{ID: 1, OrganizationID: 20}, nil
+		},
+	}
+	mockTCS := &mock.TaskControlService{
+		FinishRunFn: func(ctx context.Context, taskID, runID influxdb.ID) (*influxdb.Run, error) {
+			return runs[runID-2], nil
+		},
+	}
+	mockBS := mock.NewBucketService()
+
+	svcStack := backend.NewAnalyticalStorage(zaptest.NewLogger(t), mockTS, mockBS, mockTCS, ab.PointsWriter(), ab.QueryService())
+
+	for _, r := range runs {
+		if _, err := svcStack.FinishRun(context.Background(), 1, r.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stats, err := svcStack.FindTaskStats(context.Background(), 1, now.Add(-time.Hour), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := &influxdb.TaskStats{
+		TaskID:      1,
+		Start:       now.Add(-time.Hour),
+		Stop:        now,
+		Runs:        4,
+		Succeeded:   3,
+		Failed:      1,
+		SuccessRate: 0.75,
+		Duration:    influxdb.TaskStatsPercentiles{P50: 4, P95: 8},
+		Lag:         influxdb.TaskStatsPercentiles{P50: 2, P95: 4},
+	}
+	if !reflect.DeepEqual(stats, exp) {
+		t.Fatalf("unexpected task stats, got %+v, want %+v", stats, exp)
+	}
+
+	if _, err := svcStack.FindTaskStats(context.Background(), 1, now, now); err == nil {
+		t.Fatal("expected an error for an empty window")
+	}
+}
+
 type analyticalBackend struct {
 	queryController *control.Controller
 	rootDir         string
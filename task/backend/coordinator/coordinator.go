@@ -21,14 +21,21 @@ const DefaultLimit = 1000
 // Executor is an abstraction of the task executor with only the functions needed by the coordinator
 type Executor interface {
 	ManualRun(ctx context.Context, id influxdb.ID, runID influxdb.ID) (executor.Promise, error)
+	DownstreamRun(ctx context.Context, id influxdb.ID, upstream *influxdb.Run) (executor.Promise, error)
 	Cancel(ctx context.Context, runID influxdb.ID) error
 }
 
+// TaskFinder is an abstraction of the task service with only the functions needed by the coordinator
+type TaskFinder interface {
+	FindTasks(ctx context.Context, filter influxdb.TaskFilter) ([]*influxdb.Task, int, error)
+}
+
 // Coordinator is the intermediary between the scheduling/executing system and the rest of the task system
 type Coordinator struct {
 	log *zap.Logger
 	sch scheduler.Scheduler
 	ex  Executor
+	ts  TaskFinder
 
 	limit int
 }
@@ -67,6 +74,14 @@ func WithLimitOpt(i int) CoordinatorOption {
 	}
 }
 
+// WithTaskFinderOpt sets the task finder the coordinator finds the tasks that run after a succeeded run with.
+// Without it, succeeded runs do not trigger the runs of other tasks.
+func WithTaskFinderOpt(ts TaskFinder) CoordinatorOption {
+	return func(c *Coordinator) {
+		c.ts = ts
+	}
+}
+
 // NewSchedulableTask transforms an influxdb task to a schedulable task type
 func NewSchedulableTask(task *influxdb.Task) (SchedulableTask, error) {
 
@@ -112,6 +127,12 @@ func (c *Coordinator) TaskCreated(ctx context.Context, task *influxdb.Task) erro
 	if err != nil {
 		return err
 	}
+
+	// a task that runs after another task is triggered by the runs of the other task instead
+	if task.UpstreamID.Valid() {
+		return c.release(t.ID())
+	}
+
 	// func new schedulable task
 	// catch errors from offset and last scheduled
 	if err = c.sch.Schedule(t); err != nil {
@@ -129,9 +150,9 @@ func (c *Coordinator) TaskUpdated(ctx context.Context, from, to *influxdb.Task)
 		return err
 	}
 
-	// if disabling the task, release it before schedule update
-	if to.Status != from.Status && to.Status == string(influxdb.TaskInactive) {
-		if err := c.sch.Release(sid); err != nil && err != influxdb.ErrTaskNotClaimed {
+	// if disabling the task or making it run after another task, release it before schedule update
+	if (to.Status != from.Status && to.Status == string(influxdb.TaskInactive)) || to.UpstreamID.Valid() {
+		if err := c.release(sid); err != nil {
 			return err
 		}
 	} else {
@@ -145,8 +166,11 @@ func (c *Coordinator) TaskUpdated(ctx context.Context, from, to *influxdb.Task)
 
 //TaskDeleted asks the Scheduler to release the deleted task
 func (c *Coordinator) TaskDeleted(ctx context.Context, id influxdb.ID) error {
-	tid := scheduler.ID(id)
-	if err := c.sch.Release(tid); err != nil && err != influxdb.ErrTaskNotClaimed {
+	return c.release(scheduler.ID(id))
+}
+
+func (c *Coordinator) release(id scheduler.ID) error {
+	if err := c.sch.Release(id); err != nil && err != influxdb.ErrTaskNotClaimed {
 		return err
 	}
 
@@ -189,3 +213,62 @@ func (c *Coordinator) RunForced(ctx context.Context, task *influxdb.Task, run *i
 
 	return nil
 }
+
+// RunSucceeded asks the Executor to run the active tasks that run after the task of the succeeded run,
+// if they are scheduled for the scheduled for time of the run. It does not wait for their runs to finish.
+func (c *Coordinator) RunSucceeded(ctx context.Context, task *influxdb.Task, run *influxdb.Run) {
+	if c.ts == nil {
+		return
+	}
+
+	active := string(influxdb.TaskActive)
+	filter := influxdb.TaskFilter{
+		OrganizationID: &task.OrganizationID,
+		Upstream:       &task.ID,
+		Status:         &active,
+		Limit:          influxdb.TaskMaxPageSize,
+	}
+	for {
+		tasks, _, err := c.ts.FindTasks(ctx, filter)
+		if err != nil {
+			c.log.Error("Failed to find downstream tasks", zap.String("taskID", task.ID.String()), zap.Error(err))
+			return
+		}
+
+		for _, t := range tasks {
+			ok, err := isScheduledFor(t, run.ScheduledFor)
+			if err != nil {
+				c.log.Error("Failed to parse downstream task schedule", zap.String("taskID", t.ID.String()), zap.Error(err))
+				continue
+			}
+			if !ok {
+				continue
+			}
+			if _, err := c.ex.DownstreamRun(ctx, t.ID, run); err != nil {
+				c.log.Error("Failed to run downstream task",
+					zap.String("taskID", t.ID.String()),
+					zap.String("upstreamRunID", run.ID.String()),
+					zap.Error(err))
+			}
+		}
+
+		if len(tasks) < filter.Limit {
+			return
+		}
+		filter.After = &tasks[len(tasks)-1].ID
+	}
+}
+
+// isScheduledFor returns true if the schedule of the task includes the time.
+func isScheduledFor(task *influxdb.Task, scheduledFor time.Time) (bool, error) {
+	// the schedule starts after the given time, so start a second early to include scheduledFor.
+	sch, from, err := scheduler.NewSchedule(task.EffectiveCron(), scheduledFor.Add(-time.Second))
+	if err != nil {
+		return false, err
+	}
+	next, err := sch.Next(from)
+	if err != nil {
+		return false, err
+	}
+	return next.Equal(scheduledFor), nil
+}
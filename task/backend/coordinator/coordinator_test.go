@@ -143,6 +143,14 @@ func Test_Coordinator_Scheduler_Methods(t *testing.T) {
 			CreatedAt: now,
 			Cron:      "* * * * *",
 		}
+		taskThreeDownstream = &influxdb.Task{
+			ID:         three,
+			Status:     "active",
+			Name:       "Renamed",
+			CreatedAt:  now,
+			Cron:       "* * * * *",
+			UpstreamID: one,
+		}
 	)
 
 	schedulableT, err := NewSchedulableTask(taskOne)
@@ -186,6 +194,19 @@ func Test_Coordinator_Scheduler_Methods(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "TaskCreated - runs after another task",
+			call: func(t *testing.T, c *Coordinator) {
+				if err := c.TaskCreated(context.Background(), taskThreeDownstream); err != nil {
+					t.Errorf("expected nil error found %q", err)
+				}
+			},
+			scheduler: &schedulerC{
+				calls: []interface{}{
+					releaseCallC{scheduler.ID(taskThreeDownstream.ID)},
+				},
+			},
+		},
 		{
 			name: "TaskUpdated - deactivate task",
 			call: func(t *testing.T, c *Coordinator) {
@@ -225,6 +246,19 @@ func Test_Coordinator_Scheduler_Methods(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "TaskUpdated - run after another task",
+			call: func(t *testing.T, c *Coordinator) {
+				if err := c.TaskUpdated(context.Background(), taskThreeNew, taskThreeDownstream); err != nil {
+					t.Errorf("expected nil error found %q", err)
+				}
+			},
+			scheduler: &schedulerC{
+				calls: []interface{}{
+					releaseCallC{scheduler.ID(taskThreeDownstream.ID)},
+				},
+			},
+		},
 		{
 			name: "TaskDeleted",
 			call: func(t *testing.T, c *Coordinator) {
@@ -259,3 +293,56 @@ func Test_Coordinator_Scheduler_Methods(t *testing.T) {
 		})
 	}
 }
+
+func Test_Coordinator_RunSucceeded(t *testing.T) {
+	var (
+		one   = influxdb.ID(1)
+		two   = influxdb.ID(2)
+		three = influxdb.ID(3)
+		org   = influxdb.ID(10)
+
+		taskOne   = &influxdb.Task{ID: one, OrganizationID: org, Status: "active", Every: "1m"}
+		taskTwo   = &influxdb.Task{ID: two, OrganizationID: org, Status: "active", Every: "1m", UpstreamID: one}
+		taskThree = &influxdb.Task{ID: three, OrganizationID: org, Status: "active", Every: "1h", UpstreamID: one}
+	)
+
+	for _, test := range []struct {
+		name         string
+		scheduledFor time.Time
+		calls        []interface{}
+	}{
+		{
+			name:         "triggers the tasks scheduled for the run",
+			scheduledFor: time.Date(2019, 11, 1, 10, 0, 0, 0, time.UTC),
+			calls: []interface{}{
+				downstreamRunCall{taskTwo.ID, 100},
+				downstreamRunCall{taskThree.ID, 100},
+			},
+		},
+		{
+			name:         "skips the tasks not scheduled for the run",
+			scheduledFor: time.Date(2019, 11, 1, 10, 30, 0, 0, time.UTC),
+			calls: []interface{}{
+				downstreamRunCall{taskTwo.ID, 100},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				executor = &executorE{}
+				finder   = &taskFinder{tasks: []*influxdb.Task{taskOne, taskTwo, taskThree}}
+				coord    = NewCoordinator(zaptest.NewLogger(t), &schedulerC{}, executor, WithTaskFinderOpt(finder))
+			)
+
+			coord.RunSucceeded(context.Background(), taskOne, &influxdb.Run{
+				ID:           100,
+				TaskID:       one,
+				ScheduledFor: test.scheduledFor,
+			})
+
+			if diff := cmp.Diff(test.calls, executor.calls); diff != "" {
+				t.Errorf("unexpected executor contents %s", diff)
+			}
+		})
+	}
+}
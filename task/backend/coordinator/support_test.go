@@ -20,6 +20,11 @@ type (
 		RunID  influxdb.ID
 	}
 
+	downstreamRunCall struct {
+		TaskID        influxdb.ID
+		UpstreamRunID influxdb.ID
+	}
+
 	cancelCallC struct {
 		RunID influxdb.ID
 	}
@@ -41,6 +46,25 @@ type (
 	}
 )
 
+// taskFinder matches the upstream and status of the task filter against its tasks.
+type taskFinder struct {
+	tasks []*influxdb.Task
+}
+
+func (f *taskFinder) FindTasks(ctx context.Context, filter influxdb.TaskFilter) ([]*influxdb.Task, int, error) {
+	var tasks []*influxdb.Task
+	for _, t := range f.tasks {
+		if filter.Upstream != nil && t.UpstreamID != *filter.Upstream {
+			continue
+		}
+		if filter.Status != nil && t.Status != *filter.Status {
+			continue
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, len(tasks), nil
+}
+
 type (
 	promise struct {
 		run *influxdb.Run
@@ -108,6 +132,19 @@ func (e *executorE) ManualRun(ctx context.Context, id influxdb.ID, runID influxd
 	return &p, err
 }
 
+func (e *executorE) DownstreamRun(ctx context.Context, id influxdb.ID, upstream *influxdb.Run) (executor.Promise, error) {
+	e.calls = append(e.calls, downstreamRunCall{id, upstream.ID})
+	ctx, cancel := context.WithCancel(ctx)
+	p := promise{
+		done:       make(chan struct{}),
+		ctx:        ctx,
+		cancelFunc: cancel,
+	}
+	close(p.done)
+
+	return &p, nil
+}
+
 func (e *executorE) Cancel(ctx context.Context, runID influxdb.ID) error {
 	e.calls = append(e.calls, cancelCallC{runID})
 	return nil
@@ -0,0 +1,284 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/snowflake"
+	"github.com/influxdata/influxdb/task/backend/scheduler"
+	"github.com/influxdata/influxdb/task/options"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxBackfillRuns is the largest number of runs a single backfill may queue.
+	maxBackfillRuns = 10000
+
+	// finishedBackfillRetention is how long the progress of a finished backfill is kept.
+	finishedBackfillRetention = time.Hour
+)
+
+var _ influxdb.TaskBackfillService = (*Backfiller)(nil)
+
+// Backfiller queues a run of a task for every time the task is scheduled for in a time range.
+// A backfill has at most as many unfinished runs as the concurrency option of the task allows,
+// and each of its runs passes the limit funcs of the executor like any other run.
+// The progress of backfills is kept in memory.
+type Backfiller struct {
+	log   *zap.Logger
+	ts    influxdb.TaskService
+	ex    *Executor
+	idGen influxdb.IDGenerator
+
+	mu        sync.Mutex
+	backfills map[influxdb.ID]*backfill
+}
+
+// NewBackfiller creates a new Backfiller. The manual runs of the backfills are created with ts,
+// which must not hand them to a coordinator, as the backfiller passes them to ex itself.
+func NewBackfiller(log *zap.Logger, ts influxdb.TaskService, ex *Executor) *Backfiller {
+	return &Backfiller{
+		log:       log,
+		ts:        ts,
+		ex:        ex,
+		idGen:     snowflake.NewIDGenerator(),
+		backfills: make(map[influxdb.ID]*backfill),
+	}
+}
+
+type backfill struct {
+	mu       sync.Mutex
+	progress influxdb.TaskBackfill
+	cancel   context.CancelFunc
+}
+
+func (b *backfill) update(fn func(p *influxdb.TaskBackfill)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	fn(&b.progress)
+}
+
+func (b *backfill) snapshot() *influxdb.TaskBackfill {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	p := b.progress
+	return &p
+}
+
+// BackfillTask starts queueing a run of the task for every time in [start, stop] the task is scheduled for.
+func (b *Backfiller) BackfillTask(ctx context.Context, taskID influxdb.ID, start, stop time.Time) (*influxdb.TaskBackfill, error) {
+	if !start.Before(stop) {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "backfill start must be before stop",
+		}
+	}
+
+	t, err := b.ts.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	times, err := scheduledFor(t, start, stop)
+	if err != nil {
+		return nil, err
+	}
+	o, err := options.FromScript(t.Flux)
+	if err != nil {
+		return nil, influxdb.ErrTaskOptionParse(err)
+	}
+	concurrency := 1
+	if o.Concurrency != nil && *o.Concurrency > 1 {
+		concurrency = int(*o.Concurrency)
+	}
+
+	// The runs outlive the request starting the backfill, but keep its authorizer.
+	rctx := context.Background()
+	if auth, err := icontext.GetAuthorizer(ctx); err == nil {
+		rctx = icontext.SetAuthorizer(rctx, auth)
+	}
+	rctx, cancel := context.WithCancel(rctx)
+	bf := &backfill{
+		progress: influxdb.TaskBackfill{
+			ID:        b.idGen.ID(),
+			TaskID:    taskID,
+			Start:     start.UTC(),
+			Stop:      stop.UTC(),
+			Status:    influxdb.BackfillRunning,
+			Total:     len(times),
+			CreatedAt: time.Now().UTC(),
+		},
+		cancel: cancel,
+	}
+
+	b.mu.Lock()
+	b.prune()
+	b.backfills[bf.progress.ID] = bf
+	b.mu.Unlock()
+
+	go b.run(rctx, bf, times, concurrency)
+	return bf.snapshot(), nil
+}
+
+// FindBackfillByID returns the progress of a backfill of a task.
+func (b *Backfiller) FindBackfillByID(ctx context.Context, taskID, backfillID influxdb.ID) (*influxdb.TaskBackfill, error) {
+	bf, err := b.find(taskID, backfillID)
+	if err != nil {
+		return nil, err
+	}
+	return bf.snapshot(), nil
+}
+
+// CancelBackfill stops queueing the runs of a backfill and cancels its unfinished runs.
+// The backfill is canceled once its unfinished runs are.
+func (b *Backfiller) CancelBackfill(ctx context.Context, taskID, backfillID influxdb.ID) error {
+	bf, err := b.find(taskID, backfillID)
+	if err != nil {
+		return err
+	}
+	bf.cancel()
+	return nil
+}
+
+func (b *Backfiller) find(taskID, backfillID influxdb.ID) (*backfill, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	bf, ok := b.backfills[backfillID]
+	if !ok || bf.progress.TaskID != taskID {
+		return nil, influxdb.ErrBackfillNotFound
+	}
+	return bf, nil
+}
+
+// prune drops the progress of the backfills that finished before the retention.
+func (b *Backfiller) prune() {
+	for id, bf := range b.backfills {
+		p := bf.snapshot()
+		if p.Done() && time.Since(p.FinishedAt) > finishedBackfillRetention {
+			delete(b.backfills, id)
+		}
+	}
+}
+
+func (b *Backfiller) run(ctx context.Context, bf *backfill, times []time.Time, concurrency int) {
+	taskID := bf.progress.TaskID
+	log := b.log.With(zap.String("taskID", taskID.String()), zap.String("backfillID", bf.progress.ID.String()))
+
+	var (
+		wg     sync.WaitGroup
+		runErr error
+		slots  = make(chan struct{}, concurrency)
+	)
+	for _, sf := range times {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		run, err := b.ts.ForceRun(ctx, taskID, sf.Unix())
+		if influxdb.ErrorCode(err) == influxdb.EConflict {
+			// The time was queued by a manual run or an earlier backfill.
+			bf.update(func(p *influxdb.TaskBackfill) { p.Skipped++ })
+			<-slots
+			continue
+		}
+		if err != nil {
+			runErr = fmt.Errorf("failed to queue run for %s: %v", sf.Format(time.RFC3339), err)
+			break
+		}
+		promise, err := b.ex.ManualRun(ctx, taskID, run.ID)
+		if err != nil {
+			runErr = fmt.Errorf("failed to start run for %s: %v", sf.Format(time.RFC3339), err)
+			break
+		}
+		bf.update(func(p *influxdb.TaskBackfill) { p.Queued++ })
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := promise.Error()
+			bf.update(func(p *influxdb.TaskBackfill) {
+				switch {
+				case err == nil:
+					p.Succeeded++
+				case ctx.Err() != nil:
+					p.Canceled++
+				default:
+					p.Failed++
+				}
+			})
+			<-slots
+		}()
+	}
+	wg.Wait()
+
+	bf.update(func(p *influxdb.TaskBackfill) {
+		switch {
+		case runErr != nil:
+			p.Status = influxdb.BackfillFailed
+			p.Error = runErr.Error()
+		case ctx.Err() != nil:
+			p.Status = influxdb.BackfillCanceled
+		default:
+			p.Status = influxdb.BackfillCompleted
+		}
+		p.FinishedAt = time.Now().UTC()
+	})
+	bf.cancel()
+
+	if runErr != nil {
+		log.Info("Backfill failed", zap.Error(runErr))
+		return
+	}
+	log.Debug("Backfill finished")
+}
+
+// scheduledFor returns the times in [start, stop] the task is scheduled for.
+func scheduledFor(t *influxdb.Task, start, stop time.Time) ([]time.Time, error) {
+	cron := t.EffectiveCron()
+	if cron == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "task has no every or cron option to backfill",
+		}
+	}
+	// The schedule starts after the given time, so start a second early to include start.
+	sch, from, err := scheduler.NewSchedule(cron, start.Add(-time.Second))
+	if err != nil {
+		return nil, influxdb.ErrTaskOptionParse(err)
+	}
+
+	var times []time.Time
+	for {
+		next, err := sch.Next(from)
+		if err != nil {
+			return nil, influxdb.ErrTaskOptionParse(err)
+		}
+		if next.After(stop) {
+			break
+		}
+		if !next.Before(start) {
+			times = append(times, next)
+		}
+		if len(times) > maxBackfillRuns {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("backfill would queue more than %d runs", maxBackfillRuns),
+			}
+		}
+		from = next
+	}
+	if len(times) == 0 {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "task is not scheduled between backfill start and stop",
+		}
+	}
+	return times, nil
+}
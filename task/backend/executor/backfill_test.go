@@ -0,0 +1,187 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestBackfiller(t *testing.T) {
+	t.Run("Completes", testBackfillCompletes)
+	t.Run("SkipsQueuedRuns", testBackfillSkipsQueuedRuns)
+	t.Run("Cancel", testBackfillCancel)
+	t.Run("InvalidRange", testBackfillInvalidRange)
+}
+
+// succeedQueries succeeds every query of the fake query service until stop is closed,
+// and sends the now time of the queries to nows if it is not nil.
+func (s *fakeQueryService) succeedQueries(stop <-chan struct{}, nows chan<- time.Time) {
+	for {
+		select {
+		case <-stop:
+			return
+		case <-time.After(time.Millisecond):
+		}
+		s.mu.Lock()
+		for spec, fq := range s.queries {
+			var c struct{ Now time.Time }
+			if err := json.Unmarshal([]byte(spec), &c); err != nil {
+				panic(err)
+			}
+			if nows != nil {
+				nows <- c.Now
+			}
+			close(fq.wait)
+			delete(s.queries, spec)
+		}
+		s.mu.Unlock()
+	}
+}
+
+func waitForBackfill(t *testing.T, b *Backfiller, taskID, id influxdb.ID, done func(*influxdb.TaskBackfill) bool) *influxdb.TaskBackfill {
+	t.Helper()
+
+	for i := 0; i < 500; i++ {
+		p, err := b.FindBackfillByID(context.Background(), taskID, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if done(p) {
+			return p
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("backfill did not progress in time")
+	return nil
+}
+
+func createBackfillTask(t *testing.T, tes tes) (context.Context, *influxdb.Task) {
+	t.Helper()
+
+	ctx := icontext.SetAuthorizer(context.Background(), tes.tc.Auth)
+	task, err := tes.i.CreateTask(ctx, influxdb.TaskCreate{
+		OrganizationID: tes.tc.OrgID,
+		OwnerID:        tes.tc.Auth.GetUserID(),
+		Flux:           fmt.Sprintf(fmtTestScript, t.Name()),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ctx, task
+}
+
+func testBackfillCompletes(t *testing.T) {
+	t.Parallel()
+	tes := taskExecutorSystem(t)
+	ctx, task := createBackfillTask(t, tes)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	nows := make(chan time.Time, 6)
+	go tes.svc.succeedQueries(stop, nows)
+
+	b := NewBackfiller(zaptest.NewLogger(t), tes.i, tes.ex)
+	bf, err := b.BackfillTask(ctx, task.ID, time.Unix(0, 0), time.Unix(300, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bf.Total != 6 {
+		t.Fatalf("expected 6 scheduled times, got %d", bf.Total)
+	}
+
+	p := waitForBackfill(t, b, task.ID, bf.ID, (*influxdb.TaskBackfill).Done)
+	if p.Status != influxdb.BackfillCompleted {
+		t.Fatalf("expected status %q, got %q: %s", influxdb.BackfillCompleted, p.Status, p.Error)
+	}
+	if p.Queued != 6 || p.Succeeded != 6 || p.Failed != 0 {
+		t.Fatalf("unexpected progress %+v", p)
+	}
+
+	// The task has a concurrency of one, so the runs are queued in order.
+	for sf := int64(0); sf <= 300; sf += 60 {
+		if now := <-nows; now.Unix() != sf {
+			t.Errorf("expected run scheduled for %d, got %d", sf, now.Unix())
+		}
+	}
+}
+
+func testBackfillSkipsQueuedRuns(t *testing.T) {
+	t.Parallel()
+	tes := taskExecutorSystem(t)
+	ctx, task := createBackfillTask(t, tes)
+
+	if _, err := tes.i.ForceRun(ctx, task.ID, 120); err != nil {
+		t.Fatal(err)
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go tes.svc.succeedQueries(stop, nil)
+
+	b := NewBackfiller(zaptest.NewLogger(t), tes.i, tes.ex)
+	bf, err := b.BackfillTask(ctx, task.ID, time.Unix(60, 0), time.Unix(180, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := waitForBackfill(t, b, task.ID, bf.ID, (*influxdb.TaskBackfill).Done)
+	if p.Status != influxdb.BackfillCompleted {
+		t.Fatalf("expected status %q, got %q: %s", influxdb.BackfillCompleted, p.Status, p.Error)
+	}
+	if p.Total != 3 || p.Skipped != 1 || p.Queued != 2 || p.Succeeded != 2 {
+		t.Fatalf("unexpected progress %+v", p)
+	}
+}
+
+func testBackfillCancel(t *testing.T) {
+	t.Parallel()
+	tes := taskExecutorSystem(t)
+	ctx, task := createBackfillTask(t, tes)
+
+	b := NewBackfiller(zaptest.NewLogger(t), tes.i, tes.ex)
+	bf, err := b.BackfillTask(ctx, task.ID, time.Unix(0, 0), time.Unix(600, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The task has a concurrency of one, so the first run blocks the backfill.
+	waitForBackfill(t, b, task.ID, bf.ID, func(p *influxdb.TaskBackfill) bool { return p.Queued == 1 })
+	if err := b.CancelBackfill(ctx, task.ID, bf.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	p := waitForBackfill(t, b, task.ID, bf.ID, (*influxdb.TaskBackfill).Done)
+	if p.Status != influxdb.BackfillCanceled {
+		t.Fatalf("expected status %q, got %q", influxdb.BackfillCanceled, p.Status)
+	}
+	if p.Total != 11 || p.Queued != 1 || p.Canceled != 1 {
+		t.Fatalf("unexpected progress %+v", p)
+	}
+
+	if _, err := b.FindBackfillByID(ctx, influxdb.ID(1), bf.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected backfill of another task to be not found, got %v", err)
+	}
+}
+
+func testBackfillInvalidRange(t *testing.T) {
+	t.Parallel()
+	tes := taskExecutorSystem(t)
+	ctx, task := createBackfillTask(t, tes)
+
+	b := NewBackfiller(zaptest.NewLogger(t), tes.i, tes.ex)
+	for _, r := range []struct{ start, stop time.Time }{
+		{start: time.Unix(60, 0), stop: time.Unix(0, 0)},
+		{start: time.Unix(1, 0), stop: time.Unix(59, 0)},
+		{start: time.Unix(0, 0), stop: time.Unix(0, 0).Add(time.Minute * (maxBackfillRuns + 1))},
+	} {
+		if _, err := b.BackfillTask(ctx, task.ID, r.start, r.stop); influxdb.ErrorCode(err) != influxdb.EInvalid {
+			t.Errorf("expected invalid backfill from %s to %s, got %v", r.start, r.stop, err)
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/task/backend"
 	"github.com/influxdata/influxdb/task/backend/scheduler"
+	"github.com/influxdata/influxdb/task/options"
 	"go.uber.org/zap"
 )
 
@@ -41,6 +42,9 @@ func MultiLimit(limits ...LimitFunc) LimitFunc {
 // LimitFunc is a function the executor will use to
 type LimitFunc func(*influxdb.Task, *influxdb.Run) error
 
+// RunSucceededFunc is a function the executor calls after a run of a task succeeded.
+type RunSucceededFunc func(ctx context.Context, task *influxdb.Task, run *influxdb.Run)
+
 // NewExecutor creates a new task executor
 func NewExecutor(log *zap.Logger, qs query.QueryService, as influxdb.AuthorizationService, ts influxdb.TaskService, tcs backend.TaskControlService) (*Executor, *ExecutorMetrics) {
 	e := &Executor{
@@ -54,6 +58,7 @@ func NewExecutor(log *zap.Logger, qs query.QueryService, as influxdb.Authorizati
 		promiseQueue:    make(chan *promise, 1000),                                //TODO(lh): make this configurable
 		workerLimit:     make(chan struct{}, 100),                                 //TODO(lh): make this configurable
 		limitFunc:       func(*influxdb.Task, *influxdb.Run) error { return nil }, // noop
+		succeededFunc:   func(context.Context, *influxdb.Task, *influxdb.Run) {},  // noop
 	}
 
 	e.metrics = NewExecutorMetrics(e)
@@ -85,6 +90,8 @@ type Executor struct {
 
 	limitFunc LimitFunc
 
+	succeededFunc RunSucceededFunc
+
 	// keep a pool of execution workers.
 	workerPool  sync.Pool
 	workerLimit chan struct{}
@@ -95,6 +102,12 @@ func (e *Executor) SetLimitFunc(l LimitFunc) {
 	e.limitFunc = l
 }
 
+// SetRunSucceededFunc sets the function this task executor calls after a run succeeded.
+// The function is called in its own goroutine.
+func (e *Executor) SetRunSucceededFunc(fn RunSucceededFunc) {
+	e.succeededFunc = fn
+}
+
 // Execute is a executor to satisfy the needs of tasks
 func (e *Executor) Execute(ctx context.Context, id scheduler.ID, scheduledFor time.Time, runAt time.Time) error {
 	_, err := e.PromisedExecute(ctx, id, scheduledFor, runAt)
@@ -131,6 +144,18 @@ func (e *Executor) ManualRun(ctx context.Context, id influxdb.ID, runID influxdb
 	return p, err
 }
 
+// DownstreamRun begins execution of a run of the task with the scheduled for time of the upstream run that triggered it.
+func (e *Executor) DownstreamRun(ctx context.Context, id influxdb.ID, upstream *influxdb.Run) (Promise, error) {
+	r, err := e.tcs.CreateDownstreamRun(ctx, id, upstream)
+	if err != nil {
+		return nil, err
+	}
+	p, err := e.createPromise(ctx, r)
+
+	e.startWorker()
+	return p, err
+}
+
 func (e *Executor) ResumeCurrentRun(ctx context.Context, id influxdb.ID, runID influxdb.ID) (Promise, error) {
 	cr, err := e.tcs.CurrentlyRunning(ctx, id)
 	if err != nil {
@@ -311,6 +336,7 @@ func (w *worker) start(p *promise) {
 	// add to metrics
 	w.e.metrics.StartRun(p.task, time.Since(p.createdAt), time.Since(p.run.RunAt))
 	p.startedAt = time.Now()
+	p.attempt++
 }
 
 func (w *worker) finish(p *promise, rs influxdb.RunStatus, err error) {
@@ -354,6 +380,15 @@ func (w *worker) finish(p *promise, rs influxdb.RunStatus, err error) {
 	if _, err := w.e.tcs.FinishRun(p.ctx, p.task.ID, p.run.ID); err != nil {
 		w.e.log.Error("Failed to finish run", zap.String("taskID", p.task.ID.String()), zap.String("runID", p.run.ID.String()), zap.Error(err))
 	}
+
+	if rs == influxdb.RunSuccess {
+		// the runs the succeeded run triggers outlive its context, but keep its authorizer.
+		sctx := context.Background()
+		if auth, err := icontext.GetAuthorizer(p.ctx); err == nil {
+			sctx = icontext.SetAuthorizer(sctx, auth)
+		}
+		go w.e.succeededFunc(sctx, p.task, p.run)
+	}
 }
 
 func (w *worker) executeQuery(p *promise) {
@@ -384,7 +419,7 @@ func (w *worker) executeQuery(p *promise) {
 	it, err := w.e.qs.Query(ctx, req)
 	if err != nil {
 		// Assume the error should not be part of the runResult.
-		w.finish(p, influxdb.RunFail, influxdb.ErrQueryError(err))
+		w.fail(p, options.RetryOnQuery, influxdb.ErrQueryError(err))
 		return
 	}
 
@@ -407,12 +442,12 @@ func (w *worker) executeQuery(p *promise) {
 	}
 
 	if runErr != nil {
-		w.finish(p, influxdb.RunFail, influxdb.ErrRunExecutionError(runErr))
+		w.fail(p, options.RetryOnExecution, influxdb.ErrRunExecutionError(runErr))
 		return
 	}
 
 	if it.Err() != nil {
-		w.finish(p, influxdb.RunFail, influxdb.ErrResultIteratorError(it.Err()))
+		w.fail(p, options.RetryOnExecution, influxdb.ErrResultIteratorError(it.Err()))
 		return
 	}
 
@@ -446,6 +481,8 @@ type promise struct {
 
 	createdAt time.Time
 	startedAt time.Time
+	// attempt is the number of the attempt at the run currently being made.
+	attempt int
 
 	ctx        context.Context
 	cancelFunc context.CancelFunc
@@ -21,6 +21,7 @@ import (
 	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/task/backend"
 	"github.com/influxdata/influxdb/task/backend/scheduler"
+	"github.com/influxdata/influxdb/task/options"
 	"github.com/opentracing/opentracing-go"
 	"github.com/uber/jaeger-client-go"
 	"go.uber.org/zap/zaptest"
@@ -70,12 +71,15 @@ func TestTaskExecutor(t *testing.T) {
 	t.Run("QuerySuccess", testQuerySuccess)
 	t.Run("QueryFailure", testQueryFailure)
 	t.Run("ManualRun", testManualRun)
+	t.Run("DownstreamRun", testDownstreamRun)
 	t.Run("ResumeRun", testResumingRun)
 	t.Run("WorkerLimit", testWorkerLimit)
 	t.Run("LimitFunc", testLimitFunc)
 	t.Run("Metrics", testMetrics)
 	t.Run("IteratorFailure", testIteratorFailure)
 	t.Run("ErrorHandling", testErrorHandling)
+	t.Run("Retry", testRetry)
+	t.Run("RetryOn", testRetryOn)
 }
 
 func testQuerySuccess(t *testing.T) {
@@ -229,6 +233,67 @@ func testManualRun(t *testing.T) {
 	}
 }
 
+func testDownstreamRun(t *testing.T) {
+	t.Parallel()
+	tes := taskExecutorSystem(t)
+
+	ctx := icontext.SetAuthorizer(context.Background(), tes.tc.Auth)
+	script := fmt.Sprintf(fmtTestScript, t.Name())
+	task, err := tes.i.CreateTask(ctx, influxdb.TaskCreate{OrganizationID: tes.tc.OrgID, OwnerID: tes.tc.Auth.GetUserID(), Flux: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	downstreamScript := fmt.Sprintf(`option task = {name: %q, every: 1m, after: %q}
+from(bucket: "two") |> to(bucket: "three", orgID: "0000000000000000")`, t.Name()+"-downstream", task.ID)
+	downstream, err := tes.i.CreateTask(ctx, influxdb.TaskCreate{OrganizationID: tes.tc.OrgID, OwnerID: tes.tc.Auth.GetUserID(), Flux: downstreamScript})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	succeeded := make(chan *influxdb.Run, 2)
+	tes.ex.SetRunSucceededFunc(func(ctx context.Context, task *influxdb.Task, run *influxdb.Run) {
+		succeeded <- run
+	})
+
+	promise, err := tes.ex.PromisedExecute(ctx, scheduler.ID(task.ID), time.Unix(123, 0), time.Unix(123, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tes.svc.WaitForQueryLive(t, script)
+	tes.svc.SucceedQuery(script)
+	if err := promise.Error(); err != nil {
+		t.Fatal(err)
+	}
+
+	var upstream *influxdb.Run
+	select {
+	case upstream = <-succeeded:
+	case <-time.After(time.Second):
+		t.Fatal("succeeded run was not reported")
+	}
+	if upstream.ID != promise.ID() {
+		t.Fatalf("expected run %s to be reported, got %s", promise.ID(), upstream.ID)
+	}
+
+	promise, err = tes.ex.DownstreamRun(ctx, downstream.ID, upstream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	run, err := tes.i.FindRunByID(context.Background(), downstream.ID, promise.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.UpstreamRunID != upstream.ID || !run.ScheduledFor.Equal(time.Unix(123, 0)) {
+		t.Fatalf("expected run scheduled for %s after run %s, got %+v", time.Unix(123, 0).UTC(), upstream.ID, run)
+	}
+
+	tes.svc.WaitForQueryLive(t, downstreamScript)
+	tes.svc.SucceedQuery(downstreamScript)
+	if err := promise.Error(); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func testResumingRun(t *testing.T) {
 	t.Parallel()
 	tes := taskExecutorSystem(t)
@@ -527,6 +592,95 @@ func testErrorHandling(t *testing.T) {
 	*/
 }
 
+const fmtRetryTestScript = `
+option task = {
+			name: %q,
+			every: 1m,
+			retry: 2,
+			retryBackoff: 0s,
+			retryOn: [%q],
+}
+from(bucket: "one") |> to(bucket: "two", orgID: "0000000000000000")`
+
+func testRetry(t *testing.T) {
+	t.Parallel()
+	tes := taskExecutorSystem(t)
+
+	script := fmt.Sprintf(fmtRetryTestScript, t.Name(), options.RetryOnQuery)
+	ctx := icontext.SetAuthorizer(context.Background(), tes.tc.Auth)
+	task, err := tes.i.CreateTask(ctx, influxdb.TaskCreate{OrganizationID: tes.tc.OrgID, OwnerID: tes.tc.Auth.GetUserID(), Flux: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the first attempt fails to start its query, the second one succeeds.
+	tes.svc.FailNextQuery(errors.New("query service unavailable"))
+
+	promise, err := tes.ex.PromisedExecute(ctx, scheduler.ID(task.ID), time.Unix(123, 0), time.Unix(126, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tes.svc.WaitForQueryLive(t, script)
+	tes.svc.SucceedQuery(script)
+
+	<-promise.Done()
+
+	if got := promise.Error(); got != nil {
+		t.Fatal(got)
+	}
+
+	run := tes.tcs.run
+	if run == nil {
+		t.Fatal("expected run returned by FinishRun to not be nil")
+	}
+	if run.Status != influxdb.RunSuccess.String() {
+		t.Fatalf("expected run to succeed, got status %q", run.Status)
+	}
+	if run.Attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", run.Attempts)
+	}
+
+	var logged bool
+	for _, l := range run.Log {
+		if strings.HasPrefix(l.Message, "Attempt 1 of 2 failed, retrying in 0s") {
+			logged = true
+		}
+	}
+	if !logged {
+		t.Fatalf("expected the failed attempt in the run log, got %+v", run.Log)
+	}
+}
+
+func testRetryOn(t *testing.T) {
+	t.Parallel()
+	tes := taskExecutorSystem(t)
+
+	script := fmt.Sprintf(fmtRetryTestScript, t.Name(), options.RetryOnExecution)
+	ctx := icontext.SetAuthorizer(context.Background(), tes.tc.Auth)
+	task, err := tes.i.CreateTask(ctx, influxdb.TaskCreate{OrganizationID: tes.tc.OrgID, OwnerID: tes.tc.Auth.GetUserID(), Flux: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the task is only retried on execution errors, so a query error fails the run.
+	tes.svc.FailNextQuery(errors.New("query service unavailable"))
+
+	promise, err := tes.ex.PromisedExecute(ctx, scheduler.ID(task.ID), time.Unix(123, 0), time.Unix(126, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	<-promise.Done()
+
+	if got := promise.Error(); got == nil {
+		t.Fatal("got no error when I should have")
+	}
+	if run := tes.tcs.run; run == nil || run.Attempts != 1 {
+		t.Fatalf("expected a single attempt, got run %+v", run)
+	}
+}
+
 type taskControlService struct {
 	backend.TaskControlService
 
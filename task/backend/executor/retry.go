@@ -0,0 +1,104 @@
+package executor
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/task/backend"
+	"github.com/influxdata/influxdb/task/options"
+)
+
+const (
+	// defaultRetryBackoff is how long a failed run waits before its retry
+	// when the task does not set the retryBackoff option.
+	defaultRetryBackoff = 10 * time.Second
+	// defaultRetryMultiplier is the factor the wait grows by between retries
+	// when the task does not set the retryMultiplier option.
+	defaultRetryMultiplier = 2.0
+)
+
+// retryPolicy describes how the failed runs of a task are retried.
+type retryPolicy struct {
+	maxAttempts int
+	backoff     time.Duration
+	multiplier  float64
+	retryOn     []string
+}
+
+// newRetryPolicy builds the retry policy of a task from its options.
+// A task without retry options makes a single attempt at each run.
+func newRetryPolicy(t *influxdb.Task) retryPolicy {
+	rp := retryPolicy{
+		maxAttempts: 1,
+		backoff:     defaultRetryBackoff,
+		multiplier:  defaultRetryMultiplier,
+		retryOn:     []string{options.RetryOnQuery, options.RetryOnExecution},
+	}
+
+	o, err := options.FromScript(t.Flux)
+	if err != nil {
+		return rp
+	}
+	if o.Retry != nil {
+		rp.maxAttempts = int(*o.Retry)
+	}
+	if o.RetryBackoff != nil {
+		if d, err := o.RetryBackoff.DurationFrom(time.Now()); err == nil {
+			rp.backoff = d
+		}
+	}
+	if o.RetryMultiplier != nil {
+		rp.multiplier = *o.RetryMultiplier
+	}
+	if len(o.RetryOn) > 0 {
+		rp.retryOn = o.RetryOn
+	}
+	return rp
+}
+
+// retries reports whether errors of the given class are retried.
+func (rp retryPolicy) retries(class string) bool {
+	for _, c := range rp.retryOn {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// wait returns how long to wait before the attempt following the given one.
+func (rp retryPolicy) wait(attempt int) time.Duration {
+	return time.Duration(float64(rp.backoff) * math.Pow(rp.multiplier, float64(attempt-1)))
+}
+
+// fail finishes a failed attempt at a run, retrying the run if its task's retry policy allows it.
+func (w *worker) fail(p *promise, class string, err error) {
+	if w.retry(p, class, err) {
+		w.executeQuery(p)
+		return
+	}
+	w.finish(p, influxdb.RunFail, err)
+}
+
+// retry waits out the backoff before the next attempt at the run,
+// and reports whether that attempt should be made.
+func (w *worker) retry(p *promise, class string, err error) bool {
+	rp := newRetryPolicy(p.task)
+	if p.attempt >= rp.maxAttempts || !rp.retries(class) || backend.IsUnrecoverable(err) {
+		return false
+	}
+
+	wait := rp.wait(p.attempt)
+	w.e.tcs.AddRunLog(p.ctx, p.task.ID, p.run.ID, time.Now().UTC(), fmt.Sprintf("Attempt %d of %d failed, retrying in %s: %v", p.attempt, rp.maxAttempts, wait, err))
+	w.e.metrics.LogError(p.task.Type, err)
+
+	select {
+	case <-p.ctx.Done():
+		// the run was canceled while waiting, so report the failure.
+		return false
+	case <-time.After(wait):
+	}
+	return true
+}
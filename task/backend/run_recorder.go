@@ -49,6 +49,12 @@ func (s *StoragePointsWriterRecorder) Record(ctx context.Context, orgID influxdb
 	fields[finishedAtField] = run.FinishedAt.Format(time.RFC3339Nano)
 	fields[scheduledForField] = run.ScheduledFor.Format(time.RFC3339)
 	fields[requestedAtField] = run.RequestedAt.Format(time.RFC3339)
+	if run.UpstreamRunID.Valid() {
+		fields[upstreamRunField] = run.UpstreamRunID.String()
+	}
+	if run.Attempts > 0 {
+		fields[attemptsField] = int64(run.Attempts)
+	}
 
 	startedAt := run.StartedAt
 	if startedAt.IsZero() {
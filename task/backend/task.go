@@ -14,6 +14,9 @@ type TaskControlService interface {
 	// CreateRun creates a run with a scheduled for time.
 	CreateRun(ctx context.Context, taskID influxdb.ID, scheduledFor time.Time, runAt time.Time) (*influxdb.Run, error)
 
+	// CreateDownstreamRun creates a run of a task with the scheduled for time of the upstream run that triggered it.
+	CreateDownstreamRun(ctx context.Context, taskID influxdb.ID, upstream *influxdb.Run) (*influxdb.Run, error)
+
 	CurrentlyRunning(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Run, error)
 	ManualRuns(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Run, error)
 
@@ -0,0 +1,129 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/query"
+	"go.uber.org/zap"
+)
+
+var _ influxdb.TaskStatsService = (*AnalyticalStorage)(nil)
+
+// FindTaskStats computes statistics over the finished runs of a task that started in [start, stop),
+// from the run history kept in the system bucket of the task's org.
+func (as *AnalyticalStorage) FindTaskStats(ctx context.Context, taskID influxdb.ID, start, stop time.Time) (*influxdb.TaskStats, error) {
+	if !stop.After(start) {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "task stats stop must be later than start",
+		}
+	}
+
+	task, err := as.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	sb, err := as.BucketService.FindBucketByName(ctx, task.OrganizationID, influxdb.TasksSystemBucketName)
+	if err != nil {
+		return nil, err
+	}
+
+	// runs are recorded at the time they started, and the logs are not needed here.
+	statsScript := fmt.Sprintf(`from(bucketID: %q)
+	|> range(start: %s, stop: %s)
+	|> filter(fn: (r) => r._measurement == "runs" and r.taskID == %q)
+	|> filter(fn: (r) => r._field == %q or r._field == %q or r._field == %q or r._field == %q)
+	|> pivot(rowKey:["_time"], columnKey: ["_field"], valueColumn: "_value")
+	|> group(columns: ["taskID"])
+	`, sb.ID.String(), start.UTC().Format(time.RFC3339Nano), stop.UTC().Format(time.RFC3339Nano), taskID.String(),
+		runIDField, scheduledForField, startedAtField, finishedAtField)
+
+	// At this point we are behind authorization
+	// so we are faking a read only permission to the org's system bucket
+	request := &query.Request{Authorization: systemBucketAuth(task.OrganizationID, sb.ID), OrganizationID: task.OrganizationID, Compiler: lang.FluxCompiler{Query: statsScript}}
+
+	ittr, err := as.qs.Query(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	defer ittr.Release()
+
+	re := &runReader{log: as.log.With(zap.String("component", "run-reader"), zap.String("taskID", taskID.String()))}
+	for ittr.More() {
+		if err := ittr.Next().Tables().Do(re.readTable); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ittr.Err(); err != nil {
+		return nil, fmt.Errorf("unexpected internal error while decoding run response: %v", err)
+	}
+
+	return newTaskStats(taskID, start, stop, re.runs), nil
+}
+
+// newTaskStats computes the statistics of a task from its finished runs.
+func newTaskStats(taskID influxdb.ID, start, stop time.Time, runs []*influxdb.Run) *influxdb.TaskStats {
+	stats := &influxdb.TaskStats{
+		TaskID: taskID,
+		Start:  start.UTC(),
+		Stop:   stop.UTC(),
+	}
+
+	var durations, lags []time.Duration
+	for _, r := range runs {
+		switch r.Status {
+		case influxdb.RunSuccess.String():
+			stats.Succeeded++
+		case influxdb.RunFail.String():
+			stats.Failed++
+		case influxdb.RunCanceled.String():
+			stats.Canceled++
+		default:
+			// not a finished run.
+			continue
+		}
+		stats.Runs++
+
+		if !r.StartedAt.IsZero() && !r.FinishedAt.IsZero() {
+			durations = append(durations, r.FinishedAt.Sub(r.StartedAt))
+		}
+		if !r.StartedAt.IsZero() && !r.ScheduledFor.IsZero() {
+			lags = append(lags, r.StartedAt.Sub(r.ScheduledFor))
+		}
+	}
+
+	if stats.Runs > 0 {
+		stats.SuccessRate = float64(stats.Succeeded) / float64(stats.Runs)
+	}
+	stats.Duration = newTaskStatsPercentiles(durations)
+	stats.Lag = newTaskStatsPercentiles(lags)
+	return stats
+}
+
+func newTaskStatsPercentiles(ds []time.Duration) influxdb.TaskStatsPercentiles {
+	sort.Slice(ds, func(i, j int) bool { return ds[i] < ds[j] })
+	return influxdb.TaskStatsPercentiles{
+		P50: percentile(ds, 0.5).Seconds(),
+		P95: percentile(ds, 0.95).Seconds(),
+	}
+}
+
+// percentile returns the nearest-rank percentile p of the sorted durations ds, 0 without durations.
+func percentile(ds []time.Duration, p float64) time.Duration {
+	if len(ds) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p*float64(len(ds)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	return ds[i]
+}
@@ -107,6 +107,12 @@ func (e *Executor) ManualRun(ctx context.Context, id influxdb.ID, runID influxdb
 	return p, err
 }
 
+func (e *Executor) DownstreamRun(ctx context.Context, id influxdb.ID, upstream *influxdb.Run) (executor.Promise, error) {
+	run := &influxdb.Run{ID: idgen.ID(), TaskID: id, ScheduledFor: upstream.ScheduledFor, UpstreamRunID: upstream.ID, StartedAt: time.Now().UTC()}
+	p, err := e.createPromise(ctx, run)
+	return p, err
+}
+
 func (e *Executor) Wait() {
 	e.wg.Wait()
 }
@@ -73,6 +73,24 @@ func (t *TaskControlService) CreateRun(_ context.Context, taskID influxdb.ID, sc
 	return runs[runID], nil
 }
 
+func (t *TaskControlService) CreateDownstreamRun(_ context.Context, taskID influxdb.ID, upstream *influxdb.Run) (*influxdb.Run, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	runID := idgen.ID()
+	runs, ok := t.runs[taskID]
+	if !ok {
+		runs = make(map[influxdb.ID]*influxdb.Run)
+	}
+	runs[runID] = &influxdb.Run{
+		ID:            runID,
+		ScheduledFor:  upstream.ScheduledFor,
+		UpstreamRunID: upstream.ID,
+	}
+	t.runs[taskID] = runs
+	return runs[runID], nil
+}
+
 func (t *TaskControlService) StartManualRun(_ context.Context, taskID, runID influxdb.ID) (*influxdb.Run, error) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -19,6 +19,14 @@ import (
 const maxConcurrency = 100
 const maxRetry = 10
 
+// The classes of errors that can be named in the retryOn option.
+const (
+	// RetryOnQuery covers runs whose query could not be started.
+	RetryOnQuery = "query"
+	// RetryOnExecution covers runs whose query failed while it was executing.
+	RetryOnExecution = "execution"
+)
+
 // Options are the task-related options that can be specified in a Flux script.
 type Options struct {
 	// Name is a non optional name designator for each task.
@@ -37,7 +45,22 @@ type Options struct {
 
 	Concurrency *int64 `json:"concurrency,omitempty"`
 
+	// Retry is the maximum number of attempts made for a run, the first one included.
 	Retry *int64 `json:"retry,omitempty"`
+
+	// RetryBackoff is how long to wait before retrying a failed run.
+	RetryBackoff *Duration `json:"retryBackoff,omitempty"`
+
+	// RetryMultiplier multiplies the wait before every further retry of the run.
+	RetryMultiplier *float64 `json:"retryMultiplier,omitempty"`
+
+	// RetryOn are the classes of errors that a failed run is retried on.
+	RetryOn []string `json:"retryOn,omitempty"`
+
+	// After is the ID of the task this task runs after.
+	// A run of the task is triggered by every successful run of the task named by After,
+	// whose scheduled for time the task is scheduled for.
+	After string `json:"after,omitempty"`
 }
 
 // Duration is a time span that supports the same units as the flux parser's time duration, as well as negative length time spans.
@@ -127,6 +150,10 @@ func (o *Options) Clear() {
 	o.Offset = nil
 	o.Concurrency = nil
 	o.Retry = nil
+	o.RetryBackoff = nil
+	o.RetryMultiplier = nil
+	o.RetryOn = nil
+	o.After = ""
 }
 
 // IsZero tells us if the options has been zeroed out.
@@ -136,17 +163,25 @@ func (o *Options) IsZero() bool {
 		o.Every.IsZero() &&
 		(o.Offset == nil || o.Offset.IsZero()) &&
 		o.Concurrency == nil &&
-		o.Retry == nil
+		o.Retry == nil &&
+		(o.RetryBackoff == nil || o.RetryBackoff.IsZero()) &&
+		o.RetryMultiplier == nil &&
+		len(o.RetryOn) == 0 &&
+		o.After == ""
 }
 
 // All the task option names we accept.
 const (
-	optName        = "name"
-	optCron        = "cron"
-	optEvery       = "every"
-	optOffset      = "offset"
-	optConcurrency = "concurrency"
-	optRetry       = "retry"
+	optName            = "name"
+	optCron            = "cron"
+	optEvery           = "every"
+	optOffset          = "offset"
+	optConcurrency     = "concurrency"
+	optRetry           = "retry"
+	optRetryBackoff    = "retryBackoff"
+	optRetryMultiplier = "retryMultiplier"
+	optRetryOn         = "retryOn"
+	optAfter           = "after"
 )
 
 // contains is a helper function to see if an array of strings contains a string
@@ -210,7 +245,7 @@ func FromScript(script string) (Options, error) {
 	if err != nil {
 		return opt, err
 	}
-	durTypes := grabTaskOptionAST(fluxAST, optEvery, optOffset)
+	durTypes := grabTaskOptionAST(fluxAST, optEvery, optOffset, optRetryBackoff)
 	// TODO(desa): should be dependencies.NewEmpty(), but for now we'll hack things together
 	ctx := newDeps().Inject(context.Background())
 	_, scope, err := flux.EvalAST(ctx, fluxAST)
@@ -313,6 +348,60 @@ func FromScript(script string) (Options, error) {
 		opt.Retry = pointer.Int64(retryVal.Int())
 	}
 
+	if backoffVal, ok := optObject.Get(optRetryBackoff); ok {
+		if err := checkNature(backoffVal.PolyType().Nature(), semantic.Duration); err != nil {
+			return opt, err
+		}
+		dur, ok := durTypes[optRetryBackoff]
+		if !ok || dur == nil {
+			return opt, ErrParseTaskOptionField(optRetryBackoff)
+		}
+		durNode, err := parseSignedDuration(dur.Location().Source)
+		if err != nil {
+			return opt, err
+		}
+		opt.RetryBackoff = &Duration{Node: *durNode}
+	}
+
+	if multiplierVal, ok := optObject.Get(optRetryMultiplier); ok {
+		// Whole multipliers such as 2 are written as integers, so accept both.
+		switch multiplierVal.PolyType().Nature() {
+		case semantic.Int:
+			m := float64(multiplierVal.Int())
+			opt.RetryMultiplier = &m
+		case semantic.Float:
+			m := multiplierVal.Float()
+			opt.RetryMultiplier = &m
+		default:
+			return opt, checkNature(multiplierVal.PolyType().Nature(), semantic.Float)
+		}
+	}
+
+	if retryOnVal, ok := optObject.Get(optRetryOn); ok {
+		if err := checkNature(retryOnVal.PolyType().Nature(), semantic.Array); err != nil {
+			return opt, err
+		}
+		var err error
+		retryOnVal.Array().Range(func(i int, v values.Value) {
+			if err != nil {
+				return
+			}
+			if err = checkNature(v.PolyType().Nature(), semantic.String); err == nil {
+				opt.RetryOn = append(opt.RetryOn, v.Str())
+			}
+		})
+		if err != nil {
+			return opt, err
+		}
+	}
+
+	if afterVal, ok := optObject.Get(optAfter); ok {
+		if err := checkNature(afterVal.PolyType().Nature(), semantic.String); err != nil {
+			return opt, err
+		}
+		opt.After = afterVal.Str()
+	}
+
 	if err := opt.Validate(); err != nil {
 		return opt, err
 	}
@@ -373,6 +462,23 @@ func (o *Options) Validate() error {
 			errs = append(errs, fmt.Sprintf("retry exceeded max of %d", maxRetry))
 		}
 	}
+	if o.RetryBackoff != nil {
+		backoff, err := o.RetryBackoff.DurationFrom(now)
+		if err != nil {
+			return err
+		}
+		if backoff < 0 {
+			errs = append(errs, "retryBackoff must not be negative")
+		}
+	}
+	if o.RetryMultiplier != nil && *o.RetryMultiplier < 1 {
+		errs = append(errs, "retryMultiplier must be at least 1")
+	}
+	for _, class := range o.RetryOn {
+		if class != RetryOnQuery && class != RetryOnExecution {
+			errs = append(errs, fmt.Sprintf("retryOn must only contain %q or %q, got %q", RetryOnQuery, RetryOnExecution, class))
+		}
+	}
 
 	if len(errs) == 0 {
 		return nil
@@ -414,7 +520,7 @@ func validateOptionNames(o values.Object) error {
 	var unexpected []string
 	o.Range(func(name string, _ values.Value) {
 		switch name {
-		case optName, optCron, optEvery, optOffset, optConcurrency, optRetry:
+		case optName, optCron, optEvery, optOffset, optConcurrency, optRetry, optRetryBackoff, optRetryMultiplier, optRetryOn, optAfter:
 			// Known option. Nothing to do.
 		default:
 			unexpected = append(unexpected, name)
@@ -423,7 +529,7 @@ func validateOptionNames(o values.Object) error {
 
 	if len(unexpected) > 0 {
 		u := strings.Join(unexpected, ", ")
-		v := strings.Join([]string{optName, optCron, optEvery, optOffset, optConcurrency, optRetry}, ", ")
+		v := strings.Join([]string{optName, optCron, optEvery, optOffset, optConcurrency, optRetry, optRetryBackoff, optRetryMultiplier, optRetryOn, optAfter}, ", ")
 		return fmt.Errorf("unknown task option(s): %s. valid options are %s", u, v)
 	}
 
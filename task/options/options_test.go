@@ -33,6 +33,18 @@ func scriptGenerator(opt options.Options, body string) string {
 	if opt.Retry != nil && *opt.Retry != 0 {
 		taskData = fmt.Sprintf("%s  retry: %d,\n", taskData, *opt.Retry)
 	}
+	if opt.RetryBackoff != nil && !opt.RetryBackoff.IsZero() {
+		taskData = fmt.Sprintf("%s  retryBackoff: %s,\n", taskData, opt.RetryBackoff.String())
+	}
+	if opt.RetryMultiplier != nil {
+		taskData = fmt.Sprintf("%s  retryMultiplier: %v,\n", taskData, *opt.RetryMultiplier)
+	}
+	if len(opt.RetryOn) > 0 {
+		taskData = fmt.Sprintf("%s  retryOn: [\"%s\"],\n", taskData, strings.Join(opt.RetryOn, `", "`))
+	}
+	if opt.After != "" {
+		taskData = fmt.Sprintf("%s  after: %q,\n", taskData, opt.After)
+	}
 	if body == "" {
 		body = `from(bucket: "test")
     |> range(start:-1h)`
@@ -99,6 +111,19 @@ func TestFromScript(t *testing.T) {
 		`,
 			exp: options.Options{Name: "name11", Every: *(options.MustParseDuration("1m")), Concurrency: pointer.Int64(1), Retry: pointer.Int64(1), Offset: options.MustParseDuration("1d")},
 		},
+		{script: scriptGenerator(options.Options{Name: "name12", Every: *(options.MustParseDuration("1h")), After: "020f755c3c082000"}, ""),
+			exp: options.Options{Name: "name12", Every: *(options.MustParseDuration("1h")), Concurrency: pointer.Int64(1), Retry: pointer.Int64(1), After: "020f755c3c082000"},
+		},
+		{script: "option task = {\n  name: \"name13\",\n  after: 1,\n  every: 1m0s,\n\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)", shouldErr: true},
+		{script: scriptGenerator(options.Options{Name: "name14", Every: *(options.MustParseDuration("1h")), Retry: pointer.Int64(3), RetryBackoff: options.MustParseDuration("30s"), RetryMultiplier: pointer.Float64(1.5), RetryOn: []string{options.RetryOnExecution}}, ""),
+			exp: options.Options{Name: "name14", Every: *(options.MustParseDuration("1h")), Concurrency: pointer.Int64(1), Retry: pointer.Int64(3), RetryBackoff: options.MustParseDuration("30s"), RetryMultiplier: pointer.Float64(1.5), RetryOn: []string{options.RetryOnExecution}},
+		},
+		{script: "option task = {\n  name: \"name15\",\n  retryMultiplier: 3,\n  every: 1m0s,\n\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)",
+			exp: options.Options{Name: "name15", Every: *(options.MustParseDuration("1m0s")), Concurrency: pointer.Int64(1), Retry: pointer.Int64(1), RetryMultiplier: pointer.Float64(3)},
+		},
+		{script: scriptGenerator(options.Options{Name: "name16", Every: *(options.MustParseDuration("1h")), RetryMultiplier: pointer.Float64(0.5)}, ""), shouldErr: true},
+		{script: scriptGenerator(options.Options{Name: "name17", Every: *(options.MustParseDuration("1h")), RetryOn: []string{"network"}}, ""), shouldErr: true},
+		{script: "option task = {\n  name: \"name18\",\n  retryBackoff: \"1m\",\n  every: 1m0s,\n\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)", shouldErr: true},
 		{script: "option task = {name:\"test_task_smoke_name\", every:30s} from(bucket:\"test_tasks_smoke_bucket_source\") |> range(start: -1h) |> map(fn: (r) => ({r with _time: r._time, _value:r._value, t : \"quality_rocks\"}))|> to(bucket:\"test_tasks_smoke_bucket_dest\", orgID:\"3e73e749495d37d5\")",
 			exp: options.Options{Name: "test_task_smoke_name", Every: *(options.MustParseDuration("30s")), Retry: pointer.Int64(1), Concurrency: pointer.Int64(1)}, shouldErr: false}, // TODO(docmerlin): remove this once tasks fully supports all flux duration units.
 
@@ -146,7 +171,7 @@ func TestFromScriptWithUnknownOptions(t *testing.T) {
 		t.Errorf("expected error to mention unrecognized options, but it said: %v", err)
 	}
 
-	validOpts := []string{"name", "cron", "every", "offset", "concurrency", "retry"}
+	validOpts := []string{"name", "cron", "every", "offset", "concurrency", "retry", "retryBackoff", "retryMultiplier", "retryOn", "after"}
 	for _, o := range validOpts {
 		if !strings.Contains(msg, o) {
 			t.Errorf("expected error to mention valid option %q but it said: %v", o, err)
@@ -222,6 +247,24 @@ func TestValidate(t *testing.T) {
 		t.Error("expected error for retry too large")
 	}
 
+	*bad = good
+	bad.RetryBackoff = options.MustParseDuration("-1m")
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for negative retryBackoff")
+	}
+
+	*bad = good
+	bad.RetryMultiplier = pointer.Float64(0)
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for retryMultiplier below 1")
+	}
+
+	*bad = good
+	bad.RetryOn = []string{options.RetryOnQuery, "network"}
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for unknown retryOn class")
+	}
+
 	notbad := new(options.Options)
 	*notbad = good
 	notbad.Cron = ""
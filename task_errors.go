@@ -72,6 +72,18 @@ var (
 		Code: EConflict,
 	}
 
+	// ErrTaskDependencyCycle is returned when the upstream tasks of a task lead back to the task.
+	ErrTaskDependencyCycle = &Error{
+		Code: EInvalid,
+		Msg:  "task dependencies cannot form a cycle",
+	}
+
+	// ErrBackfillNotFound is returned when a backfill does not exist or its progress was dropped.
+	ErrBackfillNotFound = &Error{
+		Code: ENotFound,
+		Msg:  "backfill not found",
+	}
+
 	// ErrOutOfBoundsLimit is returned with FindRuns is called with an invalid filter limit.
 	ErrOutOfBoundsLimit = &Error{
 		Code: EUnprocessableEntity,
@@ -153,6 +165,16 @@ func ErrTaskOptionParse(err error) *Error {
 	}
 }
 
+// ErrInvalidUpstreamTask is returned when the after option of a task does not name a task it can run after.
+func ErrInvalidUpstreamTask(err error) *Error {
+	return &Error{
+		Code: EInvalid,
+		Msg:  fmt.Sprintf("invalid upstream task; Err: %v", err),
+		Op:   "taskOptions",
+		Err:  err,
+	}
+}
+
 func ErrJsonMarshalError(err error) *Error {
 	return &Error{
 		Code: EInvalid,
@@ -5,6 +5,7 @@ import (
 	"context"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/influxdb"
@@ -261,6 +262,160 @@ func AddTarget(
 				},
 			},
 		},
+		{
+			name: "create target with scrape settings",
+			fields: TargetFields{
+				IDGenerator:          mock.NewIDGenerator(targetOneID, t),
+				Targets:              []*influxdb.ScraperTarget{},
+				UserResourceMappings: []*influxdb.UserResourceMapping{},
+				Organizations:        []*influxdb.Organization{&org1},
+			},
+			args: args{
+				userID: MustIDBase16(threeID),
+				target: &influxdb.ScraperTarget{
+					Name:     "name1",
+					Type:     influxdb.PrometheusScraperType,
+					OrgID:    MustIDBase16(orgOneID),
+					BucketID: MustIDBase16(bucketOneID),
+					URL:      "https://url1",
+					Auth: &influxdb.ScraperAuth{
+						Method:    influxdb.ScraperAuthBearer,
+						SecretKey: "token1",
+					},
+					TLS: &influxdb.ScraperTLSConfig{
+						Cert:         "cert1",
+						KeySecretKey: "key1",
+						ServerName:   "server1",
+					},
+					Interval: influxdb.Duration{Duration: 30 * time.Second},
+					Timeout:  influxdb.Duration{Duration: 5 * time.Second},
+					Labels:   map[string]string{"env": "prod"},
+				},
+			},
+			wants: wants{
+				userResourceMappings: []*influxdb.UserResourceMapping{
+					{
+						ResourceID:   MustIDBase16(oneID),
+						ResourceType: influxdb.ScraperResourceType,
+						UserID:       MustIDBase16(threeID),
+						UserType:     influxdb.Owner,
+					},
+				},
+				targets: []influxdb.ScraperTarget{
+					{
+						Name:     "name1",
+						Type:     influxdb.PrometheusScraperType,
+						OrgID:    MustIDBase16(orgOneID),
+						BucketID: MustIDBase16(bucketOneID),
+						URL:      "https://url1",
+						ID:       MustIDBase16(targetOneID),
+						Auth: &influxdb.ScraperAuth{
+							Method:    influxdb.ScraperAuthBearer,
+							SecretKey: "token1",
+						},
+						TLS: &influxdb.ScraperTLSConfig{
+							Cert:         "cert1",
+							KeySecretKey: "key1",
+							ServerName:   "server1",
+						},
+						Interval: influxdb.Duration{Duration: 30 * time.Second},
+						Timeout:  influxdb.Duration{Duration: 5 * time.Second},
+						Labels:   map[string]string{"env": "prod"},
+					},
+				},
+			},
+		},
+		{
+			name: "create target with invalid auth method",
+			fields: TargetFields{
+				IDGenerator:          mock.NewIDGenerator(targetOneID, t),
+				Targets:              []*influxdb.ScraperTarget{},
+				UserResourceMappings: []*influxdb.UserResourceMapping{},
+				Organizations:        []*influxdb.Organization{&org1},
+			},
+			args: args{
+				userID: MustIDBase16(threeID),
+				target: &influxdb.ScraperTarget{
+					Name:     "name1",
+					Type:     influxdb.PrometheusScraperType,
+					OrgID:    MustIDBase16(orgOneID),
+					BucketID: MustIDBase16(bucketOneID),
+					URL:      "url1",
+					Auth: &influxdb.ScraperAuth{
+						Method:    "digest",
+						SecretKey: "token1",
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  `invalid scraper target auth method "digest"`,
+					Op:   influxdb.OpAddTarget,
+				},
+				userResourceMappings: []*influxdb.UserResourceMapping{},
+				targets:              []influxdb.ScraperTarget{},
+			},
+		},
+		{
+			name: "create target with invalid type",
+			fields: TargetFields{
+				IDGenerator:          mock.NewIDGenerator(targetOneID, t),
+				Targets:              []*influxdb.ScraperTarget{},
+				UserResourceMappings: []*influxdb.UserResourceMapping{},
+				Organizations:        []*influxdb.Organization{&org1},
+			},
+			args: args{
+				userID: MustIDBase16(threeID),
+				target: &influxdb.ScraperTarget{
+					Name:     "name1",
+					Type:     "graphite",
+					OrgID:    MustIDBase16(orgOneID),
+					BucketID: MustIDBase16(bucketOneID),
+					URL:      "url1",
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  `invalid scraper target type "graphite"`,
+					Op:   influxdb.OpAddTarget,
+				},
+				userResourceMappings: []*influxdb.UserResourceMapping{},
+				targets:              []influxdb.ScraperTarget{},
+			},
+		},
+		{
+			name: "create json target without fields",
+			fields: TargetFields{
+				IDGenerator:          mock.NewIDGenerator(targetOneID, t),
+				Targets:              []*influxdb.ScraperTarget{},
+				UserResourceMappings: []*influxdb.UserResourceMapping{},
+				Organizations:        []*influxdb.Organization{&org1},
+			},
+			args: args{
+				userID: MustIDBase16(threeID),
+				target: &influxdb.ScraperTarget{
+					Name:     "name1",
+					Type:     influxdb.JSONScraperType,
+					OrgID:    MustIDBase16(orgOneID),
+					BucketID: MustIDBase16(bucketOneID),
+					URL:      "url1",
+					JSON: &influxdb.ScraperJSONConfig{
+						Measurement: "stats",
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  "json scraper target requires at least one field",
+					Op:   influxdb.OpAddTarget,
+				},
+				userResourceMappings: []*influxdb.UserResourceMapping{},
+				targets:              []influxdb.ScraperTarget{},
+			},
+		},
 		{
 			name: "basic create target",
 			fields: TargetFields{
@@ -927,12 +1082,14 @@ func UpdateTarget(
 				Targets: []*influxdb.ScraperTarget{
 					{
 						ID:       MustIDBase16(targetOneID),
+						Type:     influxdb.PrometheusScraperType,
 						URL:      "url1",
 						OrgID:    MustIDBase16(orgOneID),
 						BucketID: MustIDBase16(bucketOneID),
 					},
 					{
 						ID:       MustIDBase16(targetTwoID),
+						Type:     influxdb.PrometheusScraperType,
 						URL:      "url2",
 						OrgID:    MustIDBase16(orgOneID),
 						BucketID: MustIDBase16(bucketOneID),
@@ -946,6 +1103,7 @@ func UpdateTarget(
 			wants: wants{
 				target: &influxdb.ScraperTarget{
 					ID:       MustIDBase16(targetOneID),
+					Type:     influxdb.PrometheusScraperType,
 					URL:      "changed",
 					OrgID:    MustIDBase16(orgOneID),
 					BucketID: MustIDBase16(bucketOneID),
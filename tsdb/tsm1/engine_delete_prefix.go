@@ -5,10 +5,12 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/kit/tracing"
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/tsdb"
@@ -281,3 +283,150 @@ func (e *Engine) DeletePrefixRange(rootCtx context.Context, name []byte, min, ma
 
 	return nil
 }
+
+// EstimateDeletePrefixRange estimates the data that DeletePrefixRange would remove for
+// the same arguments without removing anything. The blocks and values with data in the
+// time range are counted in the TSM index and the cache. The series are read from the
+// index and are only counted if they have data in the time range.
+func (e *Engine) EstimateDeletePrefixRange(ctx context.Context, name []byte, min, max int64, pred Predicate) ([]influxdb.DeleteEstimate, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	span.LogKV("name_prefix", fmt.Sprintf("%x", name),
+		"min", time.Unix(0, min), "max", time.Unix(0, max),
+		"has_pred", pred != nil,
+	)
+	defer span.Finish()
+
+	if min == influxql.MinTime {
+		min = math.MinInt64
+	}
+	if max == influxql.MaxTime {
+		max = math.MaxInt64
+	}
+
+	estimates := make(map[string]*influxdb.DeleteEstimate)
+	estimate := func(seriesKey []byte, tags models.Tags) (*influxdb.DeleteEstimate, models.Tags) {
+		tags = models.ParseTagsWithTags(seriesKey, tags[:0])
+		m := tags.Get(models.MeasurementTagKeyBytes)
+		est, ok := estimates[string(m)]
+		if !ok {
+			est = &influxdb.DeleteEstimate{Measurement: string(m)}
+			estimates[string(m)] = est
+		}
+		return est, tags
+	}
+
+	// The series keys with data in the time range.
+	series := make(map[string]struct{})
+
+	var (
+		tags     models.Tags
+		canceled bool
+		err      error
+	)
+	e.FileStore.ForEachFile(func(f TSMFile) bool {
+		// Check the context before accessing each tsm file
+		select {
+		case <-ctx.Done():
+			canceled = true
+			return false
+		default:
+		}
+		if !f.OverlapsTimeRange(min, max) || !f.OverlapsKeyPrefixRange(name, name) {
+			return true
+		}
+
+		iter := f.TimeRangeIterator(name, min, max)
+		for iter.Next() {
+			key := iter.Key()
+			if !bytes.HasPrefix(key, name) {
+				break
+			}
+			if pred != nil && !pred.Matches(key) {
+				continue
+			}
+
+			blocks, values := iter.Count()
+			if values == 0 {
+				continue
+			}
+			seriesKey, _ := SeriesAndFieldFromCompositeKey(key)
+			var est *influxdb.DeleteEstimate
+			est, tags = estimate(seriesKey, tags)
+			est.Blocks += int64(blocks)
+			est.Points += int64(values)
+			series[string(seriesKey)] = struct{}{}
+		}
+		err = iter.Err()
+		return err == nil
+	})
+	if canceled {
+		return nil, ctx.Err()
+	} else if err != nil {
+		return nil, err
+	}
+
+	nameStr := string(name)
+	_ = e.Cache.ApplyEntryFn(func(k string, entry *entry) error {
+		if !strings.HasPrefix(k, nameStr) {
+			return nil
+		}
+		if pred != nil && !pred.Matches([]byte(k)) {
+			return nil
+		}
+
+		var values int64
+		for _, v := range entry.values {
+			if t := v.UnixNano(); t >= min && t <= max {
+				values++
+			}
+		}
+		if values == 0 {
+			return nil
+		}
+		seriesKey, _ := SeriesAndFieldFromCompositeKey([]byte(k))
+		var est *influxdb.DeleteEstimate
+		est, tags = estimate(seriesKey, tags)
+		est.Points += values
+		series[string(seriesKey)] = struct{}{}
+		return nil
+	})
+
+	// The TSI index and Series File do not store series data in escaped form.
+	itr, err := e.index.MeasurementSeriesIDIterator(models.UnescapeMeasurement(name))
+	if err != nil {
+		return nil, err
+	} else if itr != nil {
+		defer itr.Close()
+
+		var buf []byte
+		for {
+			elem, err := itr.Next()
+			if err != nil {
+				return nil, err
+			} else if elem.SeriesID.IsZero() {
+				break
+			}
+
+			key := e.sfile.SeriesKey(elem.SeriesID)
+			if len(key) == 0 {
+				continue
+			}
+			var seriesName []byte
+			seriesName, tags = tsdb.ParseSeriesKeyInto(key, tags[:0])
+			buf = models.AppendMakeKey(buf[:0], seriesName, tags)
+			if _, ok := series[string(buf)]; !ok {
+				continue
+			}
+			estimates[string(tags.Get(models.MeasurementTagKeyBytes))].Series++
+		}
+	}
+
+	result := make([]influxdb.DeleteEstimate, 0, len(estimates))
+	for _, est := range estimates {
+		result = append(result, *est)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Measurement < result[j].Measurement
+	})
+	return result, nil
+}
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"reflect"
+	"sort"
 	"testing"
 
+	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/predicate"
 	"github.com/influxdata/influxdb/tsdb/tsm1"
 )
 
@@ -119,3 +122,177 @@ func TestEngine_DeletePrefix(t *testing.T) {
 		}
 	}
 }
+
+func TestEngine_DeletePrefix_Predicate(t *testing.T) {
+	points := MustParsePointsString(`cpu,host=web-1 usage=1.1,idle=2.1 2
+cpu,host=web-2 usage=1.2,idle=2.2 3
+cpu,host=db-1 usage=1.3,idle=2.3 3
+cpu,host=db-1 idle=2.4 5
+mem,host=web-1 used=1.5 2`, "mm0")
+	points = append(points, MustParsePointString("cpu,host=web-1 usage=1.6 2", "mm1"))
+
+	e, err := NewEngine(tsm1.NewConfig(), t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	if err := e.writePoints(points...); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+	if err := e.WriteSnapshot(context.Background(), tsm1.CacheStatusColdNoWrites); err != nil {
+		t.Fatalf("failed to snapshot: %s", err.Error())
+	}
+	if err := e.writePoints(MustParsePointString("cpu,host=db-1 usage=1.7 4", "mm0")); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+
+	mustParsePredicate := func(s string) tsm1.Predicate {
+		t.Helper()
+		node, err := predicate.Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pred, err := predicate.New(node)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pred
+	}
+
+	// Remove the usage field of every host and all the fields of the web hosts.
+	pred := mustParsePredicate(`_measurement="cpu" and (_field="usage" or host=~/^web-/)`)
+	if err := e.DeletePrefixRange(context.Background(), []byte("mm0"), 0, 4, pred); err != nil {
+		t.Fatalf("failed to delete series: %v", err)
+	}
+
+	// Remove a part of the idle field of the other hosts.
+	pred = mustParsePredicate(`_field="idle" and host!~/^web-/`)
+	if err := e.DeletePrefixRange(context.Background(), []byte("mm0"), 0, 4, pred); err != nil {
+		t.Fatalf("failed to delete series: %v", err)
+	}
+
+	check := func() {
+		t.Helper()
+
+		exp := map[string]byte{
+			"mm0,\x00=cpu,host=db-1,\xff=idle#!~#idle":    0,
+			"mm0,\x00=mem,host=web-1,\xff=used#!~#used":   0,
+			"mm1,\x00=cpu,host=web-1,\xff=usage#!~#usage": 0,
+		}
+		if keys := e.FileStore.Keys(); !reflect.DeepEqual(keys, exp) {
+			t.Fatalf("unexpected series in file store: %v != %v", keys, exp)
+		}
+
+		// Only the deleted time range of the idle field is tombstoned.
+		tombstones := make(map[string][]tsm1.TimeRange)
+		e.FileStore.ForEachFile(func(f tsm1.TSMFile) bool {
+			for key := range exp {
+				if ts := f.TombstoneRange([]byte(key), nil); len(ts) > 0 {
+					tombstones[key] = append(tombstones[key], ts...)
+				}
+			}
+			return true
+		})
+		expTombstones := map[string][]tsm1.TimeRange{
+			"mm0,\x00=cpu,host=db-1,\xff=idle#!~#idle": {{Min: 0, Max: 4}},
+		}
+		if !reflect.DeepEqual(tombstones, expTombstones) {
+			t.Fatalf("unexpected tombstones: %v != %v", tombstones, expTombstones)
+		}
+
+		if values := e.Cache.Values([]byte("mm0,\x00=cpu,host=db-1,\xff=usage#!~#usage")); len(values) != 0 {
+			t.Fatalf("unexpected values in cache: %v", values)
+		}
+
+		// The series without data are removed from the index.
+		iter, err := e.index.MeasurementSeriesIDIterator([]byte("mm0"))
+		if err != nil {
+			t.Fatalf("iterator error: %v", err)
+		}
+		defer iter.Close()
+
+		var series []string
+		for {
+			elem, err := iter.Next()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if elem.SeriesID.IsZero() {
+				break
+			}
+			_, tags := e.sfile.Series(elem.SeriesID)
+			series = append(series, tags.String())
+		}
+		sort.Strings(series)
+		expSeries := []string{
+			models.NewTags(map[string]string{models.MeasurementTagKey: "cpu", "host": "db-1", models.FieldKeyTagKey: "idle"}).String(),
+			models.NewTags(map[string]string{models.MeasurementTagKey: "mem", "host": "web-1", models.FieldKeyTagKey: "used"}).String(),
+		}
+		sort.Strings(expSeries)
+		if !reflect.DeepEqual(series, expSeries) {
+			t.Fatalf("unexpected series in index: %v != %v", series, expSeries)
+		}
+	}
+
+	check()
+
+	// The tombstones are applied again when the files are reopened.
+	if err := e.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+	check()
+}
+
+func TestEngine_EstimateDeletePrefixRange(t *testing.T) {
+	p1 := MustParsePointString("cpu,host=A value=1.1 2", "mm0")
+	p2 := MustParsePointString("cpu,host=A value=1.2 3", "mm0")
+	p3 := MustParsePointString("cpu,host=B value=1.3 4", "mm0")
+	p4 := MustParsePointString("mem,host=C value=1.4 1", "mm0")
+	p5 := MustParsePointString("disk,host=C value=1.5 1", "mm1")
+	p6 := MustParsePointString("cpu,host=C value=1.6 2", "mm0")
+
+	e, err := NewEngine(tsm1.NewConfig(), t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	if err := e.writePoints(p1, p2, p3, p4, p5); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+	if err := e.WriteSnapshot(context.Background(), tsm1.CacheStatusColdNoWrites); err != nil {
+		t.Fatalf("failed to snapshot: %s", err.Error())
+	}
+
+	// The point in the cache is counted without a block.
+	if err := e.writePoints(p6); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+
+	got, err := e.EstimateDeletePrefixRange(context.Background(), []byte("mm0"), 0, 3, nil)
+	if err != nil {
+		t.Fatalf("failed to estimate delete: %v", err)
+	}
+	exp := []influxdb.DeleteEstimate{
+		{Measurement: "cpu", Series: 2, Blocks: 1, Points: 3},
+		{Measurement: "mem", Series: 1, Blocks: 1, Points: 1},
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected estimate: got %v, exp %v", got, exp)
+	}
+
+	// Nothing is deleted.
+	if exp, got := 4, len(e.FileStore.Keys()); exp != got {
+		t.Fatalf("series count mismatch: exp %v, got %v", exp, got)
+	}
+	if exp, got := 1, len(e.Cache.Keys()); exp != got {
+		t.Fatalf("cache count mismatch: exp %v, got %v", exp, got)
+	}
+}
@@ -0,0 +1,211 @@
+package tsm1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/multierr"
+)
+
+// importBatchSize is the number of series added to the index at a time
+// when a TSM file is imported.
+const importBatchSize = 10000
+
+// ImportTSMFile imports the keys in the TSM file at path that begin with prefix into
+// the engine. The prefix of each key is replaced by newPrefix so the data can be imported
+// into a different bucket. Tombstones next to the TSM file are applied and are not imported.
+//
+// The imported data is written to a new TSM file with the next generation, so it takes
+// precedence over any existing data with the same timestamps. Once the file is added to
+// the file store, the series of the imported keys are added to the index and series file.
+func (e *Engine) ImportTSMFile(ctx context.Context, path string, prefix, newPrefix []byte) error {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	span.LogKV("path", path, "prefix", fmt.Sprintf("%x", prefix), "new_prefix", fmt.Sprintf("%x", newPrefix))
+	defer span.Finish()
+
+	fd, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	r, err := NewTSMReader(fd)
+	if err != nil {
+		fd.Close()
+		return err
+	}
+	defer r.Close()
+
+	if !r.OverlapsKeyPrefixRange(prefix, prefix) {
+		return nil
+	}
+
+	generation := e.FileStore.NextGeneration()
+	fileName := filepath.Join(e.path, e.formatFileName(generation, 1)+"."+TSMFileExtension+"."+TmpTSMFileExtension)
+	out, err := os.OpenFile(fileName, os.O_CREATE|os.O_RDWR|os.O_EXCL, 0666)
+	if err != nil {
+		return err
+	}
+
+	w, err := NewTSMWriter(out)
+	if err != nil {
+		out.Close()
+		return err
+	}
+
+	var (
+		values     []Value
+		tombstones []TimeRange
+		n          int
+	)
+	iter := r.Iterator(prefix)
+	for iter.Next() {
+		key := iter.Key()
+		if !bytes.HasPrefix(key, prefix) {
+			break
+		}
+
+		newKey := make([]byte, 0, len(newPrefix)+len(key)-len(prefix))
+		newKey = append(append(newKey, newPrefix...), key[len(prefix):]...)
+
+		tombstones = r.TombstoneRange(key, tombstones[:0])
+		for _, entry := range iter.Entries() {
+			values, err = r.ReadAt(&entry, values[:0])
+			if err != nil {
+				return removeImportFile(w, fileName, err)
+			}
+			for _, tr := range tombstones {
+				values = Values(values).Exclude(tr.Min, tr.Max)
+			}
+			if len(values) == 0 {
+				continue
+			}
+
+			if err := w.Write(newKey, values); err != nil {
+				return removeImportFile(w, fileName, err)
+			}
+			n++
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return removeImportFile(w, fileName, err)
+	}
+
+	if n == 0 {
+		return removeImportFile(w, fileName, nil)
+	}
+
+	if err := w.WriteIndex(); err != nil {
+		return removeImportFile(w, fileName, err)
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	// The file is opened before it is added to the file store, so the keys can be
+	// indexed even if the file is compacted in the meantime.
+	imported, err := openImportFile(fileName)
+	if err != nil {
+		return multierr.Append(err, os.Remove(fileName))
+	}
+	defer imported.Close()
+
+	if err := e.FileStore.Replace(nil, []string{fileName}); err != nil {
+		return multierr.Append(err, os.Remove(fileName))
+	}
+
+	// The series are only indexed once the data is in the file store. If they can not
+	// be indexed, the file is removed again so no data is left without its series.
+	if err := e.indexImportFile(imported); err != nil {
+		path := strings.TrimSuffix(fileName, "."+TmpTSMFileExtension)
+		return multierr.Append(err, e.FileStore.Replace([]string{path}, nil))
+	}
+	return nil
+}
+
+// openImportFile opens the imported TSM file at path.
+func openImportFile(path string) (*TSMReader, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	r, err := NewTSMReader(fd)
+	if err != nil {
+		fd.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// indexImportFile adds the series of the keys in an imported TSM file to the index
+// and series file.
+func (e *Engine) indexImportFile(r *TSMReader) error {
+	collection := &tsdb.SeriesCollection{
+		Keys:  make([][]byte, 0, importBatchSize),
+		Names: make([][]byte, 0, importBatchSize),
+		Tags:  make([]models.Tags, 0, importBatchSize),
+		Types: make([]models.FieldType, 0, importBatchSize),
+	}
+
+	iter := r.Iterator(nil)
+	for iter.Next() {
+		seriesKey, _ := SeriesAndFieldFromCompositeKey(iter.Key())
+		name, tags := models.ParseKeyBytes(seriesKey)
+		collection.Keys = append(collection.Keys, seriesKey)
+		collection.Names = append(collection.Names, name)
+		collection.Tags = append(collection.Tags, tags)
+		collection.Types = append(collection.Types, blockTypeToFieldType(iter.Type()))
+
+		if len(collection.Keys) == importBatchSize {
+			if err := e.index.CreateSeriesListIfNotExists(collection); err != nil {
+				return err
+			}
+			collection.Truncate(0)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	if len(collection.Keys) > 0 {
+		return e.index.CreateSeriesListIfNotExists(collection)
+	}
+	return nil
+}
+
+// removeImportFile closes the writer and removes the partially written file.
+// The error is returned so the function can be used in return statements.
+func removeImportFile(w TSMWriter, fileName string, err error) error {
+	w.Close()
+	if rerr := os.Remove(fileName); rerr != nil && err == nil {
+		err = rerr
+	}
+	if rerr := os.Remove(StatsFilename(fileName)); rerr != nil && !os.IsNotExist(rerr) && err == nil {
+		err = rerr
+	}
+	return err
+}
+
+// blockTypeToFieldType returns the field type of the values in a block.
+func blockTypeToFieldType(typ byte) models.FieldType {
+	switch typ {
+	case BlockFloat64:
+		return models.Float
+	case BlockInteger:
+		return models.Integer
+	case BlockBoolean:
+		return models.Boolean
+	case BlockString:
+		return models.String
+	case BlockUnsigned:
+		return models.Unsigned
+	default:
+		return models.Empty
+	}
+}
@@ -0,0 +1,59 @@
+package tsm1_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/influxdb/tsdb/tsm1"
+)
+
+func TestEngine_ImportTSMFile(t *testing.T) {
+	p1 := MustParsePointString("cpu,host=A value=1.1 1", "mm0")
+	p2 := MustParsePointString("cpu,host=B value=1.2 2", "mm0")
+	p3 := MustParsePointString("mem,host=C value=1.3 3", "mm1")
+
+	e, err := NewEngine(tsm1.NewConfig(), t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	if err := e.writePoints(p1, p2, p3); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+	if err := e.WriteSnapshot(context.Background(), tsm1.CacheStatusColdNoWrites); err != nil {
+		t.Fatalf("failed to snapshot: %s", err.Error())
+	}
+
+	files := e.FileStore.Files()
+	if exp, got := 1, len(files); exp != got {
+		t.Fatalf("file count mismatch: exp %v, got %v", exp, got)
+	}
+
+	// Import the data of mm0 into mm2.
+	if err := e.ImportTSMFile(context.Background(), files[0].Path(), []byte("mm0"), []byte("mm2")); err != nil {
+		t.Fatalf("failed to import file: %v", err)
+	}
+
+	exp := map[string]byte{
+		"mm0,\x00=cpu,host=A,\xff=value#!~#value": 0,
+		"mm0,\x00=cpu,host=B,\xff=value#!~#value": 0,
+		"mm1,\x00=mem,host=C,\xff=value#!~#value": 0,
+		"mm2,\x00=cpu,host=A,\xff=value#!~#value": 0,
+		"mm2,\x00=cpu,host=B,\xff=value#!~#value": 0,
+	}
+	if keys := e.FileStore.Keys(); !reflect.DeepEqual(keys, exp) {
+		t.Fatalf("unexpected series in file store: %v != %v", keys, exp)
+	}
+
+	// The series of the imported keys are added to the index.
+	if ok, err := e.MeasurementExists([]byte("mm2")); err != nil {
+		t.Fatal(err)
+	} else if !ok {
+		t.Fatal("expected imported measurement to exist in the index")
+	}
+}
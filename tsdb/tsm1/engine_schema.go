@@ -525,3 +525,86 @@ func ValidateTagPredicate(expr influxql.Expr) (err error) {
 	})
 	return err
 }
+
+// MeasurementFields returns the fields of the measurement in the given bucket
+// with data within the time range (start, end] and the type of each field.
+// The type of a field is read from the blocks of the TSM files or the values
+// in the cache. If a field has different types in different series, the type of
+// the first series that is found is returned.
+//
+// If the context is canceled before MeasurementFields has finished processing,
+// a non-nil error will be returned.
+func (e *Engine) MeasurementFields(ctx context.Context, orgID, bucketID influxdb.ID, measurement string, start, end int64) (map[string]influxql.DataType, error) {
+	encoded := tsdb.EncodeName(orgID, bucketID)
+	prefix := models.EscapeMeasurement(encoded[:])
+	name := []byte(measurement)
+
+	var tags models.Tags
+	fields := make(map[string]influxql.DataType)
+	var canceled bool
+
+	e.FileStore.ForEachFile(func(f TSMFile) bool {
+		// Check the context before accessing each tsm file
+		select {
+		case <-ctx.Done():
+			canceled = true
+			return false
+		default:
+		}
+		if f.OverlapsTimeRange(start, end) && f.OverlapsKeyPrefixRange(prefix, prefix) {
+			iter := f.TimeRangeIterator(prefix, start, end)
+			for iter.Next() {
+				sfkey := iter.Key()
+				if !bytes.HasPrefix(sfkey, prefix) {
+					// end of org+bucket
+					break
+				}
+
+				key, field := SeriesAndFieldFromCompositeKey(sfkey)
+				if _, ok := fields[string(field)]; ok {
+					continue
+				}
+				tags = models.ParseTagsWithTags(key, tags[:0])
+				if !bytes.Equal(tags.Get(models.MeasurementTagKeyBytes), name) {
+					continue
+				}
+
+				if iter.HasData() {
+					fields[string(field)] = BlockTypeToInfluxQLDataType(iter.Type())
+				}
+			}
+		}
+		return true
+	})
+
+	if canceled {
+		return nil, ctx.Err()
+	}
+
+	// With performance in mind, we explicitly do not check the context
+	// while scanning the entries in the cache.
+	prefixStr := string(prefix)
+	_ = e.Cache.ApplyEntryFn(func(sfkey string, entry *entry) error {
+		if !strings.HasPrefix(sfkey, prefixStr) {
+			return nil
+		}
+
+		key, field := SeriesAndFieldFromCompositeKey([]byte(sfkey))
+		if _, ok := fields[string(field)]; ok {
+			return nil
+		}
+		tags = models.ParseTagsWithTags(key, tags[:0])
+		if !bytes.Equal(tags.Get(models.MeasurementTagKeyBytes), name) {
+			return nil
+		}
+
+		if entry.values.Contains(start, end) {
+			if typ, err := entry.InfluxQLType(); err == nil {
+				fields[string(field)] = typ
+			}
+		}
+		return nil
+	})
+
+	return fields, nil
+}
@@ -683,3 +683,71 @@ func TestValidateTagPredicate(t *testing.T) {
 		})
 	}
 }
+
+func TestEngine_MeasurementFields(t *testing.T) {
+	e, err := NewEngine(tsm1.NewConfig(), t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	var (
+		org    influxdb.ID = 0x6000
+		bucket influxdb.ID = 0x6100
+	)
+
+	e.MustWritePointsString(org, bucket, `
+cpuB,host=0A f=1.1,i=1i 101
+cpuB,host=AA s="a",b=true 102
+memB,host=AA free=1i 101`)
+
+	// send some points to TSM data
+	e.MustWriteSnapshot()
+
+	e.MustWritePointsString(org, bucket, `
+cpuB,host=0A u=1u 201
+cpuB,host=AA late=1.5 301`)
+
+	t.Run("files and cache", func(t *testing.T) {
+		got, err := e.MeasurementFields(context.Background(), org, bucket, "cpuB", 0, math.MaxInt64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp := map[string]influxql.DataType{
+			"f":    influxql.Float,
+			"i":    influxql.Integer,
+			"s":    influxql.String,
+			"b":    influxql.Boolean,
+			"u":    influxql.Unsigned,
+			"late": influxql.Float,
+		}
+		if !cmp.Equal(got, exp) {
+			t.Errorf("unexpected fields: -got/+exp\n%v", cmp.Diff(got, exp))
+		}
+	})
+
+	t.Run("time range", func(t *testing.T) {
+		got, err := e.MeasurementFields(context.Background(), org, bucket, "cpuB", 150, 250)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp := map[string]influxql.DataType{
+			"u": influxql.Unsigned,
+		}
+		if !cmp.Equal(got, exp) {
+			t.Errorf("unexpected fields: -got/+exp\n%v", cmp.Diff(got, exp))
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if _, err := e.MeasurementFields(ctx, org, bucket, "cpuB", 0, math.MaxInt64); err == nil {
+			t.Fatal("MeasurementFields: expected error but got nothing")
+		}
+	})
+}
@@ -0,0 +1,247 @@
+package tsm1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/influxdata/influxdb/kit/tracing"
+	"go.uber.org/multierr"
+)
+
+// SnapshotFilter restricts the data that is written to a snapshot.
+type SnapshotFilter struct {
+	// Prefix restricts the snapshot to the keys that begin with the prefix.
+	// A nil prefix includes every key.
+	Prefix []byte
+
+	// MinTime and MaxTime restrict the snapshot to the values within the time range.
+	MinTime, MaxTime int64
+
+	// Skip is called for each TSM file that contains data for the snapshot. If it returns
+	// true, the file is reported in the snapshot, but nothing is written to the snapshot
+	// directory. This allows an incremental snapshot to skip the files that were written
+	// by a previous snapshot.
+	Skip func(file SnapshotFile) bool
+}
+
+// NewSnapshotFilter returns a SnapshotFilter that includes all of the data in the store.
+func NewSnapshotFilter() SnapshotFilter {
+	return SnapshotFilter{
+		MinTime: math.MinInt64,
+		MaxTime: math.MaxInt64,
+	}
+}
+
+// overlaps returns true if the file contains any data that passes the filter.
+func (s SnapshotFilter) overlaps(f TSMFile) bool {
+	if !f.OverlapsTimeRange(s.MinTime, s.MaxTime) {
+		return false
+	}
+	return len(s.Prefix) == 0 || f.OverlapsKeyPrefixRange(s.Prefix, s.Prefix)
+}
+
+// contains returns true if all of the data in the file passes the filter.
+func (s SnapshotFilter) contains(f TSMFile) bool {
+	minTime, maxTime := f.TimeRange()
+	if minTime < s.MinTime || maxTime > s.MaxTime {
+		return false
+	}
+	minKey, maxKey := f.KeyRange()
+	return bytes.HasPrefix(minKey, s.Prefix) && bytes.HasPrefix(maxKey, s.Prefix)
+}
+
+// SnapshotFile describes a TSM file in the store that contains data for a snapshot.
+type SnapshotFile struct {
+	// Name is the base name of the TSM file.
+	Name string
+
+	// Size is the size of the TSM file in the store.
+	Size int64
+
+	// TombstoneSize is the combined size of the tombstone files for the TSM file.
+	TombstoneSize int64
+
+	// Files are the names of the files written to the snapshot directory for the
+	// TSM file. Files is empty if the file was skipped.
+	Files []string
+}
+
+// CreateFilteredSnapshot creates a snapshot of the data that passes the filter
+// in a new backup directory. Files that only contain data that passes the filter
+// are hard linked along with their tombstones. Other files are rewritten with
+// only the data that passes the filter and the tombstones are applied.
+func (f *FileStore) CreateFilteredSnapshot(ctx context.Context, filter SnapshotFilter) (backupID int, backupDirFullPath string, snapshot []SnapshotFile, err error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	span.LogKV("dir", f.dir)
+
+	f.mu.Lock()
+	// create a copy of the files slice and ensure they aren't closed out from
+	// under us, nor the slice mutated.
+	files := make([]TSMFile, len(f.files))
+	copy(files, f.files)
+
+	for _, tsmf := range files {
+		tsmf.Ref()
+		defer tsmf.Unref()
+	}
+
+	// increment and keep track of the current temp dir for when we drop the lock.
+	// this ensures we are the only writer to the directory.
+	f.currentTempDirID += 1
+	backupID = f.currentTempDirID
+	f.mu.Unlock()
+
+	backupDirFullPath = f.InternalBackupPath(backupID)
+
+	// create the tmp directory and add the files. there is no longer any shared
+	// mutable state.
+	if err := os.Mkdir(backupDirFullPath, 0777); err != nil {
+		return 0, "", nil, err
+	}
+	for _, tsmf := range files {
+		if !filter.overlaps(tsmf) {
+			continue
+		}
+
+		file := SnapshotFile{
+			Name: filepath.Base(tsmf.Path()),
+			Size: int64(tsmf.Size()),
+		}
+		for _, tf := range tsmf.TombstoneFiles() {
+			file.TombstoneSize += int64(tf.Size)
+		}
+
+		if filter.Skip == nil || !filter.Skip(file) {
+			if filter.contains(tsmf) {
+				file.Files, err = linkSnapshotFile(tsmf, backupDirFullPath)
+			} else {
+				file.Files, err = writeSnapshotFile(tsmf, backupDirFullPath, filter)
+			}
+			if err != nil {
+				// Remove the links and the partially written file from the engine directory.
+				return 0, "", nil, multierr.Append(err, os.RemoveAll(backupDirFullPath))
+			} else if len(file.Files) == 0 {
+				// None of the data remained after the filter was applied.
+				continue
+			}
+		}
+		snapshot = append(snapshot, file)
+	}
+
+	return backupID, backupDirFullPath, snapshot, nil
+}
+
+// linkSnapshotFile creates hard links for the TSM file and its tombstones in dir.
+func linkSnapshotFile(tsmf TSMFile, dir string) ([]string, error) {
+	names := []string{filepath.Base(tsmf.Path())}
+	if err := os.Link(tsmf.Path(), filepath.Join(dir, names[0])); err != nil {
+		return nil, fmt.Errorf("error creating tsm hard link: %q", err)
+	}
+	for _, tf := range tsmf.TombstoneFiles() {
+		name := filepath.Base(tf.Path)
+		if err := os.Link(tf.Path, filepath.Join(dir, name)); err != nil {
+			return nil, fmt.Errorf("error creating tombstone hard link: %q", err)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// writeSnapshotFile writes the values in the TSM file that pass the filter to a
+// new TSM file with the same name in dir. Deleted values are not written.
+// No file is written if none of the values pass the filter.
+func writeSnapshotFile(tsmf TSMFile, dir string, filter SnapshotFilter) ([]string, error) {
+	name := filepath.Base(tsmf.Path())
+	path := filepath.Join(dir, name)
+	fd, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_EXCL, 0666)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := NewTSMWriter(fd)
+	if err != nil {
+		fd.Close()
+		return nil, err
+	}
+
+	var (
+		values     []Value
+		tombstones []TimeRange
+		n          int
+	)
+	iter := tsmf.Iterator(filter.Prefix)
+	for iter.Next() {
+		key := iter.Key()
+		if !bytes.HasPrefix(key, filter.Prefix) {
+			break
+		}
+
+		tombstones = tsmf.TombstoneRange(key, tombstones[:0])
+		for _, entry := range iter.Entries() {
+			if !entry.OverlapsTimeRange(filter.MinTime, filter.MaxTime) {
+				continue
+			}
+
+			values, err = tsmf.ReadAt(&entry, values[:0])
+			if err != nil {
+				w.Close()
+				return nil, err
+			}
+			values = Values(values).Include(filter.MinTime, filter.MaxTime)
+			for _, tr := range tombstones {
+				values = Values(values).Exclude(tr.Min, tr.Max)
+			}
+			if len(values) == 0 {
+				continue
+			}
+
+			if err := w.Write(key, values); err != nil {
+				w.Close()
+				return nil, err
+			}
+			n++
+		}
+	}
+	if err := iter.Err(); err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	if n == 0 {
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return nil, removeSnapshotFile(path)
+	}
+
+	if err := w.WriteIndex(); err != nil {
+		w.Close()
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	// The measurement stats are rebuilt when the file is restored.
+	if err := os.Remove(StatsFilename(path)); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return []string{name}, nil
+}
+
+// removeSnapshotFile removes the TSM file and the stats file written alongside it.
+func removeSnapshotFile(path string) error {
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	if err := os.Remove(StatsFilename(path)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package tsm1_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/influxdb/tsdb/tsm1"
+)
+
+func TestFileStore_CreateFilteredSnapshot(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+	fs := tsm1.NewFileStore(dir)
+
+	// Setup 3 files
+	data := []keyValues{
+		keyValues{"cpu", []tsm1.Value{tsm1.NewValue(0, 1.0), tsm1.NewValue(1, 2.0)}},
+		keyValues{"mem", []tsm1.Value{tsm1.NewValue(1, 3.0)}},
+		keyValues{"cpu", []tsm1.Value{tsm1.NewValue(2, 4.0)}},
+	}
+
+	files, err := newFiles(dir, data...)
+	if err != nil {
+		t.Fatalf("unexpected error creating files: %v", err)
+	}
+
+	fs.Replace(nil, files)
+
+	filter := tsm1.SnapshotFilter{
+		Prefix:  []byte("cpu"),
+		MinTime: 1,
+		MaxTime: 2,
+	}
+	_, s, snapshot, err := fs.CreateFilteredSnapshot(context.Background(), filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The file with mem is excluded, the first file is rewritten
+	// and the last file is linked.
+	if got, exp := len(snapshot), 2; got != exp {
+		t.Fatalf("unexpected number of files: got %d, exp %d", got, exp)
+	}
+	for i, exp := range []string{filepath.Base(files[0]), filepath.Base(files[2])} {
+		if got := snapshot[i].Name; got != exp {
+			t.Fatalf("unexpected file name: got %s, exp %s", got, exp)
+		}
+		if got := snapshot[i].Files; !reflect.DeepEqual(got, []string{exp}) {
+			t.Fatalf("unexpected snapshot files: got %v, exp %v", got, []string{exp})
+		}
+	}
+
+	f, err := os.Open(filepath.Join(s, snapshot[0].Name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := tsm1.NewTSMReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	values, err := r.ReadAll([]byte("cpu"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := []tsm1.Value{tsm1.NewValue(1, 2.0)}; !reflect.DeepEqual(values, exp) {
+		t.Fatalf("unexpected values: got %v, exp %v", values, exp)
+	}
+}
+
+func TestFileStore_CreateFilteredSnapshot_Skip(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+	fs := tsm1.NewFileStore(dir)
+
+	data := []keyValues{
+		keyValues{"cpu", []tsm1.Value{tsm1.NewValue(0, 1.0)}},
+		keyValues{"cpu", []tsm1.Value{tsm1.NewValue(1, 2.0)}},
+	}
+
+	files, err := newFiles(dir, data...)
+	if err != nil {
+		t.Fatalf("unexpected error creating files: %v", err)
+	}
+
+	fs.Replace(nil, files)
+
+	filter := tsm1.NewSnapshotFilter()
+	filter.Skip = func(file tsm1.SnapshotFile) bool {
+		return file.Name == filepath.Base(files[0])
+	}
+	_, s, snapshot, err := fs.CreateFilteredSnapshot(context.Background(), filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, exp := len(snapshot), 2; got != exp {
+		t.Fatalf("unexpected number of files: got %d, exp %d", got, exp)
+	}
+	if got := snapshot[0].Files; len(got) != 0 {
+		t.Fatalf("expected skipped file to have no snapshot files, got %v", got)
+	}
+	if _, err := os.Stat(filepath.Join(s, snapshot[0].Name)); !os.IsNotExist(err) {
+		t.Fatalf("expected skipped file to not exist in snapshot, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(s, snapshot[1].Name)); err != nil {
+		t.Fatalf("unable to find file %q: %v", snapshot[1].Name, err)
+	}
+}
+
+func TestFileStore_CreateFilteredSnapshot_Error(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+	fs := tsm1.NewFileStore(dir)
+
+	data := []keyValues{
+		keyValues{"cpu", []tsm1.Value{tsm1.NewValue(0, 1.0)}},
+		keyValues{"cpu", []tsm1.Value{tsm1.NewValue(1, 2.0)}},
+	}
+
+	files, err := newFiles(dir, data...)
+	if err != nil {
+		t.Fatalf("unexpected error creating files: %v", err)
+	}
+
+	fs.Replace(nil, files)
+
+	// The first file is linked, but the link of the second file fails.
+	if err := os.Remove(files[1]); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := fs.CreateFilteredSnapshot(context.Background(), tsm1.NewSnapshotFilter()); err == nil {
+		t.Fatal("expected error linking a missing file")
+	}
+
+	// The backup directory is removed along with the link of the first file.
+	if _, err := os.Stat(fs.InternalBackupPath(1)); !os.IsNotExist(err) {
+		t.Fatalf("expected backup directory to be removed, got %v", err)
+	}
+}
@@ -53,6 +53,11 @@ func (b *TimeRangeIterator) Key() []byte {
 	return b.iter.Key()
 }
 
+// Type reports the block type of the current key.
+func (b *TimeRangeIterator) Type() byte {
+	return b.iter.Type()
+}
+
 // HasData reports true if the current key has data for the time range.
 func (b *TimeRangeIterator) HasData() bool {
 	if b.Err() != nil {
@@ -106,6 +111,44 @@ func (b *TimeRangeIterator) HasData() bool {
 	return false
 }
 
+// Count reports the number of blocks of the current key with data for the time
+// range and the number of values within the time range that are not deleted.
+func (b *TimeRangeIterator) Count() (blocks, values int) {
+	if b.Err() != nil {
+		return 0, 0
+	}
+
+	e := excludeEntries(b.iter.Entries(), b.tr)
+	if len(e) == 0 {
+		return 0, 0
+	}
+
+	b.trbuf = b.r.TombstoneRange(b.iter.Key(), b.trbuf[:0])
+	var ts []TimeRange
+	if len(b.trbuf) > 0 {
+		ts = excludeTimeRanges(b.trbuf, b.tr)
+	}
+
+	for i := range e {
+		if !b.readBlock(&e[i]) {
+			return 0, 0
+		}
+
+		// remove tombstoned timestamps
+		for i := range ts {
+			b.a.Exclude(ts[i].Min, ts[i].Max)
+		}
+
+		n := b.a.Len()
+		b.a.Exclude(b.tr.Min, b.tr.Max)
+		if n -= b.a.Len(); n > 0 {
+			blocks++
+			values += n
+		}
+	}
+	return blocks, values
+}
+
 // readBlock reads the block identified by IndexEntry e and accumulates
 // statistics. readBlock returns true on success.
 func (b *TimeRangeIterator) readBlock(e *IndexEntry) bool {
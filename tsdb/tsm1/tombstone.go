@@ -60,6 +60,9 @@ import (
 )
 
 const (
+	// TombstoneFileExtension is the extension used for tombstone files.
+	TombstoneFileExtension = "tombstone"
+
 	headerSize = 4
 	v4header   = 0x1504
 )
@@ -566,7 +569,7 @@ func (t *Tombstoner) readTombstoneV4(f *os.File, fn func(t Tombstone) error) err
 }
 
 func (t *Tombstoner) tombstonePath() string {
-	if strings.HasSuffix(t.Path, "tombstone") {
+	if strings.HasSuffix(t.Path, TombstoneFileExtension) {
 		return t.Path
 	}
 
@@ -580,7 +583,7 @@ func (t *Tombstoner) tombstonePath() string {
 	}
 
 	// Append the "tombstone" suffix to create a 0000001.tombstone file
-	return filepath.Join(filepath.Dir(t.Path), filename+".tombstone")
+	return filepath.Join(filepath.Dir(t.Path), filename+"."+TombstoneFileExtension)
 }
 
 func (t *Tombstoner) writeTombstoneV4(dst io.Writer, ts Tombstone) error {